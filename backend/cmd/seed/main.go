@@ -51,7 +51,7 @@ func main() {
 
 	// リポジトリとサービスの初期化
 	repo := repository.NewRepository(db)
-	tournamentService := service.NewTournamentService(repo.Tournament, repo.Match)
+	tournamentService := service.NewTournamentService(repo.Tournament, repo.Team, repo.Match, repo.Event)
 	seedingService := service.NewSeedingService(repo.Tournament, repo.Match, tournamentService)
 
 	// シーディング実行
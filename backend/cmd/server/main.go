@@ -71,6 +71,10 @@ func main() {
 	tournamentRepo := repository.NewTournamentRepository(db)
 	matchRepo := repository.NewMatchRepository(db)
 	teamRepo := repository.NewTeamRepository(db)
+	sportRepo := repository.NewSportRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	playerRepo := repository.NewPlayerRepository(db)
+	venueRepo := repository.NewVenueRepository(db)
 
 	// 管理者ユーザーの初期化
 	adminInitService := service.NewAdminInitService(userRepo, cfg)
@@ -78,23 +82,47 @@ func main() {
 		log.Fatal("管理者ユーザーの初期化に失敗しました", logger.Err(err))
 	}
 
+	// 種目のレジストリを sports テーブルから読み込む
+	sportService := service.NewSportService(sportRepo)
+	if err := sportService.LoadRegistry(context.Background()); err != nil {
+		log.Fatal("種目の読み込みに失敗しました", logger.Err(err))
+	}
+
 	// WebSocketマネージャーの初期化
 	wsManager := websocketManager.NewManager()
 	wsManager.Start()
 	defer wsManager.Stop()
 
 	// 通知サービスの初期化
-	notificationService := service.NewNotificationService(wsManager)
+	notificationService := service.NewNotificationService(wsManager, tournamentRepo)
 
 	// サービスの初期化
 	authService := service.NewAuthService(userRepo, cfg)
-	tournamentService := service.NewTournamentService(tournamentRepo, teamRepo, matchRepo)
-	matchService := service.NewMatchService(matchRepo)
-	pollingService := service.NewPollingService(tournamentRepo, matchRepo)
+	eventService := service.NewEventService(eventRepo, tournamentRepo)
+	tournamentService := service.NewTournamentService(tournamentRepo, teamRepo, matchRepo, eventRepo)
+	matchService := service.NewMatchService(matchRepo, tournamentRepo, eventRepo, teamRepo, playerRepo)
+	teamService := service.NewTeamService(teamRepo, tournamentRepo, eventRepo)
+	playerService := service.NewPlayerService(playerRepo, teamRepo, matchRepo, tournamentRepo, eventRepo)
+	championshipService := service.NewChampionshipService(tournamentRepo, matchRepo, teamRepo, eventRepo)
+	venueService := service.NewVenueService(venueRepo, eventRepo, matchRepo)
+	scheduleService := service.NewScheduleService(matchRepo, tournamentRepo, eventRepo, venueRepo)
+	pollingService := service.NewPollingService(tournamentRepo, matchRepo, eventRepo)
 
 	// サービスに通知サービスを設定（リアルタイム更新のため）
 	tournamentService.SetNotificationService(notificationService)
 	matchService.SetNotificationService(notificationService)
+	teamService.SetNotificationService(notificationService)
+	championshipService.SetNotificationService(notificationService)
+	scheduleService.SetNotificationService(notificationService)
+	venueService.SetNotificationService(notificationService)
+
+	// 試合結果の保存・訂正で最終順位が変わった場合に総合順位を更新する
+	tournamentService.SetChampionshipService(championshipService)
+	matchService.SetChampionshipService(championshipService)
+
+	// 試合の更新・結果・日程の変更でコートの試合順が変わった場合にコートの表示を更新する
+	matchService.SetVenueService(venueService)
+	scheduleService.SetVenueService(venueService)
 
 	// ポーリングサービスのキャッシュクリーンアップを開始
 	go pollingService.StartCacheCleanup(context.Background())
@@ -104,7 +132,7 @@ func main() {
 	pollingHandler := handler.NewPollingHandler(pollingService)
 
 	// ルーターの初期化
-	appRouter := router.NewRouter(authService, tournamentService, matchService, wsHandler, pollingHandler)
+	appRouter := router.NewRouter(authService, tournamentService, matchService, sportService, eventService, teamService, playerService, championshipService, venueService, scheduleService, wsHandler, pollingHandler)
 
 	// HTTPサーバーの設定
 	server := &http.Server{
@@ -22,20 +22,20 @@ type CacheManager interface {
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	
-	// トーナメント関連キャッシュ
-	SetTournament(ctx context.Context, sport string, tournament *models.Tournament) error
-	GetTournament(ctx context.Context, sport string) (*models.Tournament, error)
-	DeleteTournament(ctx context.Context, sport string) error
+	// トーナメント関連キャッシュ（大会・スポーツ別）
+	SetTournament(ctx context.Context, eventID uint, sport string, tournament *models.Tournament) error
+	GetTournament(ctx context.Context, eventID uint, sport string) (*models.Tournament, error)
+	DeleteTournament(ctx context.Context, eventID uint, sport string) error
 	
-	// 試合関連キャッシュ
-	SetMatches(ctx context.Context, sport string, matches []models.Match) error
-	GetMatches(ctx context.Context, sport string) ([]models.Match, error)
-	DeleteMatches(ctx context.Context, sport string) error
+	// 試合関連キャッシュ（大会・スポーツ別）
+	SetMatches(ctx context.Context, eventID uint, sport string, matches []models.Match) error
+	GetMatches(ctx context.Context, eventID uint, sport string) ([]models.Match, error)
+	DeleteMatches(ctx context.Context, eventID uint, sport string) error
 	
-	// ブラケット関連キャッシュ
-	SetBracket(ctx context.Context, sport string, bracket *models.Bracket) error
-	GetBracket(ctx context.Context, sport string) (*models.Bracket, error)
-	DeleteBracket(ctx context.Context, sport string) error
+	// ブラケット関連キャッシュ（大会・スポーツ別）
+	SetBracket(ctx context.Context, eventID uint, sport string, bracket *models.Bracket) error
+	GetBracket(ctx context.Context, eventID uint, sport string) (*models.Bracket, error)
+	DeleteBracket(ctx context.Context, eventID uint, sport string) error
 	
 	// 統計関連キャッシュ
 	SetStatistics(ctx context.Context, key string, stats interface{}) error
@@ -43,7 +43,8 @@ type CacheManager interface {
 	DeleteStatistics(ctx context.Context, key string) error
 	
 	// キャッシュ無効化
-	InvalidateTournamentCache(ctx context.Context, sport string) error
+	InvalidateTournamentCache(ctx context.Context, eventID uint, sport string) error
+	InvalidateEventCache(ctx context.Context, eventID uint) error
 	InvalidateAllCache(ctx context.Context) error
 	
 	// 接続管理
@@ -98,6 +99,12 @@ func (c *cacheManagerImpl) generateKey(prefix, identifier string) string {
 	return fmt.Sprintf("tournament:%s:%s", prefix, identifier)
 }
 
+// EventKey は大会・スポーツ別のキャッシュ識別子を生成する
+// 同じスポーツでも大会（開催回）ごとに別のキーになる
+func EventKey(eventID uint, sport string) string {
+	return fmt.Sprintf("event:%d:%s", eventID, sport)
+}
+
 // Set は指定されたキーに値を設定する
 func (c *cacheManagerImpl) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	if !c.enabled {
@@ -170,14 +177,14 @@ func (c *cacheManagerImpl) Exists(ctx context.Context, key string) (bool, error)
 }
 
 // SetTournament はトーナメントデータをキャッシュに設定する
-func (c *cacheManagerImpl) SetTournament(ctx context.Context, sport string, tournament *models.Tournament) error {
-	key := c.generateKey("tournament", sport)
+func (c *cacheManagerImpl) SetTournament(ctx context.Context, eventID uint, sport string, tournament *models.Tournament) error {
+	key := c.generateKey("tournament", EventKey(eventID, sport))
 	return c.Set(ctx, key, tournament, 5*time.Minute)
 }
 
 // GetTournament はトーナメントデータをキャッシュから取得する
-func (c *cacheManagerImpl) GetTournament(ctx context.Context, sport string) (*models.Tournament, error) {
-	key := c.generateKey("tournament", sport)
+func (c *cacheManagerImpl) GetTournament(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	key := c.generateKey("tournament", EventKey(eventID, sport))
 	var tournament models.Tournament
 	
 	if err := c.Get(ctx, key, &tournament); err != nil {
@@ -188,20 +195,20 @@ func (c *cacheManagerImpl) GetTournament(ctx context.Context, sport string) (*mo
 }
 
 // DeleteTournament はトーナメントキャッシュを削除する
-func (c *cacheManagerImpl) DeleteTournament(ctx context.Context, sport string) error {
-	key := c.generateKey("tournament", sport)
+func (c *cacheManagerImpl) DeleteTournament(ctx context.Context, eventID uint, sport string) error {
+	key := c.generateKey("tournament", EventKey(eventID, sport))
 	return c.Delete(ctx, key)
 }
 
 // SetMatches は試合データをキャッシュに設定する
-func (c *cacheManagerImpl) SetMatches(ctx context.Context, sport string, matches []models.Match) error {
-	key := c.generateKey("matches", sport)
+func (c *cacheManagerImpl) SetMatches(ctx context.Context, eventID uint, sport string, matches []models.Match) error {
+	key := c.generateKey("matches", EventKey(eventID, sport))
 	return c.Set(ctx, key, matches, 3*time.Minute)
 }
 
 // GetMatches は試合データをキャッシュから取得する
-func (c *cacheManagerImpl) GetMatches(ctx context.Context, sport string) ([]models.Match, error) {
-	key := c.generateKey("matches", sport)
+func (c *cacheManagerImpl) GetMatches(ctx context.Context, eventID uint, sport string) ([]models.Match, error) {
+	key := c.generateKey("matches", EventKey(eventID, sport))
 	var matches []models.Match
 	
 	if err := c.Get(ctx, key, &matches); err != nil {
@@ -212,20 +219,20 @@ func (c *cacheManagerImpl) GetMatches(ctx context.Context, sport string) ([]mode
 }
 
 // DeleteMatches は試合キャッシュを削除する
-func (c *cacheManagerImpl) DeleteMatches(ctx context.Context, sport string) error {
-	key := c.generateKey("matches", sport)
+func (c *cacheManagerImpl) DeleteMatches(ctx context.Context, eventID uint, sport string) error {
+	key := c.generateKey("matches", EventKey(eventID, sport))
 	return c.Delete(ctx, key)
 }
 
 // SetBracket はブラケットデータをキャッシュに設定する
-func (c *cacheManagerImpl) SetBracket(ctx context.Context, sport string, bracket *models.Bracket) error {
-	key := c.generateKey("bracket", sport)
+func (c *cacheManagerImpl) SetBracket(ctx context.Context, eventID uint, sport string, bracket *models.Bracket) error {
+	key := c.generateKey("bracket", EventKey(eventID, sport))
 	return c.Set(ctx, key, bracket, 5*time.Minute)
 }
 
 // GetBracket はブラケットデータをキャッシュから取得する
-func (c *cacheManagerImpl) GetBracket(ctx context.Context, sport string) (*models.Bracket, error) {
-	key := c.generateKey("bracket", sport)
+func (c *cacheManagerImpl) GetBracket(ctx context.Context, eventID uint, sport string) (*models.Bracket, error) {
+	key := c.generateKey("bracket", EventKey(eventID, sport))
 	var bracket models.Bracket
 	
 	if err := c.Get(ctx, key, &bracket); err != nil {
@@ -236,8 +243,8 @@ func (c *cacheManagerImpl) GetBracket(ctx context.Context, sport string) (*model
 }
 
 // DeleteBracket はブラケットキャッシュを削除する
-func (c *cacheManagerImpl) DeleteBracket(ctx context.Context, sport string) error {
-	key := c.generateKey("bracket", sport)
+func (c *cacheManagerImpl) DeleteBracket(ctx context.Context, eventID uint, sport string) error {
+	key := c.generateKey("bracket", EventKey(eventID, sport))
 	return c.Delete(ctx, key)
 }
 
@@ -259,17 +266,18 @@ func (c *cacheManagerImpl) DeleteStatistics(ctx context.Context, key string) err
 	return c.Delete(ctx, cacheKey)
 }
 
-// InvalidateTournamentCache はスポーツ別のトーナメント関連キャッシュを無効化する
-func (c *cacheManagerImpl) InvalidateTournamentCache(ctx context.Context, sport string) error {
+// InvalidateTournamentCache は大会・スポーツ別のトーナメント関連キャッシュを無効化する
+func (c *cacheManagerImpl) InvalidateTournamentCache(ctx context.Context, eventID uint, sport string) error {
 	if !c.enabled {
 		return nil
 	}
 
 	// 関連するキーを削除
+	identifier := EventKey(eventID, sport)
 	keys := []string{
-		c.generateKey("tournament", sport),
-		c.generateKey("matches", sport),
-		c.generateKey("bracket", sport),
+		c.generateKey("tournament", identifier),
+		c.generateKey("matches", identifier),
+		c.generateKey("bracket", identifier),
 	}
 
 	for _, key := range keys {
@@ -278,7 +286,29 @@ func (c *cacheManagerImpl) InvalidateTournamentCache(ctx context.Context, sport
 		}
 	}
 
-	log.Printf("トーナメントキャッシュを無効化しました: %s", sport)
+	log.Printf("トーナメントキャッシュを無効化しました: %s", identifier)
+	return nil
+}
+
+// InvalidateEventCache は大会の全てのキャッシュ（全スポーツ・統計）を無効化する
+func (c *cacheManagerImpl) InvalidateEventCache(ctx context.Context, eventID uint) error {
+	if !c.enabled {
+		return nil
+	}
+
+	pattern := fmt.Sprintf("tournament:*:event:%d:*", eventID)
+	keys, err := c.client.Keys(ctx, pattern).Result()
+	if err != nil {
+		return fmt.Errorf("キー一覧の取得に失敗しました: %w", err)
+	}
+
+	if len(keys) > 0 {
+		if err := c.client.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("キャッシュの一括削除に失敗しました: %w", err)
+		}
+	}
+
+	log.Printf("大会のキャッシュを無効化しました: event_id=%d (%d件)", eventID, len(keys))
 	return nil
 }
 
@@ -37,57 +37,57 @@ func (m *MockCacheManager) Exists(ctx context.Context, key string) (bool, error)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockCacheManager) SetTournament(ctx context.Context, sport string, tournament *models.Tournament) error {
-	args := m.Called(ctx, sport, tournament)
+func (m *MockCacheManager) SetTournament(ctx context.Context, eventID uint, sport string, tournament *models.Tournament) error {
+	args := m.Called(ctx, eventID, sport, tournament)
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) GetTournament(ctx context.Context, sport string) (*models.Tournament, error) {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) GetTournament(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	args := m.Called(ctx, eventID, sport)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Tournament), args.Error(1)
 }
 
-func (m *MockCacheManager) DeleteTournament(ctx context.Context, sport string) error {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) DeleteTournament(ctx context.Context, eventID uint, sport string) error {
+	args := m.Called(ctx, eventID, sport)
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) SetMatches(ctx context.Context, sport string, matches []models.Match) error {
-	args := m.Called(ctx, sport, matches)
+func (m *MockCacheManager) SetMatches(ctx context.Context, eventID uint, sport string, matches []models.Match) error {
+	args := m.Called(ctx, eventID, sport, matches)
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) GetMatches(ctx context.Context, sport string) ([]models.Match, error) {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) GetMatches(ctx context.Context, eventID uint, sport string) ([]models.Match, error) {
+	args := m.Called(ctx, eventID, sport)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Match), args.Error(1)
 }
 
-func (m *MockCacheManager) DeleteMatches(ctx context.Context, sport string) error {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) DeleteMatches(ctx context.Context, eventID uint, sport string) error {
+	args := m.Called(ctx, eventID, sport)
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) SetBracket(ctx context.Context, sport string, bracket *models.Bracket) error {
-	args := m.Called(ctx, sport, bracket)
+func (m *MockCacheManager) SetBracket(ctx context.Context, eventID uint, sport string, bracket *models.Bracket) error {
+	args := m.Called(ctx, eventID, sport, bracket)
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) GetBracket(ctx context.Context, sport string) (*models.Bracket, error) {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) GetBracket(ctx context.Context, eventID uint, sport string) (*models.Bracket, error) {
+	args := m.Called(ctx, eventID, sport)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Bracket), args.Error(1)
 }
 
-func (m *MockCacheManager) DeleteBracket(ctx context.Context, sport string) error {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) DeleteBracket(ctx context.Context, eventID uint, sport string) error {
+	args := m.Called(ctx, eventID, sport)
 	return args.Error(0)
 }
 
@@ -106,8 +106,13 @@ func (m *MockCacheManager) DeleteStatistics(ctx context.Context, key string) err
 	return args.Error(0)
 }
 
-func (m *MockCacheManager) InvalidateTournamentCache(ctx context.Context, sport string) error {
-	args := m.Called(ctx, sport)
+func (m *MockCacheManager) InvalidateTournamentCache(ctx context.Context, eventID uint, sport string) error {
+	args := m.Called(ctx, eventID, sport)
+	return args.Error(0)
+}
+
+func (m *MockCacheManager) InvalidateEventCache(ctx context.Context, eventID uint) error {
+	args := m.Called(ctx, eventID)
 	return args.Error(0)
 }
 
@@ -221,18 +226,18 @@ func TestTournamentCacheOperations(t *testing.T) {
 	}
 
 	t.Run("SetTournament", func(t *testing.T) {
-		err := cache.SetTournament(ctx, "volleyball", tournament)
+		err := cache.SetTournament(ctx, 1, "volleyball", tournament)
 		assert.NoError(t, err)
 	})
 
 	t.Run("GetTournament", func(t *testing.T) {
-		result, err := cache.GetTournament(ctx, "volleyball")
+		result, err := cache.GetTournament(ctx, 1, "volleyball")
 		assert.Error(t, err) // 無効化されたキャッシュではエラー
 		assert.Nil(t, result)
 	})
 
 	t.Run("DeleteTournament", func(t *testing.T) {
-		err := cache.DeleteTournament(ctx, "volleyball")
+		err := cache.DeleteTournament(ctx, 1, "volleyball")
 		assert.NoError(t, err)
 	})
 }
@@ -259,18 +264,18 @@ func TestMatchCacheOperations(t *testing.T) {
 	}
 
 	t.Run("SetMatches", func(t *testing.T) {
-		err := cache.SetMatches(ctx, "volleyball", matches)
+		err := cache.SetMatches(ctx, 1, "volleyball", matches)
 		assert.NoError(t, err)
 	})
 
 	t.Run("GetMatches", func(t *testing.T) {
-		result, err := cache.GetMatches(ctx, "volleyball")
+		result, err := cache.GetMatches(ctx, 1, "volleyball")
 		assert.Error(t, err) // 無効化されたキャッシュではエラー
 		assert.Nil(t, result)
 	})
 
 	t.Run("DeleteMatches", func(t *testing.T) {
-		err := cache.DeleteMatches(ctx, "volleyball")
+		err := cache.DeleteMatches(ctx, 1, "volleyball")
 		assert.NoError(t, err)
 	})
 }
@@ -286,7 +291,12 @@ func TestCacheInvalidation(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("InvalidateTournamentCache", func(t *testing.T) {
-		err := cache.InvalidateTournamentCache(ctx, "volleyball")
+		err := cache.InvalidateTournamentCache(ctx, 1, "volleyball")
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidateEventCache", func(t *testing.T) {
+		err := cache.InvalidateEventCache(ctx, 1)
 		assert.NoError(t, err)
 	})
 
@@ -5,21 +5,24 @@ import (
 	"context"
 	"log"
 	"time"
+
+	"backend/internal/models"
 )
 
 // InvalidationStrategy はキャッシュ無効化戦略のインターフェース
 type InvalidationStrategy interface {
-	// データ更新時の無効化
-	OnTournamentUpdate(ctx context.Context, sport string) error
-	OnMatchUpdate(ctx context.Context, matchID int, sport string) error
-	OnMatchResultUpdate(ctx context.Context, matchID int, sport string) error
+	// データ更新時の無効化（大会・スポーツ別）
+	OnTournamentUpdate(ctx context.Context, eventID uint, sport string) error
+	OnMatchUpdate(ctx context.Context, matchID int, eventID uint, sport string) error
+	OnMatchResultUpdate(ctx context.Context, matchID int, eventID uint, sport string) error
 	
 	// 定期的な無効化
 	SchedulePeriodicInvalidation(ctx context.Context, interval time.Duration)
 	
 	// 手動無効化
 	InvalidateAll(ctx context.Context) error
-	InvalidateBySport(ctx context.Context, sport string) error
+	InvalidateBySport(ctx context.Context, eventID uint, sport string) error
+	InvalidateByEvent(ctx context.Context, eventID uint) error
 }
 
 // invalidationStrategyImpl はInvalidationStrategyの実装
@@ -35,19 +38,19 @@ func NewInvalidationStrategy(cache CacheManager) InvalidationStrategy {
 }
 
 // OnTournamentUpdate はトーナメント更新時のキャッシュ無効化を実行する
-func (s *invalidationStrategyImpl) OnTournamentUpdate(ctx context.Context, sport string) error {
-	log.Printf("トーナメント更新によるキャッシュ無効化: %s", sport)
+func (s *invalidationStrategyImpl) OnTournamentUpdate(ctx context.Context, eventID uint, sport string) error {
+	log.Printf("トーナメント更新によるキャッシュ無効化: event_id=%d, sport=%s", eventID, sport)
 	
 	// トーナメント関連の全キャッシュを無効化
-	if err := s.cache.InvalidateTournamentCache(ctx, sport); err != nil {
+	if err := s.cache.InvalidateTournamentCache(ctx, eventID, sport); err != nil {
 		log.Printf("トーナメントキャッシュ無効化エラー: %v", err)
 		return err
 	}
 	
 	// 統計キャッシュも無効化
 	statsKeys := []string{
-		sport + "_progress",
-		sport + "_statistics",
+		EventKey(eventID, sport) + "_progress",
+		EventKey(eventID, sport) + "_statistics",
 		"tournament_list",
 	}
 	
@@ -61,24 +64,24 @@ func (s *invalidationStrategyImpl) OnTournamentUpdate(ctx context.Context, sport
 }
 
 // OnMatchUpdate は試合更新時のキャッシュ無効化を実行する
-func (s *invalidationStrategyImpl) OnMatchUpdate(ctx context.Context, matchID int, sport string) error {
-	log.Printf("試合更新によるキャッシュ無効化: match_id=%d, sport=%s", matchID, sport)
+func (s *invalidationStrategyImpl) OnMatchUpdate(ctx context.Context, matchID int, eventID uint, sport string) error {
+	log.Printf("試合更新によるキャッシュ無効化: match_id=%d, event_id=%d, sport=%s", matchID, eventID, sport)
 	
 	// 試合関連キャッシュを無効化
-	if err := s.cache.DeleteMatches(ctx, sport); err != nil {
+	if err := s.cache.DeleteMatches(ctx, eventID, sport); err != nil {
 		log.Printf("試合キャッシュ無効化エラー: %v", err)
 		return err
 	}
 	
 	// ブラケットキャッシュも無効化（試合更新でブラケット構造が変わる可能性）
-	if err := s.cache.DeleteBracket(ctx, sport); err != nil {
+	if err := s.cache.DeleteBracket(ctx, eventID, sport); err != nil {
 		log.Printf("ブラケットキャッシュ無効化エラー: %v", err)
 	}
 	
 	// 統計キャッシュも無効化
 	statsKeys := []string{
-		sport + "_statistics",
-		sport + "_progress",
+		EventKey(eventID, sport) + "_statistics",
+		EventKey(eventID, sport) + "_progress",
 		"match_statistics",
 	}
 	
@@ -92,19 +95,19 @@ func (s *invalidationStrategyImpl) OnMatchUpdate(ctx context.Context, matchID in
 }
 
 // OnMatchResultUpdate は試合結果更新時のキャッシュ無効化を実行する
-func (s *invalidationStrategyImpl) OnMatchResultUpdate(ctx context.Context, matchID int, sport string) error {
-	log.Printf("試合結果更新によるキャッシュ無効化: match_id=%d, sport=%s", matchID, sport)
+func (s *invalidationStrategyImpl) OnMatchResultUpdate(ctx context.Context, matchID int, eventID uint, sport string) error {
+	log.Printf("試合結果更新によるキャッシュ無効化: match_id=%d, event_id=%d, sport=%s", matchID, eventID, sport)
 	
 	// 試合結果更新は特に重要なので、関連する全キャッシュを無効化
-	if err := s.cache.InvalidateTournamentCache(ctx, sport); err != nil {
+	if err := s.cache.InvalidateTournamentCache(ctx, eventID, sport); err != nil {
 		log.Printf("トーナメントキャッシュ無効化エラー: %v", err)
 		return err
 	}
 	
 	// 統計キャッシュを無効化
 	statsKeys := []string{
-		sport + "_statistics",
-		sport + "_progress",
+		EventKey(eventID, sport) + "_statistics",
+		EventKey(eventID, sport) + "_progress",
 		"match_statistics",
 		"team_statistics",
 		"tournament_progress",
@@ -135,10 +138,8 @@ func (s *invalidationStrategyImpl) SchedulePeriodicInvalidation(ctx context.Cont
 				log.Println("定期キャッシュ無効化を実行します")
 				
 				// 統計キャッシュのみ定期的に無効化（データの整合性を保つため）
+				// 大会・スポーツ別の統計は有効期限で更新される
 				statsKeys := []string{
-					"volleyball_statistics",
-					"table_tennis_statistics", 
-					"soccer_statistics",
 					"tournament_progress",
 					"match_statistics",
 				}
@@ -168,19 +169,19 @@ func (s *invalidationStrategyImpl) InvalidateAll(ctx context.Context) error {
 	return nil
 }
 
-// InvalidateBySport はスポーツ別キャッシュを無効化する
-func (s *invalidationStrategyImpl) InvalidateBySport(ctx context.Context, sport string) error {
-	log.Printf("スポーツ別キャッシュ無効化を実行します: %s", sport)
+// InvalidateBySport は大会のスポーツ別キャッシュを無効化する
+func (s *invalidationStrategyImpl) InvalidateBySport(ctx context.Context, eventID uint, sport string) error {
+	log.Printf("スポーツ別キャッシュ無効化を実行します: event_id=%d, sport=%s", eventID, sport)
 	
-	if err := s.cache.InvalidateTournamentCache(ctx, sport); err != nil {
+	if err := s.cache.InvalidateTournamentCache(ctx, eventID, sport); err != nil {
 		log.Printf("スポーツ別キャッシュ無効化エラー: %v", err)
 		return err
 	}
 	
 	// 統計キャッシュも無効化
 	statsKeys := []string{
-		sport + "_statistics",
-		sport + "_progress",
+		EventKey(eventID, sport) + "_statistics",
+		EventKey(eventID, sport) + "_progress",
 	}
 	
 	for _, key := range statsKeys {
@@ -189,28 +190,40 @@ func (s *invalidationStrategyImpl) InvalidateBySport(ctx context.Context, sport
 		}
 	}
 	
-	log.Printf("スポーツ別キャッシュ無効化が完了しました: %s", sport)
+	log.Printf("スポーツ別キャッシュ無効化が完了しました: event_id=%d, sport=%s", eventID, sport)
+	return nil
+}
+
+// InvalidateByEvent は大会の全キャッシュを無効化する
+func (s *invalidationStrategyImpl) InvalidateByEvent(ctx context.Context, eventID uint) error {
+	log.Printf("大会別キャッシュ無効化を実行します: event_id=%d", eventID)
+	
+	if err := s.cache.InvalidateEventCache(ctx, eventID); err != nil {
+		log.Printf("大会別キャッシュ無効化エラー: %v", err)
+		return err
+	}
+	
 	return nil
 }
 
 // CacheWarmer はキャッシュのウォームアップ機能を提供する
 type CacheWarmer interface {
-	WarmupTournamentCache(ctx context.Context, sport string) error
-	WarmupAllCache(ctx context.Context) error
+	WarmupTournamentCache(ctx context.Context, eventID uint, sport string) error
+	WarmupAllCache(ctx context.Context, eventID uint) error
 }
 
 // cacheWarmerImpl はCacheWarmerの実装
 type cacheWarmerImpl struct {
 	cache           CacheManager
-	tournamentRepo  interface{ GetBySport(ctx context.Context, sport string) (*models.Tournament, error) }
-	matchRepo       interface{ GetBySport(ctx context.Context, sport string) ([]models.Match, error) }
+	tournamentRepo  interface{ GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) }
+	matchRepo       interface{ GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]models.Match, error) }
 }
 
 // NewCacheWarmer は新しいキャッシュウォーマーインスタンスを作成する
 func NewCacheWarmer(
 	cache CacheManager,
-	tournamentRepo interface{ GetBySport(ctx context.Context, sport string) (*models.Tournament, error) },
-	matchRepo interface{ GetBySport(ctx context.Context, sport string) ([]models.Match, error) },
+	tournamentRepo interface{ GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) },
+	matchRepo interface{ GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]models.Match, error) },
 ) CacheWarmer {
 	return &cacheWarmerImpl{
 		cache:          cache,
@@ -219,36 +232,36 @@ func NewCacheWarmer(
 	}
 }
 
-// WarmupTournamentCache はスポーツ別キャッシュをウォームアップする
-func (w *cacheWarmerImpl) WarmupTournamentCache(ctx context.Context, sport string) error {
-	log.Printf("キャッシュウォームアップを開始します: %s", sport)
+// WarmupTournamentCache は大会のスポーツ別キャッシュをウォームアップする
+func (w *cacheWarmerImpl) WarmupTournamentCache(ctx context.Context, eventID uint, sport string) error {
+	log.Printf("キャッシュウォームアップを開始します: event_id=%d, sport=%s", eventID, sport)
 	
 	// トーナメントデータをプリロード
-	if tournament, err := w.tournamentRepo.GetBySport(ctx, sport); err == nil {
-		if err := w.cache.SetTournament(ctx, sport, tournament); err != nil {
+	if tournament, err := w.tournamentRepo.GetByEventAndSport(ctx, eventID, sport); err == nil && tournament != nil {
+		if err := w.cache.SetTournament(ctx, eventID, sport, tournament); err != nil {
 			log.Printf("トーナメントキャッシュウォームアップエラー: %v", err)
 		}
 	}
 	
 	// 試合データをプリロード
-	if matches, err := w.matchRepo.GetBySport(ctx, sport); err == nil {
-		if err := w.cache.SetMatches(ctx, sport, matches); err != nil {
+	if matches, err := w.matchRepo.GetByEventAndSport(ctx, eventID, sport); err == nil {
+		if err := w.cache.SetMatches(ctx, eventID, sport, matches); err != nil {
 			log.Printf("試合キャッシュウォームアップエラー: %v", err)
 		}
 	}
 	
-	log.Printf("キャッシュウォームアップが完了しました: %s", sport)
+	log.Printf("キャッシュウォームアップが完了しました: event_id=%d, sport=%s", eventID, sport)
 	return nil
 }
 
-// WarmupAllCache は全スポーツのキャッシュをウォームアップする
-func (w *cacheWarmerImpl) WarmupAllCache(ctx context.Context) error {
-	log.Println("全キャッシュウォームアップを開始します")
+// WarmupAllCache は大会の全スポーツのキャッシュをウォームアップする
+func (w *cacheWarmerImpl) WarmupAllCache(ctx context.Context, eventID uint) error {
+	log.Printf("全キャッシュウォームアップを開始します: event_id=%d", eventID)
 	
-	sports := []string{"volleyball", "table_tennis", "soccer"}
+	sports := models.RegisteredSportCodes()
 	
 	for _, sport := range sports {
-		if err := w.WarmupTournamentCache(ctx, sport); err != nil {
+		if err := w.WarmupTournamentCache(ctx, eventID, sport); err != nil {
 			log.Printf("キャッシュウォームアップエラー (%s): %v", sport, err)
 		}
 	}
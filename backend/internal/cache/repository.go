@@ -25,24 +25,24 @@ func NewCachedTournamentRepository(repo repository.TournamentRepository, cache C
 	}
 }
 
-// GetBySport はスポーツ別トーナメントを取得する（キャッシュ対応）
-func (r *CachedTournamentRepository) GetBySport(ctx context.Context, sport string) (*models.Tournament, error) {
+// GetByEventAndSport は大会のスポーツ別トーナメントを取得する（キャッシュ対応）
+func (r *CachedTournamentRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
 	// キャッシュから取得を試行
-	if tournament, err := r.cache.GetTournament(ctx, sport); err == nil {
-		log.Printf("トーナメントキャッシュヒット: %s", sport)
+	if tournament, err := r.cache.GetTournament(ctx, eventID, sport); err == nil {
+		log.Printf("トーナメントキャッシュヒット: %s", EventKey(eventID, sport))
 		return tournament, nil
 	} else if err != redis.Nil {
 		log.Printf("キャッシュ取得エラー: %v", err)
 	}
 
 	// キャッシュミスの場合はDBから取得
-	tournament, err := r.repo.GetBySport(ctx, sport)
-	if err != nil {
-		return nil, err
+	tournament, err := r.repo.GetByEventAndSport(ctx, eventID, sport)
+	if err != nil || tournament == nil {
+		return tournament, err
 	}
 
 	// 取得したデータをキャッシュに保存
-	if err := r.cache.SetTournament(ctx, sport, tournament); err != nil {
+	if err := r.cache.SetTournament(ctx, eventID, sport, tournament); err != nil {
 		log.Printf("トーナメントキャッシュ保存エラー: %v", err)
 	}
 
@@ -57,7 +57,7 @@ func (r *CachedTournamentRepository) Update(ctx context.Context, tournament *mod
 	}
 
 	// 関連キャッシュを無効化
-	if err := r.cache.InvalidateTournamentCache(ctx, tournament.Sport); err != nil {
+	if err := r.cache.InvalidateTournamentCache(ctx, uint(tournament.EventID), tournament.Sport); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -72,7 +72,7 @@ func (r *CachedTournamentRepository) Create(ctx context.Context, tournament *mod
 	}
 
 	// 関連キャッシュを無効化
-	if err := r.cache.InvalidateTournamentCache(ctx, tournament.Sport); err != nil {
+	if err := r.cache.InvalidateTournamentCache(ctx, uint(tournament.EventID), tournament.Sport); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -93,7 +93,7 @@ func (r *CachedTournamentRepository) Delete(ctx context.Context, id int) error {
 	}
 
 	// 関連キャッシュを無効化
-	if err := r.cache.InvalidateTournamentCache(ctx, tournament.Sport); err != nil {
+	if err := r.cache.InvalidateTournamentCache(ctx, uint(tournament.EventID), tournament.Sport); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -109,26 +109,26 @@ func (r *CachedTournamentRepository) GetByID(ctx context.Context, id int) (*mode
 	return r.repo.GetByID(ctx, id)
 }
 
-func (r *CachedTournamentRepository) UpdateFormat(ctx context.Context, sport, format string) error {
+func (r *CachedTournamentRepository) UpdateFormat(ctx context.Context, eventID uint, sport, format string) error {
 	if err := r.repo.UpdateFormat(ctx, sport, format); err != nil {
 		return err
 	}
 	
 	// キャッシュを無効化
-	if err := r.cache.InvalidateTournamentCache(ctx, sport); err != nil {
+	if err := r.cache.InvalidateTournamentCache(ctx, eventID, sport); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 	
 	return nil
 }
 
-func (r *CachedTournamentRepository) UpdateStatus(ctx context.Context, sport, status string) error {
+func (r *CachedTournamentRepository) UpdateStatus(ctx context.Context, eventID uint, sport, status string) error {
 	if err := r.repo.UpdateStatus(ctx, sport, status); err != nil {
 		return err
 	}
 	
 	// キャッシュを無効化
-	if err := r.cache.InvalidateTournamentCache(ctx, sport); err != nil {
+	if err := r.cache.InvalidateTournamentCache(ctx, eventID, sport); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 	
@@ -149,24 +149,29 @@ func NewCachedMatchRepository(repo repository.MatchRepository, cache CacheManage
 	}
 }
 
-// GetBySport はスポーツ別試合一覧を取得する（キャッシュ対応）
-func (r *CachedMatchRepository) GetBySport(ctx context.Context, sport string) ([]models.Match, error) {
+// GetByEventAndSport は大会のスポーツ別試合一覧を取得する（キャッシュ対応）
+func (r *CachedMatchRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]models.Match, error) {
 	// キャッシュから取得を試行
-	if matches, err := r.cache.GetMatches(ctx, sport); err == nil {
-		log.Printf("試合キャッシュヒット: %s", sport)
+	if matches, err := r.cache.GetMatches(ctx, eventID, sport); err == nil {
+		log.Printf("試合キャッシュヒット: %s", EventKey(eventID, sport))
 		return matches, nil
 	} else if err != redis.Nil {
 		log.Printf("キャッシュ取得エラー: %v", err)
 	}
 
 	// キャッシュミスの場合はDBから取得
-	matches, err := r.repo.GetBySport(ctx, sport)
+	found, err := r.repo.GetByEventAndSport(ctx, eventID, sport)
 	if err != nil {
 		return nil, err
 	}
 
+	matches := make([]models.Match, 0, len(found))
+	for _, match := range found {
+		matches = append(matches, *match)
+	}
+
 	// 取得したデータをキャッシュに保存
-	if err := r.cache.SetMatches(ctx, sport, matches); err != nil {
+	if err := r.cache.SetMatches(ctx, eventID, sport, matches); err != nil {
 		log.Printf("試合キャッシュ保存エラー: %v", err)
 	}
 
@@ -189,7 +194,7 @@ func (r *CachedMatchRepository) Update(ctx context.Context, match *models.Match)
 	// トーナメントIDからスポーツを特定してキャッシュを無効化
 	// 注: 実際の実装では、トーナメントリポジトリを使ってスポーツを取得する必要がある
 	// ここでは簡略化のため、試合キャッシュのみ無効化
-	if err := r.cache.DeleteMatches(ctx, "all"); err != nil {
+	if err := r.cache.DeleteMatches(ctx, 0, "all"); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -204,7 +209,7 @@ func (r *CachedMatchRepository) Create(ctx context.Context, match *models.Match)
 	}
 
 	// 全試合キャッシュを無効化（スポーツ特定が困難なため）
-	if err := r.cache.DeleteMatches(ctx, "all"); err != nil {
+	if err := r.cache.DeleteMatches(ctx, 0, "all"); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -219,7 +224,7 @@ func (r *CachedMatchRepository) UpdateResult(ctx context.Context, matchID int, s
 	}
 
 	// 全試合キャッシュを無効化
-	if err := r.cache.DeleteMatches(ctx, "all"); err != nil {
+	if err := r.cache.DeleteMatches(ctx, 0, "all"); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 
@@ -245,7 +250,7 @@ func (r *CachedMatchRepository) Delete(ctx context.Context, id int) error {
 	}
 	
 	// 全試合キャッシュを無効化
-	if err := r.cache.DeleteMatches(ctx, "all"); err != nil {
+	if err := r.cache.DeleteMatches(ctx, 0, "all"); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 	
@@ -266,7 +271,7 @@ func (r *CachedMatchRepository) UpdateStatus(ctx context.Context, matchID int, s
 	}
 	
 	// 全試合キャッシュを無効化
-	if err := r.cache.DeleteMatches(ctx, "all"); err != nil {
+	if err := r.cache.DeleteMatches(ctx, 0, "all"); err != nil {
 		log.Printf("キャッシュ無効化エラー: %v", err)
 	}
 	
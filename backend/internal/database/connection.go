@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -91,6 +92,15 @@ func (db *DB) BeginTx() (*sql.Tx, error) {
 	return db.DB.Begin()
 }
 
+// BeginTxContext はコンテキスト付きでトランザクションを開始する
+// コンテキストがキャンセルされるとトランザクションはロールバックされる
+func (db *DB) BeginTxContext(ctx context.Context) (*sql.Tx, error) {
+	if db.DB == nil {
+		return nil, fmt.Errorf("データベース接続が初期化されていません")
+	}
+	return db.DB.BeginTx(ctx, nil)
+}
+
 // GetStats はデータベース接続の統計情報を取得する
 func (db *DB) GetStats() sql.DBStats {
 	if db.DB == nil {
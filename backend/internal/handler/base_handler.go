@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"backend/internal/models"
+	"backend/internal/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -18,11 +20,20 @@ type BaseHandler struct {
 
 // NewBaseHandler は新しいBaseHandlerを作成する
 func NewBaseHandler() *BaseHandler {
+	v := validator.New()
+	// 種目は種目のレジストリで管理するため、タグに値を列挙せず sport タグで検証する
+	_ = v.RegisterValidation("sport", validateSport)
+
 	return &BaseHandler{
-		validator: validator.New(),
+		validator: v,
 	}
 }
 
+// validateSport は値が種目のレジストリに登録された種目かどうかを検証する
+func validateSport(fl validator.FieldLevel) bool {
+	return models.SportType(fl.Field().String()).IsValid()
+}
+
 // SendSuccess は成功レスポンスを送信する
 // data: レスポンスデータ
 // message: 成功メッセージ
@@ -49,6 +60,9 @@ func (h *BaseHandler) SendSuccess(c *gin.Context, data interface{}, message stri
 // apiError: APIErrorオブジェクト
 func (h *BaseHandler) SendError(c *gin.Context, apiError *models.APIError) {
 	response := models.NewErrorResponseUnified(apiError.Code, apiError.Message, apiError.StatusCode)
+	if len(apiError.Details) > 0 {
+		response.Details = apiError.Details
+	}
 	
 	// リクエストIDが設定されている場合は追加
 	if requestID, exists := c.Get("request_id"); exists {
@@ -172,6 +186,35 @@ func (h *BaseHandler) SendInternalServerError(c *gin.Context, message ...string)
 	h.SendError(c, models.NewAPIError(models.ErrorSystemUnknownError, msg, http.StatusInternalServerError))
 }
 
+// SendServiceError はサービス層のエラーを種別に応じたエラーレスポンスとして送信する
+// fallbackMessage: サービスエラー以外、または内部エラーの場合のメッセージ
+func (h *BaseHandler) SendServiceError(c *gin.Context, err error, fallbackMessage string) {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		h.SendInternalServerError(c, fallbackMessage)
+		return
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeValidation:
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, serviceErr.Message, http.StatusBadRequest)
+	case service.ErrorTypeNotFound:
+		h.SendNotFound(c, serviceErr.Message)
+	case service.ErrorTypeConflict:
+		h.SendErrorWithCode(c, models.ErrorResourceConflict, serviceErr.Message, http.StatusConflict)
+	case service.ErrorTypeInvalidTransition:
+		apiErr := models.NewAPIError(serviceErr.ErrorCode, serviceErr.Message, http.StatusConflict)
+		for key, value := range serviceErr.Details {
+			apiErr.WithDetails(key, value)
+		}
+		h.SendError(c, apiErr)
+	case service.ErrorTypeDatabase:
+		h.SendErrorWithCode(c, models.ErrorSystemDatabaseError, fallbackMessage, http.StatusInternalServerError)
+	default:
+		h.SendInternalServerError(c, fallbackMessage)
+	}
+}
+
 // GetUserID はコンテキストからユーザーIDを取得する
 func (h *BaseHandler) GetUserID(c *gin.Context) (int, bool) {
 	userID, exists := c.Get("user_id")
@@ -219,6 +262,38 @@ func (h *BaseHandler) GetUserRole(c *gin.Context) (string, bool) {
 	return "", false
 }
 
+// GetEventID はパスパラメータまたはクエリパラメータから大会IDを取得する
+// 指定がない場合は0（現在の大会）を返し、無効な値の場合は400エラーを送信してfalseを返す
+func (h *BaseHandler) GetEventID(c *gin.Context) (uint, bool) {
+	value := c.Param("event_id")
+	if value == "" {
+		value = c.Query("event_id")
+	}
+	if value == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な大会IDです", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// GetIDParam はパスパラメータを正のIDとして取得する
+// 無効な値の場合は指定されたメッセージで400エラーを送信してfalseを返す
+func (h *BaseHandler) GetIDParam(c *gin.Context, name, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, message, http.StatusBadRequest)
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
 // ValidateStruct は構造体のバリデーションを実行する
 func (h *BaseHandler) ValidateStruct(s interface{}) error {
 	return h.validator.Struct(s)
@@ -245,6 +320,8 @@ func (h *BaseHandler) getValidationErrorMessage(fe validator.FieldError) string
 		return "英数字のみ入力可能です"
 	case "oneof":
 		return "許可された値のいずれかを選択してください: " + fe.Param()
+	case "sport":
+		return "登録されている種目を選択してください: " + strings.Join(models.RegisteredSportCodes(), ", ")
 	default:
 		return "入力値が無効です"
 	}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChampionshipHandler は総合順位（総合優勝）のHTTPハンドラー
+type ChampionshipHandler struct {
+	*BaseHandler
+	championshipService service.ChampionshipService
+}
+
+// NewChampionshipHandler は新しいChampionshipHandlerを作成する
+func NewChampionshipHandler(championshipService service.ChampionshipService) *ChampionshipHandler {
+	return &ChampionshipHandler{
+		BaseHandler:         NewBaseHandler(),
+		championshipService: championshipService,
+	}
+}
+
+// GetChampionship は総合順位取得エンドポイントハンドラー
+// @Summary 総合順位取得
+// @Description 全種目の最終順位に種目ごとの順位点を与え、クラスの総合順位と学年・学科ごとの合計を取得する。大会IDを省略した場合は今年度の大会を対象とする。順位が変わると championship_update メッセージで全ての接続に通知する
+// @Tags championship
+// @Produce json
+// @Param event_id path int false "大会ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/championship [get]
+// @Router /api/public/championship [get]
+func (h *ChampionshipHandler) GetChampionship(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	championship, err := h.championshipService.GetChampionship(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "総合順位の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, championship, "総合順位を取得しました")
+}
+
+// GetTournamentPlacements はトーナメントの最終順位取得エンドポイントハンドラー
+// @Summary トーナメントの最終順位取得
+// @Description 指定されたトーナメントで確定した最終順位と、総合順位に加算される順位点を取得する
+// @Tags championship
+// @Produce json
+// @Param id path int true "トーナメントID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/tournaments/{id}/placements [get]
+func (h *ChampionshipHandler) GetTournamentPlacements(c *gin.Context) {
+	tournamentID, ok := h.GetIDParam(c, "id", "無効なトーナメントIDです")
+	if !ok {
+		return
+	}
+
+	placements, err := h.championshipService.GetTournamentPlacements(c.Request.Context(), tournamentID)
+	if err != nil {
+		h.SendServiceError(c, err, "最終順位の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, placements, "最終順位を取得しました")
+}
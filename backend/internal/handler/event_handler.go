@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventHandler は大会（スポーツ大会の開催回）関連のHTTPハンドラー
+type EventHandler struct {
+	*BaseHandler
+	eventService service.EventService
+}
+
+// NewEventHandler は新しいEventHandlerを作成する
+func NewEventHandler(eventService service.EventService) *EventHandler {
+	return &EventHandler{
+		BaseHandler:  NewBaseHandler(),
+		eventService: eventService,
+	}
+}
+
+// EventRequest は大会の登録・更新リクエストの構造体
+type EventRequest struct {
+	Name      string `json:"name" binding:"required,max=100" example:"2024年度 春季スポーツ大会"` // 大会名
+	StartDate string `json:"start_date" binding:"required" example:"2024-05-20"`        // 開始日（YYYY-MM-DD）
+	EndDate   string `json:"end_date" binding:"required" example:"2024-05-21"`          // 終了日（YYYY-MM-DD）
+	Timezone  string `json:"timezone,omitempty" example:"Asia/Tokyo"`                   // タイムゾーン（省略時はAsia/Tokyo、更新時は変更なし）
+	Status    string `json:"status,omitempty" example:"planned"`                        // ステータス（planned/ongoing/archived）
+}
+
+// toEvent はリクエストを大会に変換する
+func (r *EventRequest) toEvent() (*models.Event, error) {
+	startDate, err := models.ParseEventDate(r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := models.ParseEventDate(r.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Event{
+		Name:      strings.TrimSpace(r.Name),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Timezone:  strings.TrimSpace(r.Timezone),
+		Status:    strings.TrimSpace(r.Status),
+	}, nil
+}
+
+// EventListResponse は大会一覧レスポンスの構造体
+type EventListResponse struct {
+	Success bool            `json:"success" example:"true"`        // 成功フラグ
+	Message string          `json:"message" example:"大会一覧を取得しました"` // メッセージ
+	Data    []*models.Event `json:"data"`                          // 大会
+}
+
+// GetEvents は大会一覧取得エンドポイントハンドラー
+// @Summary 大会一覧取得
+// @Description 過去のアーカイブを含む大会の一覧を新しい順に取得する
+// @Tags events
+// @Produce json
+// @Success 200 {object} EventListResponse "取得成功"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events [get]
+func (h *EventHandler) GetEvents(c *gin.Context) {
+	events, err := h.eventService.ListEvents(c.Request.Context())
+	if err != nil {
+		h.SendServiceError(c, err, "大会一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, events, "大会一覧を取得しました")
+}
+
+// GetCurrentEvent は現在の大会取得エンドポイントハンドラー
+// @Summary 現在の大会取得
+// @Description 大会IDを指定しないリクエストが参照する大会（開催中、なければ次に予定されている大会、なければ直近のアーカイブ）を取得する
+// @Tags events
+// @Produce json
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Router /api/public/events/current [get]
+func (h *EventHandler) GetCurrentEvent(c *gin.Context) {
+	event, err := h.eventService.GetCurrentEvent(c.Request.Context())
+	if err != nil {
+		h.SendServiceError(c, err, "大会の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, event, "大会を取得しました")
+}
+
+// GetEvent は大会取得エンドポイントハンドラー
+// @Summary 大会取得
+// @Description 指定された大会を取得する
+// @Tags events
+// @Produce json
+// @Param event_id path int true "大会ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Router /api/public/events/{event_id} [get]
+func (h *EventHandler) GetEvent(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventService.GetEvent(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "大会の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, event, "大会を取得しました")
+}
+
+// GetEventTournaments は大会のトーナメント一覧取得エンドポイントハンドラー
+// @Summary 大会のトーナメント一覧取得
+// @Description 指定された大会の種目別トーナメントを取得する
+// @Tags events
+// @Produce json
+// @Param event_id path int true "大会ID"
+// @Success 200 {object} TournamentListResponse "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/tournaments [get]
+func (h *EventHandler) GetEventTournaments(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	tournaments, err := h.eventService.GetEventTournaments(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "トーナメント一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, convertToSwaggerTournaments(tournaments), "トーナメント一覧を取得しました")
+}
+
+// CreateEvent は大会登録エンドポイントハンドラー
+// @Summary 大会登録
+// @Description 新しい大会を登録する。トーナメントは大会ごとに種目単位で作成する（管理者のみ）
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EventRequest true "大会の情報"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events [post]
+func (h *EventHandler) CreateEvent(c *gin.Context) {
+	var req EventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	event, err := req.toEvent()
+	if err != nil {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.eventService.CreateEvent(c.Request.Context(), event); err != nil {
+		h.SendServiceError(c, err, "大会の登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, event, "大会を登録しました", http.StatusCreated)
+}
+
+// UpdateEvent は大会更新エンドポイントハンドラー
+// @Summary 大会更新
+// @Description 大会の名称・開催期間・タイムゾーン・ステータスを更新する。終了した大会はアーカイブとして変更できない（管理者のみ）
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param event_id path int true "大会ID"
+// @Param request body EventRequest true "大会の情報"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events/{event_id} [put]
+func (h *EventHandler) UpdateEvent(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	var req EventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	event, err := req.toEvent()
+	if err != nil {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.eventService.UpdateEvent(c.Request.Context(), eventID, event); err != nil {
+		h.SendServiceError(c, err, "大会の更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, event, "大会を更新しました")
+}
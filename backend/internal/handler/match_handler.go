@@ -34,6 +34,8 @@ type UpdateMatchRequest struct {
 	Round       string `json:"round"`
 	Team1       string `json:"team1"`
 	Team2       string `json:"team2"`
+	Team1ID     *int   `json:"team1_id,omitempty"` // 登録チームのID（指定時はチーム名より優先）
+	Team2ID     *int   `json:"team2_id,omitempty"`
 	Status      string `json:"status"`
 	ScheduledAt string `json:"scheduled_at"`
 }
@@ -45,6 +47,40 @@ type SubmitMatchResultRequest struct {
 	Winner string `json:"winner" binding:"required"`
 }
 
+// CorrectMatchResultRequest は試合結果訂正リクエストの構造体
+type CorrectMatchResultRequest struct {
+	Score1          int                   `json:"score1" binding:"min=0" example:"2"`
+	Score2          int                   `json:"score2" binding:"min=0" example:"1"`
+	ExtraTimeScore1 *int                  `json:"extra_time_score1,omitempty" binding:"omitempty,min=0"` // 延長戦終了時のスコア（正規時間を含む）
+	ExtraTimeScore2 *int                  `json:"extra_time_score2,omitempty" binding:"omitempty,min=0"`
+	PenaltyScore1   *int                  `json:"penalty_score1,omitempty" binding:"omitempty,min=0"` // PK戦のスコア
+	PenaltyScore2   *int                  `json:"penalty_score2,omitempty" binding:"omitempty,min=0"`
+	DecisionMethod  models.DecisionMethod `json:"decision_method,omitempty" example:"regulation"`       // 省略時はスコアから判定
+	Sets            []models.SetScore     `json:"sets,omitempty"`                                       // セットごとのスコア（指定した場合はスコアをセットから算出）
+	ResultType      models.ResultType     `json:"result_type,omitempty" example:"played"`               // 不戦勝・棄権・失格の場合に指定（スコアは既定値）
+	ForfeitingTeam  string                `json:"forfeiting_team,omitempty" binding:"max=100"`          // 棄権・不出場・失格となったチーム
+	Winner          string                `json:"winner" example:"チームA"`                                // 引き分けの場合は空
+	Reason          string                `json:"reason" binding:"required,max=500" example:"スコアの入力誤り"` // 訂正理由
+	ConfirmReset    bool                  `json:"confirm_reset" example:"false"`                        // 実施済みの後続の試合の結果を取り消す場合はtrue
+}
+
+// ChangeMatchStatusRequest は試合ステータス変更リクエストの構造体
+type ChangeMatchStatusRequest struct {
+	Status      models.MatchStatus `json:"status" binding:"required" example:"postponed"`                 // 変更後のステータス（in_progress, suspended, postponed, rescheduled, pending, cancelled）
+	ScheduledAt *time.Time         `json:"scheduled_at,omitempty" example:"2024-05-21T10:00:00+09:00"` // rescheduled の場合の新しい開始時刻
+}
+
+// AppendMatchEventRequest は試合経過の記録リクエストの構造体
+type AppendMatchEventRequest struct {
+	Type     models.MatchEventType `json:"type" binding:"required" example:"goal"`                  // 出来事の種類（kickoff, goal, set_won, timeout, card, end）
+	Slot     *int                  `json:"slot,omitempty" example:"1"`                              // 対象チームの枠（1: team1, 2: team2）
+	Minute   *int                  `json:"minute,omitempty" binding:"omitempty,min=0" example:"12"` // 試合開始からの経過分
+	SetScore *models.SetScore      `json:"set_score,omitempty"`                                     // set_won の場合のセットのスコア
+	Card     *models.CardColor     `json:"card,omitempty" example:"yellow"`                         // card の場合のカードの色
+	PlayerID *int                  `json:"player_id,omitempty" example:"3"`                         // 得点・警告の選手（対象チームの名簿の選手ID）
+	Note     string                `json:"note,omitempty" binding:"max=200" example:"10番"`          // 補足（選手名など）
+}
+
 // MatchResponse は試合レスポンスの構造体
 type MatchResponse struct {
 	Success bool   `json:"success" example:"true"`          // 成功フラグ
@@ -62,19 +98,37 @@ type MatchListResponse struct {
 
 // Match はSwagger用の試合構造体
 type Match struct {
-	ID           int     `json:"id" example:"1"`                                      // 試合ID
-	TournamentID int     `json:"tournament_id" example:"1"`                           // トーナメントID
-	Round        string  `json:"round" example:"1st_round"`                           // ラウンド名
-	Team1        string  `json:"team1" example:"チームA"`                               // チーム1
-	Team2        string  `json:"team2" example:"チームB"`                               // チーム2
-	Score1       *int    `json:"score1" example:"3"`                                 // チーム1のスコア
-	Score2       *int    `json:"score2" example:"1"`                                 // チーム2のスコア
-	Winner       *string `json:"winner" example:"チームA"`                             // 勝者
-	Status       string  `json:"status" example:"pending"`                           // 試合ステータス
-	ScheduledAt  string  `json:"scheduled_at" example:"2024-01-01T10:00:00Z"`       // 予定日時
-	CompletedAt  *string `json:"completed_at" example:"2024-01-01T11:00:00Z"`       // 完了日時
-	CreatedAt    string  `json:"created_at" example:"2024-01-01T09:00:00Z"`         // 作成日時
-	UpdatedAt    string  `json:"updated_at" example:"2024-01-01T11:00:00Z"`         // 更新日時
+	ID               int               `json:"id" example:"1"`                                 // 試合ID
+	TournamentID     int               `json:"tournament_id" example:"1"`                      // トーナメントID
+	Round            string            `json:"round" example:"1st_round"`                      // ラウンド名
+	Position         int               `json:"position" example:"0"`                           // ラウンド内の位置
+	Team1            string            `json:"team1" example:"チームA"`                           // チーム1
+	Team2            string            `json:"team2" example:"チームB"`                           // チーム2
+	Team1ID          *int              `json:"team1_id,omitempty" example:"1"`                 // チーム1の登録チームID
+	Team2ID          *int              `json:"team2_id,omitempty" example:"2"`                 // チーム2の登録チームID
+	Score1           *int              `json:"score1" example:"3"`                             // チーム1のスコア（正規時間）
+	Score2           *int              `json:"score2" example:"1"`                             // チーム2のスコア（正規時間）
+	ExtraTimeScore1  *int              `json:"extra_time_score1,omitempty" example:"2"`        // チーム1の延長戦終了時のスコア
+	ExtraTimeScore2  *int              `json:"extra_time_score2,omitempty" example:"2"`        // チーム2の延長戦終了時のスコア
+	PenaltyScore1    *int              `json:"penalty_score1,omitempty" example:"4"`           // チーム1のPK戦のスコア
+	PenaltyScore2    *int              `json:"penalty_score2,omitempty" example:"3"`           // チーム2のPK戦のスコア
+	DecisionMethod   string            `json:"decision_method,omitempty" example:"penalties"`  // 決着方法
+	ResultType       string            `json:"result_type,omitempty" example:"played"`         // 結果の種類（played, walkover, forfeit, double_forfeit, disqualification）
+	ForfeitingTeam   *string           `json:"forfeiting_team,omitempty" example:"チームB"`       // 棄権・不出場・失格となったチーム
+	ForfeitingTeamID *int              `json:"forfeiting_team_id,omitempty" example:"2"`       // 棄権・不出場・失格となった登録チームのID
+	Sets             []models.SetScore `json:"sets,omitempty"`                                 // セットごとのスコア
+	ScoreDisplay     string            `json:"score_display,omitempty" example:"2-2 (PK 4-3)"` // スコアの表示用文字列
+	Winner           *string           `json:"winner" example:"チームA"`                          // 勝者
+	WinnerID         *int              `json:"winner_id,omitempty" example:"1"`                // 勝者の登録チームID
+	NextMatchID      *int              `json:"next_match_id,omitempty" example:"9"`            // 勝者の進出先試合ID
+	NextSlot         *int              `json:"next_slot,omitempty" example:"1"`                // 勝者の進出先の枠
+	LoserNextMatchID *int              `json:"loser_next_match_id,omitempty" example:"15"`     // 敗者の進出先試合ID
+	LoserNextSlot    *int              `json:"loser_next_slot,omitempty" example:"1"`          // 敗者の進出先の枠
+	Status           string            `json:"status" example:"pending"`                       // 試合ステータス
+	ScheduledAt      string            `json:"scheduled_at" example:"2024-01-01T10:00:00Z"`    // 予定日時
+	CompletedAt      *string           `json:"completed_at" example:"2024-01-01T11:00:00Z"`    // 完了日時
+	CreatedAt        string            `json:"created_at" example:"2024-01-01T09:00:00Z"`      // 作成日時
+	UpdatedAt        string            `json:"updated_at" example:"2024-01-01T11:00:00Z"`      // 更新日時
 }
 
 // convertToSwaggerMatch はmodels.MatchをSwagger用のMatchに変換する
@@ -86,19 +140,37 @@ func convertToSwaggerMatch(match *models.Match) Match {
 	}
 
 	return Match{
-		ID:           match.ID,
-		TournamentID: match.TournamentID,
-		Round:        match.Round,
-		Team1:        match.Team1,
-		Team2:        match.Team2,
-		Score1:       match.Score1,
-		Score2:       match.Score2,
-		Winner:       match.Winner,
-		Status:       match.Status,
-		ScheduledAt:  match.ScheduledAt.Format("2006-01-02T15:04:05Z"),
-		CompletedAt:  completedAt,
-		CreatedAt:    match.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:    match.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:               match.ID,
+		TournamentID:     match.TournamentID,
+		Round:            match.Round,
+		Position:         match.Position,
+		Team1:            match.Team1,
+		Team2:            match.Team2,
+		Team1ID:          match.Team1ID,
+		Team2ID:          match.Team2ID,
+		Score1:           match.Score1,
+		Score2:           match.Score2,
+		ExtraTimeScore1:  match.ExtraTimeScore1,
+		ExtraTimeScore2:  match.ExtraTimeScore2,
+		PenaltyScore1:    match.PenaltyScore1,
+		PenaltyScore2:    match.PenaltyScore2,
+		DecisionMethod:   match.GetDecisionMethod().String(),
+		ResultType:       match.GetResultType().String(),
+		ForfeitingTeam:   match.ForfeitingTeam,
+		ForfeitingTeamID: match.ForfeitingTeamID,
+		Sets:             match.Sets,
+		ScoreDisplay:     match.ScoreDisplay(),
+		Winner:           match.Winner,
+		WinnerID:         match.WinnerID,
+		NextMatchID:      match.NextMatchID,
+		NextSlot:         match.NextSlot,
+		LoserNextMatchID: match.LoserNextMatchID,
+		LoserNextSlot:    match.LoserNextSlot,
+		Status:           match.Status,
+		ScheduledAt:      match.ScheduledAt.Format("2006-01-02T15:04:05Z"),
+		CompletedAt:      completedAt,
+		CreatedAt:        match.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:        match.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
 
@@ -121,20 +193,23 @@ func convertToSwaggerMatchStatistics(stats *service.MatchStatistics) MatchStatis
 			MatchesPlayed: serviceTeamStats.MatchesPlayed,
 			Wins:          serviceTeamStats.Wins,
 			Losses:        serviceTeamStats.Losses,
+			Forfeits:      serviceTeamStats.Forfeits,
 			TotalScore:    serviceTeamStats.TotalScore,
 			AverageScore:  serviceTeamStats.AverageScore,
 		}
 	}
 
 	return MatchStatistics{
-		TournamentID:     stats.TournamentID,
-		TotalMatches:     stats.TotalMatches,
-		CompletedMatches: stats.CompletedMatches,
-		PendingMatches:   stats.PendingMatches,
-		MatchesByRound:   stats.MatchesByRound,
-		CompletionRate:   stats.CompletionRate,
-		AverageScore:     stats.AverageScore,
-		TeamStats:        teamStats,
+		TournamentID:      stats.TournamentID,
+		TotalMatches:      stats.TotalMatches,
+		CompletedMatches:  stats.CompletedMatches,
+		PendingMatches:    stats.PendingMatches,
+		MatchesByRound:    stats.MatchesByRound,
+		MatchesByDecision: stats.MatchesByDecision,
+		MatchesByResult:   stats.MatchesByResult,
+		CompletionRate:    stats.CompletionRate,
+		AverageScore:      stats.AverageScore,
+		TeamStats:         teamStats,
 	}
 }
 
@@ -147,14 +222,16 @@ type MatchStatisticsResponse struct {
 
 // MatchStatistics はSwagger用の試合統計構造体
 type MatchStatistics struct {
-	TournamentID     int                   `json:"tournament_id" example:"1"`     // トーナメントID
-	TotalMatches     int                   `json:"total_matches" example:"16"`    // 総試合数
-	CompletedMatches int                   `json:"completed_matches" example:"8"` // 完了試合数
-	PendingMatches   int                   `json:"pending_matches" example:"8"`   // 未完了試合数
-	MatchesByRound   map[string]int        `json:"matches_by_round"`              // ラウンド別試合数
-	CompletionRate   float64               `json:"completion_rate" example:"0.5"` // 完了率
-	AverageScore     map[string]float64    `json:"average_score"`                 // 平均スコア
-	TeamStats        map[string]*TeamStats `json:"team_stats"`                    // チーム統計
+	TournamentID      int                   `json:"tournament_id" example:"1"`     // トーナメントID
+	TotalMatches      int                   `json:"total_matches" example:"16"`    // 総試合数
+	CompletedMatches  int                   `json:"completed_matches" example:"8"` // 完了試合数
+	PendingMatches    int                   `json:"pending_matches" example:"8"`   // 未完了試合数
+	MatchesByRound    map[string]int        `json:"matches_by_round"`              // ラウンド別試合数
+	MatchesByDecision map[string]int        `json:"matches_by_decision"`           // 決着方法別の完了試合数
+	MatchesByResult   map[string]int        `json:"matches_by_result"`             // 結果の種類別（played, walkover など）の完了試合数
+	CompletionRate    float64               `json:"completion_rate" example:"0.5"` // 完了率
+	AverageScore      map[string]float64    `json:"average_score"`                 // 平均スコア
+	TeamStats         map[string]*TeamStats `json:"team_stats"`                    // チーム統計
 }
 
 // TeamStats はSwagger用のチーム統計構造体
@@ -163,6 +240,7 @@ type TeamStats struct {
 	MatchesPlayed int     `json:"matches_played" example:"4"`     // 試合数
 	Wins          int     `json:"wins" example:"3"`               // 勝利数
 	Losses        int     `json:"losses" example:"1"`             // 敗北数
+	Forfeits      int     `json:"forfeits" example:"0"`           // 棄権・不出場・失格の回数
 	TotalScore    int     `json:"total_score" example:"12"`       // 総得点
 	AverageScore  float64 `json:"average_score" example:"3.0"`    // 平均得点
 }
@@ -196,24 +274,6 @@ func (h *MatchHandler) CreateMatch(c *gin.Context) {
 		return
 	}
 
-	if strings.TrimSpace(req.Team2) == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "チーム2は必須です",
-			Code:    http.StatusBadRequest,
-		})
-		return
-	}
-
-	if req.Team1 == req.Team2 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "同じチーム同士の試合はできません",
-			Code:    http.StatusBadRequest,
-		})
-		return
-	}
-
 	// 日時のパース
 	scheduledAt, err := parseDateTime(req.ScheduledAt)
 	if err != nil {
@@ -231,6 +291,8 @@ func (h *MatchHandler) CreateMatch(c *gin.Context) {
 		Round:        req.Round,
 		Team1:        req.Team1,
 		Team2:        req.Team2,
+		Team1ID:      req.Team1ID,
+		Team2ID:      req.Team2ID,
 		Status:       models.MatchStatusPending,
 		ScheduledAt:  scheduledAt,
 	}
@@ -238,6 +300,11 @@ func (h *MatchHandler) CreateMatch(c *gin.Context) {
 	// 試合作成
 	err = h.matchService.CreateMatch(match)
 	if err != nil {
+		if _, ok := err.(*service.ServiceError); ok {
+			h.SendServiceError(c, err, "試合の作成に失敗しました")
+			return
+		}
+
 		if strings.Contains(err.Error(), "既に存在") {
 			c.JSON(http.StatusConflict, ErrorResponse{
 				Error:   "Conflict",
@@ -325,9 +392,11 @@ func (h *MatchHandler) GetMatches(c *gin.Context) {
 // @Description 指定されたスポーツの試合を取得する
 // @Tags matches
 // @Produce json
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} MatchListResponse "取得成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/matches/{sport} [get]
 func (h *MatchHandler) GetMatchesBySport(c *gin.Context) {
@@ -343,9 +412,23 @@ func (h *MatchHandler) GetMatchesBySport(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// スポーツ別試合取得
-	matches, err := h.matchService.GetMatchesBySport(sport)
+	matches, err := h.matchService.GetMatchesBySport(eventID, sport)
 	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not Found",
+				Message: "指定された大会が見つかりません",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "無効な") {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Bad Request",
@@ -427,7 +510,7 @@ func (h *MatchHandler) GetMatch(c *gin.Context) {
 
 // UpdateMatch は試合更新エンドポイントハンドラー
 // @Summary 試合更新
-// @Description 指定されたIDの試合を更新する（管理者のみ）
+// @Description 指定されたIDの試合のラウンド・対戦チーム・開始時刻を更新する（管理者のみ）。ステータスを指定した場合は試合ステータスの遷移として反映する。試合結果（completed）は試合結果提出エンドポイントで登録する
 // @Tags matches
 // @Accept json
 // @Produce json
@@ -438,6 +521,7 @@ func (h *MatchHandler) GetMatch(c *gin.Context) {
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 401 {object} ErrorResponse "認証エラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "許可されていないステータスの変更"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/matches/{id} [put]
 func (h *MatchHandler) UpdateMatch(c *gin.Context) {
@@ -489,11 +573,18 @@ func (h *MatchHandler) UpdateMatch(c *gin.Context) {
 	if strings.TrimSpace(req.Round) != "" {
 		match.Round = req.Round
 	}
-	if strings.TrimSpace(req.Team1) != "" {
+	// チームIDを指定した場合はチーム名より優先し、チーム名のみの場合は登録チームとの対応を名前から求め直す
+	if req.Team1ID != nil {
+		match.Team1ID = req.Team1ID
+	} else if strings.TrimSpace(req.Team1) != "" {
 		match.Team1 = req.Team1
+		match.Team1ID = nil
 	}
-	if strings.TrimSpace(req.Team2) != "" {
+	if req.Team2ID != nil {
+		match.Team2ID = req.Team2ID
+	} else if strings.TrimSpace(req.Team2) != "" {
 		match.Team2 = req.Team2
+		match.Team2ID = nil
 	}
 	if strings.TrimSpace(req.Status) != "" {
 		match.Status = req.Status
@@ -514,6 +605,11 @@ func (h *MatchHandler) UpdateMatch(c *gin.Context) {
 	// 試合更新
 	err = h.matchService.UpdateMatch(match)
 	if err != nil {
+		if _, ok := err.(*service.ServiceError); ok {
+			h.SendServiceError(c, err, "試合の更新に失敗しました")
+			return
+		}
+
 		if strings.Contains(err.Error(), "無効な") || strings.Contains(err.Error(), "検証") {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Bad Request",
@@ -541,7 +637,7 @@ func (h *MatchHandler) UpdateMatch(c *gin.Context) {
 
 // SubmitMatchResult は試合結果提出エンドポイントハンドラー
 // @Summary 試合結果提出
-// @Description 指定された試合の結果を提出する（管理者のみ）
+// @Description 指定された試合の結果を提出する（管理者のみ）。総当たり戦の試合では勝者を空にした同点の結果（引き分け）を提出できる。勝ち上がりのある試合は延長戦終了時のスコアとPK戦のスコアを記録でき、勝者は勝敗を決めたスコアで判定する。バレーボール・卓球ではセットごとのスコアを指定でき、競技の規則で検証したうえで取ったセット数を試合のスコアとする
 // @Tags matches
 // @Accept json
 // @Produce json
@@ -552,6 +648,7 @@ func (h *MatchHandler) UpdateMatch(c *gin.Context) {
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 401 {object} ErrorResponse "認証エラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "次ラウンドの試合が既に完了している"
 // @Failure 422 {object} ErrorResponse "ビジネスロジックエラー"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/matches/{id}/result [put]
@@ -578,16 +675,9 @@ func (h *MatchHandler) SubmitMatchResult(c *gin.Context) {
 		return
 	}
 
-	if req.Score1 == req.Score2 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "引き分けは許可されていません",
-			Code:    http.StatusBadRequest,
-		})
-		return
-	}
-
-	if strings.TrimSpace(req.Winner) == "" {
+	// 引き分けは総当たり戦のみ認められるため、試合のラウンドに応じてサービス層で検証する
+	// 不戦勝・棄権・失格の勝者はサービス層で棄権したチームの対戦相手とする
+	if !req.ResultType.IsForfeit() && req.Score1 != req.Score2 && strings.TrimSpace(req.Winner) == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "Bad Request",
 			Message: "勝者は必須です",
@@ -597,11 +687,7 @@ func (h *MatchHandler) SubmitMatchResult(c *gin.Context) {
 	}
 
 	// 試合結果を作成
-	result := models.MatchResult{
-		Score1: req.Score1,
-		Score2: req.Score2,
-		Winner: req.Winner,
-	}
+	result := req.ToMatchResult()
 
 	// 試合結果提出
 	err = h.matchService.UpdateMatchResult(id, result)
@@ -617,7 +703,9 @@ func (h *MatchHandler) SubmitMatchResult(c *gin.Context) {
 
 		if strings.Contains(err.Error(), "検証") || strings.Contains(err.Error(), "無効な") || 
 		   strings.Contains(err.Error(), "一致しません") || strings.Contains(err.Error(), "引き分け") ||
-		   strings.Contains(err.Error(), "完了している") || strings.Contains(err.Error(), "参加チーム") {
+		   strings.Contains(err.Error(), "完了している") || strings.Contains(err.Error(), "参加チーム") ||
+		   strings.Contains(err.Error(), "延長戦") || strings.Contains(err.Error(), "PK戦") ||
+		   strings.Contains(err.Error(), "セット") || strings.Contains(err.Error(), "棄権") {
 			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
 				Error:   "Unprocessable Entity",
 				Message: err.Error(),
@@ -626,6 +714,12 @@ func (h *MatchHandler) SubmitMatchResult(c *gin.Context) {
 			return
 		}
 
+		// 次ラウンドの試合が既に完了していて勝ち上がりできない場合
+		if serviceErr, ok := err.(*service.ServiceError); ok && serviceErr.Type == service.ErrorTypeConflict {
+			h.SendErrorWithCode(c, models.ErrorResourceConflict, serviceErr.Message, http.StatusConflict)
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal Server Error",
 			Message: "試合結果の提出に失敗しました",
@@ -652,6 +746,222 @@ func (h *MatchHandler) SubmitMatchResult(c *gin.Context) {
 	})
 }
 
+// CorrectMatchResult は試合結果訂正エンドポイントハンドラー
+// @Summary 試合結果訂正
+// @Description 完了した試合の結果を訂正し、勝者を再判定する（管理者のみ）。勝者・敗者が変わった場合は後続の試合の枠を付け替え、既に実施済みの後続の試合は結果を取り消して未実施に戻す。実施済みの試合の結果を取り消す場合は confirm_reset を true にする必要がある。訂正内容は記録され、リアルタイムで通知される
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body CorrectMatchResultRequest true "訂正後の試合結果と訂正理由"
+// @Success 200 {object} map[string]interface{} "訂正成功（訂正記録と影響を受けた試合、警告）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "実施済みの後続の試合の結果取り消しが未確認"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/correction [put]
+func (h *MatchHandler) CorrectMatchResult(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	var req CorrectMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	// 訂正した管理者を記録する
+	var correctedBy *int
+	if userID, ok := h.GetUserID(c); ok {
+		correctedBy = &userID
+	}
+
+	result := models.MatchResult{
+		Score1:          req.Score1,
+		Score2:          req.Score2,
+		ExtraTimeScore1: req.ExtraTimeScore1,
+		ExtraTimeScore2: req.ExtraTimeScore2,
+		PenaltyScore1:   req.PenaltyScore1,
+		PenaltyScore2:   req.PenaltyScore2,
+		DecisionMethod:  req.DecisionMethod,
+		Sets:            req.Sets,
+		ResultType:      req.ResultType,
+		ForfeitingTeam:  strings.TrimSpace(req.ForfeitingTeam),
+		Winner:          strings.TrimSpace(req.Winner),
+	}
+	result.DeriveScoreFromSets()
+
+	correction, err := h.matchService.CorrectMatchResult(id, result, req.Reason, correctedBy, req.ConfirmReset)
+	if err != nil {
+		h.SendServiceError(c, err, "試合結果の訂正に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, correction, "試合結果を訂正しました")
+}
+
+// ChangeMatchStatus は試合ステータス変更エンドポイントハンドラー
+// @Summary 試合ステータス変更
+// @Description 試合の開始・中断・延期・日程変更・中止を行う（管理者のみ）。遷移表で許可されていない変更は BUSINESS_INVALID_MATCH_TRANSITION として拒否し、変更前後のステータスを details に含める。日程変更（rescheduled）には新しい開始時刻が必要で、最初に予定していた開始時刻は planned_at に残す。試合結果は結果提出エンドポイントで入力する
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body ChangeMatchStatusRequest true "変更後のステータス"
+// @Success 200 {object} map[string]interface{} "変更成功（変更後の試合）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "許可されていないステータスの変更"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/status [put]
+func (h *MatchHandler) ChangeMatchStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	var req ChangeMatchStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+	if !req.Status.IsValid() {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合ステータスです", http.StatusBadRequest)
+		return
+	}
+
+	match, err := h.matchService.ChangeMatchStatus(id, req.Status, req.ScheduledAt)
+	if err != nil {
+		h.SendServiceError(c, err, "試合ステータスの変更に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, match, "試合を"+req.Status.Label()+"にしました")
+}
+
+// GetMatchCorrections は試合結果訂正履歴取得エンドポイントハンドラー
+// @Summary 試合結果訂正履歴取得
+// @Description 指定された試合の結果訂正の履歴を新しい順に取得する（管理者のみ）
+// @Tags matches
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/corrections [get]
+func (h *MatchHandler) GetMatchCorrections(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	corrections, err := h.matchService.GetMatchCorrections(id)
+	if err != nil {
+		h.SendServiceError(c, err, "試合結果の訂正履歴の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, corrections, "試合結果の訂正履歴を取得しました")
+}
+
+// AppendMatchEvent は試合経過の記録エンドポイントハンドラー
+// @Summary 試合経過の記録
+// @Description 試合中の出来事（試合開始、得点、セット終了、タイムアウト、警告・退場、試合終了）を記録し、記録全体から算出した現在のスコアを返す（管理者のみ）。試合開始を記録すると、試合中でない試合は試合中になる。記録した出来事は match_event メッセージとしてリアルタイムで通知される。正式な結果は試合結果提出で登録する
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body AppendMatchEventRequest true "試合経過の出来事"
+// @Success 201 {object} map[string]interface{} "記録成功（出来事と現在のスコア）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "試合開始前・試合終了後、または完了した試合"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/events [post]
+func (h *MatchHandler) AppendMatchEvent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	var req AppendMatchEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	event := &models.MatchEvent{
+		Type:     req.Type,
+		Slot:     req.Slot,
+		Minute:   req.Minute,
+		SetScore: req.SetScore,
+		Card:     req.Card,
+		PlayerID: req.PlayerID,
+		Note:     strings.TrimSpace(req.Note),
+	}
+
+	// 記録したユーザーを記録する
+	if userID, ok := h.GetUserID(c); ok {
+		event.RecordedBy = &userID
+	}
+
+	live, err := h.matchService.AppendMatchEvent(id, event)
+	if err != nil {
+		h.SendServiceError(c, err, "試合経過の記録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, gin.H{
+		"event":      event,
+		"live_score": live,
+	}, "試合経過を記録しました", http.StatusCreated)
+}
+
+// GetMatchTimeline は試合経過取得エンドポイントハンドラー
+// @Summary 試合経過取得
+// @Description 指定された試合の経過（記録順）と、経過から算出した現在のスコアを取得する
+// @Tags matches
+// @Produce json
+// @Param id path int true "試合ID"
+// @Success 200 {object} map[string]interface{} "取得成功（出来事の一覧と現在のスコア）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/matches/{id}/events [get]
+func (h *MatchHandler) GetMatchTimeline(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	events, live, err := h.matchService.GetMatchTimeline(id)
+	if err != nil {
+		h.SendServiceError(c, err, "試合経過の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, gin.H{
+		"events":     events,
+		"live_score": live,
+	}, "試合経過を取得しました")
+}
+
 // DeleteMatch は試合削除エンドポイントハンドラー
 // @Summary 試合削除
 // @Description 指定されたIDの試合を削除する（管理者のみ）
@@ -0,0 +1,474 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlayerHandler は選手・チームの名簿・試合のMVP・個人ランキングのHTTPハンドラー
+type PlayerHandler struct {
+	*BaseHandler
+	playerService service.PlayerService
+}
+
+// NewPlayerHandler は新しいPlayerHandlerを作成する
+func NewPlayerHandler(playerService service.PlayerService) *PlayerHandler {
+	return &PlayerHandler{
+		BaseHandler:   NewBaseHandler(),
+		playerService: playerService,
+	}
+}
+
+// PlayerRequest は選手の登録・更新リクエストの構造体
+type PlayerRequest struct {
+	StudentNumber string `json:"student_number" binding:"required,max=20" example:"20IE001"` // 学籍番号（一意）
+	Name          string `json:"name" binding:"required,max=100" example:"山田太郎"`             // 氏名
+	ClassName     string `json:"class_name" binding:"max=100" example:"IE4"`                 // 所属クラス
+}
+
+// toPlayer はリクエストを選手に変換する
+func (r *PlayerRequest) toPlayer() *models.Player {
+	return &models.Player{
+		StudentNumber: r.StudentNumber,
+		Name:          r.Name,
+		ClassName:     r.ClassName,
+	}
+}
+
+// ListPlayersQuery は選手一覧のクエリパラメータ
+type ListPlayersQuery struct {
+	ClassName string `form:"class_name" binding:"max=100"`            // 所属クラスで絞り込む
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=500"` // 取得件数（既定値100）
+	Offset    int    `form:"offset" binding:"omitempty,min=0"`        // 取得開始位置
+}
+
+// RosterRequest は名簿への登録リクエストの構造体
+type RosterRequest struct {
+	PlayerID    uint `json:"player_id" binding:"required,min=1" example:"1"`                       // 選手ID
+	ShirtNumber *int `json:"shirt_number,omitempty" binding:"omitempty,min=0,max=99" example:"10"` // 背番号
+}
+
+// MatchMVPRequest は試合のMVPの選出リクエストの構造体
+type MatchMVPRequest struct {
+	PlayerID uint `json:"player_id" binding:"required,min=1" example:"1"` // どちらかのチームの名簿の選手ID
+}
+
+// LeaderboardQuery は個人ランキングのクエリパラメータ
+type LeaderboardQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"` // 載せる順位（既定値10、同順位の選手は全員載せる）
+}
+
+// defaultPlayerListLimit は選手一覧の取得件数の既定値
+const defaultPlayerListLimit = 100
+
+// ListPlayers は選手一覧取得エンドポイントハンドラー
+// @Summary 選手一覧取得
+// @Description 登録されている選手を学籍番号順に取得する（管理者のみ）
+// @Tags players
+// @Produce json
+// @Security BearerAuth
+// @Param class_name query string false "所属クラス"
+// @Param limit query int false "取得件数（既定値100）"
+// @Param offset query int false "取得開始位置"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/players [get]
+func (h *PlayerHandler) ListPlayers(c *gin.Context) {
+	var query ListPlayersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPlayerListLimit
+	}
+
+	players, err := h.playerService.ListPlayers(c.Request.Context(), query.ClassName, query.Limit, query.Offset)
+	if err != nil {
+		h.SendServiceError(c, err, "選手一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, players, "選手一覧を取得しました")
+}
+
+// GetPlayer は選手取得エンドポイントハンドラー
+// @Summary 選手取得
+// @Description 指定された選手を取得する（管理者のみ）
+// @Tags players
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "選手ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Router /api/admin/players/{id} [get]
+func (h *PlayerHandler) GetPlayer(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効な選手IDです")
+	if !ok {
+		return
+	}
+
+	player, err := h.playerService.GetPlayer(c.Request.Context(), id)
+	if err != nil {
+		h.SendServiceError(c, err, "選手の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, player, "選手を取得しました")
+}
+
+// CreatePlayer は選手登録エンドポイントハンドラー
+// @Summary 選手登録
+// @Description 学生を選手として登録する（管理者のみ）
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body PlayerRequest true "選手の情報"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 409 {object} ErrorResponse "学籍番号の重複"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/players [post]
+func (h *PlayerHandler) CreatePlayer(c *gin.Context) {
+	var req PlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	player := req.toPlayer()
+	if err := h.playerService.CreatePlayer(c.Request.Context(), player); err != nil {
+		h.SendServiceError(c, err, "選手の登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, player, "選手を登録しました", http.StatusCreated)
+}
+
+// UpdatePlayer は選手更新エンドポイントハンドラー
+// @Summary 選手更新
+// @Description 選手の学籍番号・氏名・所属クラスを更新する（管理者のみ）
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "選手ID"
+// @Param request body PlayerRequest true "選手の情報"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "学籍番号の重複"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/players/{id} [put]
+func (h *PlayerHandler) UpdatePlayer(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効な選手IDです")
+	if !ok {
+		return
+	}
+
+	var req PlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	player := req.toPlayer()
+	if err := h.playerService.UpdatePlayer(c.Request.Context(), id, player); err != nil {
+		h.SendServiceError(c, err, "選手の更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, player, "選手を更新しました")
+}
+
+// DeletePlayer は選手削除エンドポイントハンドラー
+// @Summary 選手削除
+// @Description 選手を削除する。名簿の登録と試合のMVPも削除し、試合経過の記録は選手なしで残す（管理者のみ）
+// @Tags players
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "選手ID"
+// @Success 200 {object} map[string]interface{} "削除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/players/{id} [delete]
+func (h *PlayerHandler) DeletePlayer(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効な選手IDです")
+	if !ok {
+		return
+	}
+
+	if err := h.playerService.DeletePlayer(c.Request.Context(), id); err != nil {
+		h.SendServiceError(c, err, "選手の削除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "選手を削除しました")
+}
+
+// GetTeamRoster はチームの名簿取得エンドポイントハンドラー
+// @Summary チームの名簿取得
+// @Description 指定されたチームの名簿を背番号順に取得する（学籍番号は含めない）
+// @Tags players
+// @Produce json
+// @Param id path int true "チームID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/teams/{id}/players [get]
+func (h *PlayerHandler) GetTeamRoster(c *gin.Context) {
+	teamID, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+
+	roster, err := h.playerService.GetRoster(c.Request.Context(), teamID)
+	if err != nil {
+		h.SendServiceError(c, err, "名簿の取得に失敗しました")
+		return
+	}
+
+	// 公開の名簿には学籍番号を含めない
+	for _, entry := range roster {
+		if entry.Player != nil {
+			entry.Player.StudentNumber = ""
+		}
+	}
+
+	h.SendSuccess(c, roster, "名簿を取得しました")
+}
+
+// AddToRoster は名簿への選手登録エンドポイントハンドラー
+// @Summary 名簿への選手登録
+// @Description チームの名簿に選手を登録する。選手は1つの種目につき1つのチームにしか登録できない（管理者のみ）
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "チームID"
+// @Param request body RosterRequest true "登録する選手"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "同じ種目の他のチームに登録済み・背番号の重複"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/teams/{id}/players [post]
+func (h *PlayerHandler) AddToRoster(c *gin.Context) {
+	teamID, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+
+	var req RosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	entry := &models.RosterEntry{
+		TeamID:      teamID,
+		PlayerID:    req.PlayerID,
+		ShirtNumber: req.ShirtNumber,
+	}
+	if err := h.playerService.AddToRoster(c.Request.Context(), entry); err != nil {
+		h.SendServiceError(c, err, "名簿への登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, entry, "名簿に登録しました", http.StatusCreated)
+}
+
+// RemoveFromRoster は名簿からの選手削除エンドポイントハンドラー
+// @Summary 名簿からの選手削除
+// @Description チームの名簿から選手を削除する。トーナメントで得点・警告・MVPの記録がある選手は削除できない（管理者のみ）
+// @Tags players
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "チームID"
+// @Param player_id path int true "選手ID"
+// @Success 200 {object} map[string]interface{} "削除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "記録のある選手"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/teams/{id}/players/{player_id} [delete]
+func (h *PlayerHandler) RemoveFromRoster(c *gin.Context) {
+	teamID, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+	playerID, ok := h.GetIDParam(c, "player_id", "無効な選手IDです")
+	if !ok {
+		return
+	}
+
+	if err := h.playerService.RemoveFromRoster(c.Request.Context(), teamID, playerID); err != nil {
+		h.SendServiceError(c, err, "名簿からの削除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "名簿から削除しました")
+}
+
+// SetMatchMVP は試合のMVP選出エンドポイントハンドラー
+// @Summary 試合のMVP選出
+// @Description 試合のMVPをどちらかのチームの名簿の選手から選ぶ。選び直した場合は置き換える（管理者のみ）
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body MatchMVPRequest true "MVPの選手"
+// @Success 200 {object} map[string]interface{} "選出成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/mvp [put]
+func (h *PlayerHandler) SetMatchMVP(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || matchID <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	var req MatchMVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	// 選んだユーザーを記録する
+	var selectedBy *int
+	if userID, ok := h.GetUserID(c); ok {
+		selectedBy = &userID
+	}
+
+	player, err := h.playerService.SetMatchMVP(c.Request.Context(), matchID, req.PlayerID, selectedBy)
+	if err != nil {
+		h.SendServiceError(c, err, "試合のMVPの選出に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, player, "試合のMVPを選出しました")
+}
+
+// ClearMatchMVP は試合のMVP取消エンドポイントハンドラー
+// @Summary 試合のMVP取消
+// @Description 試合のMVPの選出を取り消す（管理者のみ）
+// @Tags players
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Success 200 {object} map[string]interface{} "取消成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/mvp [delete]
+func (h *PlayerHandler) ClearMatchMVP(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || matchID <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効な試合IDです", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.playerService.ClearMatchMVP(c.Request.Context(), matchID); err != nil {
+		h.SendServiceError(c, err, "試合のMVPの取消に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "試合のMVPを取り消しました")
+}
+
+// GetTournamentLeaderboard はトーナメントの個人ランキング取得エンドポイントハンドラー
+// @Summary トーナメントの個人ランキング取得
+// @Description 指定されたトーナメントの得点王とMVP（試合のMVPに選ばれた回数）のランキングを取得する
+// @Tags players
+// @Produce json
+// @Param id path int true "トーナメントID"
+// @Param limit query int false "載せる順位（既定値10）"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/tournaments/{id}/leaderboard [get]
+func (h *PlayerHandler) GetTournamentLeaderboard(c *gin.Context) {
+	tournamentID, ok := h.GetIDParam(c, "id", "無効なトーナメントIDです")
+	if !ok {
+		return
+	}
+	size, ok := h.getLeaderboardSize(c)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := h.playerService.GetTournamentLeaderboard(c.Request.Context(), tournamentID, size)
+	if err != nil {
+		h.SendServiceError(c, err, "個人ランキングの取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, leaderboard, "個人ランキングを取得しました")
+}
+
+// GetEventLeaderboard は大会の個人ランキング取得エンドポイントハンドラー
+// @Summary 大会の個人ランキング取得
+// @Description 指定された大会の全種目を通した得点王とMVPのランキングを取得する
+// @Tags players
+// @Produce json
+// @Param event_id path int true "大会ID"
+// @Param limit query int false "載せる順位（既定値10）"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/leaderboard [get]
+func (h *PlayerHandler) GetEventLeaderboard(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+	size, ok := h.getLeaderboardSize(c)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := h.playerService.GetEventLeaderboard(c.Request.Context(), eventID, size)
+	if err != nil {
+		h.SendServiceError(c, err, "個人ランキングの取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, leaderboard, "個人ランキングを取得しました")
+}
+
+// getLeaderboardSize はクエリパラメータからランキングに載せる順位を取得する
+func (h *PlayerHandler) getLeaderboardSize(c *gin.Context) (int, bool) {
+	var query LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.SendBindingError(c, err)
+		return 0, false
+	}
+	if query.Limit == 0 {
+		return models.DefaultLeaderboardSize, true
+	}
+	return query.Limit, true
+}
@@ -30,8 +30,9 @@ func NewPollingHandler(pollingService *service.PollingService) *PollingHandler {
 // @Tags Polling
 // @Accept json
 // @Produce json
-// @Param sport path string true "スポーツタイプ" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツタイプ（種目コード。例: volleyball）"
 // @Param data_type path string true "データタイプ" Enums(tournament,matches,bracket)
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Param last_etag query string false "最後のETag"
 // @Param last_check query string false "最後のチェック時刻"
 // @Success 200 {object} models.DataResponse[service.PollingResponse] "更新チェック結果"
@@ -67,8 +68,14 @@ func (h *PollingHandler) CheckUpdates(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// リクエストを作成
 	request := &service.UpdateCheckRequest{
+		EventID:   eventID,
 		Sport:     sport,
 		DataType:  dataType,
 		LastETag:  lastETag,
@@ -102,8 +109,9 @@ func (h *PollingHandler) CheckUpdates(c *gin.Context) {
 // @Tags Polling
 // @Accept json
 // @Produce json
-// @Param sport path string true "スポーツタイプ" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツタイプ（種目コード。例: volleyball）"
 // @Param data_type path string true "データタイプ" Enums(tournament,matches,bracket)
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} models.DataResponse[service.PollingResponse] "最新データ"
 // @Failure 400 {object} models.ErrorResponse "リクエストエラー"
 // @Failure 404 {object} models.ErrorResponse "データが見つからない"
@@ -135,8 +143,13 @@ func (h *PollingHandler) GetLatestData(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// 最新データを取得
-	response, err := h.pollingService.GetLatestData(c.Request.Context(), sport, dataType)
+	response, err := h.pollingService.GetLatestData(c.Request.Context(), eventID, sport, dataType)
 	if err != nil {
 		if serviceErr, ok := err.(*service.ServiceError); ok {
 			switch serviceErr.Type {
@@ -163,8 +176,9 @@ func (h *PollingHandler) GetLatestData(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param sport path string true "スポーツタイプ" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツタイプ（種目コード。例: volleyball）"
 // @Param data_type path string true "データタイプ" Enums(tournament,matches,bracket)
+// @Param event_id query int false "大会ID（省略時は全大会）"
 // @Success 200 {object} models.DataResponse[interface{}] "キャッシュ無効化成功"
 // @Failure 400 {object} models.ErrorResponse "リクエストエラー"
 // @Failure 401 {object} models.ErrorResponse "認証エラー"
@@ -204,10 +218,16 @@ func (h *PollingHandler) InvalidateCache(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// キャッシュを無効化
-	h.pollingService.InvalidateCache(sport, dataType)
+	h.pollingService.InvalidateCache(eventID, sport, dataType)
 
 	h.SendSuccess(c, map[string]interface{}{
+		"event_id":  eventID,
 		"sport":     sport,
 		"data_type": dataType,
 		"message":   "キャッシュを無効化しました",
@@ -254,7 +274,7 @@ func (h *PollingHandler) GetPollingConfig(c *gin.Context) {
 		MaxInterval:        300, // 最大5分
 		UpdatedInterval:    10,  // 更新時は10秒
 		SupportedDataTypes: []string{"tournament", "matches", "bracket"},
-		SupportedSports:    []string{"volleyball", "table_tennis", "soccer"},
+		SupportedSports:    models.RegisteredSportCodes(),
 		CacheExpiry:        30,  // キャッシュ30秒
 		UseETag:            true,
 	}
@@ -311,6 +331,9 @@ func (h *PollingHandler) BatchCheckUpdates(c *gin.Context) {
 
 	for _, check := range request.Checks {
 		key := check.Sport.String() + ":" + check.DataType
+		if check.EventID != 0 {
+			key = strconv.FormatUint(uint64(check.EventID), 10) + ":" + key
+		}
 		
 		result, err := h.pollingService.CheckForUpdates(c.Request.Context(), &check)
 		if err != nil {
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleHandler は試合日程の自動作成・試合の日程固定・カレンダー配信のHTTPハンドラー
+type ScheduleHandler struct {
+	*BaseHandler
+	scheduleService service.ScheduleService
+}
+
+// NewScheduleHandler は新しいScheduleHandlerを作成する
+func NewScheduleHandler(scheduleService service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{
+		BaseHandler:     NewBaseHandler(),
+		scheduleService: scheduleService,
+	}
+}
+
+// PinScheduleRequest は試合の日程固定リクエストの構造体
+type PinScheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required" example:"2024-05-20T10:30:00+09:00"` // 開始時刻
+	CourtID     *int      `json:"court_id,omitempty" example:"1"`                                      // コート（省略時は未割り当て）
+}
+
+// AssignCourtRequest は試合のコート割り当てリクエストの構造体
+type AssignCourtRequest struct {
+	CourtID *int `json:"court_id" example:"1"` // コート（nullの場合は未割り当て）
+}
+
+// GetScheduleOptions は日程の条件取得エンドポイントハンドラー
+// @Summary 日程の条件取得
+// @Description 最後に日程を自動作成した条件を取得する。まだ作成していない場合は大会の開始日の既定の条件を返す（管理者のみ）
+// @Tags schedule
+// @Produce json
+// @Security BearerAuth
+// @Param event_id path int true "大会ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events/{event_id}/schedule [get]
+func (h *ScheduleHandler) GetScheduleOptions(c *gin.Context) {
+	eventID, ok := h.GetIDParam(c, "event_id", "無効な大会IDです")
+	if !ok {
+		return
+	}
+
+	options, err := h.scheduleService.GetScheduleOptions(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "日程の条件の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, options, "日程の条件を取得しました")
+}
+
+// GenerateSchedule は試合日程の自動作成エンドポイントハンドラー
+// @Summary 試合日程の自動作成
+// @Description 大会の全種目の試合に開始時刻とコートを割り当てる。同じクラスの試合は種目をまたいで重ならないようにし、休憩の時間帯・勝者が進出する試合の順序を守る。固定した試合・実施中または実施済みの試合は動かさない。全ての試合を割り当てられた場合のみ反映し（dry_run=true の場合は反映しない）、割り当てられない試合は issues に理由を示す（管理者のみ）
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param event_id path int true "大会ID"
+// @Param dry_run query bool false "trueの場合は反映せずに結果のみを返す"
+// @Param request body models.ScheduleOptions true "日程の条件"
+// @Success 200 {object} map[string]interface{} "作成成功（feasible・appliedで反映の有無を示す）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events/{event_id}/schedule [post]
+func (h *ScheduleHandler) GenerateSchedule(c *gin.Context) {
+	eventID, ok := h.GetIDParam(c, "event_id", "無効な大会IDです")
+	if !ok {
+		return
+	}
+
+	var options models.ScheduleOptions
+	if err := c.ShouldBindJSON(&options); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	schedule, err := h.scheduleService.GenerateSchedule(c.Request.Context(), eventID, &options, dryRun)
+	if err != nil {
+		h.SendServiceError(c, err, "試合日程の作成に失敗しました")
+		return
+	}
+
+	switch {
+	case schedule.Applied:
+		h.SendSuccess(c, schedule, "試合日程を作成しました")
+	case schedule.Feasible:
+		h.SendSuccess(c, schedule, "試合日程を試行しました")
+	default:
+		h.SendSuccess(c, schedule, "割り当てられない試合があるため試合日程を反映しませんでした")
+	}
+}
+
+// ShiftSchedule は日程の後ろ倒しエンドポイントハンドラー
+// @Summary 日程の後ろ倒し
+// @Description 試合が延びた場合などに、コートまたはトーナメントのまだ始まっていない試合を指定した時間だけ後ろ倒しする。同じコート・同じクラス・勝者が進出する試合は重ならないように続けて後ろ倒しし、休憩の時間帯にかかる試合は休憩の後に移す。固定した試合・実施中または実施済みの試合は動かさない。試合には最初に予定していた開始時刻（planned_at）を残し、開始時刻を変えた全ての試合を schedule_update で1つのメッセージとして送る。終了時刻を過ぎる試合などは issues で警告するが、反映は行う（dry_run=true の場合は反映しない）（管理者のみ）
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param event_id path int true "大会ID"
+// @Param dry_run query bool false "trueの場合は反映せずに結果のみを返す"
+// @Param request body models.ScheduleShiftRequest true "後ろ倒しの条件"
+// @Success 200 {object} map[string]interface{} "後ろ倒し成功（appliedで反映の有無を示す）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events/{event_id}/schedule/shift [post]
+func (h *ScheduleHandler) ShiftSchedule(c *gin.Context) {
+	eventID, ok := h.GetIDParam(c, "event_id", "無効な大会IDです")
+	if !ok {
+		return
+	}
+
+	var request models.ScheduleShiftRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	shift, err := h.scheduleService.ShiftSchedule(c.Request.Context(), eventID, &request, dryRun)
+	if err != nil {
+		h.SendServiceError(c, err, "日程の後ろ倒しに失敗しました")
+		return
+	}
+
+	if shift.Applied {
+		h.SendSuccess(c, shift, "日程を後ろ倒ししました")
+		return
+	}
+	h.SendSuccess(c, shift, "日程の後ろ倒しを試行しました")
+}
+
+// PinMatchSchedule は試合の日程固定エンドポイントハンドラー
+// @Summary 試合の日程固定
+// @Description 試合の開始時刻とコートを指定して固定する。固定した試合は日程の自動作成で動かさない（管理者のみ）
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body PinScheduleRequest true "開始時刻とコート"
+// @Success 200 {object} map[string]interface{} "固定成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "終了した試合・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/schedule [put]
+func (h *ScheduleHandler) PinMatchSchedule(c *gin.Context) {
+	matchID, ok := h.GetIDParam(c, "id", "無効な試合IDです")
+	if !ok {
+		return
+	}
+
+	var req PinScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	match, err := h.scheduleService.PinMatch(c.Request.Context(), matchID, req.ScheduledAt, req.CourtID)
+	if err != nil {
+		h.SendServiceError(c, err, "試合の日程の固定に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, match, "試合の日程を固定しました")
+}
+
+// UnpinMatchSchedule は試合の日程固定解除エンドポイントハンドラー
+// @Summary 試合の日程固定解除
+// @Description 試合の日程の固定を解除する。開始時刻とコートは次に日程を自動作成するまでそのまま残る（管理者のみ）
+// @Tags schedule
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Success 200 {object} map[string]interface{} "解除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/schedule/pin [delete]
+func (h *ScheduleHandler) UnpinMatchSchedule(c *gin.Context) {
+	matchID, ok := h.GetIDParam(c, "id", "無効な試合IDです")
+	if !ok {
+		return
+	}
+
+	match, err := h.scheduleService.UnpinMatch(c.Request.Context(), matchID)
+	if err != nil {
+		h.SendServiceError(c, err, "試合の日程の固定の解除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, match, "試合の日程の固定を解除しました")
+}
+
+// AssignMatchCourt は試合のコート割り当てエンドポイントハンドラー
+// @Summary 試合のコート割り当て
+// @Description 試合を別のコートに移す。開始時刻と日程の固定はそのまま残る。移動前後のコートの試合順は court_update で送られる（管理者のみ）
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "試合ID"
+// @Param request body AssignCourtRequest true "コート"
+// @Success 200 {object} map[string]interface{} "割り当て成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "終了した試合・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/matches/{id}/court [put]
+func (h *ScheduleHandler) AssignMatchCourt(c *gin.Context) {
+	matchID, ok := h.GetIDParam(c, "id", "無効な試合IDです")
+	if !ok {
+		return
+	}
+
+	var req AssignCourtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	match, err := h.scheduleService.AssignCourt(c.Request.Context(), matchID, req.CourtID)
+	if err != nil {
+		h.SendServiceError(c, err, "試合のコートの割り当てに失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, match, "試合のコートを割り当てました")
+}
+
+// GetCalendar は試合日程のカレンダー配信エンドポイントハンドラー
+// @Summary 試合日程のカレンダー配信
+// @Description 試合日程をiCalendar形式（.ics）で配信する。チーム・種目・コートで絞り込め、省略した場合は大会の全試合を含める。試合ごとにUIDが変わらないため、日程が変わった試合はカレンダーアプリで重複せずに更新され、終了した試合は説明に結果を含める。大会IDを省略した場合は今年度の大会を対象とする
+// @Tags schedule
+// @Produce text/calendar
+// @Param event_id path int false "大会ID"
+// @Param team query string false "チーム（クラス）"
+// @Param sport query string false "種目"
+// @Param court_id query int false "コートID"
+// @Success 200 {string} string "iCalendar"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/calendar.ics [get]
+// @Router /api/public/calendar.ics [get]
+func (h *ScheduleHandler) GetCalendar(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	var filter models.CalendarFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	calendar, err := h.scheduleService.GetCalendar(c.Request.Context(), eventID, filter)
+	if err != nil {
+		h.SendServiceError(c, err, "カレンダーの取得に失敗しました")
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar.Encode()))
+}
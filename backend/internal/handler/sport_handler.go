@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SportHandler は種目（スポーツのレジストリ）関連のHTTPハンドラー
+type SportHandler struct {
+	*BaseHandler
+	sportService service.SportService
+}
+
+// NewSportHandler は新しいSportHandlerを作成する
+func NewSportHandler(sportService service.SportService) *SportHandler {
+	return &SportHandler{
+		BaseHandler:  NewBaseHandler(),
+		sportService: sportService,
+	}
+}
+
+// SportRequest は種目の登録・更新リクエストの構造体
+type SportRequest struct {
+	Code            models.SportType          `json:"code" example:"basketball"`                                  // 種目コード（登録時のみ、英小文字・数字・アンダースコア）
+	DisplayName     string                    `json:"display_name" binding:"required,max=100" example:"バスケットボール"` // 表示名
+	Formats         []models.TournamentFormat `json:"formats" binding:"required,min=1"`                           // 利用可能なトーナメント形式
+	Rounds          []models.RoundType        `json:"rounds" binding:"required,min=1"`                            // 利用可能なラウンド
+	SetRules        *models.SetRules          `json:"set_rules,omitempty"`                                        // セット制の規則（セット制でない場合は省略）
+	ForfeitScore    int                       `json:"forfeit_score" example:"20"`                                 // 得点制の試合で不戦勝のチームに与える得点
+	PlacementPoints []int                     `json:"placement_points,omitempty"`                                 // 総合順位の順位点（1位から順に、省略時は既定の10・7・5・3点）
+	SortOrder       int                       `json:"sort_order" example:"4"`                                     // 一覧の表示順
+	IsActive        *bool                     `json:"is_active,omitempty" example:"true"`                         // 新しいトーナメントを作成できるかどうか（省略時はtrue）
+}
+
+// toSport はリクエストを種目に変換する
+func (r *SportRequest) toSport() *models.Sport {
+	sport := &models.Sport{
+		Code:            models.SportType(strings.TrimSpace(string(r.Code))),
+		DisplayName:     strings.TrimSpace(r.DisplayName),
+		Formats:         r.Formats,
+		Rounds:          r.Rounds,
+		SetRules:        r.SetRules,
+		ForfeitScore:    r.ForfeitScore,
+		PlacementPoints: r.PlacementPoints,
+		SortOrder:       r.SortOrder,
+		IsActive:        true,
+	}
+	if r.IsActive != nil {
+		sport.IsActive = *r.IsActive
+	}
+	return sport
+}
+
+// SportListResponse は種目一覧レスポンスの構造体
+type SportListResponse struct {
+	Success bool            `json:"success" example:"true"`        // 成功フラグ
+	Message string          `json:"message" example:"種目一覧を取得しました"` // メッセージ
+	Data    []*models.Sport `json:"data"`                          // 種目
+}
+
+// GetSports は種目一覧取得エンドポイントハンドラー
+// @Summary 種目一覧取得
+// @Description 登録されている種目（表示名・利用可能な形式・ラウンド・得点方式）を表示順に取得する。all=true の場合は無効化された種目も含める
+// @Tags sports
+// @Produce json
+// @Param all query bool false "無効化された種目も含める"
+// @Success 200 {object} SportListResponse "取得成功"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/sports [get]
+func (h *SportHandler) GetSports(c *gin.Context) {
+	activeOnly := c.Query("all") != "true"
+
+	sports, err := h.sportService.ListSports(c.Request.Context(), activeOnly)
+	if err != nil {
+		h.SendServiceError(c, err, "種目一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, sports, "種目一覧を取得しました")
+}
+
+// GetSport は種目取得エンドポイントハンドラー
+// @Summary 種目取得
+// @Description 指定された種目の定義を取得する
+// @Tags sports
+// @Produce json
+// @Param sport path string true "種目コード（例: volleyball）"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Router /api/public/sports/{sport} [get]
+func (h *SportHandler) GetSport(c *gin.Context) {
+	sport, err := h.sportService.GetSport(c.Request.Context(), models.SportType(c.Param("sport")))
+	if err != nil {
+		h.SendServiceError(c, err, "種目の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, sport, "種目を取得しました")
+}
+
+// CreateSport は種目登録エンドポイントハンドラー
+// @Summary 種目登録
+// @Description 新しい種目を登録する。登録した種目はトーナメントの作成・URL・WebSocketの購読ですぐに利用できる（管理者のみ）
+// @Tags sports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SportRequest true "種目の定義"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 409 {object} ErrorResponse "種目コードの重複"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/sports [post]
+func (h *SportHandler) CreateSport(c *gin.Context) {
+	var req SportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	sport := req.toSport()
+	if err := h.sportService.CreateSport(c.Request.Context(), sport); err != nil {
+		h.SendServiceError(c, err, "種目の登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, sport, "種目を登録しました", http.StatusCreated)
+}
+
+// UpdateSport は種目更新エンドポイントハンドラー
+// @Summary 種目更新
+// @Description 種目の表示名・利用可能な形式・ラウンド・得点方式を更新する。種目コードは変更できない（管理者のみ）
+// @Tags sports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sport path string true "種目コード（例: volleyball）"
+// @Param request body SportRequest true "種目の定義"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/sports/{sport} [put]
+func (h *SportHandler) UpdateSport(c *gin.Context) {
+	var req SportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	sport := req.toSport()
+	if err := h.sportService.UpdateSport(c.Request.Context(), models.SportType(c.Param("sport")), sport); err != nil {
+		h.SendServiceError(c, err, "種目の更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, sport, "種目を更新しました")
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TeamHandler はチーム（クラス）の登録・管理のHTTPハンドラー
+type TeamHandler struct {
+	*BaseHandler
+	teamService service.TeamService
+}
+
+// NewTeamHandler は新しいTeamHandlerを作成する
+func NewTeamHandler(teamService service.TeamService) *TeamHandler {
+	return &TeamHandler{
+		BaseHandler: NewBaseHandler(),
+		teamService: teamService,
+	}
+}
+
+// TeamRequest はチームの登録・更新リクエストの構造体
+type TeamRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"IE4"` // チーム名（トーナメント内で一意）
+	Description string `json:"description" binding:"max=255" example:""`      // 補足
+}
+
+// toTeam はリクエストをチームに変換する
+func (r *TeamRequest) toTeam() *models.Team {
+	return &models.Team{
+		Name:        strings.TrimSpace(r.Name),
+		Description: strings.TrimSpace(r.Description),
+	}
+}
+
+// TeamListResponse はチーム一覧レスポンスの構造体
+type TeamListResponse struct {
+	Success bool           `json:"success" example:"true"`         // 成功フラグ
+	Message string         `json:"message" example:"チーム一覧を取得しました"` // メッセージ
+	Data    []*models.Team `json:"data"`                           // チーム
+}
+
+// GetTournamentTeams はトーナメントのチーム一覧取得エンドポイントハンドラー
+// @Summary トーナメントのチーム一覧取得
+// @Description 指定されたトーナメントに登録されているチームをチーム名順に取得する
+// @Tags teams
+// @Produce json
+// @Param id path int true "トーナメントID"
+// @Success 200 {object} TeamListResponse "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/tournaments/{id}/teams [get]
+func (h *TeamHandler) GetTournamentTeams(c *gin.Context) {
+	tournamentID, ok := h.GetIDParam(c, "id", "無効なトーナメントIDです")
+	if !ok {
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(c.Request.Context(), tournamentID)
+	if err != nil {
+		h.SendServiceError(c, err, "チーム一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, teams, "チーム一覧を取得しました")
+}
+
+// GetTeam はチーム取得エンドポイントハンドラー
+// @Summary チーム取得
+// @Description 指定されたチームを取得する
+// @Tags teams
+// @Produce json
+// @Param id path int true "チームID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Router /api/public/teams/{id} [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), id)
+	if err != nil {
+		h.SendServiceError(c, err, "チームの取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, team, "チームを取得しました")
+}
+
+// RegisterTeam はチーム登録エンドポイントハンドラー
+// @Summary チーム登録
+// @Description トーナメントにチームを登録する。登録できるのは組み合わせ抽選の前（registrationステータス）のみ（管理者のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Param request body TeamRequest true "チームの情報"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "チーム名の重複・登録期間外"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/teams [post]
+func (h *TeamHandler) RegisterTeam(c *gin.Context) {
+	tournamentID, ok := h.GetIDParam(c, "id", "無効なトーナメントIDです")
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	team := req.toTeam()
+	if err := h.teamService.RegisterTeam(c.Request.Context(), tournamentID, team); err != nil {
+		h.SendServiceError(c, err, "チームの登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, team, "チームを登録しました", http.StatusCreated)
+}
+
+// UpdateTeam はチーム更新エンドポイントハンドラー
+// @Summary チーム更新
+// @Description チーム名・補足を更新する。チーム名を変更すると試合の記録のチーム名・勝者も新しい名前になる（管理者のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "チームID"
+// @Param request body TeamRequest true "チームの情報"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "チーム名の重複"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/teams/{id} [put]
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	team := req.toTeam()
+	if err := h.teamService.UpdateTeam(c.Request.Context(), id, team); err != nil {
+		h.SendServiceError(c, err, "チームの更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, team, "チームを更新しました")
+}
+
+// DeleteTeam はチーム削除エンドポイントハンドラー
+// @Summary チーム削除
+// @Description 試合に組み込まれていないチームの登録を取り消す（管理者のみ）
+// @Tags teams
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "チームID"
+// @Success 200 {object} map[string]interface{} "削除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "試合に組み込まれているチーム"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/teams/{id} [delete]
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なチームIDです")
+	if !ok {
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), id); err != nil {
+		h.SendServiceError(c, err, "チームの削除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "チームを削除しました")
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"backend/internal/models"
 	"backend/internal/service"
@@ -40,6 +41,7 @@ type TournamentListResponse struct {
 // Tournament はSwagger用のトーナメント構造体
 type Tournament struct {
 	ID        int    `json:"id" example:"1"`                                      // トーナメントID
+	EventID   int    `json:"event_id" example:"1"`                                // 大会ID
 	Sport     string `json:"sport" example:"volleyball"`                         // スポーツ種目
 	Format    string `json:"format" example:"standard"`                          // トーナメント形式
 	Status    string `json:"status" example:"active"`                            // ステータス
@@ -51,6 +53,7 @@ type Tournament struct {
 func convertToSwaggerTournament(tournament *models.Tournament) Tournament {
 	return Tournament{
 		ID:        tournament.ID,
+		EventID:   tournament.EventID,
 		Sport:     tournament.Sport,
 		Format:    tournament.Format,
 		Status:    tournament.Status,
@@ -81,19 +84,26 @@ func convertToSwaggerBracket(bracket *models.Bracket) Bracket {
 			}
 
 			matches[j] = Match{
-				ID:           match.ID,
-				TournamentID: match.TournamentID,
-				Round:        match.Round,
-				Team1:        match.Team1,
-				Team2:        match.Team2,
-				Score1:       match.Score1,
-				Score2:       match.Score2,
-				Winner:       match.Winner,
-				Status:       match.Status,
-				ScheduledAt:  match.ScheduledAt.Format("2006-01-02T15:04:05Z"),
-				CompletedAt:  completedAt,
-				CreatedAt:    match.CreatedAt.Format("2006-01-02T15:04:05Z"),
-				UpdatedAt:    match.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+				ID:              match.ID,
+				TournamentID:    match.TournamentID,
+				Round:           match.Round,
+				Team1:           match.Team1,
+				Team2:           match.Team2,
+				Score1:          match.Score1,
+				Score2:          match.Score2,
+				ExtraTimeScore1: match.ExtraTimeScore1,
+				ExtraTimeScore2: match.ExtraTimeScore2,
+				PenaltyScore1:   match.PenaltyScore1,
+				PenaltyScore2:   match.PenaltyScore2,
+				DecisionMethod:  match.GetDecisionMethod().String(),
+				Sets:            match.Sets,
+				ScoreDisplay:    match.ScoreDisplay(),
+				Winner:          match.Winner,
+				Status:          match.Status,
+				ScheduledAt:     match.ScheduledAt.Format("2006-01-02T15:04:05Z"),
+				CompletedAt:     completedAt,
+				CreatedAt:       match.CreatedAt.Format("2006-01-02T15:04:05Z"),
+				UpdatedAt:       match.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 			}
 		}
 		rounds[i] = Round{
@@ -126,48 +136,9 @@ func convertToSwaggerTournamentProgress(progress *service.TournamentProgress) To
 }
 
 // convertMatchesToRounds はマッチデータをラウンド形式に変換する
+// ラウンド内の試合は保存されたブラケット上の位置（position）順に並ぶ
 func convertMatchesToRounds(matches []*models.Match) []models.Round {
-	roundMap := make(map[string][]models.Match)
-	
-	// ラウンド別にマッチをグループ化（ポインタから値に変換）
-	for _, match := range matches {
-		roundMap[match.Round] = append(roundMap[match.Round], *match)
-	}
-	
-	// ラウンドの順序を定義
-	roundOrder := []string{
-		"1st_round", "2nd_round", "3rd_round", "4th_round",
-		"quarterfinal", "semifinal", "final",
-	}
-	
-	var rounds []models.Round
-	for _, roundName := range roundOrder {
-		if roundMatches, exists := roundMap[roundName]; exists {
-			rounds = append(rounds, models.Round{
-				Name:    roundName,
-				Matches: roundMatches,
-			})
-		}
-	}
-	
-	// 定義されていないラウンドも追加
-	for roundName, roundMatches := range roundMap {
-		found := false
-		for _, definedRound := range roundOrder {
-			if roundName == definedRound {
-				found = true
-				break
-			}
-		}
-		if !found {
-			rounds = append(rounds, models.Round{
-				Name:    roundName,
-				Matches: roundMatches,
-			})
-		}
-	}
-	
-	return rounds
+	return models.GroupMatchesIntoRounds(matches)
 }
 
 // BracketResponse はブラケットレスポンスの構造体
@@ -196,6 +167,15 @@ type Bracket struct {
 type Round struct {
 	Name    string  `json:"name" example:"1st_round"`    // ラウンド名
 	Matches []Match `json:"matches"`                     // 試合配列
+	Byes    []Bye   `json:"byes,omitempty"`              // 不戦勝の枠
+}
+
+// Bye はSwagger用の不戦勝構造体
+type Bye struct {
+	Position    int    `json:"position" example:"0"`      // ラウンド内の位置
+	Team        string `json:"team" example:"IE4"`        // 不戦勝のチーム
+	NextMatchID int    `json:"next_match_id" example:"5"` // 進出先の試合ID
+	NextSlot    int    `json:"next_slot" example:"1"`     // 進出先の枠
 }
 
 // TournamentProgress はSwagger用のトーナメント進行状況構造体
@@ -242,13 +222,19 @@ func (h *TournamentHandler) CreateTournament(c *gin.Context) {
 
 	// トーナメント作成
 	tournament := &models.Tournament{
-		Sport:  string(req.Sport),
-		Format: string(req.Format),
-		Status: models.TournamentStatusRegistration, // デフォルトステータス
+		EventID: req.EventID,
+		Sport:   string(req.Sport),
+		Format:  string(req.Format),
+		Status:  models.TournamentStatusRegistration, // デフォルトステータス
 	}
 	
 	err := h.tournamentService.CreateTournament(context.Background(), tournament)
 	if err != nil {
+		if strings.Contains(err.Error(), "archived") {
+			h.SendServiceError(c, err, "トーナメントの作成に失敗しました")
+			return
+		}
+
 		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "既に") {
 			h.SendError(c, models.ErrResourceAlreadyExists.WithDetails("tournament", "既にアクティブなトーナメントが存在します"))
 			return
@@ -298,7 +284,8 @@ func (h *TournamentHandler) GetTournaments(c *gin.Context) {
 // @Description 指定されたスポーツのトーナメントを取得する
 // @Tags tournaments
 // @Produce json
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} TournamentResponse "取得成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
@@ -317,8 +304,13 @@ func (h *TournamentHandler) GetTournamentBySport(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// スポーツ別トーナメント取得
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), sport, 1, 0)
+	tournament, err := h.tournamentService.GetTournamentBySport(context.Background(), eventID, sport)
 	if err != nil {
 		if strings.Contains(err.Error(), "見つかりません") || strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, ErrorResponse{
@@ -345,17 +337,6 @@ func (h *TournamentHandler) GetTournamentBySport(c *gin.Context) {
 		})
 		return
 	}
-	
-	if len(tournaments) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: "指定されたスポーツのトーナメントが見つかりません",
-			Code:    http.StatusNotFound,
-		})
-		return
-	}
-	
-	tournament := tournaments[0]
 
 
 	// 成功レスポンス
@@ -490,6 +471,12 @@ func (h *TournamentHandler) UpdateTournament(c *gin.Context) {
 	// トーナメント更新
 	err = h.tournamentService.UpdateTournament(context.Background(), uint(id), tournament)
 	if err != nil {
+		// ステータスの変更が遷移表やガード条件に反する場合はその理由を返す
+		if _, ok := err.(*service.ServiceError); ok {
+			h.SendServiceError(c, err, "トーナメントの更新に失敗しました")
+			return
+		}
+
 		if strings.Contains(err.Error(), "無効な") {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Bad Request",
@@ -583,7 +570,8 @@ func (h *TournamentHandler) DeleteTournament(c *gin.Context) {
 // @Description 指定されたスポーツのトーナメントブラケットを取得する
 // @Tags tournaments
 // @Produce json
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} BracketResponse "取得成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
@@ -602,8 +590,13 @@ func (h *TournamentHandler) GetTournamentBracket(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// スポーツ別トーナメント取得
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), sport, 1, 0)
+	tournament, err := h.tournamentService.GetTournamentBySport(context.Background(), eventID, sport)
 	if err != nil {
 		if strings.Contains(err.Error(), "見つかりません") || strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, ErrorResponse{
@@ -631,17 +624,6 @@ func (h *TournamentHandler) GetTournamentBracket(c *gin.Context) {
 		return
 	}
 	
-	if len(tournaments) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: "指定されたスポーツのトーナメントが見つかりません",
-			Code:    http.StatusNotFound,
-		})
-		return
-	}
-	
-	tournament := tournaments[0]
-	
 	// ブラケット取得
 	matches, err := h.tournamentService.GetBracket(context.Background(), uint(tournament.ID))
 	if err != nil {
@@ -697,139 +679,49 @@ func (h *TournamentHandler) GetTournamentBracket(c *gin.Context) {
 
 // SwitchTournamentFormat はトーナメント形式切り替えエンドポイントハンドラー
 // @Summary トーナメント形式切り替え
-// @Description 指定されたスポーツのトーナメント形式を切り替える（卓球の天候条件用、管理者のみ）
+// @Description トーナメント形式を切り替え、作成済みの試合を新しい形式に合わせる（卓球の雨天時など、管理者のみ）。雨天時形式（rainy）へは1回戦の敗者による敗者復活戦を作成し、1回戦が終わった試合の敗者を配置する。雨天時形式からは終了していない敗者復活戦を中止する。終了した試合の結果は全て残し、残す試合（kept）・中止する試合（cancelled）・作成する試合（created）を返す。dry_run=true で反映せずに計画をプレビューし、反映は1つのトランザクションで行う。ブラケット生成後は標準（standard）と雨天時形式（rainy）の間のみ切り替えられ、それ以外の形式（ダブルイリミネーションなど）への切り替えは400を返す（ブラケット生成前にトーナメントの更新で変更する）
 // @Tags tournaments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "トーナメントID"
-// @Param request body SwitchFormatRequest true "形式切り替え情報"
-// @Success 200 {object} map[string]string "切り替え成功"
-// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Param dry_run query bool false "trueの場合は反映せずに計画のみを返す"
+// @Param request body models.SwitchFormatRequest true "形式切り替え情報"
+// @Success 200 {object} map[string]interface{} "切り替え成功（appliedで反映の有無を示す）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー（ブラケット生成後に標準・雨天時形式以外へ切り替える場合を含む）"
 // @Failure 401 {object} ErrorResponse "認証エラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
 // @Failure 409 {object} ErrorResponse "競合エラー"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/tournaments/{id}/format [put]
 func (h *TournamentHandler) SwitchTournamentFormat(c *gin.Context) {
-	idStr := c.Param("id")
-	var req SwitchFormatRequest
-
-	// IDの変換
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "無効なトーナメントIDです",
-			Code:    http.StatusBadRequest,
-		})
+	id, ok := h.GetIDParam(c, "id", "無効なトーナメントIDです")
+	if !ok {
 		return
 	}
 
-	// リクエストボディをバインド
+	var req models.SwitchFormatRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "無効なリクエスト形式です",
-			Code:    http.StatusBadRequest,
-		})
+		h.SendBindingError(c, err)
 		return
 	}
-
-	// 入力値の検証
-	if strings.TrimSpace(req.Format) == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "フォーマットは必須です",
-			Code:    http.StatusBadRequest,
-		})
+	if err := req.Validate(); err != nil {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, err.Error(), http.StatusBadRequest)
 		return
 	}
+	dryRun := c.Query("dry_run") == "true"
 
-	// トーナメント取得
-	tournament, err := h.tournamentService.GetTournament(context.Background(), uint(id))
+	plan, err := h.tournamentService.SwitchTournamentFormat(c.Request.Context(), id, req.Format, dryRun)
 	if err != nil {
-		if strings.Contains(err.Error(), "見つかりません") {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Not Found",
-				Message: err.Error(),
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメントの取得に失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+		h.SendServiceError(c, err, "トーナメント形式の切り替えに失敗しました")
 		return
 	}
-	
-	// 既に同じ形式の場合
-	if tournament.Format == req.Format {
-		c.JSON(http.StatusConflict, ErrorResponse{
-			Error:   "Conflict",
-			Message: "既に指定された形式です",
-			Code:    http.StatusConflict,
-		})
-		return
-	}
-	
-	// トーナメント形式更新
-	tournament.Format = req.Format
-	err = h.tournamentService.UpdateTournament(context.Background(), uint(tournament.ID), tournament)
-	if err != nil {
-		if strings.Contains(err.Error(), "サポートしていません") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "既に") || strings.Contains(err.Error(), "変更できません") {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "Conflict",
-				Message: err.Error(),
-				Code:    http.StatusConflict,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "見つかりません") {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Not Found",
-				Message: err.Error(),
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
 
-		if strings.Contains(err.Error(), "無効な") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメント形式の切り替えに失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+	if plan.Applied {
+		h.SendSuccess(c, plan, "トーナメント形式を切り替えました")
 		return
 	}
-
-	// 成功レスポンス
-	c.JSON(http.StatusOK, TournamentResponse{
-		Success: true,
-		Data:    convertToSwaggerTournament(tournament),
-		Message: "トーナメント形式を切り替えました",
-	})
+	h.SendSuccess(c, plan, "トーナメント形式の切り替えのプレビューです（反映していません）")
 }
 
 // GetActiveTournaments はアクティブトーナメント取得エンドポイントハンドラー
@@ -837,11 +729,17 @@ func (h *TournamentHandler) SwitchTournamentFormat(c *gin.Context) {
 // @Description アクティブなトーナメントのみを取得する
 // @Tags tournaments
 // @Produce json
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} TournamentListResponse "取得成功"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/tournaments/active [get]
 func (h *TournamentHandler) GetActiveTournaments(c *gin.Context) {
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), "active", 100, 0)
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	eventTournaments, err := h.tournamentService.GetTournamentsByEvent(context.Background(), eventID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal Server Error",
@@ -851,6 +749,13 @@ func (h *TournamentHandler) GetActiveTournaments(c *gin.Context) {
 		return
 	}
 
+	tournaments := make([]*models.Tournament, 0, len(eventTournaments))
+	for _, tournament := range eventTournaments {
+		if tournament.IsActive() {
+			tournaments = append(tournaments, tournament)
+		}
+	}
+
 	// 成功レスポンス
 	c.JSON(http.StatusOK, TournamentListResponse{
 		Success: true,
@@ -865,7 +770,8 @@ func (h *TournamentHandler) GetActiveTournaments(c *gin.Context) {
 // @Description 指定されたスポーツのトーナメント進行状況を取得する
 // @Tags tournaments
 // @Produce json
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} ProgressResponse "取得成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
@@ -884,10 +790,15 @@ func (h *TournamentHandler) GetTournamentProgress(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// 進行状況取得
-	progress, err := h.tournamentService.GetTournamentProgress(sport)
+	progress, err := h.tournamentService.GetTournamentProgress(eventID, sport)
 	if err != nil {
-		if strings.Contains(err.Error(), "見つかりません") {
+		if strings.Contains(err.Error(), "見つかりません") || strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "Not Found",
 				Message: err.Error(),
@@ -927,7 +838,8 @@ func (h *TournamentHandler) GetTournamentProgress(c *gin.Context) {
 // @Tags tournaments
 // @Produce json
 // @Security BearerAuth
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} map[string]string "完了成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 401 {object} ErrorResponse "認証エラー"
@@ -948,9 +860,23 @@ func (h *TournamentHandler) CompleteTournament(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// スポーツ別トーナメント取得
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), sport, 1, 0)
+	tournament, err := h.tournamentService.GetTournamentBySport(context.Background(), eventID, sport)
 	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not Found",
+				Message: "指定されたスポーツのトーナメントが見つかりません",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal Server Error",
 			Message: "トーナメントの取得に失敗しました",
@@ -959,19 +885,8 @@ func (h *TournamentHandler) CompleteTournament(c *gin.Context) {
 		return
 	}
 	
-	if len(tournaments) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: "指定されたスポーツのトーナメントが見つかりません",
-			Code:    http.StatusNotFound,
-		})
-		return
-	}
-	
-	tournament := tournaments[0]
-	
 	// 既に完了している場合
-	if tournament.Status == "completed" {
+	if tournament.IsCompleted() {
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Error:   "Conflict",
 			Message: "既に完了しています",
@@ -980,51 +895,10 @@ func (h *TournamentHandler) CompleteTournament(c *gin.Context) {
 		return
 	}
 	
-	// トーナメント完了
-	tournament.Status = "completed"
-	err = h.tournamentService.UpdateTournament(context.Background(), uint(tournament.ID), tournament)
+	// トーナメント完了（優勝を決める試合が終わっていない場合は拒否する）
+	tournament, err = h.tournamentService.ChangeTournamentStatus(context.Background(), uint(tournament.ID), models.TournamentStatusCompletedEnum)
 	if err != nil {
-		if strings.Contains(err.Error(), "既に完了") {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "Conflict",
-				Message: err.Error(),
-				Code:    http.StatusConflict,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "完了していない") {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "Conflict",
-				Message: err.Error(),
-				Code:    http.StatusConflict,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "見つかりません") {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Not Found",
-				Message: err.Error(),
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "無効な") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメントの完了に失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+		h.SendServiceError(c, err, "トーナメントの完了に失敗しました")
 		return
 	}
 
@@ -1036,12 +910,260 @@ func (h *TournamentHandler) CompleteTournament(c *gin.Context) {
 	})
 }
 
+// DrawRequest はブラケット抽選リクエストの構造体
+type DrawRequest struct {
+	Seeds      []string `json:"seeds" example:"IE5,IS5"`              // シード順位（上位から）
+	Separation []string `json:"separation" example:"grade,department"` // 1回戦で対戦させない規則（grade, department）
+	RandomSeed *int64   `json:"random_seed,omitempty" example:"42"`   // 乱数シード（省略時は自動生成、再現時は記録された値を指定）
+
+	// グループリーグ形式のみ（省略時は1グループ4チームを目安、各グループ上位2チームが進出）
+	GroupCount         int `json:"group_count,omitempty" binding:"omitempty,min=1,max=26" example:"4"`   // グループ数
+	QualifiersPerGroup int `json:"qualifiers_per_group,omitempty" binding:"omitempty,min=1" example:"2"` // 各グループから決勝トーナメントに進むチーム数
+
+	// スイス式のみ（省略時はチーム数から決定）
+	SwissRounds int `json:"swiss_rounds,omitempty" binding:"omitempty,min=1" example:"5"` // 回戦数
+}
+
+// DrawResponse はブラケット抽選レスポンスの構造体
+type DrawResponse struct {
+	Success bool              `json:"success" example:"true"`     // 成功フラグ
+	Message string            `json:"message" example:"抽選を行いました"` // メッセージ
+	Data    models.DrawResult `json:"data"`                       // 抽選結果
+}
+
+// DrawTournamentBracket はブラケット抽選エンドポイントハンドラー
+// @Summary ブラケット抽選
+// @Description シード順位と分離規則に従って1回戦の組み合わせを抽選し、ブラケットを生成する（管理者のみ）。抽選条件と乱数シードはトーナメントに記録され、同じ条件で再現できる。分離規則を満たす組み合わせがない場合はその規則を適用せずに抽選し、warnings で知らせる。グループリーグ形式ではシード順にグループへ振り分ける
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Param request body DrawRequest true "抽選条件"
+// @Success 201 {object} DrawResponse "抽選成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/draw [post]
+func (h *TournamentHandler) DrawTournamentBracket(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	var req DrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	opts := models.DrawOptions{
+		Seeds:              req.Seeds,
+		RandomSeed:         time.Now().UnixNano(),
+		GroupCount:         req.GroupCount,
+		QualifiersPerGroup: req.QualifiersPerGroup,
+		SwissRounds:        req.SwissRounds,
+	}
+	if req.RandomSeed != nil {
+		opts.RandomSeed = *req.RandomSeed
+	}
+	for _, rule := range req.Separation {
+		opts.Separation = append(opts.Separation, models.SeparationRule(rule))
+	}
+
+	draw, err := h.tournamentService.DrawBracket(c.Request.Context(), uint(id), opts)
+	if err != nil {
+		h.SendServiceError(c, err, "ブラケットの抽選に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, draw, "抽選を行いました", http.StatusCreated)
+}
+
+// GetTournamentDraw は抽選記録取得エンドポイントハンドラー
+// @Summary 抽選記録取得
+// @Description トーナメントの抽選条件（シード順位・分離規則・乱数シード）と組み合わせを取得する（監査・再現用、管理者のみ）
+// @Tags tournaments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Success 200 {object} DrawResponse "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/draw [get]
+func (h *TournamentHandler) GetTournamentDraw(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	draw, err := h.tournamentService.GetDraw(c.Request.Context(), uint(id))
+	if err != nil {
+		h.SendServiceError(c, err, "抽選記録の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, draw, "抽選記録を取得しました")
+}
+
+// StandingsResponse は順位表レスポンスの構造体
+type StandingsResponse struct {
+	Success bool             `json:"success" example:"true"`      // 成功フラグ
+	Message string           `json:"message" example:"順位表を取得しました"` // メッセージ
+	Data    models.Standings `json:"data"`                        // 順位表
+}
+
+// GetTournamentStandings は順位表取得エンドポイントハンドラー
+// @Summary 順位表取得
+// @Description 総当たり戦の順位表（試合数・勝・分・敗・得失点差・勝点）を取得する。グループリーグ形式ではグループごとの順位表をgroupsに返す。勝点が並んだ場合はトーナメントに設定された順位決定方法を順に適用する
+// @Tags tournaments
+// @Produce json
+// @Param id path int true "トーナメントID"
+// @Success 200 {object} StandingsResponse "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/tournaments/{id}/standings [get]
+func (h *TournamentHandler) GetTournamentStandings(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	standings, err := h.tournamentService.GetStandings(c.Request.Context(), uint(id))
+	if err != nil {
+		h.SendServiceError(c, err, "順位表の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, standings, "順位表を取得しました")
+}
+
+// UpdateLeagueRules は順位表の規則更新エンドポイントハンドラー
+// @Summary 順位表の規則更新
+// @Description 総当たり戦の勝点と順位決定方法（head_to_head, goal_difference, goals_scored, lottery, buchholz）を適用順に設定する（管理者のみ）
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Param request body models.LeagueRules true "勝点と順位決定方法"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/league-rules [put]
+func (h *TournamentHandler) UpdateLeagueRules(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	var rules models.LeagueRules
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	if err := h.tournamentService.UpdateLeagueRules(c.Request.Context(), uint(id), rules); err != nil {
+		h.SendServiceError(c, err, "順位表の規則の更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, rules, "順位表の規則を更新しました")
+}
+
+// GenerateNextSwissRound はスイス式の次の回戦作成エンドポイントハンドラー
+// @Summary スイス式の次の回戦作成
+// @Description 現在の回戦の試合が全て完了した後、順位表から再戦とならないように次の回戦の組み合わせを作成する（管理者のみ）。チーム数が奇数の場合は不戦勝のない最下位のチームが不戦勝となる
+// @Tags tournaments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Success 201 {object} map[string]interface{} "作成成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "回戦が未完了、全回戦終了、または次の回戦が作成済み"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/swiss/next-round [post]
+func (h *TournamentHandler) GenerateNextSwissRound(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	matches, err := h.tournamentService.GenerateNextSwissRound(c.Request.Context(), uint(id))
+	if err != nil {
+		h.SendServiceError(c, err, "次の回戦の作成に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, matches, "次の回戦を作成しました", http.StatusCreated)
+}
+
+// ChangeTournamentStatusRequest はトーナメントステータス変更リクエストの構造体
+type ChangeTournamentStatusRequest struct {
+	Status models.TournamentStatus `json:"status" binding:"required" example:"registration"` // 変更後のステータス（draft, registration, seeded, active, completed, cancelled, archived）
+}
+
+// ChangeTournamentStatus はトーナメントステータス変更エンドポイントハンドラー
+// @Summary トーナメントステータス変更
+// @Description トーナメントのステータスを準備中 → 参加登録中 → 組み合わせ確定 → 開催中 → 終了・中止 → 保管済みの順に変更する（管理者のみ）。遷移表で許可されていない変更や、条件（登録チームが2チーム以上で試合に未登録のチームがいない、優勝を決める試合が終わっている など）を満たさない変更は BUSINESS_INVALID_TOURNAMENT_TRANSITION として拒否する。組み合わせ確定は通常は抽選で、終了は決勝・3位決定戦の終了時に自動で行う。変更はリアルタイムで通知される
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "トーナメントID"
+// @Param request body ChangeTournamentStatusRequest true "変更後のステータス"
+// @Success 200 {object} map[string]interface{} "変更成功（変更後のトーナメント）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "許可されていないステータスの変更"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/tournaments/{id}/status [put]
+func (h *TournamentHandler) ChangeTournamentStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントIDです", http.StatusBadRequest)
+		return
+	}
+
+	var req ChangeTournamentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+	if !req.Status.IsValid() {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, "無効なトーナメントステータスです", http.StatusBadRequest)
+		return
+	}
+
+	tournament, err := h.tournamentService.ChangeTournamentStatus(c.Request.Context(), uint(id), req.Status)
+	if err != nil {
+		h.SendServiceError(c, err, "トーナメントステータスの変更に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, tournament, "トーナメントを"+req.Status.Label()+"にしました")
+}
+
 // GetAvailableFormats は利用可能な形式一覧取得エンドポイントハンドラー
 // @Summary 利用可能な形式一覧取得
 // @Description 指定されたスポーツで利用可能なトーナメント形式一覧を取得する
 // @Tags tournaments
 // @Produce json
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
 // @Success 200 {object} map[string]interface{} "取得成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
@@ -1059,16 +1181,9 @@ func (h *TournamentHandler) GetAvailableFormats(c *gin.Context) {
 		return
 	}
 
-	// スポーツ別の利用可能な形式を定義
-	var formats []string
-	switch sport {
-	case "volleyball":
-		formats = []string{"standard", "single_elimination", "double_elimination"}
-	case "table_tennis":
-		formats = []string{"sunny", "rainy", "standard"}
-	case "soccer":
-		formats = []string{"standard", "group_stage", "knockout"}
-	default:
+	// 利用可能な形式は種目のレジストリで管理する
+	registered, ok := models.LookupSport(models.SportType(sport))
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "Bad Request",
 			Message: "サポートされていないスポーツです",
@@ -1077,6 +1192,11 @@ func (h *TournamentHandler) GetAvailableFormats(c *gin.Context) {
 		return
 	}
 
+	formats := make([]string, len(registered.Formats))
+	for i, format := range registered.Formats {
+		formats[i] = string(format)
+	}
+
 	// 成功レスポンス
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -1087,130 +1207,68 @@ func (h *TournamentHandler) GetAvailableFormats(c *gin.Context) {
 
 // UpdateTournamentFormat はトーナメント形式更新エンドポイントハンドラー
 // @Summary トーナメント形式更新
-// @Description 指定されたスポーツのトーナメント形式を更新する（管理者のみ）
+// @Description 指定されたスポーツのトーナメント形式を切り替え、作成済みの試合を新しい形式に合わせる（管理者のみ）。内容は PUT /api/tournaments/{id}/format と同じで、dry_run=true で反映せずに計画をプレビューできる。ブラケット生成後は標準と雨天時形式の間のみ切り替えられる
 // @Tags tournaments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
-// @Param request body SwitchFormatRequest true "形式更新情報"
-// @Success 200 {object} TournamentResponse "更新成功"
-// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param dry_run query bool false "trueの場合は反映せずに計画のみを返す"
+// @Param request body models.SwitchFormatRequest true "形式更新情報"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
+// @Success 200 {object} map[string]interface{} "更新成功（appliedで反映の有無を示す）"
+// @Failure 400 {object} ErrorResponse "リクエストエラー（ブラケット生成後に標準・雨天時形式以外へ切り替える場合を含む）"
 // @Failure 401 {object} ErrorResponse "認証エラー"
 // @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "競合エラー"
 // @Failure 500 {object} ErrorResponse "サーバーエラー"
 // @Router /api/tournaments/{sport}/format [put]
 func (h *TournamentHandler) UpdateTournamentFormat(c *gin.Context) {
 	sport := c.Param("sport")
-	var req SwitchFormatRequest
-
-	// 入力値の検証
 	if strings.TrimSpace(sport) == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "スポーツパラメータは必須です",
-			Code:    http.StatusBadRequest,
-		})
+		h.SendErrorWithCode(c, models.ErrorValidationRequiredField, "スポーツパラメータは必須です", http.StatusBadRequest)
 		return
 	}
 
-	// リクエストボディをバインド
+	var req models.SwitchFormatRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "無効なリクエスト形式です",
-			Code:    http.StatusBadRequest,
-		})
+		h.SendBindingError(c, err)
 		return
 	}
-
-	// 入力値の検証
-	if strings.TrimSpace(req.Format) == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "フォーマットは必須です",
-			Code:    http.StatusBadRequest,
-		})
+	if err := req.Validate(); err != nil {
+		h.SendErrorWithCode(c, models.ErrorValidationInvalidFormat, err.Error(), http.StatusBadRequest)
 		return
 	}
+	dryRun := c.Query("dry_run") == "true"
 
-	// スポーツ別トーナメント取得
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), sport, 1, 0)
-	if err != nil {
-		if strings.Contains(err.Error(), "見つかりません") || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Not Found",
-				Message: "指定されたスポーツのトーナメントが見つかりません",
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメントの取得に失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+	eventID, ok := h.GetEventID(c)
+	if !ok {
 		return
 	}
-	
-	if len(tournaments) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: "指定されたスポーツのトーナメントが見つかりません",
-			Code:    http.StatusNotFound,
-		})
+
+	tournament, err := h.tournamentService.GetTournamentBySport(c.Request.Context(), eventID, sport)
+	if err != nil {
+		h.SendServiceError(c, err, "トーナメントの取得に失敗しました")
 		return
 	}
-	
-	tournament := tournaments[0]
-	
+
 	// 既に同じ形式の場合
-	if tournament.Format == req.Format {
-		c.JSON(http.StatusOK, TournamentResponse{
-			Success: true,
-			Data:    convertToSwaggerTournament(tournament),
-			Message: "既に指定された形式です",
-		})
+	if tournament.GetFormat() == req.Format {
+		h.SendSuccess(c, tournament, "既に指定された形式です")
 		return
 	}
-	
-	// トーナメント形式更新
-	tournament.Format = req.Format
-	err = h.tournamentService.UpdateTournament(context.Background(), uint(tournament.ID), tournament)
-	if err != nil {
-		if strings.Contains(err.Error(), "サポートしていません") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "無効な") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメント形式の更新に失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+	plan, err := h.tournamentService.SwitchTournamentFormat(c.Request.Context(), uint(tournament.ID), req.Format, dryRun)
+	if err != nil {
+		h.SendServiceError(c, err, "トーナメント形式の更新に失敗しました")
 		return
 	}
 
-	// 成功レスポンス
-	c.JSON(http.StatusOK, TournamentResponse{
-		Success: true,
-		Data:    convertToSwaggerTournament(tournament),
-		Message: "トーナメント形式を更新しました",
-	})
+	if plan.Applied {
+		h.SendSuccess(c, plan, "トーナメント形式を更新しました")
+		return
+	}
+	h.SendSuccess(c, plan, "トーナメント形式の更新のプレビューです（反映していません）")
 }
 
 // ActivateTournament はトーナメントアクティブ化エンドポイントハンドラー
@@ -1219,7 +1277,8 @@ func (h *TournamentHandler) UpdateTournamentFormat(c *gin.Context) {
 // @Tags tournaments
 // @Produce json
 // @Security BearerAuth
-// @Param sport path string true "スポーツ名" Enums(volleyball,table_tennis,soccer)
+// @Param sport path string true "スポーツ名（種目コード。例: volleyball）"
+// @Param event_id query int false "大会ID（省略時は現在の大会）"
 // @Success 200 {object} map[string]string "アクティブ化成功"
 // @Failure 400 {object} ErrorResponse "リクエストエラー"
 // @Failure 401 {object} ErrorResponse "認証エラー"
@@ -1240,9 +1299,23 @@ func (h *TournamentHandler) ActivateTournament(c *gin.Context) {
 		return
 	}
 
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
 	// スポーツ別トーナメント取得
-	tournaments, err := h.tournamentService.GetTournamentBySport(context.Background(), sport, 1, 0)
+	tournament, err := h.tournamentService.GetTournamentBySport(context.Background(), eventID, sport)
 	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not Found",
+				Message: "指定されたスポーツのトーナメントが見つかりません",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal Server Error",
 			Message: "トーナメントの取得に失敗しました",
@@ -1251,19 +1324,8 @@ func (h *TournamentHandler) ActivateTournament(c *gin.Context) {
 		return
 	}
 	
-	if len(tournaments) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: "指定されたスポーツのトーナメントが見つかりません",
-			Code:    http.StatusNotFound,
-		})
-		return
-	}
-	
-	tournament := tournaments[0]
-	
 	// 既にアクティブの場合
-	if tournament.Status == "active" {
+	if tournament.IsActive() {
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Error:   "Conflict",
 			Message: "既にアクティブです",
@@ -1272,42 +1334,10 @@ func (h *TournamentHandler) ActivateTournament(c *gin.Context) {
 		return
 	}
 	
-	// トーナメントアクティブ化
-	tournament.Status = "active"
-	err = h.tournamentService.UpdateTournament(context.Background(), uint(tournament.ID), tournament)
+	// トーナメントアクティブ化（組み合わせが確定していない場合は拒否する）
+	tournament, err = h.tournamentService.ChangeTournamentStatus(context.Background(), uint(tournament.ID), models.TournamentStatusActiveEnum)
 	if err != nil {
-		if strings.Contains(err.Error(), "既にアクティブ") {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "Conflict",
-				Message: err.Error(),
-				Code:    http.StatusConflict,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "見つかりません") {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "Not Found",
-				Message: err.Error(),
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		if strings.Contains(err.Error(), "無効な") {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "Bad Request",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "トーナメントのアクティブ化に失敗しました",
-			Code:    http.StatusInternalServerError,
-		})
+		h.SendServiceError(c, err, "トーナメントのアクティブ化に失敗しました")
 		return
 	}
 
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VenueHandler は会場・コートの登録・管理のHTTPハンドラー
+type VenueHandler struct {
+	*BaseHandler
+	venueService service.VenueService
+}
+
+// NewVenueHandler は新しいVenueHandlerを作成する
+func NewVenueHandler(venueService service.VenueService) *VenueHandler {
+	return &VenueHandler{
+		BaseHandler:  NewBaseHandler(),
+		venueService: venueService,
+	}
+}
+
+// VenueRequest は会場の登録・更新リクエストの構造体
+type VenueRequest struct {
+	Name      string `json:"name" binding:"required,max=100" example:"体育館"` // 会場名（大会内で一意）
+	SortOrder int    `json:"sort_order" example:"1"`                        // 一覧の表示順
+}
+
+// toVenue はリクエストを会場に変換する
+func (r *VenueRequest) toVenue() *models.Venue {
+	return &models.Venue{
+		Name:      strings.TrimSpace(r.Name),
+		SortOrder: r.SortOrder,
+	}
+}
+
+// CourtRequest はコートの登録・更新リクエストの構造体
+type CourtRequest struct {
+	Name      string             `json:"name" binding:"required,max=100" example:"Aコート"` // コート名（会場内で一意）
+	Sports    []models.SportType `json:"sports" binding:"required,min=1"`                // コートで行える種目
+	SortOrder int                `json:"sort_order" example:"1"`                         // 会場内の表示順・日程の割り当て順
+}
+
+// toCourt はリクエストをコートに変換する
+func (r *CourtRequest) toCourt() *models.Court {
+	return &models.Court{
+		Name:      strings.TrimSpace(r.Name),
+		Sports:    r.Sports,
+		SortOrder: r.SortOrder,
+	}
+}
+
+// GetVenues は会場一覧取得エンドポイントハンドラー
+// @Summary 会場一覧取得
+// @Description 大会の会場とコートを表示順に取得する。大会IDを省略した場合は今年度の大会を対象とする
+// @Tags venues
+// @Produce json
+// @Param event_id path int false "大会ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/venues [get]
+// @Router /api/public/venues [get]
+func (h *VenueHandler) GetVenues(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	venues, err := h.venueService.ListVenues(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "会場一覧の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, venues, "会場一覧を取得しました")
+}
+
+// CreateVenue は会場登録エンドポイントハンドラー
+// @Summary 会場登録
+// @Description 大会に会場を登録する（管理者のみ）
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param event_id path int true "大会ID"
+// @Param request body VenueRequest true "会場の情報"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "会場名の重複・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/events/{event_id}/venues [post]
+func (h *VenueHandler) CreateVenue(c *gin.Context) {
+	eventID, ok := h.GetIDParam(c, "event_id", "無効な大会IDです")
+	if !ok {
+		return
+	}
+
+	var req VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	venue := req.toVenue()
+	if err := h.venueService.CreateVenue(c.Request.Context(), eventID, venue); err != nil {
+		h.SendServiceError(c, err, "会場の登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, venue, "会場を登録しました", http.StatusCreated)
+}
+
+// UpdateVenue は会場更新エンドポイントハンドラー
+// @Summary 会場更新
+// @Description 会場名・表示順を更新する（管理者のみ）
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会場ID"
+// @Param request body VenueRequest true "会場の情報"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "会場名の重複・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/venues/{id} [put]
+func (h *VenueHandler) UpdateVenue(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効な会場IDです")
+	if !ok {
+		return
+	}
+
+	var req VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	venue := req.toVenue()
+	if err := h.venueService.UpdateVenue(c.Request.Context(), id, venue); err != nil {
+		h.SendServiceError(c, err, "会場の更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, venue, "会場を更新しました")
+}
+
+// DeleteVenue は会場削除エンドポイントハンドラー
+// @Summary 会場削除
+// @Description 会場とそのコートを削除する。割り当てられていた試合はコート未割り当てとなる（管理者のみ）
+// @Tags venues
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会場ID"
+// @Success 200 {object} map[string]interface{} "削除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/venues/{id} [delete]
+func (h *VenueHandler) DeleteVenue(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効な会場IDです")
+	if !ok {
+		return
+	}
+
+	if err := h.venueService.DeleteVenue(c.Request.Context(), id); err != nil {
+		h.SendServiceError(c, err, "会場の削除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "会場を削除しました")
+}
+
+// CreateCourt はコート登録エンドポイントハンドラー
+// @Summary コート登録
+// @Description 会場にコートを登録する。日程の自動作成では、コートで行える種目の試合のみを割り当てる（管理者のみ）
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "会場ID"
+// @Param request body CourtRequest true "コートの情報"
+// @Success 201 {object} map[string]interface{} "登録成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "コート名の重複・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/venues/{id}/courts [post]
+func (h *VenueHandler) CreateCourt(c *gin.Context) {
+	venueID, ok := h.GetIDParam(c, "id", "無効な会場IDです")
+	if !ok {
+		return
+	}
+
+	var req CourtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	court := req.toCourt()
+	if err := h.venueService.CreateCourt(c.Request.Context(), venueID, court); err != nil {
+		h.SendServiceError(c, err, "コートの登録に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, court, "コートを登録しました", http.StatusCreated)
+}
+
+// UpdateCourt はコート更新エンドポイントハンドラー
+// @Summary コート更新
+// @Description コート名・行える種目・表示順を更新する（管理者のみ）
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コートID"
+// @Param request body CourtRequest true "コートの情報"
+// @Success 200 {object} map[string]interface{} "更新成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "コート名の重複・アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/courts/{id} [put]
+func (h *VenueHandler) UpdateCourt(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なコートIDです")
+	if !ok {
+		return
+	}
+
+	var req CourtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.SendBindingError(c, err)
+		return
+	}
+
+	court := req.toCourt()
+	if err := h.venueService.UpdateCourt(c.Request.Context(), id, court); err != nil {
+		h.SendServiceError(c, err, "コートの更新に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, court, "コートを更新しました")
+}
+
+// DeleteCourt はコート削除エンドポイントハンドラー
+// @Summary コート削除
+// @Description コートを削除する。割り当てられていた試合はコート未割り当てとなる（管理者のみ）
+// @Tags venues
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "コートID"
+// @Success 200 {object} map[string]interface{} "削除成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 401 {object} ErrorResponse "認証エラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 409 {object} ErrorResponse "アーカイブ済みの大会"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/admin/courts/{id} [delete]
+func (h *VenueHandler) DeleteCourt(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なコートIDです")
+	if !ok {
+		return
+	}
+
+	if err := h.venueService.DeleteCourt(c.Request.Context(), id); err != nil {
+		h.SendServiceError(c, err, "コートの削除に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, nil, "コートを削除しました")
+}
+
+// GetCourtBoards はコートの試合状況一覧取得エンドポイントハンドラー
+// @Summary コートの試合状況一覧取得
+// @Description 大会の全コートについて、試合中の試合・次の試合・その後の数試合・直近に終わった試合を会場とコートの表示順に取得する。大会IDを省略した場合は今年度の大会を対象とする
+// @Tags venues
+// @Produce json
+// @Param event_id path int false "大会ID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/events/{event_id}/courts [get]
+// @Router /api/public/courts [get]
+func (h *VenueHandler) GetCourtBoards(c *gin.Context) {
+	eventID, ok := h.GetEventID(c)
+	if !ok {
+		return
+	}
+
+	boards, err := h.venueService.GetCourtBoards(c.Request.Context(), eventID)
+	if err != nil {
+		h.SendServiceError(c, err, "コートの試合状況の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, boards, "コートの試合状況を取得しました")
+}
+
+// GetCourtBoard はコートの試合順取得エンドポイントハンドラー
+// @Summary コートの試合順取得
+// @Description コートの試合中の試合・次の試合・これから行う全ての試合・直近に終わった試合を取得する。WebSocketでコートを購読すると変更時に court_update で同じ内容が送られる
+// @Tags venues
+// @Produce json
+// @Param id path int true "コートID"
+// @Success 200 {object} map[string]interface{} "取得成功"
+// @Failure 400 {object} ErrorResponse "リクエストエラー"
+// @Failure 404 {object} ErrorResponse "未発見エラー"
+// @Failure 500 {object} ErrorResponse "サーバーエラー"
+// @Router /api/public/courts/{id}/board [get]
+func (h *VenueHandler) GetCourtBoard(c *gin.Context) {
+	id, ok := h.GetIDParam(c, "id", "無効なコートIDです")
+	if !ok {
+		return
+	}
+
+	board, err := h.venueService.GetCourtBoard(c.Request.Context(), id)
+	if err != nil {
+		h.SendServiceError(c, err, "コートの試合順の取得に失敗しました")
+		return
+	}
+
+	h.SendSuccess(c, board, "コートの試合順を取得しました")
+}
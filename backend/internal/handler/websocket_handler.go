@@ -26,7 +26,7 @@ func NewWebSocketHandler(manager *websocketManager.Manager) *WebSocketHandler {
 
 // HandleWebSocket はWebSocket接続を処理する
 // @Summary WebSocket接続
-// @Description WebSocketでリアルタイム更新を受信するための接続エンドポイント
+// @Description WebSocketでリアルタイム更新を受信するための接続エンドポイント。subscribe メッセージで種目（sports）またはコート（courts）を購読すると、種目の更新またはコートの試合の更新・試合の順番（court_update）を受信する
 // @Tags WebSocket
 // @Accept json
 // @Produce json
@@ -151,7 +151,7 @@ func (h *WebSocketHandler) BroadcastMessage(c *gin.Context) {
 type BroadcastRequest struct {
 	Type    string              `json:"type" validate:"required"`
 	Data    interface{}         `json:"data" validate:"required"`
-	Sports  []models.SportType  `json:"sports,omitempty" validate:"omitempty,dive,oneof=volleyball table_tennis soccer"`
+	Sports  []models.SportType  `json:"sports,omitempty" validate:"omitempty,dive,sport"`
 	UserIDs []int               `json:"user_ids,omitempty" validate:"omitempty,dive,min=1"`
 }
 
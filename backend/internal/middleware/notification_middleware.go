@@ -175,16 +175,12 @@ func (m *NotificationMiddleware) handleMatchNotification(c *gin.Context, method,
 	}
 }
 
-// extractSportFromPath はパスからスポーツを抽出する
+// extractSportFromPath はパスから種目のレジストリに登録された種目を抽出する
 func (m *NotificationMiddleware) extractSportFromPath(path string) models.SportType {
-	if strings.Contains(path, "/volleyball") {
-		return models.SportTypeVolleyball
-	}
-	if strings.Contains(path, "/table_tennis") {
-		return models.SportTypeTableTennis
-	}
-	if strings.Contains(path, "/soccer") {
-		return models.SportTypeSoccer
+	for _, segment := range strings.Split(path, "/") {
+		if sport := models.SportType(segment); sport.IsValid() {
+			return sport
+		}
 	}
 	return ""
 }
@@ -246,16 +242,7 @@ func (m *NotificationMiddleware) generateMatchMessage(action string, sport model
 
 // getSportDisplayName はスポーツの表示名を取得する
 func (m *NotificationMiddleware) getSportDisplayName(sport models.SportType) string {
-	switch sport {
-	case models.SportTypeVolleyball:
-		return "バレーボール"
-	case models.SportTypeTableTennis:
-		return "卓球"
-	case models.SportTypeSoccer:
-		return "サッカー"
-	default:
-		return "スポーツ"
-	}
+	return models.SportDisplayName(sport)
 }
 
 // responseWriter はレスポンスを監視するためのラッパー
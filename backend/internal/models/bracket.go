@@ -17,6 +17,15 @@ type Bracket struct {
 type Round struct {
 	Name    string  `json:"name"`
 	Matches []Match `json:"matches"`
+	Byes    []Bye   `json:"byes,omitempty"` // 試合を行わずに次のラウンドへ進む枠
+}
+
+// Bye は不戦勝を表すモデル（試合行は作成されない）
+type Bye struct {
+	Position    int    `json:"position"`      // ラウンド内の位置
+	Team        string `json:"team"`          // 不戦勝のチーム
+	NextMatchID int    `json:"next_match_id"` // 進出先の試合ID
+	NextSlot    int    `json:"next_slot"`     // 進出先の枠
 }
 
 // Validate はブラケットデータの検証を行う
@@ -0,0 +1,368 @@
+package models
+
+import (
+	"errors"
+	"sort"
+)
+
+// TeamTBD は対戦相手が未確定の枠を表すプレースホルダー
+const TeamTBD = "TBD"
+
+// ブラケット枠の番号
+const (
+	SlotTeam1 = 1 // team1の枠
+	SlotTeam2 = 2 // team2の枠
+)
+
+// knockoutRoundOrder はノックアウト方式で勝者が進むラウンドの順序
+// 3位決定戦は準決勝の敗者が進むため含めない
+var knockoutRoundOrder = []RoundType{
+	Round1stRoundEnum,
+	Round2ndRoundEnum,
+	Round3rdRoundEnum,
+	Round4thRoundEnum,
+	RoundQuarterfinalEnum,
+	RoundSemifinalEnum,
+	RoundFinalEnum,
+}
+
+// BracketSlot は勝者または敗者が進出する試合と枠を表す
+type BracketSlot struct {
+	MatchID int `json:"match_id"`
+	Slot    int `json:"slot"` // 1: team1, 2: team2
+}
+
+// BracketProgression は試合の勝者・敗者の進出先を表す
+type BracketProgression struct {
+	Winner *BracketSlot `json:"winner,omitempty"`
+	Loser  *BracketSlot `json:"loser,omitempty"`
+}
+
+// BuildBracketProgression はトーナメント内の試合一覧から各試合の進出先を導出する
+// 位置pの試合の勝者は次のラウンドの位置 p/2 の試合の (p%2)+1 番目の枠に進出する。
+// 準決勝の敗者は3位決定戦に進出する。不戦勝で試合行がない位置は単に飛ばされる。
+// 導出した進出先はLinkBracketで試合に保存し、以降は保存された構造を参照する。
+func BuildBracketProgression(matches []*Match) map[int]BracketProgression {
+	byRound := groupMatchesByRound(matches)
+	rounds := presentKnockoutRounds(byRound)
+
+	progression := make(map[int]BracketProgression)
+	for i := 0; i+1 < len(rounds); i++ {
+		next := indexByPosition(byRound[rounds[i+1]])
+		for _, match := range byRound[rounds[i]] {
+			target, ok := next[match.Position/2]
+			if !ok {
+				continue
+			}
+			p := progression[match.ID]
+			p.Winner = &BracketSlot{MatchID: target.ID, Slot: match.Position%2 + 1}
+			progression[match.ID] = p
+		}
+	}
+
+	// 準決勝の敗者は3位決定戦へ
+	if thirdPlace := byRound[RoundThirdPlaceEnum]; len(thirdPlace) > 0 {
+		for _, match := range byRound[RoundSemifinalEnum] {
+			if match.Position > 1 {
+				continue
+			}
+			p := progression[match.ID]
+			p.Loser = &BracketSlot{MatchID: thirdPlace[0].ID, Slot: match.Position + 1}
+			progression[match.ID] = p
+		}
+	}
+
+	// ダブルイリミネーション形式の場合は敗者側ブラケットとグランドファイナルへ
+	// 雨天時形式の場合は1回戦の敗者を敗者復活戦へ
+	if len(byRound[RoundGrandFinalEnum]) > 0 {
+		addDoubleEliminationProgression(progression, byRound)
+	} else if len(byRound[RoundLoserBracketEnum]) > 0 {
+		addConsolationProgression(progression, byRound)
+	}
+
+	return progression
+}
+
+// LinkBracket は導出した進出先を各試合のnext_match_id等に設定する
+// 全ての試合にIDが採番されている必要がある
+func LinkBracket(matches []*Match) {
+	progression := BuildBracketProgression(matches)
+	for _, match := range matches {
+		match.SetProgression(progression[match.ID])
+	}
+}
+
+// FindByes は保存されたブラケット構造から不戦勝を導出する
+// 最初のラウンドの次のラウンドで、どの試合からも勝者が進出してこない枠が不戦勝の枠となる
+func FindByes(matches []*Match) []Bye {
+	byRound := groupMatchesByRound(matches)
+	rounds := presentKnockoutRounds(byRound)
+	if len(rounds) < 2 {
+		return nil
+	}
+
+	fed := make(map[BracketSlot]bool)
+	for _, match := range byRound[rounds[0]] {
+		if winner := match.GetProgression().Winner; winner != nil {
+			fed[*winner] = true
+		}
+	}
+	// 進出先が保存されていない（構造を持たない）ブラケットでは判定しない
+	if len(fed) == 0 {
+		return nil
+	}
+
+	var byes []Bye
+	for _, match := range byRound[rounds[1]] {
+		for _, slot := range []int{SlotTeam1, SlotTeam2} {
+			if fed[BracketSlot{MatchID: match.ID, Slot: slot}] {
+				continue
+			}
+			byes = append(byes, Bye{
+				Position:    match.Position*2 + slot - 1,
+				Team:        match.GetTeamInSlot(slot),
+				NextMatchID: match.ID,
+				NextSlot:    slot,
+			})
+		}
+	}
+
+	return byes
+}
+
+// SeedPositions は標準的なシード配置を返す
+// 1回戦で1位と最下位が対戦し、上位シード同士ほど後のラウンドまで当たらない並びになる
+// 例: size=8 の場合 [1 8 4 5 2 7 3 6]
+func SeedPositions(size int) []int {
+	positions := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, seed := range positions {
+			next = append(next, seed, n+1-seed)
+		}
+		positions = next
+	}
+	return positions
+}
+
+// NewSeededPairs はシード順に並んだチームから1回戦の対戦カードを作成する
+// チーム数が2の累乗でない場合、上位シードから順に不戦勝（空文字の相手）を割り当てる
+func NewSeededPairs(teams []string) [][2]string {
+	size := 1
+	for size < len(teams) {
+		size *= 2
+	}
+
+	positions := SeedPositions(size)
+	pairs := make([][2]string, 0, size/2)
+	for i := 0; i+1 < len(positions); i += 2 {
+		pairs = append(pairs, [2]string{seedTeam(teams, positions[i]), seedTeam(teams, positions[i+1])})
+	}
+	return pairs
+}
+
+// seedTeam はシード番号のチームを返す（該当チームがなければ不戦勝として空文字を返す）
+func seedTeam(teams []string, seed int) string {
+	if seed > len(teams) {
+		return ""
+	}
+	return teams[seed-1]
+}
+
+// KnockoutRounds はラウンド数に応じたラウンド名を1回戦から順に返す
+// 最後の3ラウンドを準々決勝・準決勝・決勝とし、それ以前は1回戦から順に番号を振る
+func KnockoutRounds(roundCount int) ([]RoundType, error) {
+	numbered := []RoundType{Round1stRoundEnum, Round2ndRoundEnum, Round3rdRoundEnum, Round4thRoundEnum}
+	finals := []RoundType{RoundQuarterfinalEnum, RoundSemifinalEnum, RoundFinalEnum}
+
+	if roundCount <= 0 {
+		return nil, errors.New("ラウンド数は1以上である必要があります")
+	}
+	if roundCount > len(numbered)+len(finals) {
+		return nil, errors.New("ラウンド数が多すぎます")
+	}
+
+	if roundCount <= len(finals) {
+		return append([]RoundType{}, finals[len(finals)-roundCount:]...), nil
+	}
+	rounds := append([]RoundType{}, numbered[:roundCount-len(finals)]...)
+	return append(rounds, finals...), nil
+}
+
+// NewKnockoutMatches は1回戦の対戦カードから全ラウンドの試合を作成する
+// 相手が空文字の対戦は不戦勝として試合を作成せず、そのチームを次のラウンドの枠に直接配置する。
+// それ以外の2回戦以降の枠はTBDとし、準決勝が2試合とも行われる場合は3位決定戦を追加する
+func NewKnockoutMatches(tournamentID int, pairs [][2]string) ([]*Match, error) {
+	if len(pairs) == 0 || len(pairs)&(len(pairs)-1) != 0 {
+		return nil, errors.New("1回戦の試合数は2の累乗である必要があります")
+	}
+
+	// 不戦勝のチームを1回戦の位置ごとに記録
+	byes := make(map[int]string)
+	for pos, pair := range pairs {
+		switch {
+		case pair[0] == "" && pair[1] == "":
+			return nil, errors.New("両チームとも不戦勝の対戦は作成できません")
+		case pair[0] == "":
+			byes[pos] = pair[1]
+		case pair[1] == "":
+			byes[pos] = pair[0]
+		}
+	}
+	if len(pairs) == 1 && len(byes) > 0 {
+		return nil, errors.New("2チーム以上が必要です")
+	}
+
+	roundCount := 1
+	for n := len(pairs); n > 1; n /= 2 {
+		roundCount++
+	}
+	rounds, err := KnockoutRounds(roundCount)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Match
+	matchCount := len(pairs)
+	for i, round := range rounds {
+		// 準決勝が1回戦で不戦勝を含む場合、敗者が揃わないため3位決定戦は行わない
+		if round == RoundFinalEnum && i > 0 && !(i == 1 && len(byes) > 0) {
+			matches = append(matches, newPendingMatch(tournamentID, RoundThirdPlaceEnum, 0, TeamTBD, TeamTBD))
+		}
+		for pos := 0; pos < matchCount; pos++ {
+			team1, team2 := TeamTBD, TeamTBD
+			switch i {
+			case 0:
+				if _, isBye := byes[pos]; isBye {
+					continue
+				}
+				team1, team2 = pairs[pos][0], pairs[pos][1]
+			case 1:
+				if team, ok := byes[pos*2]; ok {
+					team1 = team
+				}
+				if team, ok := byes[pos*2+1]; ok {
+					team2 = team
+				}
+			}
+			matches = append(matches, newPendingMatch(tournamentID, round, pos, team1, team2))
+		}
+		matchCount /= 2
+	}
+
+	return matches, nil
+}
+
+// newPendingMatch は未実施の試合を作成する
+func newPendingMatch(tournamentID int, round RoundType, position int, team1, team2 string) *Match {
+	return &Match{
+		TournamentID: tournamentID,
+		Round:        round.String(),
+		Position:     position,
+		Team1:        team1,
+		Team2:        team2,
+		Status:       MatchStatusPendingEnum.String(),
+	}
+}
+
+// presentKnockoutRounds は試合が存在するノックアウトのラウンドを進行順に返す
+func presentKnockoutRounds(byRound map[RoundType][]*Match) []RoundType {
+	rounds := make([]RoundType, 0, len(knockoutRoundOrder))
+	for _, round := range knockoutRoundOrder {
+		if len(byRound[round]) > 0 {
+			rounds = append(rounds, round)
+		}
+	}
+	return rounds
+}
+
+// indexByPosition はラウンド内の試合を位置で引けるようにする
+func indexByPosition(matches []*Match) map[int]*Match {
+	index := make(map[int]*Match, len(matches))
+	for _, match := range matches {
+		index[match.Position] = match
+	}
+	return index
+}
+
+// groupMatchesByRound は試合をラウンドごとに位置順で分類する
+func groupMatchesByRound(matches []*Match) map[RoundType][]*Match {
+	byRound := make(map[RoundType][]*Match)
+	for _, match := range matches {
+		if match == nil {
+			continue
+		}
+		byRound[match.GetRound()] = append(byRound[match.GetRound()], match)
+	}
+
+	for _, roundMatches := range byRound {
+		sort.Slice(roundMatches, func(i, j int) bool {
+			if roundMatches[i].Position != roundMatches[j].Position {
+				return roundMatches[i].Position < roundMatches[j].Position
+			}
+			return roundMatches[i].ID < roundMatches[j].ID
+		})
+	}
+
+	return byRound
+}
+
+// GroupMatchesIntoRounds は試合をラウンド順に並べたRoundのリストに変換する
+func GroupMatchesIntoRounds(matches []*Match) []Round {
+	byRound := groupMatchesByRound(matches)
+
+	// グループリーグは決勝トーナメントより前に並べる
+	order := make([]RoundType, 0, len(knockoutRoundOrder)+5)
+	order = append(order, RoundGroupStageEnum)
+	for _, round := range knockoutRoundOrder {
+		if round == RoundFinalEnum {
+			order = append(order, RoundThirdPlaceEnum)
+		}
+		order = append(order, round)
+	}
+	// ダブルイリミネーション形式の敗者側ブラケットとグランドファイナル
+	order = append(order, RoundLoserBracketEnum, RoundGrandFinalEnum, RoundGrandFinalResetEnum)
+
+	rounds := make([]Round, 0, len(byRound))
+	seen := make(map[RoundType]bool)
+	appendRound := func(round RoundType) {
+		if seen[round] || len(byRound[round]) == 0 {
+			return
+		}
+		seen[round] = true
+		roundMatches := make([]Match, 0, len(byRound[round]))
+		for _, match := range byRound[round] {
+			roundMatches = append(roundMatches, *match)
+		}
+		rounds = append(rounds, Round{Name: round.String(), Matches: roundMatches})
+	}
+
+	for _, round := range order {
+		appendRound(round)
+	}
+
+	// 不戦勝は最初のラウンドに明示する
+	if byes := FindByes(matches); len(byes) > 0 {
+		if first := presentKnockoutRounds(byRound); len(first) > 0 {
+			for i := range rounds {
+				if rounds[i].Name == first[0].String() {
+					rounds[i].Byes = byes
+				}
+			}
+		}
+	}
+
+	// 順序が定義されていないラウンドは名前順で末尾に追加
+	others := make([]string, 0)
+	for round := range byRound {
+		if !seen[round] {
+			others = append(others, round.String())
+		}
+	}
+	sort.Strings(others)
+	for _, round := range others {
+		appendRound(RoundType(round))
+	}
+
+	return rounds
+}
@@ -0,0 +1,286 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestBuildBracketProgression(t *testing.T) {
+	progression := BuildBracketProgression(newEightTeamBracket())
+
+	tests := []struct {
+		name    string
+		matchID int
+		winner  *BracketSlot
+		loser   *BracketSlot
+	}{
+		{
+			name:    "1回戦1試合目の勝者は準決勝1試合目のteam1",
+			matchID: 1,
+			winner:  &BracketSlot{MatchID: 5, Slot: SlotTeam1},
+		},
+		{
+			name:    "1回戦2試合目の勝者は準決勝1試合目のteam2",
+			matchID: 2,
+			winner:  &BracketSlot{MatchID: 5, Slot: SlotTeam2},
+		},
+		{
+			name:    "1回戦4試合目の勝者は準決勝2試合目のteam2",
+			matchID: 4,
+			winner:  &BracketSlot{MatchID: 6, Slot: SlotTeam2},
+		},
+		{
+			name:    "準決勝の敗者は3位決定戦へ",
+			matchID: 6,
+			winner:  &BracketSlot{MatchID: 8, Slot: SlotTeam2},
+			loser:   &BracketSlot{MatchID: 7, Slot: SlotTeam2},
+		},
+		{
+			name:    "決勝は進出先なし",
+			matchID: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := progression[tt.matchID]
+			assertSlot(t, "winner", p.Winner, tt.winner)
+			assertSlot(t, "loser", p.Loser, tt.loser)
+		})
+	}
+}
+
+func TestBuildBracketProgression_PositionOrder(t *testing.T) {
+	// IDの順序ではなくラウンド内の位置で進出先が決まる
+	matches := []*Match{
+		{ID: 10, Round: RoundSemifinal, Position: 1},
+		{ID: 11, Round: RoundSemifinal, Position: 0},
+		{ID: 12, Round: RoundFinal, Position: 0},
+	}
+
+	progression := BuildBracketProgression(matches)
+	assertSlot(t, "winner", progression[11].Winner, &BracketSlot{MatchID: 12, Slot: SlotTeam1})
+	assertSlot(t, "winner", progression[10].Winner, &BracketSlot{MatchID: 12, Slot: SlotTeam2})
+}
+
+func TestKnockoutRounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		roundCount int
+		want       []RoundType
+		wantErr    bool
+	}{
+		{name: "2チーム", roundCount: 1, want: []RoundType{RoundFinalEnum}},
+		{name: "8チーム", roundCount: 3, want: []RoundType{RoundQuarterfinalEnum, RoundSemifinalEnum, RoundFinalEnum}},
+		{name: "32チーム", roundCount: 5, want: []RoundType{Round1stRoundEnum, Round2ndRoundEnum, RoundQuarterfinalEnum, RoundSemifinalEnum, RoundFinalEnum}},
+		{name: "0ラウンド", roundCount: 0, wantErr: true},
+		{name: "ラウンド数超過", roundCount: 8, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KnockoutRounds(tt.roundCount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KnockoutRounds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("KnockoutRounds() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("KnockoutRounds()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewKnockoutMatches_LinkBracket(t *testing.T) {
+	pairs := [][2]string{{"専・教", "IE4"}, {"IS5", "IT4"}, {"IT3", "IT2"}, {"1-1", "IE2"}}
+
+	matches, err := NewKnockoutMatches(1, pairs)
+	if err != nil {
+		t.Fatalf("NewKnockoutMatches() error = %v", err)
+	}
+
+	// 準々決勝4試合 + 準決勝2試合 + 3位決定戦 + 決勝
+	if len(matches) != 8 {
+		t.Fatalf("NewKnockoutMatches() matches = %d, want 8", len(matches))
+	}
+
+	// 採番を模してIDを設定し、進出先を保存する
+	byRound := groupMatchesByRound(linkTestMatches(matches))
+
+	first := byRound[RoundQuarterfinalEnum]
+	semis := byRound[RoundSemifinalEnum]
+	final := byRound[RoundFinalEnum][0]
+	third := byRound[RoundThirdPlaceEnum][0]
+
+	if first[0].Team1 != "専・教" || semis[0].Team1 != TeamTBD {
+		t.Errorf("NewKnockoutMatches() teams = %v / %v", first[0].Team1, semis[0].Team1)
+	}
+	assertSlot(t, "winner", first[3].GetProgression().Winner, &BracketSlot{MatchID: semis[1].ID, Slot: SlotTeam2})
+	assertSlot(t, "winner", semis[0].GetProgression().Winner, &BracketSlot{MatchID: final.ID, Slot: SlotTeam1})
+	assertSlot(t, "loser", semis[1].GetProgression().Loser, &BracketSlot{MatchID: third.ID, Slot: SlotTeam2})
+	assertSlot(t, "winner", final.GetProgression().Winner, nil)
+
+	if _, err := NewKnockoutMatches(1, pairs[:3]); err == nil {
+		t.Error("NewKnockoutMatches() should reject non power of two pairs")
+	}
+}
+
+func TestSeedPositions(t *testing.T) {
+	want := []int{1, 8, 4, 5, 2, 7, 3, 6}
+	got := SeedPositions(8)
+	if len(got) != len(want) {
+		t.Fatalf("SeedPositions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SeedPositions()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewSeededPairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []string
+		want  [][2]string
+	}{
+		{
+			name:  "4チーム",
+			teams: []string{"A", "B", "C", "D"},
+			want:  [][2]string{{"A", "D"}, {"B", "C"}},
+		},
+		{
+			name:  "5チームは上位3シードが不戦勝",
+			teams: []string{"A", "B", "C", "D", "E"},
+			want:  [][2]string{{"A", ""}, {"D", "E"}, {"B", ""}, {"C", ""}},
+		},
+		{
+			name:  "6チームは上位2シードが不戦勝",
+			teams: []string{"A", "B", "C", "D", "E", "F"},
+			want:  [][2]string{{"A", ""}, {"D", "E"}, {"B", ""}, {"C", "F"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSeededPairs(tt.teams)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NewSeededPairs() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("NewSeededPairs()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewKnockoutMatches_Byes(t *testing.T) {
+	matches, err := NewKnockoutMatches(1, NewSeededPairs([]string{"A", "B", "C", "D", "E", "F"}))
+	if err != nil {
+		t.Fatalf("NewKnockoutMatches() error = %v", err)
+	}
+
+	// 準々決勝2試合（不戦勝の2枠は試合なし） + 準決勝2試合 + 3位決定戦 + 決勝
+	if len(matches) != 6 {
+		t.Fatalf("NewKnockoutMatches() matches = %d, want 6", len(matches))
+	}
+
+	byRound := groupMatchesByRound(linkTestMatches(matches))
+
+	first := byRound[RoundQuarterfinalEnum]
+	semis := byRound[RoundSemifinalEnum]
+	if first[0].Position != 1 || first[1].Position != 3 {
+		t.Errorf("NewKnockoutMatches() positions = %d, %d, want 1, 3", first[0].Position, first[1].Position)
+	}
+
+	// 不戦勝のチームは準決勝に直接配置される
+	if semis[0].Team1 != "A" || semis[0].Team2 != TeamTBD {
+		t.Errorf("semifinal[0] = %v vs %v, want A vs TBD", semis[0].Team1, semis[0].Team2)
+	}
+	if semis[1].Team1 != "B" || semis[1].Team2 != TeamTBD {
+		t.Errorf("semifinal[1] = %v vs %v, want B vs TBD", semis[1].Team1, semis[1].Team2)
+	}
+	assertSlot(t, "winner", first[0].GetProgression().Winner, &BracketSlot{MatchID: semis[0].ID, Slot: SlotTeam2})
+	assertSlot(t, "winner", first[1].GetProgression().Winner, &BracketSlot{MatchID: semis[1].ID, Slot: SlotTeam2})
+
+	byes := FindByes(matches)
+	if len(byes) != 2 {
+		t.Fatalf("FindByes() = %d, want 2", len(byes))
+	}
+	want := []Bye{
+		{Position: 0, Team: "A", NextMatchID: semis[0].ID, NextSlot: SlotTeam1},
+		{Position: 2, Team: "B", NextMatchID: semis[1].ID, NextSlot: SlotTeam1},
+	}
+	for i := range want {
+		if byes[i] != want[i] {
+			t.Errorf("FindByes()[%d] = %+v, want %+v", i, byes[i], want[i])
+		}
+	}
+
+	rounds := GroupMatchesIntoRounds(matches)
+	if rounds[0].Name != RoundQuarterfinal || len(rounds[0].Byes) != 2 {
+		t.Errorf("GroupMatchesIntoRounds() first round = %v with %d byes", rounds[0].Name, len(rounds[0].Byes))
+	}
+}
+
+func TestNewKnockoutMatches_ThreeTeams(t *testing.T) {
+	matches, err := NewKnockoutMatches(1, NewSeededPairs([]string{"A", "B", "C"}))
+	if err != nil {
+		t.Fatalf("NewKnockoutMatches() error = %v", err)
+	}
+
+	// 準決勝1試合 + 決勝（3位決定戦なし）
+	if len(matches) != 2 {
+		t.Fatalf("NewKnockoutMatches() matches = %d, want 2", len(matches))
+	}
+	if matches[1].Round != RoundFinal || matches[1].Team1 != "A" {
+		t.Errorf("final = %v team1 %v, want final team1 A", matches[1].Round, matches[1].Team1)
+	}
+
+	if _, err := NewKnockoutMatches(1, [][2]string{{"A", ""}, {"", ""}}); err == nil {
+		t.Error("NewKnockoutMatches() should reject a pair without teams")
+	}
+}
+
+func TestGroupMatchesIntoRounds(t *testing.T) {
+	rounds := GroupMatchesIntoRounds(newEightTeamBracket())
+
+	want := []string{Round1stRound, RoundSemifinal, RoundThirdPlace, RoundFinal}
+	if len(rounds) != len(want) {
+		t.Fatalf("GroupMatchesIntoRounds() rounds = %d, want %d", len(rounds), len(want))
+	}
+	for i, name := range want {
+		if rounds[i].Name != name {
+			t.Errorf("GroupMatchesIntoRounds() round[%d] = %v, want %v", i, rounds[i].Name, name)
+		}
+	}
+}
+
+func TestMatch_GetLoser(t *testing.T) {
+	team1 := "IE4"
+	other := "IS5"
+
+	tests := []struct {
+		name   string
+		winner *string
+		want   string
+	}{
+		{name: "team1が勝者", winner: &team1, want: "専・教"},
+		{name: "勝者未確定", winner: nil, want: ""},
+		{name: "参加チーム以外", winner: &other, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{Team1: "IE4", Team2: "専・教", Winner: tt.winner}
+			if got := match.GetLoser(); got != tt.want {
+				t.Errorf("GetLoser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// calendarProductID はiCalendarのPRODID
+	calendarProductID = "-//GYOUJI_HP//Match Schedule//JA"
+	// calendarUIDDomain は試合のUIDのドメイン部分
+	calendarUIDDomain = "gyouji-hp"
+	// calendarRefreshInterval はカレンダーアプリに再取得を促す間隔
+	calendarRefreshInterval = "PT15M"
+	// calendarLineLimit は1行の長さの上限（オクテット、改行を除く）
+	calendarLineLimit = 75
+	// calendarTimeLayout はUTCの日時の形式
+	calendarTimeLayout = "20060102T150405Z"
+)
+
+// CalendarEventStatus はiCalendarの予定のステータス
+type CalendarEventStatus string
+
+const (
+	CalendarEventConfirmed CalendarEventStatus = "CONFIRMED" // 予定どおり行う
+	CalendarEventTentative CalendarEventStatus = "TENTATIVE" // 延期・中断で開始時刻が未定
+	CalendarEventCancelled CalendarEventStatus = "CANCELLED" // 中止
+)
+
+// CalendarFilter はカレンダーに含める試合の条件（全て省略した場合は大会の全試合）
+type CalendarFilter struct {
+	Team    string    `form:"team" example:"IE4"`         // チーム（クラス）の試合のみ
+	Sport   SportType `form:"sport" example:"volleyball"` // 種目の試合のみ
+	CourtID *int      `form:"court_id" example:"1"`       // コートの試合のみ
+}
+
+// Validate はカレンダーの条件を検証する
+func (f *CalendarFilter) Validate() error {
+	if len(f.Team) > 100 {
+		return errors.New("チーム名は100文字以下である必要があります")
+	}
+	if f.Sport != "" && !f.Sport.IsValid() {
+		return fmt.Errorf("無効な種目です: %s", f.Sport)
+	}
+	if f.CourtID != nil && *f.CourtID < 1 {
+		return errors.New("無効なコートIDです")
+	}
+	return nil
+}
+
+// includes は試合が条件に当てはまるかどうかを返す
+func (f *CalendarFilter) includes(match *Match, sport SportType) bool {
+	if team := strings.TrimSpace(f.Team); team != "" && match.Team1 != team && match.Team2 != team {
+		return false
+	}
+	if f.Sport != "" && sport != f.Sport {
+		return false
+	}
+	if f.CourtID != nil && !sameCourt(match.CourtID, f.CourtID) {
+		return false
+	}
+	return true
+}
+
+// CalendarInput はカレンダーの作成に必要な情報
+type CalendarInput struct {
+	Name     string            // 大会名
+	Filter   CalendarFilter    // 含める試合の条件
+	Options  ScheduleOptions   // 種目ごとの試合の枠の長さ
+	Location *time.Location    // 大会のタイムゾーン（説明文の時刻に使う）
+	Matches  []*Match          // 大会の試合
+	Sports   map[int]SportType // トーナメントIDごとの種目
+	Courts   map[int]string    // コートIDごとの表示名（「会場 コート」）
+}
+
+// CalendarEvent は1試合分のiCalendarの予定
+type CalendarEvent struct {
+	UID         string              // 試合ごとに変わらないID（日程が変わっても同じ予定として更新される）
+	Sequence    int                 // 更新の版数（試合を更新するたびに増える）
+	Stamp       time.Time           // 試合の最終更新日時
+	Start       time.Time           // 開始予定時刻
+	End         time.Time           // 終了予定時刻（開始時刻に種目の枠の長さを足したもの）
+	Summary     string              // 件名（例: "[バレーボール] IE4 vs IS4（準決勝）"）
+	Location    string              // コート（未割り当ての場合は空）
+	Description string              // 説明（状態、遅れ、終了した試合の結果）
+	Category    string              // 種目の表示名
+	Status      CalendarEventStatus // 予定のステータス
+}
+
+// Calendar は試合日程のiCalendar（RFC 5545）
+type Calendar struct {
+	Name     string
+	Timezone string
+	Events   []CalendarEvent
+}
+
+// BuildCalendar は条件に当てはまる試合の予定を開始時刻順に並べたカレンダーを作成する
+// 開始時刻が決まっていない試合は含めない
+func BuildCalendar(input CalendarInput) *Calendar {
+	loc := input.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	name := []string{input.Name}
+	if team := strings.TrimSpace(input.Filter.Team); team != "" {
+		name = append(name, team)
+	}
+	if input.Filter.Sport != "" {
+		name = append(name, SportDisplayName(input.Filter.Sport))
+	}
+	if input.Filter.CourtID != nil {
+		name = append(name, input.Courts[*input.Filter.CourtID])
+	}
+	calendar := &Calendar{
+		Name:     strings.TrimSpace(strings.Join(name, " ")),
+		Timezone: loc.String(),
+		Events:   []CalendarEvent{},
+	}
+
+	matches := make([]*Match, 0, len(input.Matches))
+	for _, match := range input.Matches {
+		if match.ScheduledAt.IsZero() || !input.Filter.includes(match, input.Sports[match.TournamentID]) {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].ScheduledAt.Equal(matches[j].ScheduledAt) {
+			return matches[i].ScheduledAt.Before(matches[j].ScheduledAt)
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	for _, match := range matches {
+		calendar.Events = append(calendar.Events, newCalendarEvent(match, input, loc))
+	}
+	return calendar
+}
+
+// newCalendarEvent は試合の予定を作成する
+func newCalendarEvent(match *Match, input CalendarInput, loc *time.Location) CalendarEvent {
+	sport := input.Sports[match.TournamentID]
+	sportName := SportDisplayName(sport)
+
+	event := CalendarEvent{
+		UID:      fmt.Sprintf("match-%d@%s", match.ID, calendarUIDDomain),
+		Stamp:    match.UpdatedAt,
+		Start:    match.ScheduledAt,
+		End:      match.ScheduledAt.Add(input.Options.SlotDuration(sport)),
+		Summary:  fmt.Sprintf("[%s] %s vs %s（%s）", sportName, match.Team1, match.Team2, calendarRoundLabel(match)),
+		Category: sportName,
+		Status:   CalendarEventConfirmed,
+	}
+	if event.Stamp.IsZero() {
+		event.Stamp = match.CreatedAt
+	}
+	// 更新日時は試合を更新するたびに進むため、作成からの経過秒数を版数とする
+	if !match.CreatedAt.IsZero() && match.UpdatedAt.After(match.CreatedAt) {
+		event.Sequence = int(match.UpdatedAt.Sub(match.CreatedAt) / time.Second)
+	}
+	if match.CourtID != nil {
+		event.Location = input.Courts[*match.CourtID]
+	}
+	switch {
+	case match.IsCancelled():
+		event.Status = CalendarEventCancelled
+	case match.IsPostponed(), match.IsSuspended():
+		event.Status = CalendarEventTentative
+	}
+
+	description := []string{sportName + " " + calendarRoundLabel(match)}
+	if !match.IsPending() {
+		description = append(description, "状態: "+match.GetStatus().Label())
+	}
+	if match.PlannedAt != nil && !match.IsCompleted() {
+		delay := int(match.ScheduledAt.Sub(*match.PlannedAt) / time.Minute)
+		description = append(description, fmt.Sprintf("当初の予定: %s（%d分遅れ）", match.PlannedAt.In(loc).Format("15:04"), delay))
+	}
+	if event.Location == "" {
+		description = append(description, "コート: 未定")
+	}
+	if match.IsCompleted() && match.HasResult() {
+		description = append(description, "結果: "+match.ScoreDisplay())
+		if match.Winner != nil && *match.Winner != "" {
+			description = append(description, "勝者: "+*match.Winner)
+		} else if match.IsDraw() {
+			description = append(description, "引き分け")
+		}
+	}
+	event.Description = strings.Join(description, "\n")
+
+	return event
+}
+
+// calendarRoundLabel は試合のラウンドの表示名を返す（グループ名・回戦番号を含む）
+func calendarRoundLabel(match *Match) string {
+	label := match.GetRound().Label()
+	if match.GroupName != nil && *match.GroupName != "" {
+		label += " " + *match.GroupName + "組"
+	}
+	if match.SwissRound != nil {
+		label += fmt.Sprintf(" 第%d回戦", *match.SwissRound)
+	}
+	return label
+}
+
+// Encode はカレンダーをiCalendar形式（CRLF改行、75オクテットで折り返し）で返す
+func (c *Calendar) Encode() string {
+	var b strings.Builder
+	write := func(name, value string) {
+		writeCalendarLine(&b, name+":"+value)
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", calendarProductID)
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	write("X-WR-CALNAME", escapeCalendarText(c.Name))
+	write("X-WR-TIMEZONE", c.Timezone)
+	write("REFRESH-INTERVAL;VALUE=DURATION", calendarRefreshInterval)
+	write("X-PUBLISHED-TTL", calendarRefreshInterval)
+	for _, event := range c.Events {
+		write("BEGIN", "VEVENT")
+		write("UID", event.UID)
+		write("SEQUENCE", fmt.Sprintf("%d", event.Sequence))
+		write("DTSTAMP", event.Stamp.UTC().Format(calendarTimeLayout))
+		write("LAST-MODIFIED", event.Stamp.UTC().Format(calendarTimeLayout))
+		write("DTSTART", event.Start.UTC().Format(calendarTimeLayout))
+		write("DTEND", event.End.UTC().Format(calendarTimeLayout))
+		write("SUMMARY", escapeCalendarText(event.Summary))
+		if event.Location != "" {
+			write("LOCATION", escapeCalendarText(event.Location))
+		}
+		write("DESCRIPTION", escapeCalendarText(event.Description))
+		write("CATEGORIES", escapeCalendarText(event.Category))
+		write("STATUS", string(event.Status))
+		write("END", "VEVENT")
+	}
+	write("END", "VCALENDAR")
+
+	return b.String()
+}
+
+// calendarTextEscaper はTEXT型の値の特殊文字をエスケープする
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeCalendarText はTEXT型の値をエスケープする
+func escapeCalendarText(value string) string {
+	return calendarTextEscaper.Replace(value)
+}
+
+// writeCalendarLine は1行を75オクテットごとに折り返して書き込む
+// 折り返した行は空白1文字で始まり、UTF-8の文字の途中では折り返さない
+func writeCalendarLine(b *strings.Builder, line string) {
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > calendarLineLimit {
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCalendarTestInput は2種目・2コートの試合からカレンダーの作成条件を作成する
+func newCalendarTestInput(t *testing.T) CalendarInput {
+	t.Helper()
+	loc := time.FixedZone("JST", 9*60*60)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 20, hour, minute, 0, 0, loc)
+	}
+	court := func(id int) *int { return &id }
+	created := at(8, 0)
+
+	volleyball := &Match{ID: 1, TournamentID: 1, Round: string(RoundSemifinalEnum), Team1: "IE4", Team2: "IS4", Status: string(MatchStatusPendingEnum), ScheduledAt: at(10, 0), CourtID: court(1), CreatedAt: created, UpdatedAt: created}
+	tableTennis := &Match{ID: 2, TournamentID: 2, Round: string(RoundFinalEnum), Team1: "IT4", Team2: "IE4", Status: string(MatchStatusPendingEnum), ScheduledAt: at(9, 0), CourtID: court(2), CreatedAt: created, UpdatedAt: created}
+	other := &Match{ID: 3, TournamentID: 1, Round: string(RoundSemifinalEnum), Team1: "IC4", Team2: "IM4", Status: string(MatchStatusPendingEnum), ScheduledAt: at(10, 30), CreatedAt: created, UpdatedAt: created}
+	unscheduled := &Match{ID: 4, TournamentID: 1, Round: string(RoundFinalEnum), Team1: TeamTBD, Team2: TeamTBD, Status: string(MatchStatusPendingEnum), CreatedAt: created, UpdatedAt: created}
+
+	return CalendarInput{
+		Name:     "春季球技大会",
+		Options:  ScheduleOptions{SlotMinutes: map[SportType]int{SportTypeVolleyball: 40}},
+		Location: loc,
+		Matches:  []*Match{volleyball, tableTennis, other, unscheduled},
+		Sports:   map[int]SportType{1: SportTypeVolleyball, 2: SportTypeTableTennis},
+		Courts:   map[int]string{1: "第1体育館 Aコート", 2: "卓球場 1番台"},
+	}
+}
+
+func TestBuildCalendar_Filter(t *testing.T) {
+	courtID := 1
+
+	tests := []struct {
+		name     string
+		filter   CalendarFilter
+		wantIDs  []string
+		wantName string
+	}{
+		{name: "大会の全試合を開始時刻順に並べる", wantIDs: []string{"match-2@gyouji-hp", "match-1@gyouji-hp", "match-3@gyouji-hp"}, wantName: "春季球技大会"},
+		{name: "チームの試合", filter: CalendarFilter{Team: "IE4"}, wantIDs: []string{"match-2@gyouji-hp", "match-1@gyouji-hp"}, wantName: "春季球技大会 IE4"},
+		{name: "種目の試合", filter: CalendarFilter{Sport: SportTypeVolleyball}, wantIDs: []string{"match-1@gyouji-hp", "match-3@gyouji-hp"}, wantName: "春季球技大会 バレーボール"},
+		{name: "コートの試合", filter: CalendarFilter{CourtID: &courtID}, wantIDs: []string{"match-1@gyouji-hp"}, wantName: "春季球技大会 第1体育館 Aコート"},
+		{name: "条件を組み合わせる", filter: CalendarFilter{Team: "IE4", Sport: SportTypeTableTennis}, wantIDs: []string{"match-2@gyouji-hp"}, wantName: "春季球技大会 IE4 卓球"},
+		{name: "該当する試合がないチーム", filter: CalendarFilter{Team: "IA1"}, wantName: "春季球技大会 IA1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newCalendarTestInput(t)
+			input.Filter = tt.filter
+			calendar := BuildCalendar(input)
+			if calendar.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", calendar.Name, tt.wantName)
+			}
+			if len(calendar.Events) != len(tt.wantIDs) {
+				t.Fatalf("got %d events, want %d", len(calendar.Events), len(tt.wantIDs))
+			}
+			for i, event := range calendar.Events {
+				if event.UID != tt.wantIDs[i] {
+					t.Errorf("event %d UID = %s, want %s", i, event.UID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarFilter_Validate(t *testing.T) {
+	zero := 0
+	tests := []struct {
+		name    string
+		filter  CalendarFilter
+		wantErr bool
+	}{
+		{name: "条件なし"},
+		{name: "有効な条件", filter: CalendarFilter{Team: "IE4", Sport: SportTypeVolleyball}},
+		{name: "無効な種目", filter: CalendarFilter{Sport: SportType("curling")}, wantErr: true},
+		{name: "無効なコートID", filter: CalendarFilter{CourtID: &zero}, wantErr: true},
+		{name: "長すぎるチーム名", filter: CalendarFilter{Team: strings.Repeat("A", 101)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildCalendar_Event(t *testing.T) {
+	input := newCalendarTestInput(t)
+	input.Filter = CalendarFilter{Sport: SportTypeVolleyball}
+	match := input.Matches[0]
+
+	event := BuildCalendar(input).Events[0]
+	if event.Summary != "[バレーボール] IE4 vs IS4（準決勝）" {
+		t.Errorf("Summary = %q", event.Summary)
+	}
+	if event.Location != "第1体育館 Aコート" || event.Status != CalendarEventConfirmed || event.Sequence != 0 {
+		t.Errorf("event = %+v, want court, confirmed and sequence 0", event)
+	}
+	if got := event.End.Sub(event.Start); got != 40*time.Minute {
+		t.Errorf("duration = %v, want the volleyball slot of 40m", got)
+	}
+
+	// 日程を変えても同じUIDのまま版数が増え、カレンダーアプリでは予定が更新される
+	planned := match.ScheduledAt
+	match.PlannedAt = &planned
+	match.ScheduledAt = planned.Add(15 * time.Minute)
+	match.UpdatedAt = match.CreatedAt.Add(2 * time.Hour)
+	shifted := BuildCalendar(input).Events[0]
+	if shifted.UID != event.UID || shifted.Sequence <= event.Sequence {
+		t.Errorf("shifted UID %s sequence %d, want %s with sequence above %d", shifted.UID, shifted.Sequence, event.UID, event.Sequence)
+	}
+	if !strings.Contains(shifted.Description, "当初の予定: 10:00（15分遅れ）") {
+		t.Errorf("Description = %q, want the original start time", shifted.Description)
+	}
+
+	// 終了した試合は説明に結果を含める
+	completeTestMatch(match, 2, 1, "IE4")
+	match.Sets = []SetScore{{Score1: 25, Score2: 20}, {Score1: 22, Score2: 25}, {Score1: 15, Score2: 13}}
+	completed := BuildCalendar(input).Events[0]
+	for _, want := range []string{"状態: 終了", "結果: 2-1 (25-20, 22-25, 15-13)", "勝者: IE4"} {
+		if !strings.Contains(completed.Description, want) {
+			t.Errorf("Description = %q, want %q", completed.Description, want)
+		}
+	}
+	if strings.Contains(completed.Description, "当初の予定") {
+		t.Errorf("Description = %q, want no delay for a completed match", completed.Description)
+	}
+
+	// 延期・中止はステータスに反映する
+	for status, want := range map[MatchStatus]CalendarEventStatus{
+		MatchStatusPostponedEnum: CalendarEventTentative,
+		MatchStatusSuspendedEnum: CalendarEventTentative,
+		MatchStatusCancelledEnum: CalendarEventCancelled,
+	} {
+		match.SetStatus(status)
+		if got := BuildCalendar(input).Events[0].Status; got != want {
+			t.Errorf("%s match status = %s, want %s", status, got, want)
+		}
+	}
+}
+
+func TestCalendar_Encode(t *testing.T) {
+	input := newCalendarTestInput(t)
+	input.Name = "春季球技大会; 雨天時, 体育館"
+	encoded := BuildCalendar(input).Encode()
+
+	if !strings.HasPrefix(encoded, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(encoded, "END:VCALENDAR\r\n") {
+		t.Fatalf("encoded calendar is not wrapped in VCALENDAR:\n%s", encoded)
+	}
+	for _, want := range []string{
+		"UID:match-2@gyouji-hp\r\n",
+		"DTSTART:20240520T000000Z\r\n",
+		"DTEND:20240520T003000Z\r\n",
+		"STATUS:CONFIRMED\r\n",
+	} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("encoded calendar has no %q:\n%s", want, encoded)
+		}
+	}
+
+	// 折り返しを戻すと特殊文字をエスケープした値になる
+	unfolded := strings.ReplaceAll(encoded, "\r\n ", "")
+	if !strings.Contains(unfolded, `X-WR-CALNAME:春季球技大会\; 雨天時\, 体育館`+"\r\n") {
+		t.Errorf("calendar name is not escaped:\n%s", unfolded)
+	}
+	if !strings.Contains(unfolded, `DESCRIPTION:バレーボール 準決勝\nコート: 未定`+"\r\n") {
+		t.Errorf("description is not escaped:\n%s", unfolded)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(encoded, "\r\n"), "\r\n") {
+		if len(line) > calendarLineLimit {
+			t.Errorf("line has %d octets, want at most %d: %q", len(line), calendarLineLimit, line)
+		}
+		if strings.ToValidUTF8(line, "?") != line {
+			t.Errorf("line splits a UTF-8 character: %q", line)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Placement はトーナメントで確定したチームの最終順位
+// 順位を決める試合を行わない場合（3位決定戦のない準決勝の敗者など）は複数のチームが同じ順位となる
+type Placement struct {
+	Team   string `json:"team" example:"IE4"`
+	Place  int    `json:"place" example:"1"`
+	Points int    `json:"points" example:"10"` // 総合順位の順位点
+}
+
+// KnockoutPlacements はノックアウト方式の試合から確定した順位を返す
+// 決勝の勝者を1位・敗者を2位、3位決定戦の勝者を3位・敗者を4位とする。
+// 3位決定戦がない場合は準決勝の敗者を3位とし、それより前のラウンドの敗者は
+// 勝ち残ったチーム数の次の順位（準々決勝の敗者は5位）とする。
+// 未実施の試合・両チーム棄権の試合・棄権したチームには順位を付けない
+func KnockoutPlacements(matches []*Match) []Placement {
+	byRound := groupMatchesByRound(matches)
+	rounds := presentKnockoutRounds(byRound)
+
+	var placements []Placement
+	addResult(&placements, byRound[RoundFinalEnum], 1, 2)
+	addResult(&placements, byRound[RoundThirdPlaceEnum], 3, 4)
+
+	hasThirdPlace := len(byRound[RoundThirdPlaceEnum]) > 0
+	for i := len(rounds) - 2; i >= 0; i-- {
+		round := rounds[i]
+		if round == RoundSemifinalEnum && hasThirdPlace {
+			continue
+		}
+		// 次のラウンドに進んだチーム数（不戦勝の位置を含む）の次の順位とする
+		place := 2*len(byRound[rounds[i+1]]) + 1
+		for _, match := range byRound[round] {
+			addLoser(&placements, match, place)
+		}
+	}
+
+	return sortPlacements(placements)
+}
+
+// DoubleEliminationPlacements はダブルイリミネーション形式の試合から確定した順位を返す
+// リセットマッチ（リセットマッチを行わない場合はグランドファイナル）の勝者を1位・敗者を2位とし、
+// 敗者側ブラケットの決勝の敗者を3位とする
+func DoubleEliminationPlacements(matches []*Match) []Placement {
+	byRound := groupMatchesByRound(matches)
+
+	var placements []Placement
+	if grandFinal := byRound[RoundGrandFinalEnum]; len(grandFinal) > 0 && !grandFinal[0].NeedsGrandFinalReset() {
+		addResult(&placements, grandFinal, 1, 2)
+	} else {
+		addResult(&placements, byRound[RoundGrandFinalResetEnum], 1, 2)
+	}
+
+	// 敗者側ブラケットの位置はラウンド順の通し番号のため、最後の試合が敗者側の決勝となる
+	if loserBracket := byRound[RoundLoserBracketEnum]; len(loserBracket) > 0 {
+		addLoser(&placements, loserBracket[len(loserBracket)-1], 3)
+	}
+
+	return sortPlacements(placements)
+}
+
+// StandingsPlacements は順位表の順位をそのまま最終順位とする（総当たり・スイス式）
+// 全ての試合が終了してから呼び出す
+func StandingsPlacements(table []Standing) []Placement {
+	placements := make([]Placement, 0, len(table))
+	for _, standing := range table {
+		if standing.Team == TeamWithdrawn {
+			continue
+		}
+		placements = append(placements, Placement{Team: standing.Team, Place: standing.Rank})
+	}
+	return sortPlacements(placements)
+}
+
+// ApplyPlacementPoints は種目の順位点を順位に与える
+// 同じ順位のチームにはそれぞれその順位の順位点を与える
+func ApplyPlacementPoints(placements []Placement, sport *Sport) {
+	for i := range placements {
+		placements[i].Points = sport.PointsForPlace(placements[i].Place)
+	}
+}
+
+// addResult は終了した試合の勝者と敗者に順位を付ける
+func addResult(placements *[]Placement, matches []*Match, winnerPlace, loserPlace int) {
+	if len(matches) == 0 {
+		return
+	}
+	match := matches[0]
+	if !decidedMatch(match) {
+		return
+	}
+	addPlacement(placements, *match.Winner, winnerPlace)
+	addPlacement(placements, match.GetLoser(), loserPlace)
+}
+
+// addLoser は終了した試合の敗者に順位を付ける
+func addLoser(placements *[]Placement, match *Match, place int) {
+	if decidedMatch(match) {
+		addPlacement(placements, match.GetLoser(), place)
+	}
+}
+
+// decidedMatch は勝者が決まった試合かどうかを返す
+func decidedMatch(match *Match) bool {
+	return match.IsCompleted() && !match.IsDoubleForfeit() && match.Winner != nil
+}
+
+// addPlacement はチームに順位を付ける（未確定の枠・棄権したチームは除く）
+func addPlacement(placements *[]Placement, team string, place int) {
+	if team == "" || team == TeamWithdrawn || IsUndecidedTeam(team) {
+		return
+	}
+	*placements = append(*placements, Placement{Team: team, Place: place})
+}
+
+// sortPlacements は順位を上位から並べる（同じ順位はチーム名順）
+func sortPlacements(placements []Placement) []Placement {
+	if placements == nil {
+		return []Placement{}
+	}
+	sort.SliceStable(placements, func(i, j int) bool {
+		if placements[i].Place != placements[j].Place {
+			return placements[i].Place < placements[j].Place
+		}
+		return placements[i].Team < placements[j].Team
+	})
+	return placements
+}
+
+// TournamentPlacements はトーナメント（種目）ごとの確定した順位
+type TournamentPlacements struct {
+	TournamentID int         `json:"tournament_id"`
+	Sport        SportType   `json:"sport"`
+	Placements   []Placement `json:"placements"`
+}
+
+// SportPlacement はクラスが種目で獲得した順位と順位点
+type SportPlacement struct {
+	TournamentID int       `json:"tournament_id"`
+	Sport        SportType `json:"sport" example:"soccer"`
+	Place        int       `json:"place" example:"1"`
+	Points       int       `json:"points" example:"10"`
+}
+
+// ClassScore はクラスの総合順位
+type ClassScore struct {
+	Rank       int              `json:"rank" example:"1"` // 合計の順位点が同じ場合は同順位
+	ClassName  string           `json:"class_name" example:"IE4"`
+	Grade      int              `json:"grade,omitempty" example:"4"`       // 学年（クラス名から判定できない場合は省略）
+	Department string           `json:"department,omitempty" example:"IE"` // 学科（クラス名から判定できない場合は省略）
+	Points     int              `json:"points" example:"17"`
+	Placements []SportPlacement `json:"placements"`
+}
+
+// GroupScore は学年・学科ごとの総合順位
+type GroupScore struct {
+	Rank    int    `json:"rank" example:"1"`
+	Name    string `json:"name" example:"4年"` // 学年（「4年」）または学科（「IE」）
+	Points  int    `json:"points" example:"32"`
+	Classes int    `json:"classes" example:"3"` // 集計したクラスの数
+}
+
+// Championship は大会の総合順位（総合優勝）
+// 全種目の順位点の合計でクラスの順位を決め、学年・学科ごとにも合計する
+type Championship struct {
+	EventID     int                    `json:"event_id"`
+	Classes     []ClassScore           `json:"classes"`
+	Grades      []GroupScore           `json:"grades"`
+	Departments []GroupScore           `json:"departments"`
+	Tournaments []TournamentPlacements `json:"tournaments"` // 種目ごとの確定した順位
+}
+
+// ParseClassName はクラス名から学年と学科を判定する（抽選の分離規則と同じ判定）
+// 判定できない場合は学年0・学科は空文字を返す
+func ParseClassName(className string) (grade int, department string) {
+	grade, _ = strconv.Atoi(SeparationGroup(className, SeparationGrade))
+	return grade, SeparationGroup(className, SeparationDepartment)
+}
+
+// BuildChampionship は種目ごとの順位から総合順位を作成する
+// classNames の全クラスを順位点0点から集計し、順位の付いたチームもクラスとして加える
+func BuildChampionship(eventID int, classNames []string, results []TournamentPlacements) *Championship {
+	scores := make(map[string]*ClassScore)
+	addClass := func(name string) *ClassScore {
+		if score, ok := scores[name]; ok {
+			return score
+		}
+		grade, department := ParseClassName(name)
+		score := &ClassScore{ClassName: name, Grade: grade, Department: department, Placements: []SportPlacement{}}
+		scores[name] = score
+		return score
+	}
+
+	for _, name := range classNames {
+		addClass(name)
+	}
+	for _, result := range results {
+		for _, placement := range result.Placements {
+			score := addClass(placement.Team)
+			score.Points += placement.Points
+			score.Placements = append(score.Placements, SportPlacement{
+				TournamentID: result.TournamentID,
+				Sport:        result.Sport,
+				Place:        placement.Place,
+				Points:       placement.Points,
+			})
+		}
+	}
+
+	classes := make([]ClassScore, 0, len(scores))
+	for _, score := range scores {
+		classes = append(classes, *score)
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		if classes[i].Points != classes[j].Points {
+			return classes[i].Points > classes[j].Points
+		}
+		return classes[i].ClassName < classes[j].ClassName
+	})
+	for i := range classes {
+		classes[i].Rank = i + 1
+		if i > 0 && classes[i].Points == classes[i-1].Points {
+			classes[i].Rank = classes[i-1].Rank
+		}
+	}
+
+	if results == nil {
+		results = []TournamentPlacements{}
+	}
+
+	return &Championship{
+		EventID: eventID,
+		Classes: classes,
+		Grades: groupScores(classes, func(score ClassScore) string {
+			if score.Grade == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%d年", score.Grade)
+		}),
+		Departments: groupScores(classes, func(score ClassScore) string { return score.Department }),
+		Tournaments: results,
+	}
+}
+
+// groupScores はクラスの順位点を key ごとに合計して順位を付ける（key が空文字のクラスは除く）
+func groupScores(classes []ClassScore, key func(ClassScore) string) []GroupScore {
+	byName := make(map[string]*GroupScore)
+	for _, class := range classes {
+		name := key(class)
+		if name == "" {
+			continue
+		}
+		group, ok := byName[name]
+		if !ok {
+			group = &GroupScore{Name: name}
+			byName[name] = group
+		}
+		group.Points += class.Points
+		group.Classes++
+	}
+
+	groups := make([]GroupScore, 0, len(byName))
+	for _, group := range byName {
+		groups = append(groups, *group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Points != groups[j].Points {
+			return groups[i].Points > groups[j].Points
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	for i := range groups {
+		groups[i].Rank = i + 1
+		if i > 0 && groups[i].Points == groups[i-1].Points {
+			groups[i].Rank = groups[i-1].Rank
+		}
+	}
+	return groups
+}
@@ -0,0 +1,212 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnockoutPlacements(t *testing.T) {
+	quarterfinals := []*Match{
+		newPlayedMatch(RoundQuarterfinal, 0, "A", "E"),
+		newPlayedMatch(RoundQuarterfinal, 1, "B", "F"),
+		newPlayedMatch(RoundQuarterfinal, 2, "C", "G"),
+		newPlayedMatch(RoundQuarterfinal, 3, "D", "H"),
+	}
+	doubleForfeit := ResultTypeDoubleForfeitEnum.String()
+	semifinals := []*Match{
+		newPlayedMatch(RoundSemifinal, 0, "A", "B"),
+		newPlayedMatch(RoundSemifinal, 1, "C", "D"),
+	}
+
+	tests := []struct {
+		name    string
+		matches []*Match
+		want    []Placement
+	}{
+		{
+			name: "3位決定戦あり",
+			matches: append(append([]*Match{
+				newPlayedMatch(RoundFinal, 0, "C", "A"),
+				newPlayedMatch(RoundThirdPlace, 0, "D", "B"),
+			}, quarterfinals...), semifinals...),
+			want: []Placement{
+				{Team: "C", Place: 1}, {Team: "A", Place: 2}, {Team: "D", Place: 3}, {Team: "B", Place: 4},
+				{Team: "E", Place: 5}, {Team: "F", Place: 5}, {Team: "G", Place: 5}, {Team: "H", Place: 5},
+			},
+		},
+		{
+			name:    "3位決定戦なし",
+			matches: append([]*Match{newPlayedMatch(RoundFinal, 0, "A", "C")}, semifinals...),
+			want:    []Placement{{Team: "A", Place: 1}, {Team: "C", Place: 2}, {Team: "B", Place: 3}, {Team: "D", Place: 3}},
+		},
+		{
+			name: "決勝が未実施",
+			matches: append([]*Match{
+				{Round: RoundFinal, Team1: "A", Team2: "C", Status: MatchStatusPending},
+				{Round: RoundThirdPlace, Team1: "B", Team2: "D", Status: MatchStatusPending},
+			}, semifinals...),
+			want: []Placement{},
+		},
+		{
+			name: "両チーム棄権の3位決定戦",
+			matches: []*Match{
+				newPlayedMatch(RoundFinal, 0, "A", "C"),
+				{Round: RoundThirdPlace, Team1: "B", Team2: "D", Status: MatchStatusCompleted, ResultType: &doubleForfeit},
+			},
+			want: []Placement{{Team: "A", Place: 1}, {Team: "C", Place: 2}},
+		},
+		{
+			name:    "棄権したチームが敗者",
+			matches: []*Match{newPlayedMatch(RoundFinal, 0, "A", TeamWithdrawn)},
+			want:    []Placement{{Team: "A", Place: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KnockoutPlacements(tt.matches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KnockoutPlacements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoubleEliminationPlacements(t *testing.T) {
+	tests := []struct {
+		name       string
+		winner     func(match *Match) string
+		finalRound RoundType
+	}{
+		{
+			name:       "グランドファイナルで決着",
+			winner:     func(match *Match) string { return match.Team1 },
+			finalRound: RoundGrandFinalEnum,
+		},
+		{
+			name: "リセットマッチで決着",
+			winner: func(match *Match) string {
+				if match.GetRound() == RoundGrandFinalEnum || match.GetRound() == RoundGrandFinalResetEnum {
+					return match.Team2
+				}
+				return match.Team1
+			},
+			finalRound: RoundGrandFinalResetEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := newLinkedDoubleElimination(t, 4)
+			playDoubleElimination(t, matches, tt.winner)
+
+			byRound := groupMatchesByRound(matches)
+			final := byRound[tt.finalRound][0]
+			loserBracket := byRound[RoundLoserBracketEnum]
+			want := []Placement{
+				{Team: *final.Winner, Place: 1},
+				{Team: final.GetLoser(), Place: 2},
+				{Team: loserBracket[len(loserBracket)-1].GetLoser(), Place: 3},
+			}
+
+			if got := DoubleEliminationPlacements(matches); !reflect.DeepEqual(got, want) {
+				t.Errorf("DoubleEliminationPlacements() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("未実施", func(t *testing.T) {
+		if got := DoubleEliminationPlacements(newLinkedDoubleElimination(t, 4)); len(got) != 0 {
+			t.Errorf("DoubleEliminationPlacements() = %v, want none", got)
+		}
+	})
+}
+
+func TestStandingsPlacements(t *testing.T) {
+	table := []Standing{{Rank: 1, Team: "A"}, {Rank: 2, Team: "C"}, {Rank: 2, Team: "B"}, {Rank: 4, Team: "D"}}
+	want := []Placement{{Team: "A", Place: 1}, {Team: "B", Place: 2}, {Team: "C", Place: 2}, {Team: "D", Place: 4}}
+	if got := StandingsPlacements(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("StandingsPlacements() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyPlacementPoints(t *testing.T) {
+	placements := []Placement{{Team: "A", Place: 1}, {Team: "B", Place: 3}, {Team: "C", Place: 3}, {Team: "E", Place: 5}}
+	ApplyPlacementPoints(placements, &Sport{})
+
+	want := []int{10, 5, 5, 0}
+	for i, placement := range placements {
+		if placement.Points != want[i] {
+			t.Errorf("%s points = %d, want %d", placement.Team, placement.Points, want[i])
+		}
+	}
+}
+
+func TestParseClassName(t *testing.T) {
+	tests := []struct {
+		className      string
+		wantGrade      int
+		wantDepartment string
+	}{
+		{className: "IE4", wantGrade: 4, wantDepartment: "IE"},
+		{className: "M2", wantGrade: 2, wantDepartment: "M"},
+		{className: "1-1", wantGrade: 1},
+		{className: "専・教"},
+		{className: "IE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.className, func(t *testing.T) {
+			grade, department := ParseClassName(tt.className)
+			if grade != tt.wantGrade || department != tt.wantDepartment {
+				t.Errorf("ParseClassName() = (%d, %q), want (%d, %q)", grade, department, tt.wantGrade, tt.wantDepartment)
+			}
+		})
+	}
+}
+
+func TestBuildChampionship(t *testing.T) {
+	results := []TournamentPlacements{
+		{TournamentID: 1, Sport: SportTypeSoccer, Placements: []Placement{
+			{Team: "IE4", Place: 1, Points: 10}, {Team: "IS4", Place: 2, Points: 7}, {Team: "IE3", Place: 3, Points: 5},
+		}},
+		{TournamentID: 2, Sport: SportTypeVolleyball, Placements: []Placement{
+			{Team: "IS4", Place: 1, Points: 10}, {Team: "IE4", Place: 2, Points: 7}, {Team: "専・教", Place: 3, Points: 5},
+		}},
+	}
+
+	championship := BuildChampionship(1, []string{"IE3", "IE4", "IS4", "IT3"}, results)
+
+	wantClasses := []struct {
+		rank   int
+		name   string
+		points int
+	}{
+		{1, "IE4", 17}, {1, "IS4", 17}, {3, "IE3", 5}, {3, "専・教", 5}, {5, "IT3", 0},
+	}
+	if len(championship.Classes) != len(wantClasses) {
+		t.Fatalf("classes = %v, want %d classes", championship.Classes, len(wantClasses))
+	}
+	for i, want := range wantClasses {
+		got := championship.Classes[i]
+		if got.Rank != want.rank || got.ClassName != want.name || got.Points != want.points {
+			t.Errorf("classes[%d] = (%d, %s, %d), want (%d, %s, %d)", i, got.Rank, got.ClassName, got.Points, want.rank, want.name, want.points)
+		}
+	}
+	if placements := championship.Classes[0].Placements; len(placements) != 2 || placements[0].Sport != SportTypeSoccer {
+		t.Errorf("IE4 placements = %v, want soccer and volleyball", placements)
+	}
+
+	wantGrades := []GroupScore{{Rank: 1, Name: "4年", Points: 34, Classes: 2}, {Rank: 2, Name: "3年", Points: 5, Classes: 2}}
+	if !reflect.DeepEqual(championship.Grades, wantGrades) {
+		t.Errorf("grades = %v, want %v", championship.Grades, wantGrades)
+	}
+
+	wantDepartments := []GroupScore{
+		{Rank: 1, Name: "IE", Points: 22, Classes: 2},
+		{Rank: 2, Name: "IS", Points: 17, Classes: 1},
+		{Rank: 3, Name: "IT", Points: 0, Classes: 1},
+	}
+	if !reflect.DeepEqual(championship.Departments, wantDepartments) {
+		t.Errorf("departments = %v, want %v", championship.Departments, wantDepartments)
+	}
+}
@@ -13,8 +13,12 @@ const (
 	SportSoccer      = "soccer"
 	
 	// TournamentFormat用の文字列定数（非推奨）
-	FormatStandard = "standard"
-	FormatRainy    = "rainy"
+	FormatStandard          = "standard"
+	FormatRainy             = "rainy"
+	FormatDoubleElimination = "double_elimination"
+	FormatRoundRobin        = "round_robin"
+	FormatGroupKnockout     = "group_knockout"
+	FormatSwiss             = "swiss"
 	
 	// TournamentStatus用の文字列定数（非推奨）
 	TournamentStatusRegistration = "registration"
@@ -26,12 +30,17 @@ const (
 	MatchStatusCompleted = "completed"
 	
 	// RoundType用の文字列定数（非推奨）
-	Round1stRound     = "1st_round"
-	RoundQuarterfinal = "quarterfinal"
-	RoundSemifinal    = "semifinal"
-	RoundThirdPlace   = "third_place"
-	RoundFinal        = "final"
-	RoundLoserBracket = "loser_bracket"
+	Round1stRound        = "1st_round"
+	RoundQuarterfinal    = "quarterfinal"
+	RoundSemifinal       = "semifinal"
+	RoundThirdPlace      = "third_place"
+	RoundFinal           = "final"
+	RoundLoserBracket    = "loser_bracket"
+	RoundGrandFinal      = "grand_final"
+	RoundGrandFinalReset = "grand_final_reset"
+	RoundLeague          = "league"
+	RoundGroupStage      = "group_stage"
+	RoundSwiss           = "swiss"
 )
 
 // 後方互換性のための関数（非推奨：新しいコードではenum型のメソッドを使用）
@@ -62,35 +71,12 @@ func IsValidRound(round string) bool {
 }
 
 // GetValidRoundsForSport はスポーツに応じた有効なラウンドを取得する（非推奨）
-// 新しいコードではtypes.goのGetValidRoundsForSport関数を使用してください
+// 新しいコードではtypes.goのGetValidRoundsForSportType関数を使用してください
 func GetValidRoundsForSport(sport string) []string {
-	switch sport {
-	case SportVolleyball:
-		return []string{
-			Round1stRound,
-			RoundQuarterfinal,
-			RoundSemifinal,
-			RoundThirdPlace,
-			RoundFinal,
-		}
-	case SportTableTennis:
-		return []string{
-			Round1stRound,
-			RoundQuarterfinal,
-			RoundSemifinal,
-			RoundThirdPlace,
-			RoundFinal,
-			RoundLoserBracket, // 雨天時のみ
-		}
-	case SportSoccer:
-		return []string{
-			Round1stRound,
-			RoundQuarterfinal,
-			RoundSemifinal,
-			RoundThirdPlace,
-			RoundFinal,
-		}
-	default:
-		return []string{}
+	rounds := GetValidRoundsForSportType(SportType(sport))
+	result := make([]string, len(rounds))
+	for i, round := range rounds {
+		result[i] = string(round)
 	}
-}
\ No newline at end of file
+	return result
+}
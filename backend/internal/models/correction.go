@@ -0,0 +1,233 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CorrectionAction は結果訂正による後続の試合への影響の種類
+type CorrectionAction string
+
+const (
+	CorrectionActionReassigned CorrectionAction = "reassigned" // 枠のチームを訂正後の勝者・敗者に入れ替えた
+	CorrectionActionCleared    CorrectionAction = "cleared"    // 枠をTBDに戻した
+	CorrectionActionReset      CorrectionAction = "reset"      // 実施済みの結果を取り消して未実施に戻した
+)
+
+// CorrectionImpact は結果訂正により変更された後続の試合
+type CorrectionImpact struct {
+	MatchID   int              `json:"match_id" example:"12"`
+	Round     string           `json:"round" example:"semifinal"`
+	Action    CorrectionAction `json:"action" example:"reassigned"`
+	WasPlayed bool             `json:"was_played" example:"false"` // 訂正前に試合が実施済み（結果が取り消された）
+}
+
+// ResultCorrection は完了した試合結果の訂正記録
+type ResultCorrection struct {
+	ID             int                `json:"id"`
+	MatchID        int                `json:"match_id"`
+	TournamentID   int                `json:"tournament_id"`
+	PreviousScore1 *int               `json:"previous_score1,omitempty"`
+	PreviousScore2 *int               `json:"previous_score2,omitempty"`
+	PreviousWinner *string            `json:"previous_winner,omitempty"`
+	Score1         int                `json:"score1"`
+	Score2         int                `json:"score2"`
+	Winner         *string            `json:"winner,omitempty"` // 引き分けの場合はnull
+	Reason         string             `json:"reason"`
+	CorrectedBy    *int               `json:"corrected_by,omitempty"` // 訂正した管理者のユーザーID
+	Impacts        []CorrectionImpact `json:"impacts"`
+	Warnings       []string           `json:"warnings,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// HasPlayedImpacts は実施済みの後続の試合の結果を取り消したかどうかを返す
+func (c *ResultCorrection) HasPlayedImpacts() bool {
+	for _, impact := range c.Impacts {
+		if impact.WasPlayed {
+			return true
+		}
+	}
+	return false
+}
+
+// CanCorrectResult は試合結果を訂正可能かどうかを返す（完了した試合のみ）
+func (m *Match) CanCorrectResult() bool {
+	return m.IsCompleted()
+}
+
+// CorrectResult は完了した試合の結果を訂正し、勝者・敗者が変わった場合は後続の試合の枠を付け替える
+// 付け替え先の試合が実施済みの場合はその結果を取り消し、さらに後続の枠をTBDに戻す。
+// match と matches の試合を直接変更し、訂正記録と変更した後続の試合を返す
+func CorrectResult(match *Match, matches []*Match, result MatchResult) (*ResultCorrection, []*Match, error) {
+	if !match.CanCorrectResult() {
+		return nil, nil, errors.New("完了した試合のみ訂正できます")
+	}
+	if err := result.ValidateForRound(match.GetRound(), match.Team1, match.Team2); err != nil {
+		return nil, nil, err
+	}
+
+	correction := &ResultCorrection{
+		MatchID:        match.ID,
+		TournamentID:   match.TournamentID,
+		PreviousScore1: match.Score1,
+		PreviousScore2: match.Score2,
+		PreviousWinner: match.Winner,
+		Score1:         result.Score1,
+		Score2:         result.Score2,
+		Impacts:        []CorrectionImpact{},
+	}
+
+	previous := *match
+	match.ApplyResult(result)
+	correction.Winner = match.Winner
+
+	c := newCorrectionCascade(matches)
+	if err := c.replaceAdvanced(&previous, match); err != nil {
+		return nil, nil, err
+	}
+
+	switch match.GetRound() {
+	case RoundGroupStageEnum:
+		if GroupStageComplete(matches) && !hasGroupPlaceholders(matches) {
+			correction.Warnings = append(correction.Warnings, "決勝トーナメントの組み合わせは既に確定しているため変更されません")
+		}
+	case RoundSwissEnum:
+		if current, _ := CurrentSwissRound(matches); match.SwissRound != nil && *match.SwissRound < current {
+			correction.Warnings = append(correction.Warnings, "以降の回戦の組み合わせは変更されません")
+		}
+	}
+
+	correction.Impacts = append(correction.Impacts, c.impacts()...)
+	for _, impact := range correction.Impacts {
+		if impact.WasPlayed {
+			correction.Warnings = append(correction.Warnings,
+				fmt.Sprintf("実施済みの試合（ID: %d）の結果を取り消しました", impact.MatchID))
+		}
+	}
+
+	return correction, c.changed, nil
+}
+
+// correctionCascade は結果訂正による後続の試合の変更を記録する
+type correctionCascade struct {
+	byID    map[int]*Match
+	changed []*Match
+	impact  map[int]*CorrectionImpact
+}
+
+func newCorrectionCascade(matches []*Match) *correctionCascade {
+	byID := make(map[int]*Match, len(matches))
+	for _, match := range matches {
+		if match != nil {
+			byID[match.ID] = match
+		}
+	}
+	return &correctionCascade{byID: byID, impact: make(map[int]*CorrectionImpact)}
+}
+
+// advancedTeams は試合結果から進出先の枠に入るチーム（勝者・敗者）を返す
+// グランドファイナルはリセットマッチが必要な場合のみ進出先がある
+func advancedTeams(match *Match) (winner, loser TeamRef) {
+	if !match.IsCompleted() {
+		return TeamRef{}, TeamRef{}
+	}
+	if match.GetRound() == RoundGrandFinalEnum && !match.NeedsGrandFinalReset() {
+		return TeamRef{}, TeamRef{}
+	}
+	return match.AdvancingTeams()
+}
+
+// replaceAdvanced は訂正前後の結果で進出先の枠に入るチームが変わった場合に枠を付け替える
+func (c *correctionCascade) replaceAdvanced(before, after *Match) error {
+	oldWinner, oldLoser := advancedTeams(before)
+	newWinner, newLoser := advancedTeams(after)
+	progression := after.GetProgression()
+
+	if !oldWinner.Is(newWinner) {
+		if err := c.replace(progression.Winner, newWinner); err != nil {
+			return err
+		}
+	}
+	if !oldLoser.Is(newLoser) {
+		if err := c.replace(progression.Loser, newLoser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replace は枠のチームを入れ替える（名前が空の場合はTBD）。実施済みの試合は結果を取り消して未実施に戻す
+func (c *correctionCascade) replace(slot *BracketSlot, team TeamRef) error {
+	if slot == nil {
+		return nil
+	}
+	target := c.byID[slot.MatchID]
+	if target == nil {
+		return nil
+	}
+	if team.Name == "" {
+		team = TeamRef{Name: TeamTBD}
+	}
+	if target.TeamInSlot(slot.Slot).Is(team) {
+		return nil
+	}
+
+	wasPlayed := target.IsCompleted() || target.IsInProgress()
+	if wasPlayed {
+		before := *target
+		if err := target.ResetByCorrection(); err != nil {
+			return err
+		}
+		if err := c.replaceAdvanced(&before, target); err != nil {
+			return err
+		}
+	}
+	if err := target.SetTeamInSlot(slot.Slot, team); err != nil {
+		return err
+	}
+
+	action := CorrectionActionReassigned
+	switch {
+	case wasPlayed:
+		action = CorrectionActionReset
+	case team.Name == TeamTBD:
+		action = CorrectionActionCleared
+	}
+	c.record(target, action, wasPlayed)
+	return nil
+}
+
+// record は後続の試合への影響を記録する（同じ試合は最も大きい影響にまとめる）
+func (c *correctionCascade) record(target *Match, action CorrectionAction, wasPlayed bool) {
+	if existing, ok := c.impact[target.ID]; ok {
+		if wasPlayed {
+			existing.Action, existing.WasPlayed = action, true
+		}
+		return
+	}
+	c.impact[target.ID] = &CorrectionImpact{MatchID: target.ID, Round: target.Round, Action: action, WasPlayed: wasPlayed}
+	c.changed = append(c.changed, target)
+}
+
+// impacts は変更した順に後続の試合への影響を返す
+func (c *correctionCascade) impacts() []CorrectionImpact {
+	impacts := make([]CorrectionImpact, 0, len(c.changed))
+	for _, match := range c.changed {
+		impacts = append(impacts, *c.impact[match.ID])
+	}
+	return impacts
+}
+
+// hasGroupPlaceholders は決勝トーナメントにグループ順位の枠が残っているかどうかを返す
+func hasGroupPlaceholders(matches []*Match) bool {
+	for _, match := range matches {
+		if match == nil || match.GetRound() == RoundGroupStageEnum {
+			continue
+		}
+		if groupPlaceholderPattern.MatchString(match.Team1) || groupPlaceholderPattern.MatchString(match.Team2) {
+			return true
+		}
+	}
+	return false
+}
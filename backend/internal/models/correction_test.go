@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestCorrectResult_ScoreOnly(t *testing.T) {
+	matches, byRound := newPlayedFourTeamBracket(t)
+	semi := byRound[RoundSemifinalEnum][0]
+
+	correction, changed, err := CorrectResult(semi, matches, MatchResult{Score1: 3, Score2: 1, Winner: "A"})
+	if err != nil {
+		t.Fatalf("CorrectResult() error = %v", err)
+	}
+	if len(changed) != 0 || len(correction.Impacts) != 0 {
+		t.Errorf("CorrectResult() with the same winner changed %d matches", len(changed))
+	}
+	if *semi.Score1 != 3 || *correction.PreviousScore1 != 2 {
+		t.Errorf("CorrectResult() score1 = %d (previous %d), want 3 (previous 2)", *semi.Score1, *correction.PreviousScore1)
+	}
+}
+
+func TestCorrectResult_ReassignsDownstream(t *testing.T) {
+	matches, byRound := newPlayedFourTeamBracket(t)
+	semi := byRound[RoundSemifinalEnum][0]
+	final := byRound[RoundFinalEnum][0]
+	thirdPlace := byRound[RoundThirdPlaceEnum][0]
+
+	correction, changed, err := CorrectResult(semi, matches, MatchResult{Score1: 1, Score2: 2, Winner: "D"})
+	if err != nil {
+		t.Fatalf("CorrectResult() error = %v", err)
+	}
+
+	if final.Team1 != "D" || thirdPlace.Team1 != "A" {
+		t.Errorf("CorrectResult() final.Team1 = %s, thirdPlace.Team1 = %s, want D, A", final.Team1, thirdPlace.Team1)
+	}
+	if len(changed) != 2 || correction.HasPlayedImpacts() || len(correction.Warnings) != 0 {
+		t.Errorf("CorrectResult() changed = %d, impacts = %+v, warnings = %v", len(changed), correction.Impacts, correction.Warnings)
+	}
+	if *correction.PreviousWinner != "A" || *correction.Winner != "D" {
+		t.Errorf("CorrectResult() winner %s -> %s, want A -> D", *correction.PreviousWinner, *correction.Winner)
+	}
+}
+
+func TestCorrectResult_ResetsPlayedDownstream(t *testing.T) {
+	matches, byRound := newPlayedFourTeamBracket(t)
+	semi := byRound[RoundSemifinalEnum][0]
+	final := byRound[RoundFinalEnum][0]
+	playTestMatch(t, matches, final, "A", 1, 0)
+
+	correction, _, err := CorrectResult(semi, matches, MatchResult{Score1: 0, Score2: 1, Winner: "D"})
+	if err != nil {
+		t.Fatalf("CorrectResult() error = %v", err)
+	}
+
+	if final.Team1 != "D" || !final.IsPending() || final.Winner != nil || final.Score1 != nil {
+		t.Errorf("CorrectResult() final = %s-%s status %s, want D-B pending without result", final.Team1, final.Team2, final.Status)
+	}
+	if !correction.HasPlayedImpacts() || len(correction.Warnings) != 1 {
+		t.Errorf("CorrectResult() should warn about the played final, impacts = %+v, warnings = %v", correction.Impacts, correction.Warnings)
+	}
+}
+
+func TestCorrectResult_Errors(t *testing.T) {
+	matches, byRound := newPlayedFourTeamBracket(t)
+
+	if _, _, err := CorrectResult(byRound[RoundFinalEnum][0], matches, MatchResult{Score1: 1, Score2: 0, Winner: "A"}); err == nil {
+		t.Error("CorrectResult() should reject a match that has not been played")
+	}
+	if _, _, err := CorrectResult(byRound[RoundSemifinalEnum][0], matches, MatchResult{Score1: 1, Score2: 1}); err == nil {
+		t.Error("CorrectResult() should reject a draw in a knockout round")
+	}
+	if _, _, err := CorrectResult(byRound[RoundSemifinalEnum][0], matches, MatchResult{Score1: 2, Score2: 1, Winner: "D"}); err == nil {
+		t.Error("CorrectResult() should reject a winner that does not match the scores")
+	}
+}
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"errors"
+)
+
+// bracketSource は試合の枠に入るチームの出どころ（ある試合の勝者または敗者）を表す
+// nilの場合、その枠に入るチームはいない（不戦勝により敗者が出ない場合など）
+type bracketSource struct {
+	round    RoundType
+	position int
+	loser    bool
+}
+
+// plannedMatch は敗者側ブラケット・グランドファイナルの試合の計画
+type plannedMatch struct {
+	round    RoundType
+	position int
+	sources  [2]*bracketSource // team1, team2の枠の出どころ
+}
+
+// planDoubleElimination は勝者側ブラケットのラウンド構成から敗者側ブラケットと
+// グランドファイナルの試合を計画する
+//
+// 敗者側ブラケットは、勝者側1回戦の敗者同士の試合から始まり、以降は
+// 「敗者側の勝ち残りと勝者側ラウンドの敗者の対戦」と「敗者側の勝ち残り同士の対戦」を交互に行う。
+// 勝者側から落ちてくる敗者は再戦を避けるため逆順に配置する。
+// 敗者側の試合は全てloser_bracketラウンドとし、位置はラウンド順の通し番号とする。
+// 一方の枠にチームが入らない試合（勝者側の不戦勝による）は計画せず、もう一方をそのまま次へ進める。
+// 計画は勝者側のラウンド構成と不戦勝の位置だけで決まるため、作成時と進出先の導出時で同じ結果になる
+func planDoubleElimination(winnerRounds []RoundType, byes map[int]bool) []plannedMatch {
+	roundCount := len(winnerRounds)
+	if roundCount == 0 {
+		return nil
+	}
+	firstRoundMatches := 1 << (roundCount - 1)
+
+	winnerLoser := func(round, position int) *bracketSource {
+		if round == 0 && byes[position] {
+			return nil
+		}
+		return &bracketSource{round: winnerRounds[round], position: position, loser: true}
+	}
+
+	var plan []plannedMatch
+	position := 0
+	// play は2つの出どころで試合を計画し、その勝者の出どころを返す
+	play := func(a, b *bracketSource) *bracketSource {
+		pos := position
+		position++
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		plan = append(plan, plannedMatch{round: RoundLoserBracketEnum, position: pos, sources: [2]*bracketSource{a, b}})
+		return &bracketSource{round: RoundLoserBracketEnum, position: pos}
+	}
+
+	// 勝者側が決勝のみ（2チーム）の場合は、決勝の敗者がそのまま敗者側の優勝チームとなる
+	loserChampion := winnerLoser(roundCount-1, 0)
+	if roundCount >= 2 {
+		survivors := make([]*bracketSource, 0, firstRoundMatches/2)
+		for i := 0; i < firstRoundMatches/2; i++ {
+			survivors = append(survivors, play(winnerLoser(0, 2*i), winnerLoser(0, 2*i+1)))
+		}
+
+		for round := 1; round < roundCount; round++ {
+			size := firstRoundMatches >> round
+
+			// 勝者側から落ちてくる敗者との対戦
+			dropped := make([]*bracketSource, 0, size)
+			for i := 0; i < size; i++ {
+				dropped = append(dropped, play(survivors[i], winnerLoser(round, size-1-i)))
+			}
+			survivors = dropped
+
+			// 敗者側の勝ち残り同士の対戦
+			if round < roundCount-1 {
+				reduced := make([]*bracketSource, 0, size/2)
+				for i := 0; i < size/2; i++ {
+					reduced = append(reduced, play(survivors[2*i], survivors[2*i+1]))
+				}
+				survivors = reduced
+			}
+		}
+		loserChampion = survivors[0]
+	}
+
+	// グランドファイナルは勝者側の優勝チームをteam1、敗者側の優勝チームをteam2とする
+	winnerChampion := &bracketSource{round: winnerRounds[roundCount-1], position: 0}
+	plan = append(plan, plannedMatch{
+		round:   RoundGrandFinalEnum,
+		sources: [2]*bracketSource{winnerChampion, loserChampion},
+	})
+
+	// リセットマッチでも同じ側の枠に入るよう、グランドファイナルの敗者（勝者側の優勝チーム）をteam1とする
+	plan = append(plan, plannedMatch{
+		round: RoundGrandFinalResetEnum,
+		sources: [2]*bracketSource{
+			{round: RoundGrandFinalEnum, loser: true},
+			{round: RoundGrandFinalEnum},
+		},
+	})
+
+	return plan
+}
+
+// NewDoubleEliminationMatches は1回戦の対戦カードからダブルイリミネーション形式の全試合を作成する
+// 勝者側ブラケットはNewKnockoutMatchesと同じ構成（3位決定戦なし）とし、
+// 敗者側ブラケット、グランドファイナル、リセットマッチを追加する
+func NewDoubleEliminationMatches(tournamentID int, pairs [][2]string) ([]*Match, error) {
+	knockout, err := NewKnockoutMatches(tournamentID, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*Match, 0, len(knockout)*2+2)
+	for _, match := range knockout {
+		// 3位は敗者側ブラケットで決まるため3位決定戦は行わない
+		if match.GetRound() == RoundThirdPlaceEnum {
+			continue
+		}
+		matches = append(matches, match)
+	}
+
+	byRound := groupMatchesByRound(matches)
+	winnerRounds := presentKnockoutRounds(byRound)
+	if len(winnerRounds) == 0 {
+		return nil, errors.New("勝者側ブラケットの試合がありません")
+	}
+
+	for _, planned := range planDoubleElimination(winnerRounds, findFirstRoundByes(byRound, winnerRounds)) {
+		matches = append(matches, newPendingMatch(tournamentID, planned.round, planned.position, TeamTBD, TeamTBD))
+	}
+
+	return matches, nil
+}
+
+// NeedsGrandFinalReset はグランドファイナルの結果からリセットマッチが必要かどうかを判定する
+// 敗者側の優勝チーム（team2）が勝った場合のみ、両チームが1敗ずつとなりリセットマッチを行う
+func (m *Match) NeedsGrandFinalReset() bool {
+	return m.GetRound() == RoundGrandFinalEnum && m.WinnerSlot() == SlotTeam2
+}
+
+// addDoubleEliminationProgression は敗者側ブラケットとグランドファイナルへの進出先を追加する
+func addDoubleEliminationProgression(progression map[int]BracketProgression, byRound map[RoundType][]*Match) {
+	winnerRounds := presentKnockoutRounds(byRound)
+	if len(winnerRounds) == 0 {
+		return
+	}
+
+	positions := make(map[RoundType]map[int]*Match)
+	lookup := func(round RoundType, position int) *Match {
+		if positions[round] == nil {
+			positions[round] = indexByPosition(byRound[round])
+		}
+		return positions[round][position]
+	}
+
+	for _, planned := range planDoubleElimination(winnerRounds, findFirstRoundByes(byRound, winnerRounds)) {
+		target := lookup(planned.round, planned.position)
+		if target == nil {
+			continue
+		}
+		for i, source := range planned.sources {
+			if source == nil {
+				continue
+			}
+			from := lookup(source.round, source.position)
+			if from == nil {
+				continue
+			}
+			slot := &BracketSlot{MatchID: target.ID, Slot: i + 1}
+			p := progression[from.ID]
+			if source.loser {
+				p.Loser = slot
+			} else {
+				p.Winner = slot
+			}
+			progression[from.ID] = p
+		}
+	}
+}
+
+// findFirstRoundByes は勝者側1回戦で試合行がない（不戦勝の）位置を返す
+func findFirstRoundByes(byRound map[RoundType][]*Match, winnerRounds []RoundType) map[int]bool {
+	firstRound := indexByPosition(byRound[winnerRounds[0]])
+	byes := make(map[int]bool)
+	for position := 0; position < 1<<(len(winnerRounds)-1); position++ {
+		if firstRound[position] == nil {
+			byes[position] = true
+		}
+	}
+	return byes
+}
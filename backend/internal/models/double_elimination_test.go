@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewDoubleEliminationMatches_Structure(t *testing.T) {
+	matches := newLinkedDoubleElimination(t, 8)
+
+	counts := make(map[string]int)
+	for _, match := range matches {
+		counts[match.Round]++
+	}
+
+	// 勝者側7試合 + 敗者側6試合 + グランドファイナル + リセットマッチ
+	if len(matches) != 15 {
+		t.Fatalf("NewDoubleEliminationMatches() matches = %d, want 15", len(matches))
+	}
+	if counts[RoundLoserBracket] != 6 || counts[RoundThirdPlace] != 0 {
+		t.Errorf("loser_bracket = %d, third_place = %d, want 6, 0", counts[RoundLoserBracket], counts[RoundThirdPlace])
+	}
+
+	// 勝者側1回戦の敗者は敗者側1回戦へ、勝者側決勝の勝者はグランドファイナルへ
+	byRound := groupMatchesByRound(matches)
+	loserBracket := byRound[RoundLoserBracketEnum]
+	assertSlot(t, "loser", byRound[RoundQuarterfinalEnum][1].GetProgression().Loser, &BracketSlot{MatchID: loserBracket[0].ID, Slot: SlotTeam2})
+	assertSlot(t, "winner", byRound[RoundFinalEnum][0].GetProgression().Winner, &BracketSlot{MatchID: byRound[RoundGrandFinalEnum][0].ID, Slot: SlotTeam1})
+	assertSlot(t, "loser", byRound[RoundFinalEnum][0].GetProgression().Loser, &BracketSlot{MatchID: loserBracket[5].ID, Slot: SlotTeam2})
+	assertSlot(t, "winner", loserBracket[5].GetProgression().Winner, &BracketSlot{MatchID: byRound[RoundGrandFinalEnum][0].ID, Slot: SlotTeam2})
+
+	rounds := GroupMatchesIntoRounds(matches)
+	last := rounds[len(rounds)-3:]
+	if last[0].Name != RoundLoserBracket || last[1].Name != RoundGrandFinal || last[2].Name != RoundGrandFinalReset {
+		t.Errorf("GroupMatchesIntoRounds() last rounds = %v, %v, %v", last[0].Name, last[1].Name, last[2].Name)
+	}
+}
+
+func TestNewDoubleEliminationMatches_Play(t *testing.T) {
+	for _, teamCount := range []int{2, 3, 4, 5, 6, 8, 11, 16} {
+		for _, resetPlayed := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%dチーム/reset=%v", teamCount, resetPlayed), func(t *testing.T) {
+				matches := newLinkedDoubleElimination(t, teamCount)
+
+				losses := playDoubleElimination(t, matches, func(match *Match) string {
+					if match.GetRound() == RoundGrandFinalEnum && resetPlayed {
+						return match.Team2
+					}
+					return match.Team1
+				})
+
+				// 優勝チーム以外は全て2敗して敗退し、優勝チームの敗戦は1以下
+				eliminated := 0
+				for team, count := range losses {
+					if count > 2 {
+						t.Errorf("%s lost %d times", team, count)
+					}
+					if count == 2 {
+						eliminated++
+					}
+				}
+				if eliminated != teamCount-1 {
+					t.Errorf("eliminated teams = %d, want %d", eliminated, teamCount-1)
+				}
+
+				for _, match := range matches {
+					played := match.IsCompleted()
+					if match.GetRound() == RoundGrandFinalResetEnum {
+						if played != resetPlayed {
+							t.Errorf("grand_final_reset played = %v, want %v", played, resetPlayed)
+						}
+						continue
+					}
+					if !played {
+						t.Errorf("%s[%d] was not played: %s vs %s", match.Round, match.Position, match.Team1, match.Team2)
+					}
+				}
+			})
+		}
+	}
+}
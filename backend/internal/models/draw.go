@@ -0,0 +1,275 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+)
+
+// SeparationRule は1回戦で対戦させないチームの組み合わせ規則
+type SeparationRule string
+
+const (
+	SeparationGrade      SeparationRule = "grade"      // 同じ学年（1-1/1-2/1-3、IE4/IS4 など）
+	SeparationDepartment SeparationRule = "department" // 同じ学科（IE/IS/IT）
+)
+
+// IsValid は分離規則が有効かどうかを検証する
+func (r SeparationRule) IsValid() bool {
+	switch r {
+	case SeparationGrade, SeparationDepartment:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	// 1年生のクラス（例: 1-1）
+	gradeClassPattern = regexp.MustCompile(`^(\d+)-\d+$`)
+	// 学科のクラス（例: IE4）
+	departmentClassPattern = regexp.MustCompile(`^([A-Za-z]+)(\d+)$`)
+)
+
+// SeparationGroup はチーム名から分離規則上のグループを返す
+// グループに属さないチーム（専攻科・教員など）の場合は空文字を返す
+func SeparationGroup(team string, rule SeparationRule) string {
+	switch rule {
+	case SeparationGrade:
+		if m := gradeClassPattern.FindStringSubmatch(team); m != nil {
+			return m[1]
+		}
+		if m := departmentClassPattern.FindStringSubmatch(team); m != nil {
+			return m[2]
+		}
+	case SeparationDepartment:
+		if m := departmentClassPattern.FindStringSubmatch(team); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// DrawOptions は抽選の条件
+// 同じ条件とチームで抽選すると常に同じ結果になるため、監査や再現に使用できる
+type DrawOptions struct {
+	Seeds      []string         `json:"seeds"`       // シード順位（上位から、例: 昨年の上位4チーム）
+	Separation []SeparationRule `json:"separation"`  // 1回戦で対戦させない規則
+	RandomSeed int64            `json:"random_seed"` // 抽選に使用した乱数シード
+
+	// グループリーグ形式のみ
+	GroupCount         int `json:"group_count,omitempty"`          // グループ数（0の場合は1グループ4チームを目安に決定）
+	QualifiersPerGroup int `json:"qualifiers_per_group,omitempty"` // 各グループから決勝トーナメントに進むチーム数（0の場合は2）
+
+	// スイス式のみ
+	SwissRounds int `json:"swiss_rounds,omitempty"` // 回戦数（0の場合はチーム数から決定）
+}
+
+// DrawResult は抽選結果
+type DrawResult struct {
+	Options  DrawOptions `json:"options"`
+	Order    []string    `json:"order"`              // シード番号順のチーム（抽選時のチーム名）
+	TeamIDs  []int       `json:"team_ids,omitempty"` // シード番号順のチームの登録チームID
+	Pairs    [][2]string `json:"pairs"`              // 1回戦の対戦カード（空文字は不戦勝）
+	Warnings []string    `json:"warnings,omitempty"` // 満たせずに適用しなかった分離規則
+}
+
+// drawSearchLimit は分離規則を満たす割り当ての探索で試すチームの配置数の上限
+// 上限までに見つからない場合は規則を満たせないものとして扱い、探索が終わらないことを防ぐ
+const drawSearchLimit = 20000
+
+// Validate は抽選条件を検証する
+func (o *DrawOptions) Validate(teams []string) error {
+	registered := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		registered[team] = true
+	}
+
+	seeded := make(map[string]bool, len(o.Seeds))
+	for _, team := range o.Seeds {
+		if !registered[team] {
+			return fmt.Errorf("シードに登録されていないチームが含まれています: %s", team)
+		}
+		if seeded[team] {
+			return fmt.Errorf("シードに同じチームが重複しています: %s", team)
+		}
+		seeded[team] = true
+	}
+
+	for _, rule := range o.Separation {
+		if !rule.IsValid() {
+			return fmt.Errorf("無効な分離規則です: %s", rule)
+		}
+	}
+
+	if o.GroupCount < 0 || o.QualifiersPerGroup < 0 {
+		return errors.New("グループ数と進出チーム数は0以上である必要があります")
+	}
+	if o.SwissRounds < 0 || o.SwissRounds >= len(teams) {
+		return errors.New("スイス式の回戦数はチーム数未満である必要があります")
+	}
+
+	return nil
+}
+
+// Draw はシード順位と分離規則に従って1回戦の組み合わせを抽選する
+// シードされたチームは上位のシード番号に固定し、残りのチームは乱数シードで並べ替えてから
+// 分離規則を満たすように順に割り当てる。規則を満たす組み合わせがない場合は、後ろの規則から
+// 順に適用をやめて抽選し直し、適用しなかった規則を警告として返す。シード同士が規則に反する場合はエラーを返す
+func Draw(teams []string, opts DrawOptions) (*DrawResult, error) {
+	if len(teams) < 2 {
+		return nil, errors.New("2チーム以上が必要です")
+	}
+	if err := opts.Validate(teams); err != nil {
+		return nil, err
+	}
+
+	size := 1
+	for size < len(teams) {
+		size *= 2
+	}
+
+	order := make([]string, len(teams))
+	copy(order, opts.Seeds)
+
+	seeded := make(map[string]bool, len(opts.Seeds))
+	for _, team := range opts.Seeds {
+		seeded[team] = true
+	}
+	pool := make([]string, 0, len(teams)-len(opts.Seeds))
+	for _, team := range teams {
+		if !seeded[team] {
+			pool = append(pool, team)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.RandomSeed))
+	rng.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	// シード同士が1回戦で当たる場合は並べ替えで解消できない
+	for i := range opts.Seeds {
+		if o := size - i - 1; o < len(opts.Seeds) && conflictsInFirstRound(order[i], order[o], opts.Separation) {
+			return nil, fmt.Errorf("シード同士の対戦が分離規則に反します: %s - %s", order[o], order[i])
+		}
+	}
+
+	result := &DrawResult{Options: opts}
+	rules := append([]SeparationRule(nil), opts.Separation...)
+	for {
+		// チーム数から満たせないことが分かる規則はその規則をやめ、
+		// 探索で見つからない場合は後ろの規則からやめる（規則なしでは必ず割り当てられる）
+		drop := unsatisfiableRule(teams, size, rules)
+		if drop < 0 {
+			budget := drawSearchLimit
+			used := make([]bool, len(pool))
+			if assignDrawSlots(order, len(opts.Seeds), size, pool, used, rules, &budget) {
+				break
+			}
+			drop = len(rules) - 1
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("分離規則（%s）を満たす組み合わせがないため、この規則を適用せずに抽選しました", rules[drop]))
+		rules = append(rules[:drop], rules[drop+1:]...)
+	}
+
+	result.Order = order
+	result.Pairs = NewSeededPairs(order)
+	return result, nil
+}
+
+// unsatisfiableRule はチーム数から満たせないことが分かる分離規則の位置を返す（ない場合は-1）
+// 1回戦の対戦（不戦勝の枠を含む size/2 組）には同じグループのチームを1チームまでしか入れられないため、
+// いずれかのグループのチーム数がそれを超える規則は満たせない
+func unsatisfiableRule(teams []string, size int, rules []SeparationRule) int {
+	for i, rule := range rules {
+		counts := make(map[string]int)
+		for _, team := range teams {
+			if group := SeparationGroup(team, rule); group != "" {
+				counts[group]++
+				if counts[group] > size/2 {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// RecordTeamIDs はシード番号順のチームの登録チームIDを記録する
+func (d *DrawResult) RecordTeamIDs(teams []*Team) {
+	ids := make(map[string]int, len(teams))
+	for _, team := range teams {
+		ids[team.Name] = int(team.ID)
+	}
+	d.TeamIDs = make([]int, 0, len(d.Order))
+	for _, name := range d.Order {
+		d.TeamIDs = append(d.TeamIDs, ids[name])
+	}
+}
+
+// CurrentOrder はシード番号順のチームを現在のチーム名で返す
+// 抽選の記録は抽選時のチーム名のまま残すため、登録チームIDを記録したチームは現在の名前に置き換える
+func (d *DrawResult) CurrentOrder(teams []*Team) []string {
+	names := make(map[int]string, len(teams))
+	for _, team := range teams {
+		names[int(team.ID)] = team.Name
+	}
+
+	order := make([]string, len(d.Order))
+	copy(order, d.Order)
+	for i, id := range d.TeamIDs {
+		if name, ok := names[id]; ok && i < len(order) {
+			order[i] = name
+		}
+	}
+	return order
+}
+
+// assignDrawSlots はシード番号 index+1 以降にチームを割り当てる（バックトラック）
+// 標準シード配置では、シード番号sの1回戦の相手はシード番号 size+1-s となる
+// チームを配置するたびに budget を減らし、使い切った場合は見つからなかったものとして打ち切る
+func assignDrawSlots(order []string, index, size int, pool []string, used []bool, rules []SeparationRule, budget *int) bool {
+	if index == len(order) {
+		return true
+	}
+
+	// 相手のシード番号が小さい場合のみ、相手は既に割り当て済み
+	opponent := ""
+	if o := size - index - 1; o < index {
+		opponent = order[o]
+	}
+
+	for i, team := range pool {
+		if used[i] || conflictsInFirstRound(team, opponent, rules) {
+			continue
+		}
+		if *budget <= 0 {
+			return false
+		}
+		*budget--
+		used[i] = true
+		order[index] = team
+		if assignDrawSlots(order, index+1, size, pool, used, rules, budget) {
+			return true
+		}
+		used[i] = false
+	}
+
+	return false
+}
+
+// conflictsInFirstRound は2チームが1回戦で対戦すると分離規則に反するかどうかを判定する
+func conflictsInFirstRound(team1, team2 string, rules []SeparationRule) bool {
+	if team1 == "" || team2 == "" {
+		return false
+	}
+	for _, rule := range rules {
+		group := SeparationGroup(team1, rule)
+		if group != "" && group == SeparationGroup(team2, rule) {
+			return true
+		}
+	}
+	return false
+}
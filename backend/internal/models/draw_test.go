@@ -0,0 +1,176 @@
+package models
+
+import (
+	"testing"
+)
+
+var drawTeams = []string{"1-1", "1-2", "1-3", "IE2", "IS2", "IT2", "IE3", "IS3", "専・教"}
+
+func TestSeparationGroup(t *testing.T) {
+	tests := []struct {
+		team string
+		rule SeparationRule
+		want string
+	}{
+		{team: "1-2", rule: SeparationGrade, want: "1"},
+		{team: "IE4", rule: SeparationGrade, want: "4"},
+		{team: "IE4", rule: SeparationDepartment, want: "IE"},
+		{team: "1-2", rule: SeparationDepartment, want: ""},
+		{team: "専・教", rule: SeparationGrade, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.team+"/"+string(tt.rule), func(t *testing.T) {
+			if got := SeparationGroup(tt.team, tt.rule); got != tt.want {
+				t.Errorf("SeparationGroup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDraw_Reproducible(t *testing.T) {
+	opts := DrawOptions{
+		Seeds:      []string{"IE3", "1-1"},
+		Separation: []SeparationRule{SeparationGrade, SeparationDepartment},
+		RandomSeed: 20240601,
+	}
+
+	first, err := Draw(drawTeams, opts)
+	if err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+	second, err := Draw(drawTeams, opts)
+	if err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	for i := range first.Order {
+		if first.Order[i] != second.Order[i] {
+			t.Fatalf("Draw() is not reproducible: %v / %v", first.Order, second.Order)
+		}
+	}
+
+	// シードは上位のシード番号に固定される
+	if first.Order[0] != "IE3" || first.Order[1] != "1-1" {
+		t.Errorf("Draw() seeds = %v, want [IE3 1-1 ...]", first.Order[:2])
+	}
+	if len(first.Pairs) != 8 {
+		t.Errorf("Draw() pairs = %d, want 8", len(first.Pairs))
+	}
+}
+
+func TestDraw_Separation(t *testing.T) {
+	rules := []SeparationRule{SeparationGrade, SeparationDepartment}
+
+	for seed := int64(0); seed < 50; seed++ {
+		result, err := Draw(drawTeams, DrawOptions{Separation: rules, RandomSeed: seed})
+		if err != nil {
+			t.Fatalf("Draw(seed=%d) error = %v", seed, err)
+		}
+		for _, pair := range result.Pairs {
+			if conflictsInFirstRound(pair[0], pair[1], rules) {
+				t.Errorf("Draw(seed=%d) pairs %v vs %v in the first round", seed, pair[0], pair[1])
+			}
+		}
+	}
+}
+
+func TestDraw_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []string
+		opts  DrawOptions
+	}{
+		{name: "未登録のシード", teams: drawTeams, opts: DrawOptions{Seeds: []string{"IT5"}}},
+		{name: "重複したシード", teams: drawTeams, opts: DrawOptions{Seeds: []string{"1-1", "1-1"}}},
+		{name: "無効な分離規則", teams: drawTeams, opts: DrawOptions{Separation: []SeparationRule{"club"}}},
+		{
+			name:  "シード同士が規則に反する",
+			teams: []string{"1-1", "1-2"},
+			opts:  DrawOptions{Seeds: []string{"1-1", "1-2"}, Separation: []SeparationRule{SeparationGrade}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Draw(tt.teams, tt.opts); err == nil {
+				t.Error("Draw() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestDraw_RelaxesUnsatisfiableRules(t *testing.T) {
+	rules := []SeparationRule{SeparationGrade, SeparationDepartment}
+
+	// 1年生が1回戦の対戦数（8組）より多い16チーム
+	dominated := newTestTeams("1-", 9)
+	dominated = append(dominated, "IE2", "IS2", "IT2", "IE3", "IS3", "IT3", "IE4")
+
+	tests := []struct {
+		name     string
+		teams    []string
+		rules    []SeparationRule
+		warnings int
+		keep     []SeparationRule // 適用し続ける規則
+	}{
+		{name: "全チームが同じ学年", teams: newTestTeams("1-", 4), rules: []SeparationRule{SeparationGrade}, warnings: 1},
+		{name: "1つの学年が半数を超える16チーム", teams: dominated, rules: rules, warnings: 1, keep: []SeparationRule{SeparationDepartment}},
+		{name: "規則を満たせる", teams: drawTeams, rules: rules, keep: rules},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				result, err := Draw(tt.teams, DrawOptions{Separation: tt.rules, RandomSeed: seed})
+				if err != nil {
+					t.Fatalf("Draw(seed=%d) error = %v", seed, err)
+				}
+				if len(result.Warnings) != tt.warnings {
+					t.Errorf("Draw(seed=%d) warnings = %v, want %d", seed, result.Warnings, tt.warnings)
+				}
+				if len(result.Order) != len(tt.teams) {
+					t.Errorf("Draw(seed=%d) order = %v, want %d teams", seed, result.Order, len(tt.teams))
+				}
+				for _, pair := range result.Pairs {
+					if conflictsInFirstRound(pair[0], pair[1], tt.keep) {
+						t.Errorf("Draw(seed=%d) pairs %v vs %v in the first round", seed, pair[0], pair[1])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDrawResult_CurrentOrder(t *testing.T) {
+	opts := DrawOptions{Seeds: []string{"IE3", "1-1"}, RandomSeed: 20240601}
+	draw, err := Draw(drawTeams, opts)
+	if err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	teams := make([]*Team, len(drawTeams))
+	for i, name := range drawTeams {
+		teams[i] = &Team{ID: uint(i + 1), TournamentID: 1, Name: name}
+	}
+	draw.RecordTeamIDs(teams)
+
+	// 抽選後にIE3をIE3Aに変更しても、記録した抽選は抽選時の名前のまま残る
+	teams[6].Name = "IE3A"
+	order := draw.CurrentOrder(teams)
+	if draw.Order[0] != "IE3" || order[0] != "IE3A" {
+		t.Errorf("CurrentOrder() = %v, recorded order = %v, want IE3A first and IE3 recorded", order, draw.Order)
+	}
+	for i := 1; i < len(order); i++ {
+		if order[i] != draw.Order[i] {
+			t.Errorf("CurrentOrder()[%d] = %s, want %s", i, order[i], draw.Order[i])
+		}
+	}
+
+	// チームIDを記録していない抽選は記録した名前を返す
+	legacy := *draw
+	legacy.TeamIDs = nil
+	if order := legacy.CurrentOrder(teams); order[0] != "IE3" {
+		t.Errorf("CurrentOrder() without team IDs = %v, want the recorded order", order)
+	}
+}
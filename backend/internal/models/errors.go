@@ -62,9 +62,11 @@ const (
 // ビジネスロジック関連エラーコード
 const (
 	// BUSINESS_* - ビジネスロジック関連エラー
-	ErrorBusinessTournamentCompleted    = "BUSINESS_TOURNAMENT_COMPLETED"     // トーナメントが既に完了
-	ErrorBusinessMatchAlreadyCompleted  = "BUSINESS_MATCH_ALREADY_COMPLETED"  // 試合が既に完了
-	ErrorBusinessInvalidMatchResult     = "BUSINESS_INVALID_MATCH_RESULT"     // 無効な試合結果
+	ErrorBusinessTournamentCompleted         = "BUSINESS_TOURNAMENT_COMPLETED"          // トーナメントが既に完了
+	ErrorBusinessMatchAlreadyCompleted       = "BUSINESS_MATCH_ALREADY_COMPLETED"       // 試合が既に完了
+	ErrorBusinessInvalidMatchResult          = "BUSINESS_INVALID_MATCH_RESULT"          // 無効な試合結果
+	ErrorBusinessInvalidMatchTransition      = "BUSINESS_INVALID_MATCH_TRANSITION"      // 許可されていない試合ステータスの変更
+	ErrorBusinessInvalidTournamentTransition = "BUSINESS_INVALID_TOURNAMENT_TRANSITION" // 許可されていないトーナメントステータスの変更
 )
 
 // システム関連エラーコード
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultEventTimezone は大会のタイムゾーンの既定値
+const DefaultEventTimezone = "Asia/Tokyo"
+
+// eventDateLayout は大会の開催日の形式
+const eventDateLayout = "2006-01-02"
+
+// Event は大会（春季・秋季などスポーツ大会の開催回）を表すモデル
+// トーナメントは大会ごとに種目単位で作成し、終了した大会はアーカイブとして読み取り専用になる
+type Event struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name" example:"2024年度 春季スポーツ大会"`    // 大会名
+	StartDate time.Time `json:"start_date" db:"start_date"`                  // 開始日（大会のタイムゾーンの日付）
+	EndDate   time.Time `json:"end_date" db:"end_date"`                      // 終了日（大会のタイムゾーンの日付）
+	Timezone  string    `json:"timezone" db:"timezone" example:"Asia/Tokyo"` // タイムゾーン（IANA形式）
+	Status    string    `json:"status" db:"status" example:"ongoing"`        // データベース互換性のため文字列型を維持
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// GetStatus はEventStatus列挙型を返す
+func (e *Event) GetStatus() EventStatus {
+	return EventStatus(e.Status)
+}
+
+// SetStatus はEventStatus列挙型から文字列を設定する
+func (e *Event) SetStatus(status EventStatus) {
+	e.Status = string(status)
+}
+
+// Validate は大会データの検証を行う
+func (e *Event) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return errors.New("大会名は必須です")
+	}
+	if len(e.Name) > 100 {
+		return errors.New("大会名は100文字以下である必要があります")
+	}
+
+	if e.StartDate.IsZero() || e.EndDate.IsZero() {
+		return errors.New("開始日と終了日は必須です")
+	}
+	if e.EndDate.Before(e.StartDate) {
+		return errors.New("終了日は開始日以降である必要があります")
+	}
+
+	if _, err := time.LoadLocation(e.Timezone); err != nil || strings.TrimSpace(e.Timezone) == "" {
+		return errors.New("無効なタイムゾーンです")
+	}
+
+	if !e.GetStatus().IsValid() {
+		return errors.New("無効な大会ステータスです")
+	}
+
+	return nil
+}
+
+// Location は大会のタイムゾーンを返す（無効な場合はUTC）
+func (e *Event) Location() *time.Location {
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StartsAt は大会の開始日の0時（大会のタイムゾーン）を返す
+func (e *Event) StartsAt() time.Time {
+	return time.Date(e.StartDate.Year(), e.StartDate.Month(), e.StartDate.Day(), 0, 0, 0, 0, e.Location())
+}
+
+// EndsAt は大会の終了日の翌日0時（大会のタイムゾーン）を返す
+func (e *Event) EndsAt() time.Time {
+	return time.Date(e.EndDate.Year(), e.EndDate.Month(), e.EndDate.Day()+1, 0, 0, 0, 0, e.Location())
+}
+
+// IsArchived は大会がアーカイブされて読み取り専用になっているかどうかを返す
+// 終了日を過ぎても結果の訂正などを行えるよう、アーカイブ済みのステータスの大会のみをアーカイブとみなす
+func (e *Event) IsArchived() bool {
+	return e.GetStatus() == EventStatusArchivedEnum
+}
+
+// HasEnded は指定した時刻に大会の終了日を過ぎているかどうかを返す
+func (e *Event) HasEnded(now time.Time) bool {
+	return !now.Before(e.EndsAt())
+}
+
+// IsOngoing は指定した時刻が大会の開催期間中かどうかを返す
+func (e *Event) IsOngoing(now time.Time) bool {
+	return !e.IsArchived() && !e.HasEnded(now) && !now.Before(e.StartsAt())
+}
+
+// ParseEventDate は大会の開催日（YYYY-MM-DD）を解析する
+func ParseEventDate(value string) (time.Time, error) {
+	date, err := time.Parse(eventDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, errors.New("日付はYYYY-MM-DD形式である必要があります")
+	}
+	return date, nil
+}
+
+// FormatEventDate は大会の開催日をYYYY-MM-DD形式で返す
+func FormatEventDate(date time.Time) string {
+	return date.Format(eventDateLayout)
+}
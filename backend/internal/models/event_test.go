@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvent_Validate(t *testing.T) {
+	valid := func() *Event {
+		return &Event{
+			Name:      "2024年度 春季スポーツ大会",
+			StartDate: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC),
+			Timezone:  DefaultEventTimezone,
+			Status:    string(EventStatusPlannedEnum),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Event)
+		wantErr bool
+	}{
+		{name: "2日間の大会", modify: func(e *Event) {}},
+		{name: "1日のみの大会", modify: func(e *Event) { e.EndDate = e.StartDate }},
+		{name: "大会名なし", modify: func(e *Event) { e.Name = " " }, wantErr: true},
+		{name: "開始日なし", modify: func(e *Event) { e.StartDate = time.Time{} }, wantErr: true},
+		{name: "終了日が開始日より前", modify: func(e *Event) { e.EndDate = e.StartDate.AddDate(0, 0, -1) }, wantErr: true},
+		{name: "タイムゾーンなし", modify: func(e *Event) { e.Timezone = "" }, wantErr: true},
+		{name: "無効なタイムゾーン", modify: func(e *Event) { e.Timezone = "Mars/Olympus" }, wantErr: true},
+		{name: "無効なステータス", modify: func(e *Event) { e.Status = "closed" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := valid()
+			tt.modify(event)
+			if err := event.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvent_IsArchived(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("Asia/Tokyo timezone is not available")
+	}
+
+	event := func(status EventStatus) *Event {
+		return &Event{
+			Name:      "秋季スポーツ大会",
+			StartDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC),
+			Timezone:  "Asia/Tokyo",
+			Status:    string(status),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		status       EventStatus
+		now          time.Time
+		wantArchived bool
+		wantEnded    bool
+		wantOngoing  bool
+	}{
+		{name: "開始前", status: EventStatusPlannedEnum, now: time.Date(2024, 9, 30, 23, 59, 0, 0, tokyo)},
+		{name: "開始日の朝", status: EventStatusOngoingEnum, now: time.Date(2024, 10, 1, 8, 0, 0, 0, tokyo), wantOngoing: true},
+		{name: "終了日の夜", status: EventStatusOngoingEnum, now: time.Date(2024, 10, 2, 23, 59, 0, 0, tokyo), wantOngoing: true},
+		// 終了日を過ぎてもアーカイブするまでは読み取り専用にしない（結果の訂正を行える）
+		{name: "終了日の翌日0時", status: EventStatusOngoingEnum, now: time.Date(2024, 10, 3, 0, 0, 0, 0, tokyo), wantEnded: true},
+		// 日本時間では終了日の翌日だがUTCではまだ終了日
+		{name: "UTCでは終了日", status: EventStatusOngoingEnum, now: time.Date(2024, 10, 2, 16, 0, 0, 0, time.UTC), wantEnded: true},
+		{name: "アーカイブ済みのステータス", status: EventStatusArchivedEnum, now: time.Date(2024, 10, 1, 8, 0, 0, 0, tokyo), wantArchived: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event(tt.status)
+			if got := e.IsArchived(); got != tt.wantArchived {
+				t.Errorf("IsArchived() = %v, want %v", got, tt.wantArchived)
+			}
+			if got := e.HasEnded(tt.now); got != tt.wantEnded {
+				t.Errorf("HasEnded() = %v, want %v", got, tt.wantEnded)
+			}
+			if got := e.IsOngoing(tt.now); got != tt.wantOngoing {
+				t.Errorf("IsOngoing() = %v, want %v", got, tt.wantOngoing)
+			}
+		})
+	}
+}
+
+func TestParseEventDate(t *testing.T) {
+	date, err := ParseEventDate(" 2024-05-20 ")
+	if err != nil {
+		t.Fatalf("ParseEventDate() error = %v", err)
+	}
+	if got := FormatEventDate(date); got != "2024-05-20" {
+		t.Errorf("FormatEventDate() = %s, want 2024-05-20", got)
+	}
+
+	for _, value := range []string{"", "2024/05/20", "2024-13-01"} {
+		if _, err := ParseEventDate(value); err == nil {
+			t.Errorf("ParseEventDate(%q) should fail", value)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// TeamWithdrawn は両チーム棄権や棄権・失格したチームの敗者の枠など、進出するチームがいない枠を表すプレースホルダー
+// この枠と対戦するチームは不戦勝となる
+const TeamWithdrawn = "WITHDRAWN"
+
+// forfeitGoals は得点制の試合で不戦勝のチームに与える得点（種目で指定がない場合）
+const forfeitGoals = 3
+
+// DefaultForfeitScore はスポーツごとの不戦勝・棄権・失格時の既定のスコアを勝者側から見た値で返す
+// セット制の試合は勝利に必要なセット数を各セットの必要な得点対0で、それ以外の試合は種目の得点（既定は3）対0とする
+func DefaultForfeitScore(sport SportType) (won, lost int, sets []SetScore) {
+	rules, ok := SetRulesForSport(sport)
+	if !ok {
+		if registered, found := LookupSport(sport); found && registered.ForfeitScore > 0 {
+			return registered.ForfeitScore, 0, nil
+		}
+		return forfeitGoals, 0, nil
+	}
+
+	for i := 0; i < rules.SetsToWin(); i++ {
+		sets = append(sets, SetScore{SetNumber: i + 1, Score1: rules.pointsForSet(i + 1), Score2: 0})
+	}
+	return rules.SetsToWin(), 0, sets
+}
+
+// NewForfeitResult は試合を行わずに決着した結果をスポーツの既定のスコアで作成する
+// forfeitingTeam は不出場・棄権・失格となったチームで、対戦相手を勝者とする。
+// 両チーム棄権の場合は0対0で勝者なしとする
+func NewForfeitResult(sport SportType, resultType ResultType, team1, team2, forfeitingTeam string) (MatchResult, error) {
+	if !resultType.IsValid() || !resultType.IsForfeit() {
+		return MatchResult{}, errors.New("無効な結果の種類です")
+	}
+
+	if resultType == ResultTypeDoubleForfeitEnum {
+		return MatchResult{ResultType: resultType}, nil
+	}
+
+	forfeitingTeam = strings.TrimSpace(forfeitingTeam)
+	if forfeitingTeam != team1 && forfeitingTeam != team2 {
+		return MatchResult{}, errors.New("棄権・不出場・失格のチームは参加チームのいずれかである必要があります")
+	}
+
+	won, lost, sets := DefaultForfeitScore(sport)
+	result := MatchResult{
+		Score1:         won,
+		Score2:         lost,
+		Sets:           sets,
+		ResultType:     resultType,
+		ForfeitingTeam: forfeitingTeam,
+		Winner:         team1,
+	}
+
+	// チーム1が棄権した場合はスコアを入れ替える
+	if forfeitingTeam == team1 {
+		result.Score1, result.Score2 = lost, won
+		result.Winner = team2
+		for i := range result.Sets {
+			result.Sets[i].Score1, result.Sets[i].Score2 = result.Sets[i].Score2, result.Sets[i].Score1
+		}
+	}
+
+	return result, nil
+}
+
+// IsForfeit は試合を行わずに決着した結果かどうかを返す
+func (mr *MatchResult) IsForfeit() bool {
+	return mr.ResultType.IsForfeit()
+}
+
+// IsDoubleForfeit は両チーム棄権の結果かどうかを返す
+func (mr *MatchResult) IsDoubleForfeit() bool {
+	return mr.ResultType == ResultTypeDoubleForfeitEnum
+}
+
+// validateForfeit は試合を行わずに決着した結果を検証する
+// 勝者は棄権・不出場・失格となったチームの対戦相手で、延長戦・PK戦は記録できない
+func (mr *MatchResult) validateForfeit(team1, team2 string) error {
+	if !mr.ResultType.IsValid() {
+		return errors.New("無効な結果の種類です")
+	}
+
+	if mr.Score1 < 0 || mr.Score2 < 0 {
+		return errors.New("スコアは0以上である必要があります")
+	}
+
+	if mr.HasExtraTime() || mr.HasPenalties() {
+		return errors.New("棄権・不出場・失格の試合では延長戦・PK戦は記録できません")
+	}
+
+	if mr.IsDoubleForfeit() {
+		if strings.TrimSpace(mr.Winner) != "" {
+			return errors.New("両チーム棄権の場合は勝者を指定できません")
+		}
+		if mr.Score1 != 0 || mr.Score2 != 0 || len(mr.Sets) > 0 {
+			return errors.New("両チーム棄権の場合はスコアを記録できません")
+		}
+		return nil
+	}
+
+	var opponent string
+	switch mr.ForfeitingTeam {
+	case team1:
+		opponent = team2
+	case team2:
+		opponent = team1
+	default:
+		return errors.New("棄権・不出場・失格のチームは参加チームのいずれかである必要があります")
+	}
+
+	if mr.Winner != opponent {
+		return errors.New("勝者は棄権・不出場・失格となったチームの対戦相手である必要があります")
+	}
+
+	if (opponent == team1 && mr.Score1 <= mr.Score2) || (opponent == team2 && mr.Score2 <= mr.Score1) {
+		return errors.New("スコアと勝者が一致しません")
+	}
+
+	return nil
+}
+
+// GetResultType は試合結果の種類を返す（未設定の結果は試合を行ったものとみなす）
+func (m *Match) GetResultType() ResultType {
+	if m.ResultType != nil && *m.ResultType != "" {
+		return ResultType(*m.ResultType)
+	}
+	if !m.HasResult() {
+		return ""
+	}
+	return ResultTypePlayedEnum
+}
+
+// IsForfeit は試合を行わずに決着したかどうかを返す
+func (m *Match) IsForfeit() bool {
+	return m.GetResultType().IsForfeit()
+}
+
+// IsDoubleForfeit は両チーム棄権で完了したかどうかを返す
+func (m *Match) IsDoubleForfeit() bool {
+	return m.GetResultType() == ResultTypeDoubleForfeitEnum
+}
+
+// HasForfeited は指定した枠のチームが棄権・不出場・失格となったかどうかを返す（両チーム棄権を含む）
+func (m *Match) HasForfeited(slot int) bool {
+	if m.IsDoubleForfeit() {
+		return slot == SlotTeam1 || slot == SlotTeam2
+	}
+	return slot != 0 && m.ForfeitingSlot() == slot
+}
+
+// AdvancingTeams は進出先の枠に入るチーム（勝者・敗者）を返す
+// 両チーム棄権の場合はどちらの枠にも TeamWithdrawn が入る。棄権・失格したチームは
+// 以降の試合（3位決定戦・敗者側）に出場しないため、敗者の枠に TeamWithdrawn が入り、
+// その試合の相手は不戦勝となる
+// 勝者・敗者は枠のチーム（登録チームはID）で返す
+func (m *Match) AdvancingTeams() (winner, loser TeamRef) {
+	withdrawn := TeamRef{Name: TeamWithdrawn}
+	if m.IsDoubleForfeit() {
+		return withdrawn, withdrawn
+	}
+	slot := m.WinnerSlot()
+	if slot == 0 {
+		return TeamRef{}, TeamRef{}
+	}
+	switch m.GetResultType() {
+	case ResultTypeForfeitEnum, ResultTypeDisqualificationEnum:
+		return m.TeamInSlot(slot), withdrawn
+	}
+	return m.TeamInSlot(slot), m.TeamInSlot(otherSlot(slot))
+}
+
+// WithdrawnResult は TeamWithdrawn の枠がある未実施の試合の結果を返す
+// 対戦相手が確定している場合は不戦勝、両方の枠が TeamWithdrawn の場合は両チーム棄権とする
+func (m *Match) WithdrawnResult(sport SportType) (MatchResult, bool) {
+	if m.IsCompleted() || (m.Team1 != TeamWithdrawn && m.Team2 != TeamWithdrawn) {
+		return MatchResult{}, false
+	}
+	if m.Team1 == TeamWithdrawn && m.Team2 == TeamWithdrawn {
+		return MatchResult{ResultType: ResultTypeDoubleForfeitEnum}, true
+	}
+	if IsUndecidedTeam(m.Team1) || IsUndecidedTeam(m.Team2) {
+		return MatchResult{}, false
+	}
+
+	result, err := NewForfeitResult(sport, ResultTypeWalkoverEnum, m.Team1, m.Team2, TeamWithdrawn)
+	if err != nil {
+		return MatchResult{}, false
+	}
+	return result, true
+}
@@ -0,0 +1,235 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestDefaultForfeitScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		sport    SportType
+		wantWon  int
+		wantSets []SetScore
+	}{
+		{name: "サッカー", sport: SportTypeSoccer, wantWon: 3},
+		{name: "バレーボール", sport: SportTypeVolleyball, wantWon: 2, wantSets: []SetScore{{SetNumber: 1, Score1: 25}, {SetNumber: 2, Score1: 25}}},
+		{name: "卓球", sport: SportTypeTableTennis, wantWon: 3, wantSets: []SetScore{{SetNumber: 1, Score1: 11}, {SetNumber: 2, Score1: 11}, {SetNumber: 3, Score1: 11}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			won, lost, sets := DefaultForfeitScore(tt.sport)
+			if won != tt.wantWon || lost != 0 {
+				t.Errorf("DefaultForfeitScore() = %d-%d, want %d-0", won, lost, tt.wantWon)
+			}
+			if len(sets) != len(tt.wantSets) {
+				t.Fatalf("DefaultForfeitScore() sets = %+v, want %+v", sets, tt.wantSets)
+			}
+			for i := range sets {
+				if sets[i] != tt.wantSets[i] {
+					t.Errorf("set %d = %+v, want %+v", i+1, sets[i], tt.wantSets[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewForfeitResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		sport      SportType
+		resultType ResultType
+		forfeiting string
+		wantScore  [2]int
+		wantWinner string
+		wantErr    bool
+	}{
+		{name: "チーム2の不出場", sport: SportTypeSoccer, resultType: ResultTypeWalkoverEnum, forfeiting: "IS4", wantScore: [2]int{3, 0}, wantWinner: "IE4"},
+		{name: "チーム1の棄権はスコアを入れ替える", sport: SportTypeVolleyball, resultType: ResultTypeForfeitEnum, forfeiting: "IE4", wantScore: [2]int{0, 2}, wantWinner: "IS4"},
+		{name: "失格", sport: SportTypeTableTennis, resultType: ResultTypeDisqualificationEnum, forfeiting: "IS4", wantScore: [2]int{3, 0}, wantWinner: "IE4"},
+		{name: "両チーム棄権", sport: SportTypeSoccer, resultType: ResultTypeDoubleForfeitEnum},
+		{name: "参加していないチーム", sport: SportTypeSoccer, resultType: ResultTypeForfeitEnum, forfeiting: "IT4", wantErr: true},
+		{name: "試合を行った結果", sport: SportTypeSoccer, resultType: ResultTypePlayedEnum, forfeiting: "IS4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewForfeitResult(tt.sport, tt.resultType, "IE4", "IS4", tt.forfeiting)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewForfeitResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Score1 != tt.wantScore[0] || result.Score2 != tt.wantScore[1] || result.Winner != tt.wantWinner {
+				t.Errorf("NewForfeitResult() = %d-%d winner %q, want %d-%d winner %q",
+					result.Score1, result.Score2, result.Winner, tt.wantScore[0], tt.wantScore[1], tt.wantWinner)
+			}
+			if err := result.ValidateForRound(RoundSemifinalEnum, "IE4", "IS4"); err != nil {
+				t.Errorf("ValidateForRound() error = %v", err)
+			}
+			if err := result.ValidateSetsForSport(tt.sport); len(result.Sets) > 0 && err != nil {
+				t.Errorf("ValidateSetsForSport() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestMatchResult_ValidateForfeit(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  MatchResult
+		wantErr bool
+	}{
+		{name: "不戦勝", result: MatchResult{Score1: 3, ResultType: ResultTypeWalkoverEnum, ForfeitingTeam: "IS4", Winner: "IE4"}},
+		{name: "勝者が棄権したチーム", result: MatchResult{Score1: 3, ResultType: ResultTypeForfeitEnum, ForfeitingTeam: "IS4", Winner: "IS4"}, wantErr: true},
+		{name: "スコアと勝者が一致しない", result: MatchResult{Score2: 3, ResultType: ResultTypeForfeitEnum, ForfeitingTeam: "IS4", Winner: "IE4"}, wantErr: true},
+		{name: "棄権したチームがない", result: MatchResult{Score1: 3, ResultType: ResultTypeForfeitEnum, Winner: "IE4"}, wantErr: true},
+		{name: "両チーム棄権に勝者", result: MatchResult{ResultType: ResultTypeDoubleForfeitEnum, Winner: "IE4"}, wantErr: true},
+		{name: "無効な結果の種類", result: MatchResult{Score1: 3, ResultType: "abandoned", ForfeitingTeam: "IS4", Winner: "IE4"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.result.ValidateForRound(RoundFinalEnum, "IE4", "IS4"); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateForRound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatch_ApplyForfeitResult(t *testing.T) {
+	result, err := NewForfeitResult(SportTypeSoccer, ResultTypeWalkoverEnum, "IE4", "IS4", "IE4")
+	if err != nil {
+		t.Fatalf("NewForfeitResult() error = %v", err)
+	}
+
+	match := newPendingMatch(1, RoundSemifinalEnum, 0, "IE4", "IS4")
+	match.ApplyResult(result)
+	match.Status = string(MatchStatusCompletedEnum)
+
+	if !match.IsForfeit() || !match.HasForfeited(SlotTeam1) || match.HasForfeited(SlotTeam2) {
+		t.Errorf("IsForfeit() = %v, HasForfeited() = %v/%v", match.IsForfeit(), match.HasForfeited(SlotTeam1), match.HasForfeited(SlotTeam2))
+	}
+	if match.GetDecisionMethod() != "" {
+		t.Errorf("GetDecisionMethod() = %q, want empty", match.GetDecisionMethod())
+	}
+	if got, want := match.ScoreDisplay(), "0-3 (不戦勝)"; got != want {
+		t.Errorf("ScoreDisplay() = %q, want %q", got, want)
+	}
+	if winner, loser := match.AdvancingTeams(); winner.Name != "IS4" || loser.Name != "IE4" {
+		t.Errorf("AdvancingTeams() = %q, %q", winner.Name, loser.Name)
+	}
+
+	double := newPendingMatch(1, RoundSemifinalEnum, 1, "IT4", "IC4")
+	double.ApplyResult(MatchResult{ResultType: ResultTypeDoubleForfeitEnum})
+	double.Status = string(MatchStatusCompletedEnum)
+
+	if double.IsDraw() || double.Winner != nil {
+		t.Errorf("double forfeit: IsDraw() = %v, Winner = %v", double.IsDraw(), double.Winner)
+	}
+	if winner, loser := double.AdvancingTeams(); winner.Name != TeamWithdrawn || loser.Name != TeamWithdrawn {
+		t.Errorf("double forfeit AdvancingTeams() = %q, %q", winner.Name, loser.Name)
+	}
+}
+
+func TestMatch_AdvancingTeams(t *testing.T) {
+	tests := []struct {
+		name       string
+		resultType ResultType
+		forfeiting string
+		wantWinner string
+		wantLoser  string
+	}{
+		{name: "試合を行って決着", wantWinner: "IE4", wantLoser: "IS4"},
+		{name: "不出場による不戦勝", resultType: ResultTypeWalkoverEnum, forfeiting: "IS4", wantWinner: "IE4", wantLoser: "IS4"},
+		{name: "棄権したチームは以降の試合に出場しない", resultType: ResultTypeForfeitEnum, forfeiting: "IS4", wantWinner: "IE4", wantLoser: TeamWithdrawn},
+		{name: "失格したチームは以降の試合に出場しない", resultType: ResultTypeDisqualificationEnum, forfeiting: "IE4", wantWinner: "IS4", wantLoser: TeamWithdrawn},
+		{name: "両チーム棄権", resultType: ResultTypeDoubleForfeitEnum, wantWinner: TeamWithdrawn, wantLoser: TeamWithdrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MatchResult{Score1: 2, Score2: 1, Winner: "IE4"}
+			if tt.resultType != "" {
+				var err error
+				if result, err = NewForfeitResult(SportTypeSoccer, tt.resultType, "IE4", "IS4", tt.forfeiting); err != nil {
+					t.Fatalf("NewForfeitResult() error = %v", err)
+				}
+			}
+			match := newPendingMatch(1, RoundSemifinalEnum, 0, "IE4", "IS4")
+			match.ApplyResult(result)
+			match.Status = string(MatchStatusCompletedEnum)
+
+			if winner, loser := match.AdvancingTeams(); winner.Name != tt.wantWinner || loser.Name != tt.wantLoser {
+				t.Errorf("AdvancingTeams() = %q, %q, want %q, %q", winner.Name, loser.Name, tt.wantWinner, tt.wantLoser)
+			}
+		})
+	}
+}
+
+func TestMatch_WithdrawnResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		team1      string
+		team2      string
+		wantOK     bool
+		wantType   ResultType
+		wantWinner string
+	}{
+		{name: "対戦相手の不戦勝", team1: "IE4", team2: TeamWithdrawn, wantOK: true, wantType: ResultTypeWalkoverEnum, wantWinner: "IE4"},
+		{name: "両方の枠が棄権", team1: TeamWithdrawn, team2: TeamWithdrawn, wantOK: true, wantType: ResultTypeDoubleForfeitEnum},
+		{name: "対戦相手が未確定", team1: TeamWithdrawn, team2: TeamTBD},
+		{name: "棄権の枠がない", team1: "IE4", team2: "IS4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := newPendingMatch(1, RoundFinalEnum, 0, tt.team1, tt.team2)
+			result, ok := match.WithdrawnResult(SportTypeSoccer)
+			if ok != tt.wantOK {
+				t.Fatalf("WithdrawnResult() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (result.ResultType != tt.wantType || result.Winner != tt.wantWinner) {
+				t.Errorf("WithdrawnResult() = %s winner %q, want %s winner %q", result.ResultType, result.Winner, tt.wantType, tt.wantWinner)
+			}
+		})
+	}
+}
+
+func TestComputeStandings_Forfeits(t *testing.T) {
+	played := newPendingMatch(1, RoundLeagueEnum, 0, "IE4", "IS4")
+	played.ApplyResult(MatchResult{Score1: 1, Score2: 1})
+	played.Status = string(MatchStatusCompletedEnum)
+
+	walkover := newPendingMatch(1, RoundLeagueEnum, 1, "IE4", "IT4")
+	result, _ := NewForfeitResult(SportTypeSoccer, ResultTypeForfeitEnum, "IE4", "IT4", "IT4")
+	walkover.ApplyResult(result)
+	walkover.Status = string(MatchStatusCompletedEnum)
+
+	double := newPendingMatch(1, RoundLeagueEnum, 2, "IS4", "IT4")
+	double.ApplyResult(MatchResult{ResultType: ResultTypeDoubleForfeitEnum})
+	double.Status = string(MatchStatusCompletedEnum)
+
+	table := ComputeStandings([]*Match{played, walkover, double}, DefaultLeagueRules())
+	byTeam := make(map[string]Standing, len(table))
+	for _, s := range table {
+		byTeam[s.Team] = s
+	}
+
+	tests := []struct {
+		team                               string
+		won, drawn, lost, forfeits, points int
+	}{
+		{team: "IE4", won: 1, drawn: 1, points: 4},
+		{team: "IS4", drawn: 1, lost: 1, forfeits: 1, points: 1},
+		{team: "IT4", lost: 2, forfeits: 2},
+	}
+	for _, tt := range tests {
+		s := byTeam[tt.team]
+		if s.Won != tt.won || s.Drawn != tt.drawn || s.Lost != tt.lost || s.Forfeits != tt.forfeits || s.Points != tt.points {
+			t.Errorf("%s: got W%d D%d L%d F%d %dpts, want W%d D%d L%d F%d %dpts", tt.team,
+				s.Won, s.Drawn, s.Lost, s.Forfeits, s.Points, tt.won, tt.drawn, tt.lost, tt.forfeits, tt.points)
+		}
+	}
+}
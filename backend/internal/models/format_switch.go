@@ -0,0 +1,187 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FormatSwitchAction は形式の切り替えで試合に行う変更
+type FormatSwitchAction string
+
+const (
+	FormatSwitchKeep   FormatSwitchAction = "keep"   // 残す（結果もそのまま残す）
+	FormatSwitchCancel FormatSwitchAction = "cancel" // 中止する
+	FormatSwitchCreate FormatSwitchAction = "create" // 新しく作成する
+)
+
+// FormatSwitchMatch は形式の切り替えでの試合の扱い
+type FormatSwitchMatch struct {
+	MatchID  int                `json:"match_id" example:"12"` // 作成する試合は反映するまで0
+	Action   FormatSwitchAction `json:"action" example:"create"`
+	Round    string             `json:"round" example:"loser_bracket"`
+	Position int                `json:"position" example:"0"`
+	Team1    string             `json:"team1" example:"IE4"`
+	Team2    string             `json:"team2" example:"TBD"`
+	Status   string             `json:"status" example:"pending"` // 切り替え後のステータス
+	Reason   string             `json:"reason,omitempty" example:"1回戦の敗者による敗者復活戦"`
+}
+
+// FormatSwitch は試合の作成後にトーナメント形式を切り替える計画
+// 終了した試合の結果は全て残し、新しい形式にない試合のうち終了していない試合のみ中止する
+type FormatSwitch struct {
+	TournamentID int                 `json:"tournament_id"`
+	From         TournamentFormat    `json:"from"`
+	To           TournamentFormat    `json:"to"`
+	Applied      bool                `json:"applied"`   // 反映したかどうか（プレビューの場合はfalse）
+	Kept         []FormatSwitchMatch `json:"kept"`      // 残す試合
+	Cancelled    []FormatSwitchMatch `json:"cancelled"` // 中止する試合
+	Created      []FormatSwitchMatch `json:"created"`   // 作成する試合（敗者復活戦など）
+
+	layout  []*Match // 切り替え後のブラケットの試合（作成する試合を含み、進出先を導出し直す）
+	retired []*Match // ブラケットから外す試合（中止する試合と、新しい形式にないラウンドの終了した試合）
+	created []*Match // 作成する試合
+}
+
+// Layout は切り替え後のブラケットの試合を返す（作成する試合はIDが0）
+func (p *FormatSwitch) Layout() []*Match {
+	return p.layout
+}
+
+// Retired はブラケットから外す試合を返す（進出先は解除済み）
+func (p *FormatSwitch) Retired() []*Match {
+	return p.retired
+}
+
+// NewMatches は作成する試合を返す
+func (p *FormatSwitch) NewMatches() []*Match {
+	return p.created
+}
+
+// MarkApplied は計画を反映したことを記録し、作成した試合のIDを計画に反映する
+func (p *FormatSwitch) MarkApplied() {
+	p.Applied = true
+	for i, match := range p.created {
+		p.Created[i].MatchID = match.ID
+	}
+}
+
+// PlanFormatSwitch はトーナメントの試合を新しい形式に合わせる計画を作成する
+//
+// 試合の作成前は形式を変えるだけでよい。試合の作成後は標準と雨天時形式の間でのみ切り替えられる。
+//   - 雨天時形式へ: 1回戦の敗者による敗者復活戦を作成する。1回戦が既に終わった試合の敗者は作成する試合に配置し、
+//     以前の切り替えで残した敗者復活戦の試合はそのまま使う
+//   - 雨天時形式から: 終了していない敗者復活戦は中止し、終了した敗者復活戦は結果を残したままブラケットから外す
+//
+// 勝者側の試合は変更せず、切り替え後のブラケットの進出先は反映時に導出し直す
+func PlanFormatSwitch(t *Tournament, to TournamentFormat, matches []*Match) (*FormatSwitch, error) {
+	from := t.GetFormat()
+	if !to.IsValid() {
+		return nil, fmt.Errorf("無効なトーナメント形式です: %s", to)
+	}
+	if to == from {
+		return nil, errors.New("既に指定された形式です")
+	}
+
+	plan := &FormatSwitch{
+		TournamentID: t.ID,
+		From:         from,
+		To:           to,
+		Kept:         []FormatSwitchMatch{},
+		Cancelled:    []FormatSwitchMatch{},
+		Created:      []FormatSwitchMatch{},
+	}
+
+	var active []*Match
+	for _, match := range matches {
+		if !match.IsCancelled() {
+			active = append(active, match)
+		}
+	}
+	if len(active) == 0 {
+		return plan, nil
+	}
+	if !from.CanMigrateMatchesTo(to) {
+		return nil, fmt.Errorf("試合の作成後は標準と雨天時形式の間でのみ切り替えられます: %s → %s", from, to)
+	}
+
+	byRound := groupMatchesByRound(active)
+	var consolation []plannedMatch
+	if to == TournamentFormatRainy {
+		winnerRounds := presentKnockoutRounds(byRound)
+		consolation = planConsolation(winnerRounds, findFirstRoundByes(byRound, winnerRounds))
+	}
+	planned := make(map[int]bool, len(consolation))
+	for _, p := range consolation {
+		planned[p.position] = true
+	}
+
+	for _, match := range active {
+		if match.GetRound() != RoundLoserBracketEnum || planned[match.Position] {
+			plan.layout = append(plan.layout, match)
+			plan.Kept = append(plan.Kept, newFormatSwitchMatch(match, FormatSwitchKeep, ""))
+			continue
+		}
+
+		retired := *match
+		retired.SetProgression(BracketProgression{})
+		if match.IsCompleted() {
+			plan.retired = append(plan.retired, &retired)
+			plan.Kept = append(plan.Kept, newFormatSwitchMatch(&retired, FormatSwitchKeep, "終了した敗者復活戦の結果を残す"))
+			continue
+		}
+		if err := retired.TransitionTo(MatchStatusCancelledEnum); err != nil {
+			return nil, err
+		}
+		plan.retired = append(plan.retired, &retired)
+		plan.Cancelled = append(plan.Cancelled, newFormatSwitchMatch(&retired, FormatSwitchCancel, "敗者復活戦を行わない形式のため中止する"))
+	}
+
+	// 残す試合で済まない敗者復活戦を作成し、終わった試合から進出するチームを配置する
+	kept := groupMatchesByRound(plan.layout)
+	for _, p := range consolation {
+		if lookupPlanned(kept, p.round, p.position) != nil {
+			continue
+		}
+		match := newPendingMatch(t.ID, p.round, p.position, TeamTBD, TeamTBD)
+		for i, source := range p.sources {
+			feeder := lookupPlanned(kept, source.round, source.position)
+			if feeder == nil || !feeder.IsCompleted() {
+				continue
+			}
+			winner, loser := feeder.AdvancingTeams()
+			team := winner
+			if source.loser {
+				team = loser
+			}
+			if team.Name == "" {
+				continue
+			}
+			slot := SlotTeam1
+			if i > 0 {
+				slot = SlotTeam2
+			}
+			if err := match.SetTeamInSlot(slot, team); err != nil {
+				return nil, err
+			}
+		}
+		plan.created = append(plan.created, match)
+		plan.layout = append(plan.layout, match)
+		plan.Created = append(plan.Created, newFormatSwitchMatch(match, FormatSwitchCreate, "1回戦の敗者による敗者復活戦"))
+	}
+
+	return plan, nil
+}
+
+// newFormatSwitchMatch は形式の切り替えでの試合の扱いを作成する
+func newFormatSwitchMatch(match *Match, action FormatSwitchAction, reason string) FormatSwitchMatch {
+	return FormatSwitchMatch{
+		MatchID:  match.ID,
+		Action:   action,
+		Round:    match.Round,
+		Position: match.Position,
+		Team1:    match.Team1,
+		Team2:    match.Team2,
+		Status:   match.Status,
+		Reason:   reason,
+	}
+}
@@ -0,0 +1,235 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestNewRainyMatches(t *testing.T) {
+	matches := newTestBracket(t, TournamentFormatRainy)
+
+	loserBracket := groupMatchesByRound(matches)[RoundLoserBracketEnum]
+	if len(loserBracket) != 3 {
+		t.Fatalf("loser bracket has %d matches, want 3", len(loserBracket))
+	}
+
+	// 準々決勝の敗者は隣り合う試合の敗者と対戦し、その勝者同士が敗者復活戦の決勝を行う
+	for position, want := range []BracketSlot{{MatchID: loserBracket[0].ID, Slot: 1}, {MatchID: loserBracket[0].ID, Slot: 2}, {MatchID: loserBracket[1].ID, Slot: 1}, {MatchID: loserBracket[1].ID, Slot: 2}} {
+		loser := findTestMatch(t, matches, RoundQuarterfinalEnum, position).GetProgression().Loser
+		if loser == nil || *loser != want {
+			t.Errorf("quarterfinal %d loser goes to %v, want %v", position, loser, want)
+		}
+	}
+	if winner := loserBracket[0].GetProgression().Winner; winner == nil || winner.MatchID != loserBracket[2].ID {
+		t.Errorf("first consolation winner goes to %v, want match %d", winner, loserBracket[2].ID)
+	}
+
+	// 準決勝の敗者は3位決定戦へ進み、敗者復活戦には進まない
+	thirdPlace := groupMatchesByRound(matches)[RoundThirdPlaceEnum][0]
+	if loser := findTestMatch(t, matches, RoundSemifinalEnum, 0).GetProgression().Loser; loser == nil || loser.MatchID != thirdPlace.ID {
+		t.Errorf("semifinal loser goes to %v, want third place match %d", loser, thirdPlace.ID)
+	}
+}
+
+func TestNewRainyMatches_SmallBracket(t *testing.T) {
+	// 1回戦が準決勝の場合は敗者復活戦を行わない
+	matches, err := NewRainyMatches(1, [][2]string{{"IE1", "IE2"}, {"IS1", "IS2"}})
+	if err != nil {
+		t.Fatalf("NewRainyMatches() error = %v", err)
+	}
+	if n := len(groupMatchesByRound(matches)[RoundLoserBracketEnum]); n != 0 {
+		t.Errorf("loser bracket has %d matches, want 0", n)
+	}
+}
+
+func TestPlanFormatSwitch_ToRainy(t *testing.T) {
+	matches := newTestBracket(t, TournamentFormatStandard)
+	// 準々決勝の最初の2試合は終わっている
+	completeTestMatch(findTestMatch(t, matches, RoundQuarterfinalEnum, 0), 3, 1, "IE1")
+	completeTestMatch(findTestMatch(t, matches, RoundQuarterfinalEnum, 1), 0, 3, "IS2")
+
+	tournament := &Tournament{ID: 1, Sport: string(SportTypeTableTennis), Format: string(TournamentFormatStandard), Status: string(TournamentStatusActiveEnum)}
+	plan, err := PlanFormatSwitch(tournament, TournamentFormatRainy, matches)
+	if err != nil {
+		t.Fatalf("PlanFormatSwitch() error = %v", err)
+	}
+
+	if len(plan.Kept) != len(matches) || len(plan.Cancelled) != 0 || len(plan.Created) != 3 {
+		t.Fatalf("plan kept %d, cancelled %d, created %d, want %d, 0, 3", len(plan.Kept), len(plan.Cancelled), len(plan.Created), len(matches))
+	}
+	// 終わった試合の敗者は作成する敗者復活戦に配置する
+	first := plan.Created[0]
+	if first.Team1 != "IE2" || first.Team2 != "IS1" {
+		t.Errorf("first consolation match = %s vs %s, want IE2 vs IS1", first.Team1, first.Team2)
+	}
+	if second := plan.Created[1]; second.Team1 != TeamTBD || second.Team2 != TeamTBD {
+		t.Errorf("second consolation match = %s vs %s, want TBD vs TBD", second.Team1, second.Team2)
+	}
+	if len(plan.Layout()) != len(matches)+3 || len(plan.Retired()) != 0 {
+		t.Errorf("layout %d, retired %d, want %d, 0", len(plan.Layout()), len(plan.Retired()), len(matches)+3)
+	}
+
+	// 反映後の進出先では、終わっていない準々決勝の敗者が作成した試合に進む
+	for i, match := range plan.NewMatches() {
+		match.ID = 100 + i
+	}
+	LinkBracket(plan.Layout())
+	plan.MarkApplied()
+	if loser := findTestMatch(t, plan.Layout(), RoundQuarterfinalEnum, 3).GetProgression().Loser; loser == nil || loser.MatchID != 101 {
+		t.Errorf("quarterfinal 3 loser goes to %v, want match 101", loser)
+	}
+	if !plan.Applied || plan.Created[2].MatchID != 102 {
+		t.Errorf("applied plan = %v, created IDs %d, want true, 102", plan.Applied, plan.Created[2].MatchID)
+	}
+}
+
+func TestPlanFormatSwitch_FromRainy(t *testing.T) {
+	matches := newTestBracket(t, TournamentFormatRainy)
+	for position, winner := range []string{"IE1", "IS1", "IT1", "IC1"} {
+		completeTestMatch(findTestMatch(t, matches, RoundQuarterfinalEnum, position), 3, 0, winner)
+	}
+	played := findTestMatch(t, matches, RoundLoserBracketEnum, 0)
+	played.Team1, played.Team2 = "IE2", "IS2"
+	completeTestMatch(played, 3, 2, "IE2")
+	playing := findTestMatch(t, matches, RoundLoserBracketEnum, 1)
+	playing.Team1, playing.Team2 = "IT2", "IC2"
+	playing.SetStatus(MatchStatusInProgressEnum)
+
+	tournament := &Tournament{ID: 1, Sport: string(SportTypeTableTennis), Format: string(TournamentFormatRainy), Status: string(TournamentStatusActiveEnum)}
+	plan, err := PlanFormatSwitch(tournament, TournamentFormatStandard, matches)
+	if err != nil {
+		t.Fatalf("PlanFormatSwitch() error = %v", err)
+	}
+
+	if len(plan.Cancelled) != 2 || len(plan.Created) != 0 {
+		t.Fatalf("plan cancelled %d, created %d, want 2, 0", len(plan.Cancelled), len(plan.Created))
+	}
+	// 終了した敗者復活戦は結果を残したままブラケットから外す
+	if len(plan.Retired()) != 3 {
+		t.Fatalf("retired %d matches, want 3", len(plan.Retired()))
+	}
+	for _, match := range plan.Retired() {
+		if match.GetProgression() != (BracketProgression{}) {
+			t.Errorf("retired match %d still has destinations", match.ID)
+		}
+		switch match.ID {
+		case played.ID:
+			if !match.IsCompleted() || match.Winner == nil || *match.Winner != "IE2" {
+				t.Errorf("completed consolation match lost its result: %s", match.Status)
+			}
+		default:
+			if !match.IsCancelled() {
+				t.Errorf("match %d status = %s, want cancelled", match.ID, match.Status)
+			}
+		}
+	}
+	if !playing.IsInProgress() {
+		t.Errorf("planning changed the stored match to %s", playing.Status)
+	}
+	for _, match := range plan.Layout() {
+		if match.GetRound() == RoundLoserBracketEnum {
+			t.Errorf("layout still contains consolation match %d", match.ID)
+		}
+	}
+
+	// 標準形式に戻した後に再び雨天時形式にすると、終了した敗者復活戦はそのまま使う
+	var again []*Match
+	retired := make(map[int]*Match)
+	for _, match := range plan.Retired() {
+		retired[match.ID] = match
+	}
+	for _, match := range matches {
+		if r, ok := retired[match.ID]; ok {
+			match = r
+		}
+		again = append(again, match)
+	}
+	tournament.Format = string(TournamentFormatStandard)
+	replan, err := PlanFormatSwitch(tournament, TournamentFormatRainy, again)
+	if err != nil {
+		t.Fatalf("PlanFormatSwitch() error = %v", err)
+	}
+	if len(replan.Created) != 2 {
+		t.Fatalf("replan created %d matches, want 2", len(replan.Created))
+	}
+	if created := replan.Created[0]; created.Position != 1 || created.Team1 != "IT2" || created.Team2 != "IC2" {
+		t.Errorf("recreated consolation match = %d: %s vs %s, want 1: IT2 vs IC2", created.Position, created.Team1, created.Team2)
+	}
+	if created := replan.Created[1]; created.Position != 2 || created.Team1 != "IE2" || created.Team2 != TeamTBD {
+		t.Errorf("recreated consolation final = %d: %s vs %s, want 2: IE2 vs TBD", created.Position, created.Team1, created.Team2)
+	}
+}
+
+func TestPlanFormatSwitch_Errors(t *testing.T) {
+	matches := newTestBracket(t, TournamentFormatStandard)
+	tournament := &Tournament{ID: 1, Sport: string(SportTypeTableTennis), Format: string(TournamentFormatStandard), Status: string(TournamentStatusActiveEnum)}
+
+	tests := []struct {
+		name    string
+		to      TournamentFormat
+		matches []*Match
+		wantErr bool
+	}{
+		{name: "試合の作成前は構造が異なる形式にも切り替えられる", to: TournamentFormatDoubleElimination},
+		{name: "試合の作成後は構造が異なる形式に切り替えられない", to: TournamentFormatDoubleElimination, matches: matches, wantErr: true},
+		{name: "試合の作成後は総当たり戦に切り替えられない", to: TournamentFormatRoundRobin, matches: matches, wantErr: true},
+		{name: "試合の作成後はスイス式に切り替えられない", to: TournamentFormatSwiss, matches: matches, wantErr: true},
+		{name: "同じ形式", to: TournamentFormatStandard, matches: matches, wantErr: true},
+		{name: "無効な形式", to: TournamentFormat("indoor"), matches: matches, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanFormatSwitch(tournament, tt.to, tt.matches)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanFormatSwitch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (len(plan.Kept) != 0 || len(plan.Cancelled) != 0 || len(plan.Created) != 0) {
+				t.Errorf("plan without matches changes matches: %+v", plan)
+			}
+		})
+	}
+}
+
+func TestTournamentFormat_CanMigrateMatchesTo(t *testing.T) {
+	tests := []struct {
+		from, to TournamentFormat
+		want     bool
+	}{
+		{from: TournamentFormatStandard, to: TournamentFormatRainy, want: true},
+		{from: TournamentFormatRainy, to: TournamentFormatStandard, want: true},
+		{from: TournamentFormatStandard, to: TournamentFormatStandard},
+		{from: TournamentFormatStandard, to: TournamentFormatDoubleElimination},
+		{from: TournamentFormatRainy, to: TournamentFormatGroupKnockout},
+		{from: TournamentFormatRoundRobin, to: TournamentFormatStandard},
+		{from: TournamentFormatSwiss, to: TournamentFormatRainy},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanMigrateMatchesTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanMigrateMatchesTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTournament_IsDecided_Rainy(t *testing.T) {
+	matches := newTestBracket(t, TournamentFormatRainy)
+	tournament := &Tournament{ID: 1, Sport: string(SportTypeTableTennis), Format: string(TournamentFormatRainy), Status: string(TournamentStatusActiveEnum)}
+	for _, match := range matches {
+		if match.GetRound() != RoundLoserBracketEnum {
+			completeTestMatch(match, 3, 0, match.Team1)
+		}
+	}
+
+	// 決勝と3位決定戦が終わっても敗者復活戦が残っていれば終わらない
+	if tournament.IsDecided(matches) {
+		t.Error("IsDecided() = true with the consolation bracket still to play")
+	}
+	for _, match := range matches {
+		if match.GetRound() == RoundLoserBracketEnum {
+			match.SetStatus(MatchStatusCancelledEnum)
+		}
+	}
+	if !tournament.IsDecided(matches) {
+		t.Error("IsDecided() = false with the consolation bracket cancelled")
+	}
+}
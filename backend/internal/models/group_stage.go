@@ -0,0 +1,242 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	// DefaultGroupSize はグループ数を指定しない場合の1グループのチーム数の目安
+	DefaultGroupSize = 4
+	// DefaultQualifiersPerGroup は各グループから決勝トーナメントに進むチーム数の既定値
+	DefaultQualifiersPerGroup = 2
+	// maxGroupCount はグループ名（A〜Z）で表せるグループ数の上限
+	maxGroupCount = 26
+)
+
+// groupPlaceholderPattern はグループ順位が確定するまでの決勝トーナメントの枠（例: A組1位）
+var groupPlaceholderPattern = regexp.MustCompile(`^[A-Z]組\d+位$`)
+
+// GroupStanding はグループごとの順位表
+type GroupStanding struct {
+	Group string     `json:"group" example:"A"`
+	Table []Standing `json:"table"`
+}
+
+// GroupLabel はグループ番号（0始まり）からグループ名を返す
+func GroupLabel(index int) string {
+	return string(rune('A' + index))
+}
+
+// GroupPlaceholder はグループ順位の枠の名前を返す
+func GroupPlaceholder(group string, rank int) string {
+	return fmt.Sprintf("%s組%d位", group, rank)
+}
+
+// IsUndecidedTeam はチーム名が未確定の枠（TBDまたはグループ順位の枠）かどうかを返す
+func IsUndecidedTeam(team string) bool {
+	return team == TeamTBD || groupPlaceholderPattern.MatchString(team)
+}
+
+// AssignGroups はシード順に並んだチームをグループに振り分ける
+// 上位シードが同じグループに偏らないよう、蛇行（サーペンタイン）方式で割り当てる
+func AssignGroups(order []string, groupCount int) [][]string {
+	groups := make([][]string, groupCount)
+	for i, team := range order {
+		row, col := i/groupCount, i%groupCount
+		if row%2 == 1 {
+			col = groupCount - 1 - col
+		}
+		groups[col] = append(groups[col], team)
+	}
+	return groups
+}
+
+// GroupKnockoutPairs は決勝トーナメント1回戦の対戦カードをグループ順位の枠で作成する
+// 各グループ上位2チームが進出し、グループ数が2の累乗の場合は A組1位-B組2位、B組1位-A組2位 のように
+// 隣り合うグループで交差させ、同じグループのチームは決勝まで当たらない側に配置する。
+// それ以外の場合は順位、グループ名の順にシード番号を付けて組み合わせる
+func GroupKnockoutPairs(groups []string, qualifiers int) [][2]string {
+	if qualifiers == 2 && len(groups) >= 2 && len(groups)&(len(groups)-1) == 0 {
+		pairs := make([][2]string, 0, len(groups))
+		for i := 0; i < len(groups); i += 2 {
+			pairs = append(pairs, [2]string{GroupPlaceholder(groups[i], 1), GroupPlaceholder(groups[i+1], 2)})
+		}
+		for i := 0; i < len(groups); i += 2 {
+			pairs = append(pairs, [2]string{GroupPlaceholder(groups[i+1], 1), GroupPlaceholder(groups[i], 2)})
+		}
+		return pairs
+	}
+
+	seeds := make([]string, 0, len(groups)*qualifiers)
+	for rank := 1; rank <= qualifiers; rank++ {
+		for _, group := range groups {
+			seeds = append(seeds, GroupPlaceholder(group, rank))
+		}
+	}
+	return NewSeededPairs(seeds)
+}
+
+// NewGroupStageMatches はグループリーグと決勝トーナメントの全試合を作成する
+// シード順のチームをグループに振り分けてグループごとに総当たり戦を行い、決勝トーナメントの
+// 1回戦はグループ順位の枠（A組1位など）で作成する。グループ数・進出チーム数が0の場合は既定値を使用する。
+// グループリーグの試合の位置はグループ順の通し番号とする
+func NewGroupStageMatches(tournamentID int, order []string, groupCount, qualifiers int) ([]*Match, error) {
+	if groupCount == 0 {
+		groupCount = (len(order) + DefaultGroupSize - 1) / DefaultGroupSize
+	}
+	if qualifiers == 0 {
+		qualifiers = DefaultQualifiersPerGroup
+	}
+
+	if groupCount < 1 || groupCount > maxGroupCount {
+		return nil, fmt.Errorf("グループ数は1から%dの範囲で指定してください", maxGroupCount)
+	}
+	if len(order) < groupCount*2 {
+		return nil, errors.New("各グループに2チーム以上が必要です")
+	}
+	// 蛇行方式では最も少ないグループのチーム数は チーム数÷グループ数（切り捨て） となる
+	if qualifiers < 1 || qualifiers > len(order)/groupCount {
+		return nil, errors.New("各グループの進出チーム数はグループのチーム数以下である必要があります")
+	}
+	if groupCount*qualifiers < 2 {
+		return nil, errors.New("決勝トーナメントには2チーム以上の進出が必要です")
+	}
+
+	var matches []*Match
+	names := make([]string, 0, groupCount)
+	position := 0
+	for i, teams := range AssignGroups(order, groupCount) {
+		name := GroupLabel(i)
+		names = append(names, name)
+
+		groupMatches, err := newRoundRobinMatches(tournamentID, RoundGroupStageEnum, teams)
+		if err != nil {
+			return nil, err
+		}
+		next := position
+		for _, match := range groupMatches {
+			group := name
+			match.GroupName = &group
+			match.Position += position
+			if match.Position >= next {
+				next = match.Position + 1
+			}
+		}
+		position = next
+		matches = append(matches, groupMatches...)
+	}
+
+	knockout, err := NewKnockoutMatches(tournamentID, GroupKnockoutPairs(names, qualifiers))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(matches, knockout...), nil
+}
+
+// ComputeGroupStandings はグループリーグの試合結果からグループ名順に各グループの順位表を作成する
+func ComputeGroupStandings(matches []*Match, rules LeagueRules) []GroupStanding {
+	byGroup := make(map[string][]*Match)
+	for _, match := range matches {
+		if match == nil || match.GetRound() != RoundGroupStageEnum || match.GroupName == nil {
+			continue
+		}
+		byGroup[*match.GroupName] = append(byGroup[*match.GroupName], match)
+	}
+
+	names := make([]string, 0, len(byGroup))
+	for name := range byGroup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	standings := make([]GroupStanding, 0, len(names))
+	for _, name := range names {
+		standings = append(standings, GroupStanding{Group: name, Table: computeTable(byGroup[name], rules, nil)})
+	}
+	return standings
+}
+
+// GroupStageComplete はグループリーグの試合があり、全て完了しているかどうかを返す
+func GroupStageComplete(matches []*Match) bool {
+	found := false
+	for _, match := range matches {
+		if match == nil || match.GetRound() != RoundGroupStageEnum {
+			continue
+		}
+		if !match.IsCompleted() {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// FillGroupQualifiers はグループリーグが全て完了している場合に、決勝トーナメントの
+// グループ順位の枠を順位表のチームに置き換え、変更した試合を返す
+// 進出に関わる順位が順位決定方法で決まらない（同順位の）場合は試合を変更せずにエラーを返す
+func FillGroupQualifiers(matches []*Match, rules LeagueRules) ([]*Match, error) {
+	if !GroupStageComplete(matches) {
+		return nil, nil
+	}
+
+	// 順位表はチーム名で集計するため、グループリーグの試合の枠から登録チームのIDを引く
+	teams := make(map[string]TeamRef)
+	for _, match := range matches {
+		if match != nil && match.GetRound() == RoundGroupStageEnum {
+			for _, slot := range []int{SlotTeam1, SlotTeam2} {
+				team := match.TeamInSlot(slot)
+				teams[team.Name] = team
+			}
+		}
+	}
+
+	placements := make(map[string]TeamRef)
+	tied := make(map[string]bool)
+	for _, group := range ComputeGroupStandings(matches, rules) {
+		for i, standing := range group.Table {
+			label := GroupPlaceholder(group.Group, i+1)
+			team, ok := teams[standing.Team]
+			if !ok {
+				team = TeamRef{Name: standing.Team}
+			}
+			placements[label] = team
+			if standing.Rank != i+1 || (i+1 < len(group.Table) && group.Table[i+1].Rank == standing.Rank) {
+				tied[label] = true
+			}
+		}
+	}
+
+	var changed []*Match
+	for _, match := range matches {
+		if match == nil || match.GetRound() == RoundGroupStageEnum {
+			continue
+		}
+		_, fill1 := placements[match.Team1]
+		_, fill2 := placements[match.Team2]
+		if !fill1 && !fill2 {
+			continue
+		}
+		for _, label := range []string{match.Team1, match.Team2} {
+			if tied[label] {
+				return nil, fmt.Errorf("%sが同順位のため決定できません", label)
+			}
+		}
+		changed = append(changed, match)
+	}
+
+	for _, match := range changed {
+		for _, slot := range []int{SlotTeam1, SlotTeam2} {
+			if team, ok := placements[match.GetTeamInSlot(slot)]; ok {
+				if err := match.SetTeamInSlot(slot, team); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignGroups(t *testing.T) {
+	order := []string{"S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8"}
+
+	got := AssignGroups(order, 2)
+	want := [][]string{{"S1", "S4", "S5", "S8"}, {"S2", "S3", "S6", "S7"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignGroups() = %v, want %v", got, want)
+	}
+}
+
+func TestGroupKnockoutPairs(t *testing.T) {
+	got := GroupKnockoutPairs([]string{"A", "B"}, 2)
+	want := [][2]string{{"A組1位", "B組2位"}, {"B組1位", "A組2位"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupKnockoutPairs(2 groups) = %v, want %v", got, want)
+	}
+
+	// 同じグループの1位と2位は準決勝までに当たらない
+	got = GroupKnockoutPairs([]string{"A", "B", "C", "D"}, 2)
+	want = [][2]string{{"A組1位", "B組2位"}, {"C組1位", "D組2位"}, {"B組1位", "A組2位"}, {"D組1位", "C組2位"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupKnockoutPairs(4 groups) = %v, want %v", got, want)
+	}
+
+	// 3グループの場合はシード順で組み合わせ、上位シードは不戦勝
+	got = GroupKnockoutPairs([]string{"A", "B", "C"}, 2)
+	if len(got) != 4 || got[0] != [2]string{"A組1位", ""} {
+		t.Errorf("GroupKnockoutPairs(3 groups) = %v", got)
+	}
+}
+
+func TestNewGroupStageMatches(t *testing.T) {
+	order := []string{"IE4", "IS4", "IT4", "IE3", "IS3", "IT3", "1-1", "1-2"}
+
+	matches, err := NewGroupStageMatches(1, order, 0, 0)
+	if err != nil {
+		t.Fatalf("NewGroupStageMatches() error = %v", err)
+	}
+
+	groups := make(map[string]map[string]bool)
+	positions := make(map[int]bool)
+	knockout := 0
+	for _, match := range matches {
+		if match.Round != RoundGroupStage {
+			knockout++
+			continue
+		}
+		if match.GroupName == nil {
+			t.Fatalf("NewGroupStageMatches() group match without group name: %+v", match)
+		}
+		if positions[match.Position] {
+			t.Errorf("NewGroupStageMatches() duplicate group position %d", match.Position)
+		}
+		positions[match.Position] = true
+		if groups[*match.GroupName] == nil {
+			groups[*match.GroupName] = make(map[string]bool)
+		}
+		groups[*match.GroupName][match.Team1] = true
+		groups[*match.GroupName][match.Team2] = true
+	}
+
+	// 8チームは4チームずつ2グループ、各6試合
+	if len(groups) != 2 || len(groups["A"]) != 4 || len(groups["B"]) != 4 {
+		t.Errorf("NewGroupStageMatches() groups = %v", groups)
+	}
+	if len(positions) != 12 {
+		t.Errorf("NewGroupStageMatches() group matches = %d, want 12", len(positions))
+	}
+	// 4チームの決勝トーナメント（準決勝2、3位決定戦、決勝）
+	if knockout != 4 {
+		t.Errorf("NewGroupStageMatches() knockout matches = %d, want 4", knockout)
+	}
+
+	if _, err := NewGroupStageMatches(1, order, 2, 5); err == nil {
+		t.Error("NewGroupStageMatches() should reject more qualifiers than teams in a group")
+	}
+	if _, err := NewGroupStageMatches(1, order, 5, 1); err == nil {
+		t.Error("NewGroupStageMatches() should reject groups with a single team")
+	}
+}
+
+func TestFillGroupQualifiers(t *testing.T) {
+	order := []string{"A1", "B1", "B2", "A2", "A3", "B3"}
+	matches, err := NewGroupStageMatches(1, order, 2, 2)
+	if err != nil {
+		t.Fatalf("NewGroupStageMatches() error = %v", err)
+	}
+	numberTestMatches(matches)
+
+	// 未完了のグループ試合があれば何もしない
+	changed, err := FillGroupQualifiers(matches, DefaultLeagueRules())
+	if err != nil || changed != nil {
+		t.Fatalf("FillGroupQualifiers() before group completion = %v, %v", changed, err)
+	}
+
+	// シード順が上のチームが全て勝つ
+	rank := map[string]int{"A1": 1, "A2": 2, "A3": 3, "B1": 1, "B2": 2, "B3": 3}
+	for _, match := range matches {
+		if match.Round != RoundGroupStage {
+			continue
+		}
+		score1, score2 := 1, 0
+		if rank[match.Team2] < rank[match.Team1] {
+			score1, score2 = 0, 1
+		}
+		match.Score1, match.Score2 = &score1, &score2
+		match.Status = MatchStatusCompleted
+	}
+
+	changed, err = FillGroupQualifiers(matches, DefaultLeagueRules())
+	if err != nil {
+		t.Fatalf("FillGroupQualifiers() error = %v", err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("FillGroupQualifiers() changed = %d, want 2", len(changed))
+	}
+
+	semis := groupMatchesByRound(matches)[RoundSemifinalEnum]
+	if semis[0].Team1 != "A1" || semis[0].Team2 != "B2" || semis[1].Team1 != "B1" || semis[1].Team2 != "A2" {
+		t.Errorf("FillGroupQualifiers() semifinals = %s-%s, %s-%s", semis[0].Team1, semis[0].Team2, semis[1].Team1, semis[1].Team2)
+	}
+	for _, match := range semis {
+		if match.HasUndecidedTeams() {
+			t.Errorf("FillGroupQualifiers() left an undecided slot: %s-%s", match.Team1, match.Team2)
+		}
+	}
+}
+
+func TestFillGroupQualifiersUnresolvedTie(t *testing.T) {
+	order := []string{"A", "B", "C", "D"}
+	matches, err := NewGroupStageMatches(1, order, 1, 2)
+	if err != nil {
+		t.Fatalf("NewGroupStageMatches() error = %v", err)
+	}
+	for _, match := range matches {
+		if match.Round != RoundGroupStage {
+			continue
+		}
+		score := 0
+		match.Score1, match.Score2 = &score, &score
+		match.Status = MatchStatusCompleted
+	}
+
+	// 抽選を含まない規則では全チーム同順位のまま
+	rules := DefaultLeagueRules()
+	rules.Tiebreakers = []Tiebreaker{TiebreakerGoalDifference}
+	if _, err := FillGroupQualifiers(matches, rules); err == nil {
+		t.Error("FillGroupQualifiers() should reject tied qualifying places")
+	}
+
+	final := groupMatchesByRound(matches)[RoundFinalEnum][0]
+	if final.Team1 != "A組1位" || final.Team2 != "A組2位" {
+		t.Errorf("FillGroupQualifiers() should leave the bracket unchanged, final = %s-%s", final.Team1, final.Team2)
+	}
+}
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+// テスト用のブラケットの作成・消化の共通ヘルパー
+
+// testPairs は8チームの1回戦の対戦カード
+var testPairs = [][2]string{{"IE1", "IE2"}, {"IS1", "IS2"}, {"IT1", "IT2"}, {"IC1", "IC2"}}
+
+// newTestTeams は prefix に1からの番号を付けたチーム名を作成する
+func newTestTeams(prefix string, count int) []string {
+	teams := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		teams = append(teams, fmt.Sprintf("%s%d", prefix, i))
+	}
+	return teams
+}
+
+// numberTestMatches は採番を模して試合に1から順にIDを振る
+func numberTestMatches(matches []*Match) []*Match {
+	for i, match := range matches {
+		match.ID = i + 1
+	}
+	return matches
+}
+
+// linkTestMatches は試合にIDを振り、勝者・敗者の進出先を設定する
+func linkTestMatches(matches []*Match) []*Match {
+	LinkBracket(numberTestMatches(matches))
+	return matches
+}
+
+// newLinkedBracket は1回戦の対戦カードから形式に応じたブラケットを作成し、IDと進出先を設定する
+func newLinkedBracket(t *testing.T, format TournamentFormat, pairs [][2]string) []*Match {
+	t.Helper()
+
+	create := NewKnockoutMatches
+	switch format {
+	case TournamentFormatRainy:
+		create = NewRainyMatches
+	case TournamentFormatDoubleElimination:
+		create = NewDoubleEliminationMatches
+	}
+	matches, err := create(1, pairs)
+	if err != nil {
+		t.Fatalf("failed to create %s bracket: %v", format, err)
+	}
+	return linkTestMatches(matches)
+}
+
+// newTestBracket は8チームの1回戦の対戦カードから形式に応じたブラケットを作成する
+func newTestBracket(t *testing.T, format TournamentFormat) []*Match {
+	t.Helper()
+	return newLinkedBracket(t, format, testPairs)
+}
+
+// newLinkedDoubleElimination はシード順に並べた teamCount チームのダブルエリミネーションを作成する
+func newLinkedDoubleElimination(t *testing.T, teamCount int) []*Match {
+	t.Helper()
+	return newLinkedBracket(t, TournamentFormatDoubleElimination, NewSeededPairs(newTestTeams("T", teamCount)))
+}
+
+// newEightTeamBracket は8チームのブラケット（1回戦4試合、準決勝2試合、3位決定戦、決勝）を進出先なしで作成する
+func newEightTeamBracket() []*Match {
+	matches := []*Match{
+		{ID: 1, Round: Round1stRound, Position: 0},
+		{ID: 2, Round: Round1stRound, Position: 1},
+		{ID: 3, Round: Round1stRound, Position: 2},
+		{ID: 4, Round: Round1stRound, Position: 3},
+		{ID: 5, Round: RoundSemifinal, Position: 0},
+		{ID: 6, Round: RoundSemifinal, Position: 1},
+		{ID: 7, Round: RoundThirdPlace, Position: 0},
+		{ID: 8, Round: RoundFinal, Position: 0},
+	}
+	return matches
+}
+
+// newPlayedFourTeamBracket は4チームのブラケットを作成し、準決勝を A・B の勝ちで消化する
+func newPlayedFourTeamBracket(t *testing.T) (matches []*Match, byRound map[RoundType][]*Match) {
+	t.Helper()
+
+	matches = newLinkedBracket(t, TournamentFormatStandard, [][2]string{{"A", "D"}, {"B", "C"}})
+	byRound = groupMatchesByRound(matches)
+	playTestMatch(t, matches, byRound[RoundSemifinalEnum][0], "A", 2, 1)
+	playTestMatch(t, matches, byRound[RoundSemifinalEnum][1], "B", 3, 0)
+	return matches, byRound
+}
+
+// newPlayedMatch は勝者が決まった終了済みの試合を作成する
+func newPlayedMatch(round string, position int, winner, loser string) *Match {
+	return &Match{Round: round, Position: position, Team1: winner, Team2: loser, Winner: &winner, Status: MatchStatusCompleted}
+}
+
+// completeTestMatch は試合に結果を設定して終了にする
+func completeTestMatch(match *Match, score1, score2 int, winner string) {
+	match.ApplyResult(MatchResult{Score1: score1, Score2: score2, Winner: winner})
+	match.SetStatus(MatchStatusCompletedEnum)
+}
+
+// playTestMatch は winner の勝ちで試合を終了させ、勝者・敗者を進出先の枠に配置する
+func playTestMatch(t *testing.T, matches []*Match, match *Match, winner string, winnerScore, loserScore int) {
+	t.Helper()
+
+	score1, score2 := winnerScore, loserScore
+	if winner == match.Team2 {
+		score1, score2 = loserScore, winnerScore
+	}
+	completeTestMatch(match, score1, score2, winner)
+	advanceTestMatch(t, matches, match)
+}
+
+// advanceTestMatch は終了した試合の勝者・敗者を進出先の枠に配置する
+func advanceTestMatch(t *testing.T, matches []*Match, match *Match) {
+	t.Helper()
+
+	progression := match.GetProgression()
+	if progression.Winner != nil && match.Winner != nil {
+		placeTestTeam(t, matches, progression.Winner, *match.Winner)
+	}
+	if progression.Loser != nil {
+		placeTestTeam(t, matches, progression.Loser, match.GetLoser())
+	}
+}
+
+// placeTestTeam は進出先の試合の枠にチームを配置する
+func placeTestTeam(t *testing.T, matches []*Match, slot *BracketSlot, team string) {
+	t.Helper()
+
+	target := findTestMatchByID(t, matches, slot.MatchID)
+	if err := target.SetTeamInSlot(slot.Slot, TeamRef{Name: team}); err != nil {
+		t.Fatalf("SetTeamInSlot(match %d) error = %v", target.ID, err)
+	}
+}
+
+// playDoubleElimination はブラケットの全試合を、進出先に従ってチームを配置しながら順に消化する
+// 各試合はwinnerが選んだ側の勝ちとし、チームごとの敗戦数を返す
+func playDoubleElimination(t *testing.T, matches []*Match, winner func(match *Match) string) map[string]int {
+	t.Helper()
+
+	losses := make(map[string]int)
+	for {
+		var next *Match
+		for _, match := range matches {
+			if !match.IsCompleted() && match.Team1 != TeamTBD && match.Team2 != TeamTBD {
+				next = match
+				break
+			}
+		}
+		if next == nil {
+			return losses
+		}
+
+		w := winner(next)
+		next.Winner = &w
+		next.Status = MatchStatusCompleted
+		losses[next.GetLoser()]++
+
+		// グランドファイナルで勝者側の優勝チームが勝った場合、リセットマッチは行わない
+		if next.GetRound() == RoundGrandFinalEnum && !next.NeedsGrandFinalReset() {
+			continue
+		}
+		advanceTestMatch(t, matches, next)
+	}
+}
+
+// playSwissRound は次の回戦の試合を作成し、teams の並びが上位のチームを勝たせる
+func playSwissRound(t *testing.T, matches []*Match, teams []string, round int) []*Match {
+	t.Helper()
+
+	pairs, _, err := PairSwissRound(teams, matches, DefaultSwissRules())
+	if err != nil {
+		t.Fatalf("PairSwissRound(round %d) error = %v", round, err)
+	}
+
+	strength := make(map[string]int, len(teams))
+	for i, team := range teams {
+		strength[team] = len(teams) - i
+	}
+	for _, match := range NewSwissRoundMatches(1, round, len(matches), pairs) {
+		score1, score2 := 1, 0
+		if strength[match.Team2] > strength[match.Team1] {
+			score1, score2 = 0, 1
+		}
+		match.Score1, match.Score2 = &score1, &score2
+		match.Status = MatchStatusCompleted
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// findTestMatch はラウンドと位置で試合を引く
+func findTestMatch(t *testing.T, matches []*Match, round RoundType, position int) *Match {
+	t.Helper()
+	for _, match := range matches {
+		if match.GetRound() == round && match.Position == position && !match.IsCancelled() {
+			return match
+		}
+	}
+	t.Fatalf("no %s match at position %d", round, position)
+	return nil
+}
+
+// findTestMatchByID はIDで試合を引く
+func findTestMatchByID(t *testing.T, matches []*Match, id int) *Match {
+	t.Helper()
+	for _, match := range matches {
+		if match.ID == id {
+			return match
+		}
+	}
+	t.Fatalf("no match with id %d", id)
+	return nil
+}
+
+// assertSlot は進出先が期待どおりであることを確認する
+func assertSlot(t *testing.T, label string, got, want *BracketSlot) {
+	t.Helper()
+	if want == nil {
+		if got != nil {
+			t.Errorf("%s = %+v, want nil", label, *got)
+		}
+		return
+	}
+	if got == nil {
+		t.Fatalf("%s = nil, want %+v", label, *want)
+	}
+	if *got != *want {
+		t.Errorf("%s = %+v, want %+v", label, *got, *want)
+	}
+}
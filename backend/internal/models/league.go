@@ -0,0 +1,363 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Tiebreaker は勝点が並んだ場合の順位決定方法
+type Tiebreaker string
+
+const (
+	TiebreakerHeadToHead     Tiebreaker = "head_to_head"    // 当該チーム間の対戦成績（勝点）
+	TiebreakerGoalDifference Tiebreaker = "goal_difference" // 得失点差
+	TiebreakerGoalsScored    Tiebreaker = "goals_scored"    // 総得点
+	TiebreakerLottery        Tiebreaker = "lottery"         // 抽選
+	TiebreakerBuchholz       Tiebreaker = "buchholz"        // 対戦相手の勝点の合計（スイス式）
+)
+
+// IsValid は順位決定方法が有効かどうかを判定する
+func (t Tiebreaker) IsValid() bool {
+	switch t {
+	case TiebreakerHeadToHead, TiebreakerGoalDifference, TiebreakerGoalsScored, TiebreakerLottery, TiebreakerBuchholz:
+		return true
+	default:
+		return false
+	}
+}
+
+// LeagueRules は総当たり戦の勝点と順位決定方法
+type LeagueRules struct {
+	PointsForWin  int          `json:"points_for_win" example:"3"`
+	PointsForDraw int          `json:"points_for_draw" example:"1"`
+	PointsForLoss int          `json:"points_for_loss" example:"0"`
+	Tiebreakers   []Tiebreaker `json:"tiebreakers"`  // 適用する順に指定
+	LotterySeed   int64        `json:"lottery_seed"` // 抽選に使用する乱数シード（同じシードなら同じ結果）
+}
+
+// DefaultLeagueRules は勝ち3点・引き分け1点の標準的な規則を返す
+func DefaultLeagueRules() LeagueRules {
+	return LeagueRules{
+		PointsForWin:  3,
+		PointsForDraw: 1,
+		PointsForLoss: 0,
+		Tiebreakers: []Tiebreaker{
+			TiebreakerHeadToHead,
+			TiebreakerGoalDifference,
+			TiebreakerGoalsScored,
+			TiebreakerLottery,
+		},
+	}
+}
+
+// DefaultSwissRules はスイス式の規則を返す
+// 対戦相手が異なるため、当該チーム間の対戦成績の代わりにブッフホルツを最初に適用する
+func DefaultSwissRules() LeagueRules {
+	rules := DefaultLeagueRules()
+	rules.Tiebreakers = []Tiebreaker{
+		TiebreakerBuchholz,
+		TiebreakerGoalDifference,
+		TiebreakerGoalsScored,
+		TiebreakerLottery,
+	}
+	return rules
+}
+
+// hasTiebreaker は規則に順位決定方法が含まれるかどうかを返す
+func (r *LeagueRules) hasTiebreaker(tiebreaker Tiebreaker) bool {
+	for _, t := range r.Tiebreakers {
+		if t == tiebreaker {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate は総当たり戦の規則を検証する
+func (r *LeagueRules) Validate() error {
+	if r.PointsForWin < r.PointsForDraw || r.PointsForDraw < r.PointsForLoss {
+		return errors.New("勝点は勝ち・引き分け・負けの順に大きい必要があります")
+	}
+
+	seen := make(map[Tiebreaker]bool, len(r.Tiebreakers))
+	for _, tiebreaker := range r.Tiebreakers {
+		if !tiebreaker.IsValid() {
+			return fmt.Errorf("無効な順位決定方法です: %s", tiebreaker)
+		}
+		if seen[tiebreaker] {
+			return fmt.Errorf("順位決定方法が重複しています: %s", tiebreaker)
+		}
+		seen[tiebreaker] = true
+	}
+
+	return nil
+}
+
+// Standing は順位表の1行を表す
+type Standing struct {
+	Rank           int    `json:"rank" example:"1"` // 全ての順位決定方法で並んだ場合は同順位
+	Team           string `json:"team" example:"IE4"`
+	Played         int    `json:"played" example:"3"`
+	Won            int    `json:"won" example:"2"`
+	Drawn          int    `json:"drawn" example:"1"`
+	Lost           int    `json:"lost" example:"0"`
+	GoalsFor       int    `json:"goals_for" example:"5"`
+	GoalsAgainst   int    `json:"goals_against" example:"2"`
+	GoalDifference int    `json:"goal_difference" example:"3"`
+	Points         int    `json:"points" example:"7"`
+	Byes           int    `json:"byes,omitempty" example:"1"`      // 不戦勝の回数（スイス式、勝ちと同じ勝点）
+	Forfeits       int    `json:"forfeits,omitempty" example:"1"`  // 棄権・不出場・失格の回数（負けに含む）
+	Buchholz       int    `json:"buchholz,omitempty" example:"12"` // 対戦相手の勝点の合計（順位決定方法に含まれる場合のみ）
+}
+
+// Standings はトーナメントの順位表
+type Standings struct {
+	TournamentID int             `json:"tournament_id"`
+	Rules        LeagueRules     `json:"rules"`
+	Table        []Standing      `json:"table"`
+	Groups       []GroupStanding `json:"groups,omitempty"` // グループリーグ形式のグループごとの順位表
+}
+
+// NewRoundRobinMatches は全てのチームの組み合わせで総当たり戦の試合を作成する
+// サークル方式で節ごとに組み合わせ、奇数チームの場合は各節で1チームが休みとなる。
+// 試合の位置は節順の通し番号とする
+func NewRoundRobinMatches(tournamentID int, teams []string) ([]*Match, error) {
+	return newRoundRobinMatches(tournamentID, RoundLeagueEnum, teams)
+}
+
+// newRoundRobinMatches は指定したラウンドで総当たり戦の試合を作成する
+func newRoundRobinMatches(tournamentID int, round RoundType, teams []string) ([]*Match, error) {
+	if len(teams) < 2 {
+		return nil, errors.New("2チーム以上が必要です")
+	}
+
+	rotation := append([]string{}, teams...)
+	if len(rotation)%2 != 0 {
+		rotation = append(rotation, "") // 休み
+	}
+
+	n := len(rotation)
+	var matches []*Match
+	position := 0
+	for matchday := 0; matchday < n-1; matchday++ {
+		for i := 0; i < n/2; i++ {
+			team1, team2 := rotation[i], rotation[n-1-i]
+			// 固定したチームが毎節同じ側にならないよう入れ替える
+			if i == 0 && matchday%2 == 1 {
+				team1, team2 = team2, team1
+			}
+			if team1 != "" && team2 != "" {
+				matches = append(matches, newPendingMatch(tournamentID, round, position, team1, team2))
+			}
+			position++
+		}
+
+		// 先頭を固定して残りを1つずつ回転させる
+		last := rotation[n-1]
+		copy(rotation[2:], rotation[1:n-1])
+		rotation[1] = last
+	}
+
+	return matches, nil
+}
+
+// tiebreakerPoints は勝点による順位付け（全ての順位決定方法より先に適用する）
+const tiebreakerPoints Tiebreaker = "points"
+
+// ComputeStandings は総当たり戦の試合結果から順位表を作成する
+// 完了した試合のスコアから勝敗を判定し、勝点、規則の順位決定方法の順に順位を決める
+func ComputeStandings(matches []*Match, rules LeagueRules) []Standing {
+	league := make([]*Match, 0, len(matches))
+	for _, match := range matches {
+		if match != nil && match.GetRound() == RoundLeagueEnum {
+			league = append(league, match)
+		}
+	}
+	return computeTable(league, rules, nil)
+}
+
+// computeTable は渡された試合とチームごとの不戦勝の回数で順位表を作成する
+func computeTable(matches []*Match, rules LeagueRules, byes map[string]int) []Standing {
+	records := make(map[string]*Standing)
+	record := func(team string) *Standing {
+		if records[team] == nil {
+			records[team] = &Standing{Team: team}
+		}
+		return records[team]
+	}
+
+	for team, count := range byes {
+		standing := record(team)
+		standing.Byes += count
+		standing.Points += count * rules.PointsForWin
+	}
+
+	var played []*Match
+	for _, match := range matches {
+		if match.Team1 == TeamTBD || match.Team2 == TeamTBD {
+			continue
+		}
+		record(match.Team1)
+		record(match.Team2)
+		if !match.IsCompleted() || match.Score1 == nil || match.Score2 == nil {
+			continue
+		}
+
+		played = append(played, match)
+		addMatchStandings(record(match.Team1), record(match.Team2), match, rules)
+	}
+
+	// 不戦勝は対戦相手がいないためブッフホルツに加算しない
+	if rules.hasTiebreaker(TiebreakerBuchholz) {
+		for _, match := range played {
+			records[match.Team1].Buchholz += records[match.Team2].Points
+			records[match.Team2].Buchholz += records[match.Team1].Points
+		}
+	}
+
+	group := make([]*Standing, 0, len(records))
+	for _, standing := range records {
+		standing.GoalDifference = standing.GoalsFor - standing.GoalsAgainst
+		group = append(group, standing)
+	}
+	sort.Slice(group, func(i, j int) bool {
+		return group[i].Team < group[j].Team
+	})
+
+	// 抽選順は乱数シードとチーム名だけで決まる
+	lottery := make(map[string]int, len(group))
+	rng := rand.New(rand.NewSource(rules.LotterySeed))
+	for i, index := range rng.Perm(len(group)) {
+		lottery[group[index].Team] = len(group) - i
+	}
+
+	key := func(tiebreaker Tiebreaker, tied []*Standing) map[string]int {
+		keys := make(map[string]int, len(tied))
+		switch tiebreaker {
+		case tiebreakerPoints:
+			for _, s := range tied {
+				keys[s.Team] = s.Points
+			}
+		case TiebreakerHeadToHead:
+			keys = headToHeadPoints(tied, played, rules)
+		case TiebreakerGoalDifference:
+			for _, s := range tied {
+				keys[s.Team] = s.GoalDifference
+			}
+		case TiebreakerGoalsScored:
+			for _, s := range tied {
+				keys[s.Team] = s.GoalsFor
+			}
+		case TiebreakerBuchholz:
+			for _, s := range tied {
+				keys[s.Team] = s.Buchholz
+			}
+		case TiebreakerLottery:
+			for _, s := range tied {
+				keys[s.Team] = lottery[s.Team]
+			}
+		}
+		return keys
+	}
+
+	tiebreakers := append([]Tiebreaker{tiebreakerPoints}, rules.Tiebreakers...)
+	table := make([]Standing, 0, len(group))
+	for _, tied := range breakTies(group, tiebreakers, key) {
+		rank := len(table) + 1
+		for _, standing := range tied {
+			standing.Rank = rank
+			table = append(table, *standing)
+		}
+	}
+
+	return table
+}
+
+// addMatchStandings は完了した試合の結果を両チームの順位表の行に加算する
+// 両チーム棄権の場合は両チームの負けとする
+func addMatchStandings(standing1, standing2 *Standing, match *Match, rules LeagueRules) {
+	if match.HasForfeited(SlotTeam1) {
+		standing1.Forfeits++
+	}
+	if match.HasForfeited(SlotTeam2) {
+		standing2.Forfeits++
+	}
+
+	if match.IsDoubleForfeit() {
+		for _, standing := range []*Standing{standing1, standing2} {
+			standing.Played++
+			standing.Lost++
+			standing.Points += rules.PointsForLoss
+		}
+		return
+	}
+
+	addStandingResult(standing1, *match.Score1, *match.Score2, rules)
+	addStandingResult(standing2, *match.Score2, *match.Score1, rules)
+}
+
+// addStandingResult は1試合の結果を順位表の行に加算する
+func addStandingResult(standing *Standing, goalsFor, goalsAgainst int, rules LeagueRules) {
+	standing.Played++
+	standing.GoalsFor += goalsFor
+	standing.GoalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		standing.Won++
+		standing.Points += rules.PointsForWin
+	case goalsFor < goalsAgainst:
+		standing.Lost++
+		standing.Points += rules.PointsForLoss
+	default:
+		standing.Drawn++
+		standing.Points += rules.PointsForDraw
+	}
+}
+
+// headToHeadPoints は並んだチーム同士の試合だけで勝点を集計する
+func headToHeadPoints(tied []*Standing, played []*Match, rules LeagueRules) map[string]int {
+	points := make(map[string]int, len(tied))
+	for _, s := range tied {
+		points[s.Team] = 0
+	}
+
+	for _, match := range played {
+		_, ok1 := points[match.Team1]
+		_, ok2 := points[match.Team2]
+		if !ok1 || !ok2 {
+			continue
+		}
+		mini1, mini2 := &Standing{}, &Standing{}
+		addMatchStandings(mini1, mini2, match, rules)
+		points[match.Team1] += mini1.Points
+		points[match.Team2] += mini2.Points
+	}
+
+	return points
+}
+
+// breakTies は順位決定方法を順に適用し、並んだままのチームをまとめたグループを上位から返す
+func breakTies(group []*Standing, tiebreakers []Tiebreaker, key func(Tiebreaker, []*Standing) map[string]int) [][]*Standing {
+	if len(group) <= 1 || len(tiebreakers) == 0 {
+		return [][]*Standing{group}
+	}
+
+	keys := key(tiebreakers[0], group)
+	sort.SliceStable(group, func(i, j int) bool {
+		return keys[group[i].Team] > keys[group[j].Team]
+	})
+
+	var result [][]*Standing
+	for i := 0; i < len(group); {
+		j := i
+		for j < len(group) && keys[group[j].Team] == keys[group[i].Team] {
+			j++
+		}
+		result = append(result, breakTies(group[i:j], tiebreakers[1:], key)...)
+		i = j
+	}
+
+	return result
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestNewRoundRobinMatches(t *testing.T) {
+	for _, teamCount := range []int{2, 4, 5} {
+		teams := []string{"IE4", "IS4", "IT4", "1-1", "1-2"}[:teamCount]
+
+		matches, err := NewRoundRobinMatches(1, teams)
+		if err != nil {
+			t.Fatalf("NewRoundRobinMatches() error = %v", err)
+		}
+
+		want := teamCount * (teamCount - 1) / 2
+		if len(matches) != want {
+			t.Fatalf("NewRoundRobinMatches(%d teams) matches = %d, want %d", teamCount, len(matches), want)
+		}
+
+		// 全ての組み合わせが1回ずつ
+		seen := make(map[[2]string]bool)
+		for _, match := range matches {
+			pair := [2]string{match.Team1, match.Team2}
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if seen[pair] || match.Team1 == match.Team2 {
+				t.Errorf("NewRoundRobinMatches() duplicate pairing %v", pair)
+			}
+			seen[pair] = true
+			if match.Round != RoundLeague {
+				t.Errorf("NewRoundRobinMatches() round = %v, want %v", match.Round, RoundLeague)
+			}
+		}
+	}
+
+	if _, err := NewRoundRobinMatches(1, []string{"IE4"}); err == nil {
+		t.Error("NewRoundRobinMatches() should reject a single team")
+	}
+}
+
+func newLeagueResult(team1, team2 string, score1, score2 int) *Match {
+	match := &Match{Round: RoundLeague, Team1: team1, Team2: team2, Score1: &score1, Score2: &score2, Status: MatchStatusCompleted}
+	if score1 > score2 {
+		match.Winner = &team1
+	} else if score2 > score1 {
+		match.Winner = &team2
+	}
+	return match
+}
+
+func TestComputeStandings(t *testing.T) {
+	matches := []*Match{
+		newLeagueResult("A", "B", 2, 0),
+		newLeagueResult("A", "C", 1, 1),
+		newLeagueResult("B", "C", 3, 1),
+		{Round: RoundLeague, Team1: "A", Team2: "D", Status: MatchStatusPending},
+	}
+
+	table := ComputeStandings(matches, DefaultLeagueRules())
+	if len(table) != 4 {
+		t.Fatalf("ComputeStandings() rows = %d, want 4", len(table))
+	}
+
+	want := []Standing{
+		{Rank: 1, Team: "A", Played: 2, Won: 1, Drawn: 1, GoalsFor: 3, GoalsAgainst: 1, GoalDifference: 2, Points: 4},
+		{Rank: 2, Team: "B", Played: 2, Won: 1, Lost: 1, GoalsFor: 3, GoalsAgainst: 3, GoalDifference: 0, Points: 3},
+		{Rank: 3, Team: "C", Played: 2, Drawn: 1, Lost: 1, GoalsFor: 2, GoalsAgainst: 4, GoalDifference: -2, Points: 1},
+		{Rank: 4, Team: "D"},
+	}
+	for i := range want {
+		if table[i] != want[i] {
+			t.Errorf("ComputeStandings()[%d] = %+v, want %+v", i, table[i], want[i])
+		}
+	}
+}
+
+func TestComputeStandings_Tiebreakers(t *testing.T) {
+	// A, B, Cが勝点3で並び、得失点差はA > B > C、当該対戦の勝点も全て3で並ぶ
+	matches := []*Match{
+		newLeagueResult("A", "B", 0, 1),
+		newLeagueResult("B", "C", 0, 1),
+		newLeagueResult("C", "A", 0, 5),
+	}
+
+	tests := []struct {
+		name        string
+		tiebreakers []Tiebreaker
+		want        []string
+		ranks       []int
+	}{
+		{
+			name:        "得失点差",
+			tiebreakers: []Tiebreaker{TiebreakerGoalDifference},
+			want:        []string{"A", "B", "C"},
+			ranks:       []int{1, 2, 3},
+		},
+		{
+			name:        "当該対戦で並ぶ場合は次の方法へ",
+			tiebreakers: []Tiebreaker{TiebreakerHeadToHead, TiebreakerGoalsScored},
+			want:        []string{"A", "B", "C"},
+			ranks:       []int{1, 2, 2},
+		},
+		{
+			name:        "当該対戦のみ",
+			tiebreakers: []Tiebreaker{TiebreakerHeadToHead},
+			want:        []string{"A", "B", "C"},
+			ranks:       []int{1, 1, 1},
+		},
+		{
+			name:        "順位決定方法なしは同順位",
+			tiebreakers: nil,
+			want:        []string{"A", "B", "C"},
+			ranks:       []int{1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultLeagueRules()
+			rules.Tiebreakers = tt.tiebreakers
+			table := ComputeStandings(matches, rules)
+			for i := range tt.want {
+				if table[i].Team != tt.want[i] || table[i].Rank != tt.ranks[i] {
+					t.Errorf("ComputeStandings()[%d] = %s (rank %d), want %s (rank %d)", i, table[i].Team, table[i].Rank, tt.want[i], tt.ranks[i])
+				}
+			}
+		})
+	}
+
+	// 2チームだけが並んだ場合は当該対戦の勝者が上位
+	twoWay := []*Match{
+		newLeagueResult("A", "B", 0, 1),
+		newLeagueResult("A", "C", 3, 0),
+		newLeagueResult("B", "C", 0, 1),
+		newLeagueResult("C", "D", 0, 0),
+	}
+	rules := DefaultLeagueRules()
+	rules.Tiebreakers = []Tiebreaker{TiebreakerHeadToHead, TiebreakerGoalDifference}
+	table := ComputeStandings(twoWay, rules)
+	if table[0].Team != "C" || table[1].Team != "B" || table[2].Team != "A" {
+		t.Errorf("ComputeStandings() head to head order = %v, %v, %v", table[0].Team, table[1].Team, table[2].Team)
+	}
+
+	// 抽選は乱数シードが同じなら同じ結果になり、全てのチームが異なる順位になる
+	rules.Tiebreakers = []Tiebreaker{TiebreakerLottery}
+	rules.LotterySeed = 7
+	first := ComputeStandings(matches, rules)
+	second := ComputeStandings(matches, rules)
+	for i := range first {
+		if first[i].Team != second[i].Team || first[i].Rank != i+1 {
+			t.Errorf("ComputeStandings() lottery[%d] = %s (rank %d) / %s", i, first[i].Team, first[i].Rank, second[i].Team)
+		}
+	}
+}
+
+func TestLeagueRules_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   LeagueRules
+		wantErr bool
+	}{
+		{name: "標準", rules: DefaultLeagueRules()},
+		{name: "勝点の大小が逆", rules: LeagueRules{PointsForWin: 1, PointsForDraw: 3}, wantErr: true},
+		{name: "無効な順位決定方法", rules: LeagueRules{PointsForWin: 3, Tiebreakers: []Tiebreaker{"fair_play"}}, wantErr: true},
+		{name: "重複", rules: LeagueRules{PointsForWin: 3, Tiebreakers: []Tiebreaker{TiebreakerLottery, TiebreakerLottery}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchResult_ValidateForRound(t *testing.T) {
+	tests := []struct {
+		name    string
+		round   RoundType
+		result  MatchResult
+		wantErr bool
+	}{
+		{name: "総当たり戦の引き分け", round: RoundLeagueEnum, result: MatchResult{Score1: 1, Score2: 1}},
+		{name: "総当たり戦の勝ち", round: RoundLeagueEnum, result: MatchResult{Score1: 2, Score2: 1, Winner: "IE4"}},
+		{name: "引き分けに勝者を指定", round: RoundLeagueEnum, result: MatchResult{Score1: 1, Score2: 1, Winner: "IE4"}, wantErr: true},
+		{name: "トーナメントの引き分け", round: RoundSemifinalEnum, result: MatchResult{Score1: 1, Score2: 1}, wantErr: true},
+		{name: "スコアと勝者の不一致", round: RoundSemifinalEnum, result: MatchResult{Score1: 1, Score2: 2, Winner: "IE4"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.result.ValidateForRound(tt.round, "IE4", "IS4"); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateForRound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -9,19 +10,41 @@ import (
 // Match は試合を表すモデル
 // @Description 試合を表すモデル
 type Match struct {
-	ID           int        `json:"id" db:"id"`
-	TournamentID int        `json:"tournament_id" db:"tournament_id"`
-	Round        string     `json:"round" db:"round"`        // データベース互換性のため文字列型を維持
-	Team1        string     `json:"team1" db:"team1"`
-	Team2        string     `json:"team2" db:"team2"`
-	Score1       *int       `json:"score1,omitempty" db:"score1"` // 試合が行われるまでnull
-	Score2       *int       `json:"score2,omitempty" db:"score2"`
-	Winner       *string    `json:"winner,omitempty" db:"winner"`
-	Status       string     `json:"status" db:"status"`           // データベース互換性のため文字列型を維持
-	ScheduledAt  time.Time  `json:"scheduled_at" db:"scheduled_at"`
-	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	ID               int        `json:"id" db:"id"`
+	TournamentID     int        `json:"tournament_id" db:"tournament_id"`
+	Round            string     `json:"round" db:"round"`                       // データベース互換性のため文字列型を維持
+	Position         int        `json:"position" db:"position"`                 // ラウンド内の位置（0始まり）
+	GroupName        *string    `json:"group_name,omitempty" db:"group_name"`   // グループリーグのグループ名（A, B, ...）
+	SwissRound       *int       `json:"swiss_round,omitempty" db:"swiss_round"` // スイス式の回戦番号（1始まり）
+	Team1            string     `json:"team1" db:"team1"`                       // チーム名（登録チームは現在のチーム名を表示する）
+	Team2            string     `json:"team2" db:"team2"`
+	Team1ID          *int       `json:"team1_id,omitempty" db:"team1_id"` // 登録チームのID（登録チームはIDで識別する。未確定の枠・未登録のチームはnull）
+	Team2ID          *int       `json:"team2_id,omitempty" db:"team2_id"`
+	Score1           *int       `json:"score1,omitempty" db:"score1"` // 正規時間のスコア、セット制の試合は取ったセット数（試合が行われるまでnull）
+	Score2           *int       `json:"score2,omitempty" db:"score2"`
+	ExtraTimeScore1  *int       `json:"extra_time_score1,omitempty" db:"extra_time_score1"` // 延長戦終了時のスコア（正規時間を含む）
+	ExtraTimeScore2  *int       `json:"extra_time_score2,omitempty" db:"extra_time_score2"`
+	PenaltyScore1    *int       `json:"penalty_score1,omitempty" db:"penalty_score1"` // PK戦のスコア
+	PenaltyScore2    *int       `json:"penalty_score2,omitempty" db:"penalty_score2"`
+	DecisionMethod   *string    `json:"decision_method,omitempty" db:"decision_method"`       // 決着方法（regulation, extra_time, penalties）
+	ResultType       *string    `json:"result_type,omitempty" db:"result_type"`               // 結果の種類（played, walkover, forfeit, double_forfeit, disqualification）
+	ForfeitingTeam   *string    `json:"forfeiting_team,omitempty" db:"forfeiting_team"`       // 棄権・不出場・失格となったチーム
+	ForfeitingTeamID *int       `json:"forfeiting_team_id,omitempty" db:"forfeiting_team_id"` // 棄権・不出場・失格となった登録チームのID
+	Sets             []SetScore `json:"sets,omitempty" db:"-"`                                // セット制の試合のセットごとのスコア（match_setsテーブル）
+	Winner           *string    `json:"winner,omitempty" db:"winner"`
+	WinnerID         *int       `json:"winner_id,omitempty" db:"winner_id"`                     // 勝者の登録チームのID（勝者はIDで識別する）
+	NextMatchID      *int       `json:"next_match_id,omitempty" db:"next_match_id"`             // 勝者の進出先試合ID
+	NextSlot         *int       `json:"next_slot,omitempty" db:"next_slot"`                     // 勝者の進出先の枠
+	LoserNextMatchID *int       `json:"loser_next_match_id,omitempty" db:"loser_next_match_id"` // 敗者の進出先試合ID
+	LoserNextSlot    *int       `json:"loser_next_slot,omitempty" db:"loser_next_slot"`         // 敗者の進出先の枠
+	Status           string     `json:"status" db:"status"`                                     // データベース互換性のため文字列型を維持
+	ScheduledAt      time.Time  `json:"scheduled_at" db:"scheduled_at"`
+	PlannedAt        *time.Time `json:"planned_at,omitempty" db:"planned_at"` // 後ろ倒しする前に予定していた開始時刻（遅れていない場合はnull）
+	CourtID          *int       `json:"court_id,omitempty" db:"court_id"`     // 試合を行うコート（未割り当ての場合はnull）
+	SchedulePinned   bool       `json:"schedule_pinned" db:"schedule_pinned"` // 管理者が日程とコートを固定した場合はtrue（日程の自動作成で動かさない）
+	CompletedAt      *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // GetRound はRoundType列挙型を返す
@@ -73,10 +96,21 @@ func (m *Match) GetUpdatedAt() DateTime {
 }
 
 // MatchResult は試合結果を表す構造体
+// Score1・Score2 は正規時間のスコア。延長戦・PK戦を行った場合はそのスコアも記録する。
+// セット制の試合では Score1・Score2 は取ったセット数とし、セットごとのスコアを Sets に記録する。
+// 不戦勝・棄権・失格の場合は ResultType と棄権したチームを指定し、スコアはスポーツの既定値とする
 type MatchResult struct {
-	Score1 int    `json:"score1"`
-	Score2 int    `json:"score2"`
-	Winner string `json:"winner"`
+	Score1          int            `json:"score1"`
+	Score2          int            `json:"score2"`
+	ExtraTimeScore1 *int           `json:"extra_time_score1,omitempty"` // 延長戦終了時のスコア（正規時間を含む）
+	ExtraTimeScore2 *int           `json:"extra_time_score2,omitempty"`
+	PenaltyScore1   *int           `json:"penalty_score1,omitempty"` // PK戦のスコア
+	PenaltyScore2   *int           `json:"penalty_score2,omitempty"`
+	DecisionMethod  DecisionMethod `json:"decision_method,omitempty"` // 省略時はスコアから判定する
+	Sets            []SetScore     `json:"sets,omitempty"`            // セットごとのスコア（セット制の試合のみ）
+	ResultType      ResultType     `json:"result_type,omitempty"`     // 省略時は試合を行った結果
+	ForfeitingTeam  string         `json:"forfeiting_team,omitempty"` // 棄権・不出場・失格となったチーム（両チーム棄権の場合は空）
+	Winner          string         `json:"winner"`
 }
 
 // Validate は試合データの検証を行う
@@ -109,7 +143,7 @@ func (m *Match) Validate() error {
 		return errors.New("チーム2名は100文字以下である必要があります")
 	}
 	
-	if m.Team1 == m.Team2 {
+	if m.TeamInSlot(SlotTeam1).Is(m.TeamInSlot(SlotTeam2)) {
 		return errors.New("同じチーム同士の試合はできません")
 	}
 	
@@ -131,22 +165,176 @@ func (m *Match) Validate() error {
 
 // ValidateResult は試合結果の検証を行う
 func (mr *MatchResult) Validate() error {
-	if mr.Score1 < 0 || mr.Score2 < 0 {
-		return errors.New("スコアは0以上である必要があります")
+	if err := mr.validateScores(); err != nil {
+		return err
 	}
 	
 	if strings.TrimSpace(mr.Winner) == "" {
 		return errors.New("勝者は必須です")
 	}
 	
-	if mr.Score1 == mr.Score2 {
+	if mr.IsDraw() {
 		return errors.New("引き分けは許可されていません")
 	}
 	
 	return nil
 }
 
+// validateScores は正規時間・延長戦・PK戦のスコアと決着方法の整合性を検証する
+func (mr *MatchResult) validateScores() error {
+	if mr.Score1 < 0 || mr.Score2 < 0 {
+		return errors.New("スコアは0以上である必要があります")
+	}
+	
+	if (mr.ExtraTimeScore1 == nil) != (mr.ExtraTimeScore2 == nil) {
+		return errors.New("延長戦のスコアは両チーム分が必要です")
+	}
+	
+	if (mr.PenaltyScore1 == nil) != (mr.PenaltyScore2 == nil) {
+		return errors.New("PK戦のスコアは両チーム分が必要です")
+	}
+	
+	if mr.HasExtraTime() {
+		if mr.Score1 != mr.Score2 {
+			return errors.New("延長戦は正規時間が同点の場合のみ行われます")
+		}
+		if *mr.ExtraTimeScore1 < mr.Score1 || *mr.ExtraTimeScore2 < mr.Score2 {
+			return errors.New("延長戦終了時のスコアは正規時間のスコア以上である必要があります")
+		}
+	}
+	
+	if mr.HasPenalties() {
+		if score1, score2 := mr.PlayedScore(); score1 != score2 {
+			return errors.New("PK戦は同点の場合のみ行われます")
+		}
+		if *mr.PenaltyScore1 < 0 || *mr.PenaltyScore2 < 0 {
+			return errors.New("スコアは0以上である必要があります")
+		}
+		if *mr.PenaltyScore1 == *mr.PenaltyScore2 {
+			return errors.New("PK戦のスコアは同点にできません")
+		}
+	}
+	
+	if len(mr.Sets) > 0 {
+		if err := validateSetScores(mr.Sets); err != nil {
+			return err
+		}
+		if mr.HasExtraTime() || mr.HasPenalties() {
+			return errors.New("セット制の試合では延長戦・PK戦は記録できません")
+		}
+		if won1, won2 := CountSetsWon(mr.Sets); mr.Score1 != won1 || mr.Score2 != won2 {
+			return errors.New("スコアとセットの結果が一致しません")
+		}
+	}
+	
+	if mr.DecisionMethod != "" {
+		if !mr.DecisionMethod.IsValid() {
+			return errors.New("無効な決着方法です")
+		}
+		if mr.DecisionMethod != mr.Decision() {
+			return errors.New("決着方法とスコアが一致しません")
+		}
+	}
+	
+	return nil
+}
+
+// DeriveScoreFromSets はセットごとのスコアから試合のスコア（取ったセット数）を設定する
+func (mr *MatchResult) DeriveScoreFromSets() {
+	if len(mr.Sets) > 0 {
+		mr.Score1, mr.Score2 = CountSetsWon(mr.Sets)
+	}
+}
+
+// ValidateSetsForSport はセットごとのスコアをスポーツの規則に照らして検証する（セットがない場合は検証しない）
+func (mr *MatchResult) ValidateSetsForSport(sport SportType) error {
+	if len(mr.Sets) == 0 {
+		return nil
+	}
+	rules, ok := SetRulesForSport(sport)
+	if !ok {
+		return errors.New("このスポーツはセット制ではありません")
+	}
+	return rules.ValidateSets(mr.Sets)
+}
+
+// HasExtraTime は延長戦を行ったかどうかを返す
+func (mr *MatchResult) HasExtraTime() bool {
+	return mr.ExtraTimeScore1 != nil && mr.ExtraTimeScore2 != nil
+}
+
+// HasPenalties はPK戦を行ったかどうかを返す
+func (mr *MatchResult) HasPenalties() bool {
+	return mr.PenaltyScore1 != nil && mr.PenaltyScore2 != nil
+}
+
+// PlayedScore は試合終了時のスコア（延長戦を行った場合は延長戦終了時、PK戦は含まない）を返す
+func (mr *MatchResult) PlayedScore() (int, int) {
+	if mr.HasExtraTime() {
+		return *mr.ExtraTimeScore1, *mr.ExtraTimeScore2
+	}
+	return mr.Score1, mr.Score2
+}
+
+// decidingScore は勝敗を決めたスコア（PK戦を行った場合はPK戦のスコア）を返す
+func (mr *MatchResult) decidingScore() (int, int) {
+	if mr.HasPenalties() {
+		return *mr.PenaltyScore1, *mr.PenaltyScore2
+	}
+	return mr.PlayedScore()
+}
+
+// Decision はスコアから決着方法を判定する
+func (mr *MatchResult) Decision() DecisionMethod {
+	switch {
+	case mr.HasPenalties():
+		return DecisionPenaltiesEnum
+	case mr.HasExtraTime():
+		return DecisionExtraTimeEnum
+	default:
+		return DecisionRegulationEnum
+	}
+}
+
+// IsDraw は引き分けの結果（延長戦を含めて同点で、PK戦を行っていない）かどうかを返す
+// 両チーム棄権は引き分けではなく両チームの負けとする
+func (mr *MatchResult) IsDraw() bool {
+	if mr.IsDoubleForfeit() {
+		return false
+	}
+	score1, score2 := mr.decidingScore()
+	return score1 == score2
+}
+
+// ValidateForRound はラウンドの形式に応じて試合結果を検証する
+// 引き分けが認められるラウンドでは、勝者を指定しない同点の結果を許可する。
+// 延長戦・PK戦は引き分けのないラウンドでのみ認める。不戦勝・棄権・失格はどのラウンドでも認める
+func (mr *MatchResult) ValidateForRound(round RoundType, team1, team2 string) error {
+	if mr.IsForfeit() {
+		return mr.validateForfeit(team1, team2)
+	}
+	
+	if round.AllowsDraw() && (mr.HasExtraTime() || mr.HasPenalties()) {
+		return errors.New("延長戦・PK戦は引き分けのないラウンドでのみ行われます")
+	}
+	
+	if !mr.IsDraw() || !round.AllowsDraw() {
+		return mr.ValidateResultWithTeams(team1, team2)
+	}
+	
+	if err := mr.validateScores(); err != nil {
+		return err
+	}
+	
+	if strings.TrimSpace(mr.Winner) != "" {
+		return errors.New("引き分けの場合は勝者を指定できません")
+	}
+	
+	return nil
+}
+
 // ValidateResultWithTeams は試合結果とチーム名の整合性を検証する
+// 勝者は勝敗を決めたスコア（延長戦・PK戦を行った場合はそのスコア）と一致する必要がある
 func (mr *MatchResult) ValidateResultWithTeams(team1, team2 string) error {
 	if err := mr.Validate(); err != nil {
 		return err
@@ -158,17 +346,113 @@ func (mr *MatchResult) ValidateResultWithTeams(team1, team2 string) error {
 	}
 	
 	// スコアと勝者の整合性チェック
-	if mr.Score1 > mr.Score2 && mr.Winner != team1 {
+	score1, score2 := mr.decidingScore()
+	if score1 > score2 && mr.Winner != team1 {
 		return errors.New("スコアと勝者が一致しません")
 	}
 	
-	if mr.Score2 > mr.Score1 && mr.Winner != team2 {
+	if score2 > score1 && mr.Winner != team2 {
 		return errors.New("スコアと勝者が一致しません")
 	}
 	
 	return nil
 }
 
+// ApplyResult は試合結果（正規時間・延長戦・PK戦のスコア、決着方法、勝者）を試合に設定する
+// 結果のチーム名は試合の枠のチームに対応させ、勝者・棄権したチームを枠のチームIDで記録する。
+// 引き分け・両チーム棄権の場合は勝者をnullにする。ステータスは変更しない
+func (m *Match) ApplyResult(result MatchResult) {
+	score1, score2 := result.Score1, result.Score2
+	m.Score1, m.Score2 = &score1, &score2
+	m.ExtraTimeScore1, m.ExtraTimeScore2 = copyIntPtr(result.ExtraTimeScore1), copyIntPtr(result.ExtraTimeScore2)
+	m.PenaltyScore1, m.PenaltyScore2 = copyIntPtr(result.PenaltyScore1), copyIntPtr(result.PenaltyScore2)
+	m.Sets = numberSets(result.Sets)
+	
+	// 試合を行わずに決着した結果には決着方法を記録しない
+	resultType := ResultTypePlayedEnum.String()
+	m.DecisionMethod, m.ForfeitingTeam, m.ForfeitingTeamID = nil, nil, nil
+	if result.IsForfeit() {
+		resultType = result.ResultType.String()
+		if result.ForfeitingTeam != "" {
+			forfeitingTeam := result.ForfeitingTeam
+			m.ForfeitingTeam, m.ForfeitingTeamID = &forfeitingTeam, m.teamIDOf(forfeitingTeam)
+		}
+	} else {
+		decision := result.Decision().String()
+		m.DecisionMethod = &decision
+	}
+	m.ResultType = &resultType
+	
+	m.Winner, m.WinnerID = nil, nil
+	if !result.IsDraw() && !result.IsDoubleForfeit() {
+		winner := result.Winner
+		m.Winner, m.WinnerID = &winner, m.teamIDOf(winner)
+	}
+}
+
+// ClearResult は試合結果（スコア、決着方法、勝者、完了日時）を取り消す。ステータスは変更しない
+func (m *Match) ClearResult() {
+	m.Score1, m.Score2 = nil, nil
+	m.ExtraTimeScore1, m.ExtraTimeScore2 = nil, nil
+	m.PenaltyScore1, m.PenaltyScore2 = nil, nil
+	m.DecisionMethod = nil
+	m.ResultType, m.ForfeitingTeam, m.ForfeitingTeamID = nil, nil, nil
+	m.Sets = nil
+	m.Winner, m.WinnerID = nil, nil
+	m.CompletedAt = nil
+}
+
+// GetDecisionMethod は決着方法を返す（未設定の完了試合は正規時間で決着とみなす）
+// 試合を行わずに決着した場合は空文字を返す
+func (m *Match) GetDecisionMethod() DecisionMethod {
+	if m.IsForfeit() {
+		return ""
+	}
+	if m.DecisionMethod != nil && *m.DecisionMethod != "" {
+		return DecisionMethod(*m.DecisionMethod)
+	}
+	if !m.HasResult() {
+		return ""
+	}
+	return DecisionRegulationEnum
+}
+
+// ScoreDisplay はスコアの表示用文字列を返す（例: "1-1 (延長 2-2, PK 4-3)"、"2-1 (25-20, 22-25, 15-13)"、"3-0 (不戦勝)"）。結果がない場合は空文字
+func (m *Match) ScoreDisplay() string {
+	if m.Score1 == nil || m.Score2 == nil {
+		return ""
+	}
+	
+	display := fmt.Sprintf("%d-%d", *m.Score1, *m.Score2)
+	var details []string
+	if m.IsForfeit() {
+		// 既定のスコアのため、セットごとのスコアは表示しない
+		return display + " (" + m.GetResultType().Label() + ")"
+	}
+	for _, set := range m.Sets {
+		details = append(details, fmt.Sprintf("%d-%d", set.Score1, set.Score2))
+	}
+	if m.ExtraTimeScore1 != nil && m.ExtraTimeScore2 != nil {
+		details = append(details, fmt.Sprintf("延長 %d-%d", *m.ExtraTimeScore1, *m.ExtraTimeScore2))
+	}
+	if m.PenaltyScore1 != nil && m.PenaltyScore2 != nil {
+		details = append(details, fmt.Sprintf("PK %d-%d", *m.PenaltyScore1, *m.PenaltyScore2))
+	}
+	if len(details) > 0 {
+		display += " (" + strings.Join(details, ", ") + ")"
+	}
+	return display
+}
+
+// copyIntPtr はintのポインタの複製を返す
+func copyIntPtr(value *int) *int {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
 // IsPending は試合が未実施かどうかを返す
 func (m *Match) IsPending() bool {
 	return m.GetStatus() == MatchStatusPendingEnum
@@ -189,17 +473,173 @@ func (m *Match) IsCancelled() bool {
 	return m.GetStatus() == MatchStatusCancelledEnum
 }
 
-// HasResult は試合結果が入力されているかどうかを返す
+// IsPostponed は試合が延期されて新しい開始時刻が未定かどうかを返す
+func (m *Match) IsPostponed() bool {
+	return m.GetStatus() == MatchStatusPostponedEnum
+}
+
+// IsSuspended は試合が中断しているかどうかを返す
+func (m *Match) IsSuspended() bool {
+	return m.GetStatus() == MatchStatusSuspendedEnum
+}
+
+// IsRescheduled は延期・中断した試合に新しい開始時刻が決まったかどうかを返す
+func (m *Match) IsRescheduled() bool {
+	return m.GetStatus() == MatchStatusRescheduledEnum
+}
+
+// HasResult は試合結果が入力されているかどうかを返す（引き分けは勝者なし）
 func (m *Match) HasResult() bool {
-	return m.Score1 != nil && m.Score2 != nil && m.Winner != nil
+	return m.Score1 != nil && m.Score2 != nil && (m.Winner != nil || *m.Score1 == *m.Score2)
 }
 
-// CanUpdateResult は試合結果を更新可能かどうかを返す
+// IsDraw は試合が引き分けで完了しているかどうかを返す（両チーム棄権は含まない）
+func (m *Match) IsDraw() bool {
+	return m.IsCompleted() && !m.IsDoubleForfeit() && m.Winner == nil && m.Score1 != nil && m.Score2 != nil && *m.Score1 == *m.Score2
+}
+
+// HasUndecidedTeams は対戦チームが未確定（TBDまたはグループ順位の枠）かどうかを返す
+func (m *Match) HasUndecidedTeams() bool {
+	return IsUndecidedTeam(m.Team1) || IsUndecidedTeam(m.Team2)
+}
+
+// CanUpdateResult は試合結果を更新可能かどうか（終了に変更できるステータスかどうか）を返す
 func (m *Match) CanUpdateResult() bool {
-	return m.IsPending() || m.IsInProgress()
+	return m.GetStatus().CanTransitionTo(MatchStatusCompletedEnum)
 }
 
 // CanDelete は試合を削除可能かどうかを返す
 func (m *Match) CanDelete() bool {
 	return !m.IsCompleted()
-}
\ No newline at end of file
+}
+
+// GetLoser は敗者チーム名を返す（勝者が未確定の場合は空文字）
+func (m *Match) GetLoser() string {
+	switch m.WinnerSlot() {
+	case SlotTeam1:
+		return m.Team2
+	case SlotTeam2:
+		return m.Team1
+	default:
+		return ""
+	}
+}
+
+// WinnerSlot は勝者の枠を返す（勝者が未確定の場合は0）
+// 勝者はチームIDで判定し、IDのない勝者（未登録のチーム）はチーム名で判定する
+func (m *Match) WinnerSlot() int {
+	if m.WinnerID != nil {
+		return m.slotOfID(*m.WinnerID)
+	}
+	if m.Winner == nil {
+		return 0
+	}
+	return m.slotOfName(*m.Winner)
+}
+
+// ForfeitingSlot は棄権・不出場・失格となったチームの枠を返す（該当しない場合・両チーム棄権の場合は0）
+func (m *Match) ForfeitingSlot() int {
+	if !m.IsForfeit() {
+		return 0
+	}
+	if m.ForfeitingTeamID != nil {
+		return m.slotOfID(*m.ForfeitingTeamID)
+	}
+	if m.ForfeitingTeam == nil {
+		return 0
+	}
+	return m.slotOfName(*m.ForfeitingTeam)
+}
+
+// slotOfID は登録チームのIDが入っている枠を返す（試合に参加していない場合は0）
+func (m *Match) slotOfID(teamID int) int {
+	switch {
+	case m.Team1ID != nil && *m.Team1ID == teamID:
+		return SlotTeam1
+	case m.Team2ID != nil && *m.Team2ID == teamID:
+		return SlotTeam2
+	default:
+		return 0
+	}
+}
+
+// slotOfName はチーム名が入っている枠を返す（試合に参加していない場合は0）
+// 同じ試合の2チームは名前で区別できるため、結果はチーム名で受け付けて枠のチームIDで記録する
+func (m *Match) slotOfName(name string) int {
+	switch name {
+	case m.Team1:
+		return SlotTeam1
+	case m.Team2:
+		return SlotTeam2
+	default:
+		return 0
+	}
+}
+
+// teamIDOf は試合に参加しているチーム名の登録チームのIDを返す（参加していない・未登録のチームはnil）
+func (m *Match) teamIDOf(name string) *int {
+	if slot := m.slotOfName(name); slot != 0 {
+		return copyIntPtr(m.TeamInSlot(slot).ID)
+	}
+	return nil
+}
+
+// otherSlot は対戦相手の枠を返す
+func otherSlot(slot int) int {
+	if slot == SlotTeam1 {
+		return SlotTeam2
+	}
+	return SlotTeam1
+}
+
+// GetTeamInSlot は指定した枠のチーム名を返す
+func (m *Match) GetTeamInSlot(slot int) string {
+	return m.TeamInSlot(slot).Name
+}
+
+// TeamInSlot は指定した枠のチームを返す
+func (m *Match) TeamInSlot(slot int) TeamRef {
+	if slot == SlotTeam2 {
+		return TeamRef{ID: m.Team2ID, Name: m.Team2}
+	}
+	return TeamRef{ID: m.Team1ID, Name: m.Team1}
+}
+
+// SetTeamInSlot は指定した枠にチームを配置する
+func (m *Match) SetTeamInSlot(slot int, team TeamRef) error {
+	switch slot {
+	case SlotTeam1:
+		m.Team1, m.Team1ID = team.Name, copyIntPtr(team.ID)
+	case SlotTeam2:
+		m.Team2, m.Team2ID = team.Name, copyIntPtr(team.ID)
+	default:
+		return errors.New("無効なブラケット枠です")
+	}
+	return nil
+}
+
+// GetProgression は保存されている進出先からBracketProgressionを返す
+func (m *Match) GetProgression() BracketProgression {
+	var progression BracketProgression
+	if m.NextMatchID != nil && m.NextSlot != nil {
+		progression.Winner = &BracketSlot{MatchID: *m.NextMatchID, Slot: *m.NextSlot}
+	}
+	if m.LoserNextMatchID != nil && m.LoserNextSlot != nil {
+		progression.Loser = &BracketSlot{MatchID: *m.LoserNextMatchID, Slot: *m.LoserNextSlot}
+	}
+	return progression
+}
+
+// SetProgression は進出先を設定する
+func (m *Match) SetProgression(progression BracketProgression) {
+	m.NextMatchID, m.NextSlot = nil, nil
+	m.LoserNextMatchID, m.LoserNextSlot = nil, nil
+	if progression.Winner != nil {
+		matchID, slot := progression.Winner.MatchID, progression.Winner.Slot
+		m.NextMatchID, m.NextSlot = &matchID, &slot
+	}
+	if progression.Loser != nil {
+		matchID, slot := progression.Loser.MatchID, progression.Loser.Slot
+		m.LoserNextMatchID, m.LoserNextSlot = &matchID, &slot
+	}
+}
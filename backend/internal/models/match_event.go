@@ -0,0 +1,253 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MatchEventType は試合中の出来事の種類を表す列挙型
+type MatchEventType string
+
+const (
+	MatchEventKickoff MatchEventType = "kickoff" // 試合開始
+	MatchEventGoal    MatchEventType = "goal"    // 得点（セット制でない試合）
+	MatchEventSetWon  MatchEventType = "set_won" // セット終了（セット制の試合、セットのスコアを記録する）
+	MatchEventTimeout MatchEventType = "timeout" // タイムアウト
+	MatchEventCard    MatchEventType = "card"    // 警告・退場
+	MatchEventEnd     MatchEventType = "end"     // 試合終了
+)
+
+// String はMatchEventTypeの文字列表現を返す
+func (t MatchEventType) String() string {
+	return string(t)
+}
+
+// IsValid はMatchEventTypeが有効かどうかを判定する
+func (t MatchEventType) IsValid() bool {
+	switch t {
+	case MatchEventKickoff, MatchEventGoal, MatchEventSetWon, MatchEventTimeout, MatchEventCard, MatchEventEnd:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequiresTeam はチームの指定が必要な出来事かどうかを返す
+func (t MatchEventType) RequiresTeam() bool {
+	switch t {
+	case MatchEventGoal, MatchEventSetWon, MatchEventTimeout, MatchEventCard:
+		return true
+	default:
+		return false
+	}
+}
+
+// AttributableToPlayer は選手の記録として残す出来事かどうかを返す
+func (t MatchEventType) AttributableToPlayer() bool {
+	return t == MatchEventGoal || t == MatchEventCard
+}
+
+// CardColor はカードの色
+type CardColor string
+
+const (
+	CardYellow CardColor = "yellow" // 警告
+	CardRed    CardColor = "red"    // 退場
+)
+
+// IsValid はCardColorが有効かどうかを判定する
+func (c CardColor) IsValid() bool {
+	return c == CardYellow || c == CardRed
+}
+
+// MatchEvent は試合の経過（タイムライン）の1件の出来事
+type MatchEvent struct {
+	ID         int            `json:"id" db:"id"`
+	MatchID    int            `json:"match_id" db:"match_id"`
+	Sequence   int            `json:"sequence" db:"sequence"`                 // 試合内の通し番号（1始まり）
+	Type       MatchEventType `json:"type" db:"event_type"`                   // 出来事の種類
+	Slot       *int           `json:"slot,omitempty" db:"slot"`               // 対象チームの枠（1: team1, 2: team2）
+	Minute     *int           `json:"minute,omitempty" db:"minute"`           // 試合開始からの経過分
+	SetScore   *SetScore      `json:"set_score,omitempty" db:"-"`             // set_won の場合のセットのスコア
+	Card       *CardColor     `json:"card,omitempty" db:"card"`               // card の場合のカードの色
+	PlayerID   *int           `json:"player_id,omitempty" db:"player_id"`     // 得点・警告の選手（対象チームの名簿の選手）
+	PlayerName string         `json:"player_name,omitempty" db:"-"`           // 選手の氏名（表示用）
+	Note       string         `json:"note,omitempty" db:"note"`               // 補足（選手名など）
+	RecordedBy *int           `json:"recorded_by,omitempty" db:"recorded_by"` // 記録したユーザーID
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
+
+// LiveState は試合経過から判定した試合の進行状況
+type LiveState string
+
+const (
+	LiveStateNotStarted LiveState = "not_started" // 試合開始前
+	LiveStateLive       LiveState = "live"        // 試合中
+	LiveStateEnded      LiveState = "ended"       // 試合終了
+)
+
+// LiveScore は試合経過から算出した現在のスコア
+// セット制の試合では Score1・Score2 は取ったセット数とし、終了したセットのスコアを Sets に記録する
+type LiveScore struct {
+	MatchID int        `json:"match_id"`
+	State   LiveState  `json:"state"`
+	Score1  int        `json:"score1"`
+	Score2  int        `json:"score2"`
+	Sets    []SetScore `json:"sets,omitempty"`
+	Cards1  int        `json:"cards1"` // チーム1が受けたカードの数
+	Cards2  int        `json:"cards2"`
+	Events  int        `json:"events"` // 記録された出来事の数
+}
+
+// Validate は出来事の内容を検証する（試合経過との整合性は ValidateNext で検証する）
+func (e *MatchEvent) Validate() error {
+	if !e.Type.IsValid() {
+		return errors.New("無効な出来事の種類です")
+	}
+
+	if e.Type.RequiresTeam() {
+		if e.Slot == nil {
+			return errors.New("チームの指定は必須です")
+		}
+		if *e.Slot != SlotTeam1 && *e.Slot != SlotTeam2 {
+			return errors.New("無効なチームの枠です")
+		}
+	} else if e.Slot != nil {
+		return errors.New("この出来事にはチームを指定できません")
+	}
+
+	if e.PlayerID != nil {
+		if !e.Type.AttributableToPlayer() {
+			return errors.New("選手を指定できるのは得点・警告・退場のみです")
+		}
+		if *e.PlayerID <= 0 {
+			return errors.New("無効な選手IDです")
+		}
+	}
+
+	if e.Minute != nil && *e.Minute < 0 {
+		return errors.New("経過時間は0以上である必要があります")
+	}
+
+	if e.Type == MatchEventSetWon {
+		if e.SetScore == nil {
+			return errors.New("セットのスコアは必須です")
+		}
+		if e.SetScore.Score1 < 0 || e.SetScore.Score2 < 0 {
+			return errors.New("スコアは0以上である必要があります")
+		}
+		if e.SetScore.Score1 == e.SetScore.Score2 {
+			return errors.New("セットのスコアが同点です")
+		}
+		if winner := e.SetScore.winnerSlot(); winner != *e.Slot {
+			return errors.New("セットのスコアとセットを取ったチームが一致しません")
+		}
+	} else if e.SetScore != nil {
+		return errors.New("セットのスコアはセット終了時のみ記録できます")
+	}
+
+	if e.Type == MatchEventCard {
+		if e.Card == nil || !e.Card.IsValid() {
+			return errors.New("カードの色（yellow, red）は必須です")
+		}
+	} else if e.Card != nil {
+		return errors.New("カードの色は警告・退場時のみ記録できます")
+	}
+
+	if len(e.Note) > 200 {
+		return errors.New("補足は200文字以下である必要があります")
+	}
+
+	return nil
+}
+
+// ValidateForSport はスポーツの得点方式に照らして出来事を検証する
+// セット制の試合はセット終了で、それ以外の試合は得点で記録する
+func (e *MatchEvent) ValidateForSport(sport SportType) error {
+	rules, setBased := SetRulesForSport(sport)
+	switch {
+	case e.Type == MatchEventGoal && setBased:
+		return errors.New("セット制の試合ではセット終了を記録してください")
+	case e.Type == MatchEventSetWon && !setBased:
+		return errors.New("このスポーツはセット制ではありません")
+	}
+
+	if e.Type == MatchEventSetWon && e.SetScore != nil {
+		high, low := e.SetScore.Score1, e.SetScore.Score2
+		if low > high {
+			high, low = low, high
+		}
+		if high-low < rules.WinBy {
+			return fmt.Errorf("セットは%d点差がついていません", rules.WinBy)
+		}
+	}
+	return nil
+}
+
+// ValidateNext は記録済みの試合経過の後に出来事を追加できるかどうかを検証する
+// 試合開始の前と試合終了の後には出来事を記録できない
+func (e *MatchEvent) ValidateNext(events []*MatchEvent) error {
+	state := DeriveLiveScore(0, events).State
+
+	switch {
+	case e.Type == MatchEventKickoff && state != LiveStateNotStarted:
+		return errors.New("試合は既に開始しています")
+	case e.Type != MatchEventKickoff && state == LiveStateNotStarted:
+		return errors.New("試合開始前の出来事は記録できません")
+	case state == LiveStateEnded:
+		return errors.New("試合終了後の出来事は記録できません")
+	}
+	return nil
+}
+
+// DeriveLiveScore は試合経過（通し番号順）から現在のスコアと進行状況を算出する
+func DeriveLiveScore(matchID int, events []*MatchEvent) *LiveScore {
+	live := &LiveScore{MatchID: matchID, State: LiveStateNotStarted, Events: len(events)}
+
+	for _, event := range events {
+		switch event.Type {
+		case MatchEventKickoff:
+			live.State = LiveStateLive
+		case MatchEventEnd:
+			live.State = LiveStateEnded
+		case MatchEventGoal:
+			live.addPoint(event.Slot)
+		case MatchEventSetWon:
+			live.addPoint(event.Slot)
+			if event.SetScore != nil {
+				set := *event.SetScore
+				set.SetNumber = len(live.Sets) + 1
+				live.Sets = append(live.Sets, set)
+			}
+		case MatchEventCard:
+			if event.Slot != nil && *event.Slot == SlotTeam2 {
+				live.Cards2++
+			} else if event.Slot != nil {
+				live.Cards1++
+			}
+		}
+	}
+
+	return live
+}
+
+// addPoint は指定した枠のチームのスコアを1加算する
+func (l *LiveScore) addPoint(slot *int) {
+	if slot == nil {
+		return
+	}
+	if *slot == SlotTeam2 {
+		l.Score2++
+	} else {
+		l.Score1++
+	}
+}
+
+// winnerSlot はセットを取ったチームの枠を返す
+func (s SetScore) winnerSlot() int {
+	if s.Score2 > s.Score1 {
+		return SlotTeam2
+	}
+	return SlotTeam1
+}
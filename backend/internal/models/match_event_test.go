@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestMatchEvent_Validate(t *testing.T) {
+	yellow := CardYellow
+
+	tests := []struct {
+		name    string
+		event   MatchEvent
+		wantErr bool
+	}{
+		{name: "試合開始", event: MatchEvent{Type: MatchEventKickoff}},
+		{name: "得点", event: MatchEvent{Type: MatchEventGoal, Slot: intPtr(SlotTeam1), Minute: intPtr(12)}},
+		{name: "セット終了", event: MatchEvent{Type: MatchEventSetWon, Slot: intPtr(SlotTeam2), SetScore: &SetScore{Score1: 20, Score2: 25}}},
+		{name: "警告", event: MatchEvent{Type: MatchEventCard, Slot: intPtr(SlotTeam2), Card: &yellow}},
+		{name: "無効な種類", event: MatchEvent{Type: "foul"}, wantErr: true},
+		{name: "得点のチームがない", event: MatchEvent{Type: MatchEventGoal}, wantErr: true},
+		{name: "無効な枠", event: MatchEvent{Type: MatchEventGoal, Slot: intPtr(3)}, wantErr: true},
+		{name: "試合開始にチームを指定", event: MatchEvent{Type: MatchEventKickoff, Slot: intPtr(SlotTeam1)}, wantErr: true},
+		{name: "セットのスコアがない", event: MatchEvent{Type: MatchEventSetWon, Slot: intPtr(SlotTeam1)}, wantErr: true},
+		{name: "セットを取ったチームが不一致", event: MatchEvent{Type: MatchEventSetWon, Slot: intPtr(SlotTeam1), SetScore: &SetScore{Score1: 20, Score2: 25}}, wantErr: true},
+		{name: "カードの色がない", event: MatchEvent{Type: MatchEventCard, Slot: intPtr(SlotTeam1)}, wantErr: true},
+		{name: "選手の得点", event: MatchEvent{Type: MatchEventGoal, Slot: intPtr(SlotTeam1), PlayerID: intPtr(7)}},
+		{name: "選手の警告", event: MatchEvent{Type: MatchEventCard, Slot: intPtr(SlotTeam2), Card: &yellow, PlayerID: intPtr(7)}},
+		{name: "タイムアウトに選手を指定", event: MatchEvent{Type: MatchEventTimeout, Slot: intPtr(SlotTeam1), PlayerID: intPtr(7)}, wantErr: true},
+		{name: "無効な選手ID", event: MatchEvent{Type: MatchEventGoal, Slot: intPtr(SlotTeam1), PlayerID: intPtr(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.event.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchEvent_ValidateForSport(t *testing.T) {
+	goal := MatchEvent{Type: MatchEventGoal, Slot: intPtr(SlotTeam1)}
+	setWon := MatchEvent{Type: MatchEventSetWon, Slot: intPtr(SlotTeam1), SetScore: &SetScore{Score1: 25, Score2: 20}}
+
+	if err := goal.ValidateForSport(SportTypeSoccer); err != nil {
+		t.Errorf("ValidateForSport() goal in soccer error = %v", err)
+	}
+	if err := goal.ValidateForSport(SportTypeVolleyball); err == nil {
+		t.Error("ValidateForSport() should reject goals in volleyball")
+	}
+	if err := setWon.ValidateForSport(SportTypeVolleyball); err != nil {
+		t.Errorf("ValidateForSport() set in volleyball error = %v", err)
+	}
+	if err := setWon.ValidateForSport(SportTypeSoccer); err == nil {
+		t.Error("ValidateForSport() should reject sets in soccer")
+	}
+
+	oneLead := MatchEvent{Type: MatchEventSetWon, Slot: intPtr(SlotTeam1), SetScore: &SetScore{Score1: 11, Score2: 10}}
+	if err := oneLead.ValidateForSport(SportTypeTableTennis); err == nil {
+		t.Error("ValidateForSport() should reject a set won by one point")
+	}
+}
+
+func TestMatchEvent_ValidateNext(t *testing.T) {
+	kickoff := &MatchEvent{Type: MatchEventKickoff}
+	goal := &MatchEvent{Type: MatchEventGoal, Slot: intPtr(SlotTeam1)}
+	end := &MatchEvent{Type: MatchEventEnd}
+
+	if err := goal.ValidateNext(nil); err == nil {
+		t.Error("ValidateNext() should reject events before kickoff")
+	}
+	if err := kickoff.ValidateNext([]*MatchEvent{kickoff}); err == nil {
+		t.Error("ValidateNext() should reject a second kickoff")
+	}
+	if err := goal.ValidateNext([]*MatchEvent{kickoff}); err != nil {
+		t.Errorf("ValidateNext() error = %v", err)
+	}
+	if err := goal.ValidateNext([]*MatchEvent{kickoff, end}); err == nil {
+		t.Error("ValidateNext() should reject events after the end")
+	}
+}
+
+func TestDeriveLiveScore(t *testing.T) {
+	red := CardRed
+	events := []*MatchEvent{
+		{Type: MatchEventKickoff},
+		{Type: MatchEventGoal, Slot: intPtr(SlotTeam1)},
+		{Type: MatchEventCard, Slot: intPtr(SlotTeam2), Card: &red},
+		{Type: MatchEventGoal, Slot: intPtr(SlotTeam2)},
+		{Type: MatchEventTimeout, Slot: intPtr(SlotTeam1)},
+		{Type: MatchEventGoal, Slot: intPtr(SlotTeam1)},
+	}
+
+	live := DeriveLiveScore(7, events)
+	if live.State != LiveStateLive || live.Score1 != 2 || live.Score2 != 1 || live.Cards2 != 1 || live.Events != 6 {
+		t.Errorf("DeriveLiveScore() = %+v", live)
+	}
+
+	live = DeriveLiveScore(7, append(events, &MatchEvent{Type: MatchEventEnd}))
+	if live.State != LiveStateEnded {
+		t.Errorf("DeriveLiveScore() state = %s, want %s", live.State, LiveStateEnded)
+	}
+
+	sets := DeriveLiveScore(8, []*MatchEvent{
+		{Type: MatchEventKickoff},
+		{Type: MatchEventSetWon, Slot: intPtr(SlotTeam1), SetScore: &SetScore{Score1: 25, Score2: 20}},
+		{Type: MatchEventSetWon, Slot: intPtr(SlotTeam2), SetScore: &SetScore{Score1: 22, Score2: 25}},
+	})
+	if sets.Score1 != 1 || sets.Score2 != 1 || len(sets.Sets) != 2 || sets.Sets[1].SetNumber != 2 {
+		t.Errorf("DeriveLiveScore() sets = %+v", sets)
+	}
+}
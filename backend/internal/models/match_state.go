@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// matchTransitions は試合ステータスの遷移表（遷移元 → 遷移できるステータス）
+//
+//	pending     → in_progress, completed, postponed, rescheduled, cancelled
+//	rescheduled → in_progress, completed, postponed, rescheduled, cancelled
+//	in_progress → completed, suspended, pending, cancelled
+//	suspended   → in_progress, completed, postponed, rescheduled, pending, cancelled
+//	postponed   → rescheduled, cancelled
+//	completed   → pending（結果の訂正で後続の試合をやり直す場合のみ、ResetByCorrection で行う）
+//	cancelled   → なし
+var matchTransitions = map[MatchStatus][]MatchStatus{
+	MatchStatusPendingEnum: {
+		MatchStatusInProgressEnum, MatchStatusCompletedEnum, MatchStatusPostponedEnum, MatchStatusRescheduledEnum, MatchStatusCancelledEnum,
+	},
+	MatchStatusRescheduledEnum: {
+		MatchStatusInProgressEnum, MatchStatusCompletedEnum, MatchStatusPostponedEnum, MatchStatusRescheduledEnum, MatchStatusCancelledEnum,
+	},
+	MatchStatusInProgressEnum: {
+		MatchStatusCompletedEnum, MatchStatusSuspendedEnum, MatchStatusPendingEnum, MatchStatusCancelledEnum,
+	},
+	MatchStatusSuspendedEnum: {
+		MatchStatusInProgressEnum, MatchStatusCompletedEnum, MatchStatusPostponedEnum, MatchStatusRescheduledEnum, MatchStatusPendingEnum, MatchStatusCancelledEnum,
+	},
+	MatchStatusPostponedEnum: {
+		MatchStatusRescheduledEnum, MatchStatusCancelledEnum,
+	},
+	MatchStatusCompletedEnum: {
+		MatchStatusPendingEnum,
+	},
+	MatchStatusCancelledEnum: {},
+}
+
+// MatchStatuses は全ての試合ステータスをデータベースのENUMの定義順に返す
+func MatchStatuses() []MatchStatus {
+	return []MatchStatus{
+		MatchStatusPendingEnum,
+		MatchStatusInProgressEnum,
+		MatchStatusCompletedEnum,
+		MatchStatusCancelledEnum,
+		MatchStatusPostponedEnum,
+		MatchStatusSuspendedEnum,
+		MatchStatusRescheduledEnum,
+	}
+}
+
+// Label は試合ステータスの表示名を返す
+func (m MatchStatus) Label() string {
+	switch m {
+	case MatchStatusPendingEnum:
+		return "未実施"
+	case MatchStatusInProgressEnum:
+		return "試合中"
+	case MatchStatusCompletedEnum:
+		return "終了"
+	case MatchStatusCancelledEnum:
+		return "中止"
+	case MatchStatusPostponedEnum:
+		return "延期"
+	case MatchStatusSuspendedEnum:
+		return "中断"
+	case MatchStatusRescheduledEnum:
+		return "日程変更"
+	default:
+		return string(m)
+	}
+}
+
+// CanTransitionTo は遷移表で to への遷移が許されているかどうかを返す（ガード条件は含まない）
+func (m MatchStatus) CanTransitionTo(to MatchStatus) bool {
+	for _, next := range matchTransitions[m] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPlayable は試合をこれから行う予定かどうか（未実施・日程変更）を返す
+func (m MatchStatus) IsPlayable() bool {
+	return m == MatchStatusPendingEnum || m == MatchStatusRescheduledEnum
+}
+
+// MatchTransitionError は許可されていない試合ステータスの変更を表すエラー
+type MatchTransitionError struct {
+	MatchID int         `json:"match_id"`
+	From    MatchStatus `json:"from"`
+	To      MatchStatus `json:"to"`
+	Reason  string      `json:"reason"`
+}
+
+// Error はerrorインターフェースを実装する
+func (e *MatchTransitionError) Error() string {
+	return fmt.Sprintf("試合（ID: %d）を%sから%sにできません: %s", e.MatchID, e.From.Label(), e.To.Label(), e.Reason)
+}
+
+// newMatchTransitionError は試合ステータスの変更のエラーを作成する
+func newMatchTransitionError(m *Match, to MatchStatus, reason string) *MatchTransitionError {
+	return &MatchTransitionError{MatchID: m.ID, From: m.GetStatus(), To: to, Reason: reason}
+}
+
+// CheckTransition は試合を to に変更できるかを遷移表で確認する（ガード条件は TransitionTo で確認する）
+func (m *Match) CheckTransition(to MatchStatus) error {
+	if !to.IsValid() {
+		return newMatchTransitionError(m, to, "無効な試合ステータスです")
+	}
+	if !m.GetStatus().CanTransitionTo(to) {
+		return newMatchTransitionError(m, to, "この変更は許可されていません")
+	}
+	return nil
+}
+
+// TransitionTo は試合ステータスを to に変更する
+// 遷移表にない変更や、次のガード条件を満たさない変更は *MatchTransitionError を返し、試合は変更しない
+//   - 試合中にするには対戦チームが確定していること
+//   - 終了にするには試合結果が入力されていること
+//   - 未実施に戻すには試合結果が取り消されていること
+//   - 日程変更は新しい開始時刻が必要なため Reschedule で行う
+func (m *Match) TransitionTo(to MatchStatus) error {
+	if err := m.CheckTransition(to); err != nil {
+		return err
+	}
+
+	switch to {
+	case MatchStatusInProgressEnum:
+		if m.HasUndecidedTeams() {
+			return newMatchTransitionError(m, to, "対戦チームが確定していません")
+		}
+	case MatchStatusCompletedEnum:
+		if !m.HasResult() {
+			return newMatchTransitionError(m, to, "試合結果が入力されていません")
+		}
+	case MatchStatusPendingEnum:
+		if m.HasResult() {
+			return newMatchTransitionError(m, to, "試合結果が残っています")
+		}
+	case MatchStatusRescheduledEnum:
+		return newMatchTransitionError(m, to, "新しい開始時刻を指定してください")
+	}
+
+	m.SetStatus(to)
+	return nil
+}
+
+// ResetByCorrection は結果の訂正で対戦チームが変わった実施済み（終了・試合中）の試合の
+// 結果を取り消して未実施に戻す。それ以外の試合は *MatchTransitionError を返し、試合は変更しない
+func (m *Match) ResetByCorrection() error {
+	to := MatchStatusPendingEnum
+	if !m.IsCompleted() && !m.IsInProgress() {
+		return newMatchTransitionError(m, to, "結果の訂正でやり直せるのは終了・試合中の試合のみです")
+	}
+	if err := m.CheckTransition(to); err != nil {
+		return err
+	}
+
+	m.ClearResult()
+	return m.TransitionTo(to)
+}
+
+// Reschedule は試合に新しい開始時刻を決めて日程変更にする
+// 最初に予定していた開始時刻は PlannedAt に残す
+func (m *Match) Reschedule(at time.Time) error {
+	to := MatchStatusRescheduledEnum
+	if err := m.CheckTransition(to); err != nil {
+		return err
+	}
+	if at.IsZero() {
+		return newMatchTransitionError(m, to, "新しい開始時刻を指定してください")
+	}
+
+	if m.PlannedAt == nil && !m.ScheduledAt.IsZero() {
+		planned := m.ScheduledAt
+		m.PlannedAt = &planned
+	}
+	m.ScheduledAt = at
+	m.SetStatus(to)
+	return nil
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatch_TransitionTo(t *testing.T) {
+	decided := func(status MatchStatus) *Match {
+		return &Match{ID: 1, Team1: "IE4", Team2: "IS4", Status: string(status)}
+	}
+	withResult := func(status MatchStatus) *Match {
+		match := decided(status)
+		match.ApplyResult(MatchResult{Score1: 2, Score2: 1, Winner: "IE4"})
+		match.Status = string(status)
+		return match
+	}
+
+	tests := []struct {
+		name    string
+		match   *Match
+		to      MatchStatus
+		wantErr bool
+	}{
+		{name: "未実施から試合中", match: decided(MatchStatusPendingEnum), to: MatchStatusInProgressEnum},
+		{name: "試合中から中断", match: decided(MatchStatusInProgressEnum), to: MatchStatusSuspendedEnum},
+		{name: "中断から再開", match: decided(MatchStatusSuspendedEnum), to: MatchStatusInProgressEnum},
+		{name: "中断から延期", match: decided(MatchStatusSuspendedEnum), to: MatchStatusPostponedEnum},
+		{name: "日程変更から試合中", match: decided(MatchStatusRescheduledEnum), to: MatchStatusInProgressEnum},
+		{name: "延期から中止", match: decided(MatchStatusPostponedEnum), to: MatchStatusCancelledEnum},
+		{name: "結果の入力で終了", match: withResult(MatchStatusInProgressEnum), to: MatchStatusCompletedEnum},
+		{name: "延期から試合中は不可", match: decided(MatchStatusPostponedEnum), to: MatchStatusInProgressEnum, wantErr: true},
+		{name: "延期から終了は不可", match: withResult(MatchStatusPostponedEnum), to: MatchStatusCompletedEnum, wantErr: true},
+		{name: "中止からは変更不可", match: decided(MatchStatusCancelledEnum), to: MatchStatusPendingEnum, wantErr: true},
+		{name: "終了から試合中は不可", match: withResult(MatchStatusCompletedEnum), to: MatchStatusInProgressEnum, wantErr: true},
+		{name: "結果がないと終了にできない", match: decided(MatchStatusInProgressEnum), to: MatchStatusCompletedEnum, wantErr: true},
+		{name: "結果が残っていると未実施に戻せない", match: withResult(MatchStatusCompletedEnum), to: MatchStatusPendingEnum, wantErr: true},
+		{name: "対戦チームが未確定だと試合中にできない", match: &Match{ID: 1, Team1: "IE4", Team2: "TBD", Status: string(MatchStatusPendingEnum)}, to: MatchStatusInProgressEnum, wantErr: true},
+		{name: "日程変更は開始時刻が必要", match: decided(MatchStatusPendingEnum), to: MatchStatusRescheduledEnum, wantErr: true},
+		{name: "無効なステータス", match: decided(MatchStatusPendingEnum), to: MatchStatus("finished"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := tt.match.GetStatus()
+			err := tt.match.TransitionTo(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo(%s) error = %v, wantErr %v", tt.to, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var transitionErr *MatchTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("error = %T, want *MatchTransitionError", err)
+				}
+				if transitionErr.From != from || transitionErr.To != tt.to {
+					t.Errorf("error from %s to %s, want from %s to %s", transitionErr.From, transitionErr.To, from, tt.to)
+				}
+				if got := tt.match.GetStatus(); got != from {
+					t.Errorf("status = %s after refused transition, want %s", got, from)
+				}
+				return
+			}
+			if got := tt.match.GetStatus(); got != tt.to {
+				t.Errorf("status = %s, want %s", got, tt.to)
+			}
+		})
+	}
+}
+
+func TestMatch_Reschedule(t *testing.T) {
+	clock := func(day, hour int) time.Time {
+		return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		status      MatchStatus
+		plannedAt   *time.Time
+		at          time.Time
+		wantErr     bool
+		wantPlanned time.Time
+	}{
+		{name: "延期した試合の日程を決める", status: MatchStatusPostponedEnum, at: clock(21, 10), wantPlanned: clock(20, 9)},
+		{name: "日程変更した試合をさらに変更しても最初の予定を残す", status: MatchStatusRescheduledEnum, plannedAt: func() *time.Time { at := clock(20, 8); return &at }(), at: clock(21, 10), wantPlanned: clock(20, 8)},
+		{name: "開始時刻の指定が必要", status: MatchStatusPostponedEnum, wantErr: true},
+		{name: "試合中の試合は日程変更できない", status: MatchStatusInProgressEnum, at: clock(21, 10), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{ID: 1, Team1: "IE4", Team2: "IS4", Status: string(tt.status), ScheduledAt: clock(20, 9), PlannedAt: tt.plannedAt}
+			err := match.Reschedule(tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reschedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !match.ScheduledAt.Equal(clock(20, 9)) || match.GetStatus() != tt.status {
+					t.Errorf("match changed by refused reschedule: %s at %s", match.Status, match.ScheduledAt)
+				}
+				return
+			}
+			if !match.IsRescheduled() || !match.ScheduledAt.Equal(tt.at) {
+				t.Errorf("match = %s at %s, want rescheduled at %s", match.Status, match.ScheduledAt, tt.at)
+			}
+			if match.PlannedAt == nil || !match.PlannedAt.Equal(tt.wantPlanned) {
+				t.Errorf("PlannedAt = %v, want %s", match.PlannedAt, tt.wantPlanned)
+			}
+		})
+	}
+}
+
+func TestMatch_ResetByCorrection(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    MatchStatus
+		hasResult bool
+		wantErr   bool
+	}{
+		{name: "終了した試合をやり直す", status: MatchStatusCompletedEnum, hasResult: true},
+		{name: "試合中の試合をやり直す", status: MatchStatusInProgressEnum},
+		{name: "未実施の試合はやり直せない", status: MatchStatusPendingEnum, wantErr: true},
+		{name: "中止した試合はやり直せない", status: MatchStatusCancelledEnum, wantErr: true},
+		{name: "延期した試合はやり直せない", status: MatchStatusPostponedEnum, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{ID: 1, Team1: "IE4", Team2: "IS4"}
+			if tt.hasResult {
+				match.ApplyResult(MatchResult{Score1: 2, Score2: 1, Winner: "IE4"})
+			}
+			match.Status = string(tt.status)
+
+			err := match.ResetByCorrection()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResetByCorrection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var transitionErr *MatchTransitionError
+				if !errors.As(err, &transitionErr) || transitionErr.To != MatchStatusPendingEnum {
+					t.Errorf("error = %v, want *MatchTransitionError to pending", err)
+				}
+				if match.GetStatus() != tt.status {
+					t.Errorf("status = %s after refused reset, want %s", match.GetStatus(), tt.status)
+				}
+				return
+			}
+			if !match.IsPending() || match.HasResult() || match.Winner != nil {
+				t.Errorf("match = %s, result %v, winner %v, want pending without result", match.Status, match.HasResult(), match.Winner)
+			}
+		})
+	}
+}
+
+// TestMatchStatuses_MatchMigrations は遷移表のステータスがマイグレーションのENUMと一致することを確認する
+func TestMatchStatuses_MatchMigrations(t *testing.T) {
+	want := MatchStatuses()
+	got := migrationStatusEnum(t, "matches")
+	if len(got) != len(want) {
+		t.Fatalf("migration ENUM = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != string(want[i]) {
+			t.Errorf("migration ENUM = %v, want %v", got, want)
+			break
+		}
+	}
+	for _, status := range want {
+		if _, ok := matchTransitions[status]; !ok {
+			t.Errorf("status %s has no entry in the transition table", status)
+		}
+	}
+}
+
+// migrationStatusEnum はマイグレーションで最後に定義したテーブルの status 列のENUMの値を返す
+func migrationStatusEnum(t *testing.T, table string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "[0-9]*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Skip("migrations not found")
+	}
+	sort.Strings(files)
+
+	pattern := regexp.MustCompile(`(?is)` + table + `\s+MODIFY\s+COLUMN\s+status\s+ENUM\(([^)]*)\)`)
+	var enum string
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, match := range pattern.FindAllStringSubmatch(string(content), -1) {
+			enum = match[1]
+		}
+	}
+	if enum == "" {
+		t.Fatalf("no migration defines the ENUM of %s.status", table)
+	}
+
+	var values []string
+	for _, value := range strings.Split(enum, ",") {
+		values = append(values, strings.Trim(strings.TrimSpace(value), "'"))
+	}
+	return values
+}
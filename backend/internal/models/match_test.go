@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestMatchResult_ExtraTimeAndPenalties(t *testing.T) {
+	tests := []struct {
+		name    string
+		round   RoundType
+		result  MatchResult
+		want    DecisionMethod
+		wantErr bool
+	}{
+		{
+			name:   "PK戦で決着",
+			round:  RoundFinalEnum,
+			result: MatchResult{Score1: 1, Score2: 1, ExtraTimeScore1: intPtr(1), ExtraTimeScore2: intPtr(1), PenaltyScore1: intPtr(3), PenaltyScore2: intPtr(4), Winner: "IS4"},
+			want:   DecisionPenaltiesEnum,
+		},
+		{
+			name:   "延長戦で決着",
+			round:  RoundSemifinalEnum,
+			result: MatchResult{Score1: 0, Score2: 0, ExtraTimeScore1: intPtr(1), ExtraTimeScore2: intPtr(0), Winner: "IE4"},
+			want:   DecisionExtraTimeEnum,
+		},
+		{
+			name:   "延長戦なしでPK戦",
+			round:  RoundSemifinalEnum,
+			result: MatchResult{Score1: 2, Score2: 2, PenaltyScore1: intPtr(5), PenaltyScore2: intPtr(4), Winner: "IE4"},
+			want:   DecisionPenaltiesEnum,
+		},
+		{
+			name:    "PK戦の勝者と不一致",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 1, Score2: 1, PenaltyScore1: intPtr(3), PenaltyScore2: intPtr(4), Winner: "IE4"},
+			wantErr: true,
+		},
+		{
+			name:    "正規時間で決着した試合の延長戦",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 2, Score2: 1, ExtraTimeScore1: intPtr(2), ExtraTimeScore2: intPtr(1), Winner: "IE4"},
+			wantErr: true,
+		},
+		{
+			name:    "延長戦のスコアが正規時間より少ない",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 1, Score2: 1, ExtraTimeScore1: intPtr(0), ExtraTimeScore2: intPtr(1), Winner: "IS4"},
+			wantErr: true,
+		},
+		{
+			name:    "PK戦の同点",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 1, Score2: 1, PenaltyScore1: intPtr(3), PenaltyScore2: intPtr(3), Winner: "IE4"},
+			wantErr: true,
+		},
+		{
+			name:    "片方のチームのみのPK戦スコア",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 1, Score2: 1, PenaltyScore1: intPtr(3), Winner: "IE4"},
+			wantErr: true,
+		},
+		{
+			name:    "決着方法とスコアの不一致",
+			round:   RoundFinalEnum,
+			result:  MatchResult{Score1: 2, Score2: 1, DecisionMethod: DecisionPenaltiesEnum, Winner: "IE4"},
+			wantErr: true,
+		},
+		{
+			name:    "総当たり戦のPK戦",
+			round:   RoundLeagueEnum,
+			result:  MatchResult{Score1: 1, Score2: 1, PenaltyScore1: intPtr(3), PenaltyScore2: intPtr(2), Winner: "IE4"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.ValidateForRound(tt.round, "IE4", "IS4")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateForRound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.result.Decision() != tt.want {
+				t.Errorf("Decision() = %s, want %s", tt.result.Decision(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch_ApplyResult(t *testing.T) {
+	match := newPendingMatch(1, RoundFinalEnum, 0, "IE4", "IS4")
+	match.ApplyResult(MatchResult{Score1: 1, Score2: 1, ExtraTimeScore1: intPtr(2), ExtraTimeScore2: intPtr(2), PenaltyScore1: intPtr(4), PenaltyScore2: intPtr(3), Winner: "IE4"})
+
+	if match.Winner == nil || *match.Winner != "IE4" {
+		t.Errorf("ApplyResult() winner = %v, want IE4", match.Winner)
+	}
+	if match.GetDecisionMethod() != DecisionPenaltiesEnum {
+		t.Errorf("GetDecisionMethod() = %s, want %s", match.GetDecisionMethod(), DecisionPenaltiesEnum)
+	}
+	if got, want := match.ScoreDisplay(), "1-1 (延長 2-2, PK 4-3)"; got != want {
+		t.Errorf("ScoreDisplay() = %q, want %q", got, want)
+	}
+
+	match.ClearResult()
+	if match.HasResult() || match.PenaltyScore1 != nil || match.GetDecisionMethod() != "" || match.ScoreDisplay() != "" {
+		t.Errorf("ClearResult() left a result: %+v", match)
+	}
+}
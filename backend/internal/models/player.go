@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Player は大会に出場する学生を表すモデル
+// 学生は大会をまたいで同じ選手として記録し、トーナメントごとにチームの名簿へ登録する
+type Player struct {
+	ID            uint      `json:"id" db:"id"`
+	StudentNumber string    `json:"student_number,omitempty" db:"student_number" example:"20IE001"` // 学籍番号（一意、公開の名簿には含めない）
+	Name          string    `json:"name" db:"name" example:"山田太郎"`                                  // 氏名
+	ClassName     string    `json:"class_name,omitempty" db:"class_name" example:"IE4"`             // 所属クラス
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// studentNumberPattern は学籍番号の形式（英数字とハイフン）
+var studentNumberPattern = regexp.MustCompile(`^[0-9A-Za-z-]{1,20}$`)
+
+// Validate は選手データの検証を行う
+func (p *Player) Validate() error {
+	if !studentNumberPattern.MatchString(p.StudentNumber) {
+		return errors.New("学籍番号は20文字以下の英数字である必要があります")
+	}
+
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return errors.New("氏名は必須です")
+	}
+	if len(name) > 100 {
+		return errors.New("氏名は100文字以下である必要があります")
+	}
+
+	if len(p.ClassName) > 100 {
+		return errors.New("所属クラスは100文字以下である必要があります")
+	}
+
+	return nil
+}
+
+// RosterEntry はチームの名簿に登録された選手
+// 選手は1つの種目（トーナメント）につき1つのチームにしか登録できない
+type RosterEntry struct {
+	ID           uint      `json:"id" db:"id"`
+	TeamID       uint      `json:"team_id" db:"team_id"`
+	PlayerID     uint      `json:"player_id" db:"player_id"`
+	TournamentID uint      `json:"tournament_id" db:"tournament_id"`                      // チームのトーナメント（種目ごとの登録を一意にするため保持）
+	ShirtNumber  *int      `json:"shirt_number,omitempty" db:"shirt_number" example:"10"` // 背番号
+	Player       *Player   `json:"player,omitempty" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate は名簿の登録内容を検証する
+func (r *RosterEntry) Validate() error {
+	if r.TeamID == 0 {
+		return errors.New("チームIDは必須です")
+	}
+	if r.PlayerID == 0 {
+		return errors.New("選手IDは必須です")
+	}
+	if r.ShirtNumber != nil && (*r.ShirtNumber < 0 || *r.ShirtNumber > 99) {
+		return errors.New("背番号は0から99である必要があります")
+	}
+	return nil
+}
+
+// PlayerStats は選手の成績
+// トーナメント単位ではチーム名を、大会単位では所属クラスのみを持つ
+type PlayerStats struct {
+	PlayerID    uint   `json:"player_id"`
+	PlayerName  string `json:"player_name"`
+	ClassName   string `json:"class_name,omitempty"`
+	TeamID      *uint  `json:"team_id,omitempty"`
+	TeamName    string `json:"team_name,omitempty"`
+	Goals       int    `json:"goals"`        // 得点
+	YellowCards int    `json:"yellow_cards"` // 警告
+	RedCards    int    `json:"red_cards"`    // 退場
+	MVPAwards   int    `json:"mvp_awards"`   // 試合のMVPに選ばれた回数
+}
+
+// LeaderboardEntry はランキングの1行（同じ成績の選手は同じ順位）
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	*PlayerStats
+}
+
+// Leaderboard はトーナメントまたは大会の個人ランキング
+type Leaderboard struct {
+	EventID      int                `json:"event_id"`
+	TournamentID *int               `json:"tournament_id,omitempty"` // 大会全体のランキングではnull
+	TopScorers   []LeaderboardEntry `json:"top_scorers"`             // 得点王（得点の多い順）
+	MVP          []LeaderboardEntry `json:"mvp"`                     // MVP（試合のMVPに選ばれた回数、同数の場合は得点の多い順）
+}
+
+// DefaultLeaderboardSize はランキングに載せる人数の既定値（同順位の選手は全員載せる）
+const DefaultLeaderboardSize = 10
+
+// BuildLeaderboard は選手の成績から得点王とMVPのランキングを作成する
+// 得点またはMVPの記録がない選手は載せず、同じ成績の選手は同じ順位とする
+func BuildLeaderboard(stats []*PlayerStats, size int) (topScorers, mvp []LeaderboardEntry) {
+	topScorers = rankPlayers(stats, size,
+		func(s *PlayerStats) bool { return s.Goals > 0 },
+		func(a, b *PlayerStats) int { return compareDesc(a.Goals, b.Goals) },
+	)
+	mvp = rankPlayers(stats, size,
+		func(s *PlayerStats) bool { return s.MVPAwards > 0 },
+		func(a, b *PlayerStats) int {
+			if c := compareDesc(a.MVPAwards, b.MVPAwards); c != 0 {
+				return c
+			}
+			return compareDesc(a.Goals, b.Goals)
+		},
+	)
+	return topScorers, mvp
+}
+
+// rankPlayers は条件を満たす選手を並べ替えて順位を付ける
+// size 位以内に入る選手のみを返す（size が0以下の場合は全員）
+func rankPlayers(stats []*PlayerStats, size int, include func(*PlayerStats) bool, compare func(a, b *PlayerStats) int) []LeaderboardEntry {
+	var ranked []*PlayerStats
+	for _, s := range stats {
+		if include(s) {
+			ranked = append(ranked, s)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if c := compare(ranked[i], ranked[j]); c != 0 {
+			return c < 0
+		}
+		return ranked[i].PlayerName < ranked[j].PlayerName
+	})
+
+	entries := []LeaderboardEntry{}
+	for i, s := range ranked {
+		rank := i + 1
+		if i > 0 && compare(ranked[i-1], s) == 0 {
+			rank = entries[i-1].Rank
+		}
+		if size > 0 && rank > size {
+			break
+		}
+		entries = append(entries, LeaderboardEntry{Rank: rank, PlayerStats: s})
+	}
+	return entries
+}
+
+// compareDesc は大きい値を先に並べる比較結果を返す
+func compareDesc(a, b int) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	default:
+		return 0
+	}
+}
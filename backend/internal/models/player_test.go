@@ -0,0 +1,116 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlayer_Validate(t *testing.T) {
+	valid := func() *Player {
+		return &Player{StudentNumber: "20IE001", Name: "山田太郎", ClassName: "IE4"}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Player)
+		wantErr bool
+	}{
+		{name: "有効な選手", modify: func(p *Player) {}},
+		{name: "所属クラスなし", modify: func(p *Player) { p.ClassName = "" }},
+		{name: "学籍番号なし", modify: func(p *Player) { p.StudentNumber = "" }, wantErr: true},
+		{name: "学籍番号に記号", modify: func(p *Player) { p.StudentNumber = "20IE 001" }, wantErr: true},
+		{name: "氏名なし", modify: func(p *Player) { p.Name = " " }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := valid()
+			tt.modify(player)
+			if err := player.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRosterEntry_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   RosterEntry
+		wantErr bool
+	}{
+		{name: "背番号あり", entry: RosterEntry{TeamID: 1, PlayerID: 2, ShirtNumber: intPtr(10)}},
+		{name: "背番号なし", entry: RosterEntry{TeamID: 1, PlayerID: 2}},
+		{name: "チームなし", entry: RosterEntry{PlayerID: 2}, wantErr: true},
+		{name: "選手なし", entry: RosterEntry{TeamID: 1}, wantErr: true},
+		{name: "背番号が範囲外", entry: RosterEntry{TeamID: 1, PlayerID: 2, ShirtNumber: intPtr(100)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.entry.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildLeaderboard(t *testing.T) {
+	stats := []*PlayerStats{
+		{PlayerID: 1, PlayerName: "A", Goals: 3, MVPAwards: 1},
+		{PlayerID: 2, PlayerName: "B", Goals: 5},
+		{PlayerID: 3, PlayerName: "C", Goals: 3, MVPAwards: 1},
+		{PlayerID: 4, PlayerName: "D", Goals: 1, MVPAwards: 2},
+		{PlayerID: 5, PlayerName: "E"},
+	}
+
+	type row struct {
+		PlayerID uint
+		Rank     int
+	}
+	rows := func(entries []LeaderboardEntry) []row {
+		result := []row{}
+		for _, e := range entries {
+			result = append(result, row{e.PlayerID, e.Rank})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		size       int
+		wantScorer []row
+		wantMVP    []row
+	}{
+		{
+			name:       "全員",
+			size:       0,
+			wantScorer: []row{{2, 1}, {1, 2}, {3, 2}, {4, 4}},
+			wantMVP:    []row{{4, 1}, {1, 2}, {3, 2}},
+		},
+		{
+			name:       "同順位は全員載せる",
+			size:       2,
+			wantScorer: []row{{2, 1}, {1, 2}, {3, 2}},
+			wantMVP:    []row{{4, 1}, {1, 2}, {3, 2}},
+		},
+		{
+			name:       "1位のみ",
+			size:       1,
+			wantScorer: []row{{2, 1}},
+			wantMVP:    []row{{4, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorers, mvp := BuildLeaderboard(stats, tt.size)
+			if got := rows(scorers); !reflect.DeepEqual(got, tt.wantScorer) {
+				t.Errorf("top scorers = %v, want %v", got, tt.wantScorer)
+			}
+			if got := rows(mvp); !reflect.DeepEqual(got, tt.wantMVP) {
+				t.Errorf("mvp = %v, want %v", got, tt.wantMVP)
+			}
+		})
+	}
+}
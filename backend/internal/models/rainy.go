@@ -0,0 +1,110 @@
+package models
+
+// minRainyRounds は敗者復活戦を行う勝者側のラウンド数の下限
+// 1回戦が準決勝の場合、準決勝の敗者は3位決定戦に進むため敗者復活戦は行わない
+const minRainyRounds = 3
+
+// planConsolation は雨天時形式の敗者復活戦の試合を計画する
+//
+// 敗者復活戦は勝者側1回戦の敗者によるノックアウト方式とし、隣り合う1回戦の敗者同士の試合から始めて
+// 勝ち残り同士の対戦を1チームになるまで繰り返す（順位には含めない）。
+// 敗者復活戦の試合は全てloser_bracketラウンドとし、位置はラウンド順の通し番号とする。
+// 1回戦の不戦勝で敗者が出ない枠は計画せず、もう一方をそのまま次へ進めるため、不戦勝の分だけ短くなる。
+// 計画は勝者側のラウンド構成と不戦勝の位置だけで決まるため、作成時と進出先の導出時で同じ結果になる
+func planConsolation(winnerRounds []RoundType, byes map[int]bool) []plannedMatch {
+	roundCount := len(winnerRounds)
+	if roundCount < minRainyRounds {
+		return nil
+	}
+	firstRoundMatches := 1 << (roundCount - 1)
+
+	var plan []plannedMatch
+	position := 0
+	// play は2つの出どころで試合を計画し、その勝者の出どころを返す
+	play := func(a, b *bracketSource) *bracketSource {
+		pos := position
+		position++
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		plan = append(plan, plannedMatch{round: RoundLoserBracketEnum, position: pos, sources: [2]*bracketSource{a, b}})
+		return &bracketSource{round: RoundLoserBracketEnum, position: pos}
+	}
+
+	survivors := make([]*bracketSource, 0, firstRoundMatches)
+	for i := 0; i < firstRoundMatches; i++ {
+		if byes[i] {
+			survivors = append(survivors, nil)
+			continue
+		}
+		survivors = append(survivors, &bracketSource{round: winnerRounds[0], position: i, loser: true})
+	}
+	for len(survivors) > 1 {
+		next := make([]*bracketSource, 0, len(survivors)/2)
+		for i := 0; i+1 < len(survivors); i += 2 {
+			next = append(next, play(survivors[i], survivors[i+1]))
+		}
+		survivors = next
+	}
+
+	return plan
+}
+
+// NewRainyMatches は1回戦の対戦カードから雨天時形式の全試合を作成する
+// NewKnockoutMatchesと同じ構成に、1回戦の敗者による敗者復活戦を追加する
+func NewRainyMatches(tournamentID int, pairs [][2]string) ([]*Match, error) {
+	matches, err := NewKnockoutMatches(tournamentID, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	byRound := groupMatchesByRound(matches)
+	winnerRounds := presentKnockoutRounds(byRound)
+	for _, planned := range planConsolation(winnerRounds, findFirstRoundByes(byRound, winnerRounds)) {
+		matches = append(matches, newPendingMatch(tournamentID, planned.round, planned.position, TeamTBD, TeamTBD))
+	}
+
+	return matches, nil
+}
+
+// addConsolationProgression は1回戦の敗者と敗者復活戦の勝者の進出先を追加する
+func addConsolationProgression(progression map[int]BracketProgression, byRound map[RoundType][]*Match) {
+	winnerRounds := presentKnockoutRounds(byRound)
+	if len(winnerRounds) == 0 {
+		return
+	}
+
+	for _, planned := range planConsolation(winnerRounds, findFirstRoundByes(byRound, winnerRounds)) {
+		target := lookupPlanned(byRound, planned.round, planned.position)
+		if target == nil {
+			continue
+		}
+		for i, source := range planned.sources {
+			from := lookupPlanned(byRound, source.round, source.position)
+			if from == nil {
+				continue
+			}
+			slot := &BracketSlot{MatchID: target.ID, Slot: i + 1}
+			p := progression[from.ID]
+			if source.loser {
+				p.Loser = slot
+			} else {
+				p.Winner = slot
+			}
+			progression[from.ID] = p
+		}
+	}
+}
+
+// lookupPlanned はラウンドと位置で試合を引く（該当する試合がなければnil）
+func lookupPlanned(byRound map[RoundType][]*Match, round RoundType, position int) *Match {
+	for _, match := range byRound[round] {
+		if match.Position == position {
+			return match
+		}
+	}
+	return nil
+}
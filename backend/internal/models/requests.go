@@ -61,8 +61,9 @@ func (r *RefreshTokenRequest) Validate() error {
 // CreateTournamentRequest はトーナメント作成リクエストの統一構造体
 type CreateTournamentRequest struct {
 	BaseRequest
-	Sport  SportType        `json:"sport" binding:"required" example:"volleyball"`
-	Format TournamentFormat `json:"format" binding:"required" example:"standard"`
+	Sport   SportType        `json:"sport" binding:"required" example:"volleyball"`
+	Format  TournamentFormat `json:"format" binding:"required" example:"standard"`
+	EventID int              `json:"event_id,omitempty" example:"1"` // 大会ID（省略時は現在の大会）
 }
 
 // Validate はCreateTournamentRequestの検証を行う
@@ -120,8 +121,10 @@ type CreateMatchRequest struct {
 	BaseRequest
 	TournamentID int       `json:"tournament_id" binding:"required,min=1"`
 	Round        RoundType `json:"round" binding:"required" example:"1st_round"`
-	Team1        string    `json:"team1" binding:"required,min=1,max=100" example:"チームA"`
-	Team2        string    `json:"team2" binding:"required,min=1,max=100" example:"チームB"`
+	Team1        string    `json:"team1" binding:"required_without=Team1ID,max=100" example:"チームA"`
+	Team2        string    `json:"team2" binding:"required_without=Team2ID,max=100" example:"チームB"`
+	Team1ID      *int      `json:"team1_id,omitempty" binding:"omitempty,min=1" example:"1"` // 登録チームのID（指定時はチーム名より優先）
+	Team2ID      *int      `json:"team2_id,omitempty" binding:"omitempty,min=1" example:"2"`
 	ScheduledAt  DateTime  `json:"scheduled_at" binding:"required" example:"2024-01-01T10:00:00Z"`
 }
 
@@ -135,7 +138,7 @@ func (r *CreateMatchRequest) Validate() error {
 		return errors.New("無効なラウンドです")
 	}
 	
-	if strings.TrimSpace(r.Team1) == "" {
+	if strings.TrimSpace(r.Team1) == "" && r.Team1ID == nil {
 		return errors.New("チーム1は必須です")
 	}
 	
@@ -143,7 +146,7 @@ func (r *CreateMatchRequest) Validate() error {
 		return errors.New("チーム1名は100文字以下である必要があります")
 	}
 	
-	if strings.TrimSpace(r.Team2) == "" {
+	if strings.TrimSpace(r.Team2) == "" && r.Team2ID == nil {
 		return errors.New("チーム2は必須です")
 	}
 	
@@ -151,7 +154,11 @@ func (r *CreateMatchRequest) Validate() error {
 		return errors.New("チーム2名は100文字以下である必要があります")
 	}
 	
-	if r.Team1 == r.Team2 {
+	if r.Team1ID != nil && r.Team2ID != nil {
+		if *r.Team1ID == *r.Team2ID {
+			return errors.New("同じチーム同士の試合はできません")
+		}
+	} else if r.Team1ID == nil && r.Team2ID == nil && r.Team1 == r.Team2 {
 		return errors.New("同じチーム同士の試合はできません")
 	}
 	
@@ -219,9 +226,37 @@ func (r *UpdateMatchRequest) Validate() error {
 // SubmitMatchResultRequest は試合結果提出リクエストの統一構造体
 type SubmitMatchResultRequest struct {
 	BaseRequest
-	Score1 int    `json:"score1" binding:"required,min=0" example:"3"`
-	Score2 int    `json:"score2" binding:"required,min=0" example:"1"`
-	Winner string `json:"winner" binding:"required,min=1,max=100" example:"チームA"`
+	Score1          int            `json:"score1" binding:"min=0" example:"1"` // 正規時間のスコア（セットを指定した場合はセットから算出）
+	Score2          int            `json:"score2" binding:"min=0" example:"1"`
+	ExtraTimeScore1 *int           `json:"extra_time_score1,omitempty" binding:"omitempty,min=0" example:"1"` // 延長戦終了時のスコア（正規時間を含む）
+	ExtraTimeScore2 *int           `json:"extra_time_score2,omitempty" binding:"omitempty,min=0" example:"1"`
+	PenaltyScore1   *int           `json:"penalty_score1,omitempty" binding:"omitempty,min=0" example:"4"` // PK戦のスコア
+	PenaltyScore2   *int           `json:"penalty_score2,omitempty" binding:"omitempty,min=0" example:"3"`
+	DecisionMethod  DecisionMethod `json:"decision_method,omitempty" example:"penalties"` // 省略時はスコアから判定
+	Sets            []SetScore     `json:"sets,omitempty"`                                // セットごとのスコア（バレーボール・卓球）
+	ResultType      ResultType     `json:"result_type,omitempty" example:"walkover"`       // 省略時は試合を行った結果（walkover, forfeit, double_forfeit, disqualification）
+	ForfeitingTeam  string         `json:"forfeiting_team,omitempty" binding:"max=100" example:"チームB"` // 棄権・不出場・失格となったチーム
+	Winner          string         `json:"winner" binding:"max=100" example:"チームA"`       // 引き分け（総当たり戦のみ）の場合は空
+}
+
+// ToMatchResult はリクエストを試合結果に変換する
+// セットごとのスコアを指定した場合、試合のスコアは取ったセット数とする
+func (r *SubmitMatchResultRequest) ToMatchResult() MatchResult {
+	result := MatchResult{
+		Score1:          r.Score1,
+		Score2:          r.Score2,
+		ExtraTimeScore1: r.ExtraTimeScore1,
+		ExtraTimeScore2: r.ExtraTimeScore2,
+		PenaltyScore1:   r.PenaltyScore1,
+		PenaltyScore2:   r.PenaltyScore2,
+		DecisionMethod:  r.DecisionMethod,
+		Sets:            r.Sets,
+		ResultType:      r.ResultType,
+		ForfeitingTeam:  strings.TrimSpace(r.ForfeitingTeam),
+		Winner:          strings.TrimSpace(r.Winner),
+	}
+	result.DeriveScoreFromSets()
+	return result
 }
 
 // Validate はSubmitMatchResultRequestの検証を行う
@@ -234,11 +269,32 @@ func (r *SubmitMatchResultRequest) Validate() error {
 		return errors.New("チーム2のスコアは0以上である必要があります")
 	}
 	
-	if r.Score1 == r.Score2 {
-		return errors.New("引き分けは許可されていません")
+	result := r.ToMatchResult()
+	
+	// 不戦勝・棄権・失格のスコアと勝者はスポーツの既定値で補うため、結果の種類とチームのみ検証する
+	if r.ResultType != "" && r.ResultType != ResultTypePlayedEnum {
+		if !r.ResultType.IsValid() {
+			return errors.New("無効な結果の種類です")
+		}
+		if !result.IsDoubleForfeit() && result.ForfeitingTeam == "" {
+			return errors.New("棄権・不出場・失格のチームは必須です")
+		}
+		return nil
+	}
+	
+	if err := result.validateScores(); err != nil {
+		return err
+	}
+	
+	// 引き分けが認められるかどうかは試合のラウンドによるため、ここでは勝者の有無のみ検証する
+	if result.IsDraw() {
+		if result.Winner != "" {
+			return errors.New("引き分けの場合は勝者を指定できません")
+		}
+		return nil
 	}
 	
-	if strings.TrimSpace(r.Winner) == "" {
+	if result.Winner == "" {
 		return errors.New("勝者は必須です")
 	}
 	
@@ -255,21 +311,20 @@ func (r *SubmitMatchResultRequest) ValidateMatchResult(team1, team2 string) erro
 		return err
 	}
 	
-	// 勝者がいずれかのチームと一致するかチェック
-	if r.Winner != team1 && r.Winner != team2 {
-		return errors.New("勝者は参加チームのいずれかである必要があります")
-	}
-	
-	// スコアと勝者の整合性チェック
-	if r.Score1 > r.Score2 && r.Winner != team1 {
-		return errors.New("スコアと勝者が一致しません")
+	// 引き分けが認められるかどうかは試合のラウンドで判定する
+	result := r.ToMatchResult()
+	if result.IsForfeit() {
+		if !result.IsDoubleForfeit() && result.ForfeitingTeam != team1 && result.ForfeitingTeam != team2 {
+			return errors.New("棄権・不出場・失格のチームは参加チームのいずれかである必要があります")
+		}
+		return nil
 	}
 	
-	if r.Score2 > r.Score1 && r.Winner != team2 {
-		return errors.New("スコアと勝者が一致しません")
+	if result.IsDraw() {
+		return nil
 	}
 	
-	return nil
+	return result.ValidateResultWithTeams(team1, team2)
 }
 
 // PaginationRequest はページネーションリクエストの統一構造体
@@ -38,7 +38,8 @@ type PaginatedResponse[T any] struct {
 // ErrorResponse はエラーレスポンスの統一構造体
 type ErrorResponse struct {
 	BaseResponse
-	Error string `json:"error,omitempty"` // エラーコード
+	Error   string                 `json:"error,omitempty"`   // エラーコード
+	Details map[string]interface{} `json:"details,omitempty"` // エラーの詳細情報
 }
 
 // SetRequestID はリクエストIDを設定する
@@ -124,6 +125,7 @@ type TokenValidationResponse struct {
 // TournamentResponse はトーナメントレスポンスの統一構造体
 type TournamentResponse struct {
 	ID        int               `json:"id" example:"1"`
+	EventID   int               `json:"event_id" example:"1"`
 	Sport     SportType         `json:"sport" example:"volleyball"`
 	Format    TournamentFormat  `json:"format" example:"standard"`
 	Status    TournamentStatus  `json:"status" example:"active"`
@@ -135,6 +137,7 @@ type TournamentResponse struct {
 func NewTournamentResponse(tournament *Tournament) *TournamentResponse {
 	return &TournamentResponse{
 		ID:        tournament.ID,
+		EventID:   tournament.EventID,
 		Sport:     SportType(tournament.Sport),
 		Format:    TournamentFormat(tournament.Format),
 		Status:    TournamentStatus(tournament.Status),
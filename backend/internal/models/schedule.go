@@ -0,0 +1,494 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSlotMinutes は1試合の枠の長さの既定値（分）
+const DefaultSlotMinutes = 30
+
+// scheduleStep は試合の開始時刻の刻み
+const scheduleStep = 5 * time.Minute
+
+// 日程の条件の上限
+const (
+	maxSlotMinutes = 240
+	maxRestMinutes = 120
+)
+
+// scheduleTimeLayout は試合日の時刻の形式
+const scheduleTimeLayout = "15:04"
+
+// ScheduleBreak は試合を行わない時間帯（昼休み・開会式など）
+type ScheduleBreak struct {
+	Name  string `json:"name" example:"昼休み"`
+	Start string `json:"start" example:"12:00"` // 開始時刻（HH:MM）
+	End   string `json:"end" example:"13:00"`   // 終了時刻（HH:MM）
+}
+
+// ScheduleOptions は試合日程の自動作成の条件
+// 同じクラスは種目をまたいで同時に試合ができないため、全種目の試合をまとめて割り当てる
+type ScheduleOptions struct {
+	Date        string            `json:"date" example:"2024-05-20"`  // 試合日（省略時は大会の開始日）
+	StartTime   string            `json:"start_time" example:"09:30"` // 最初の試合の開始時刻（HH:MM）
+	EndTime     string            `json:"end_time" example:"16:00"`   // 最後の試合の終了時刻（HH:MM）
+	Breaks      []ScheduleBreak   `json:"breaks"`                     // 試合を行わない時間帯
+	SlotMinutes map[SportType]int `json:"slot_minutes"`               // 種目ごとの1試合の枠の長さ（分、省略した種目は30分）
+	RestMinutes int               `json:"rest_minutes" example:"10"`  // 同じクラスの試合の最低の間隔（分、種目をまたいで適用）
+}
+
+// timeRange は時間帯 [Start, End)
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// overlaps は2つの時間帯が重なるかどうかを返す
+func (r timeRange) overlaps(other timeRange) bool {
+	return r.start.Before(other.end) && other.start.Before(r.end)
+}
+
+// scheduleWindow は条件を試合日の時刻に変換したもの
+type scheduleWindow struct {
+	start  time.Time
+	end    time.Time
+	breaks []timeRange
+}
+
+// Validate は日程の条件を検証する
+func (o *ScheduleOptions) Validate() error {
+	_, err := o.window(time.UTC)
+	if err != nil {
+		return err
+	}
+
+	for sport, minutes := range o.SlotMinutes {
+		if !sport.IsValid() {
+			return fmt.Errorf("無効な種目です: %s", sport)
+		}
+		if minutes < 5 || minutes > maxSlotMinutes {
+			return fmt.Errorf("1試合の枠の長さは5分から%d分である必要があります", maxSlotMinutes)
+		}
+	}
+
+	if o.RestMinutes < 0 || o.RestMinutes > maxRestMinutes {
+		return fmt.Errorf("試合の間隔は0分から%d分である必要があります", maxRestMinutes)
+	}
+	return nil
+}
+
+// SlotDuration は種目の1試合の枠の長さを返す
+func (o *ScheduleOptions) SlotDuration(sport SportType) time.Duration {
+	if minutes, ok := o.SlotMinutes[sport]; ok && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return DefaultSlotMinutes * time.Minute
+}
+
+// window は試合日の開始・終了時刻と休憩の時間帯を返す
+func (o *ScheduleOptions) window(loc *time.Location) (*scheduleWindow, error) {
+	day, err := time.ParseInLocation(eventDateLayout, strings.TrimSpace(o.Date), loc)
+	if err != nil {
+		return nil, errors.New("試合日はYYYY-MM-DD形式で指定してください")
+	}
+
+	at := func(value, label string) (time.Time, error) {
+		clock, err := time.Parse(scheduleTimeLayout, strings.TrimSpace(value))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%sはHH:MM形式で指定してください", label)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+	}
+
+	w := &scheduleWindow{}
+	if w.start, err = at(o.StartTime, "開始時刻"); err != nil {
+		return nil, err
+	}
+	if w.end, err = at(o.EndTime, "終了時刻"); err != nil {
+		return nil, err
+	}
+	if !w.start.Before(w.end) {
+		return nil, errors.New("終了時刻は開始時刻より後である必要があります")
+	}
+
+	for _, b := range o.Breaks {
+		r := timeRange{}
+		if r.start, err = at(b.Start, "休憩の開始時刻"); err != nil {
+			return nil, err
+		}
+		if r.end, err = at(b.End, "休憩の終了時刻"); err != nil {
+			return nil, err
+		}
+		if !r.start.Before(r.end) {
+			return nil, errors.New("休憩の終了時刻は開始時刻より後である必要があります")
+		}
+		w.breaks = append(w.breaks, r)
+	}
+	sort.Slice(w.breaks, func(i, j int) bool { return w.breaks[i].start.Before(w.breaks[j].start) })
+
+	return w, nil
+}
+
+// ScheduleIssueReason は試合を割り当てられなかった理由
+type ScheduleIssueReason string
+
+const (
+	ScheduleIssueNoCourt        ScheduleIssueReason = "no_court"        // 種目を行えるコートがない
+	ScheduleIssueNoTime         ScheduleIssueReason = "no_time"         // 終了時刻までに空きがない
+	ScheduleIssueDependency     ScheduleIssueReason = "dependency"      // 勝者・敗者が進出してくる試合を割り当てられない
+	ScheduleIssuePinnedConflict ScheduleIssueReason = "pinned_conflict" // 固定した試合同士でコートまたはクラスが重なる
+)
+
+// ScheduleIssue は日程を作成できない試合と理由
+type ScheduleIssue struct {
+	MatchID      int                 `json:"match_id"`
+	TournamentID int                 `json:"tournament_id"`
+	Reason       ScheduleIssueReason `json:"reason" example:"no_time"`
+	Message      string              `json:"message" example:"16:00までに空いているコートがありません"`
+}
+
+// ScheduledMatch は試合に割り当てた開始時刻とコート
+type ScheduledMatch struct {
+	MatchID      int       `json:"match_id"`
+	TournamentID int       `json:"tournament_id"`
+	Sport        SportType `json:"sport"`
+	Round        string    `json:"round"`
+	Team1        string    `json:"team1"`
+	Team2        string    `json:"team2"`
+	CourtID      *int      `json:"court_id"` // 固定した試合でコートが未割り当ての場合はnull
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Fixed        bool      `json:"fixed"` // 固定した試合・実施済みの試合（自動作成で動かさない）
+}
+
+// Schedule は大会の試合日程の自動作成の結果
+// 全ての試合を割り当てられた場合のみ Feasible とし、割り当てられない試合は Issues に理由を示す
+type Schedule struct {
+	EventID  int              `json:"event_id"`
+	Options  ScheduleOptions  `json:"options"`
+	Feasible bool             `json:"feasible"`
+	Applied  bool             `json:"applied"` // 試合の日程に反映したかどうか（試行や割り当てられない試合がある場合はfalse）
+	Matches  []ScheduledMatch `json:"matches"` // 開始時刻順
+	Issues   []ScheduleIssue  `json:"issues"`
+}
+
+// ScheduleInput は日程の自動作成の対象
+type ScheduleInput struct {
+	Options  ScheduleOptions
+	Location *time.Location    // 大会のタイムゾーン
+	Courts   []*Court          // 割り当て順に並べたコート
+	Matches  []*Match          // 大会の全ての試合
+	Sports   map[int]SportType // トーナメントIDごとの種目
+}
+
+// scheduleEntry は割り当て中の試合
+type scheduleEntry struct {
+	match    *Match
+	sport    SportType
+	duration time.Duration
+	preds    []*scheduleEntry
+	depth    int
+	slot     *timeRange
+	courtID  *int
+	fixed    bool
+	failed   bool
+}
+
+// BuildSchedule は試合に開始時刻とコートを割り当てる
+//
+// 固定した試合（管理者が固定した試合・日程変更した試合・実施中、中断中または実施済みの試合）はそのまま残し、
+// 残りの試合をブラケットの進行順（勝者・敗者が進出する試合は進出元の試合の後）に、
+// 空いているコートの最も早い時刻へ割り当てる。同じクラスの試合は種目をまたいで
+// 重ならないようにし、試合の間隔を空ける。中止した試合と延期した試合は割り当てない
+func BuildSchedule(input ScheduleInput) (*Schedule, error) {
+	loc := input.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	if err := input.Options.Validate(); err != nil {
+		return nil, err
+	}
+	w, err := input.Options.window(loc)
+	if err != nil {
+		return nil, err
+	}
+	rest := time.Duration(input.Options.RestMinutes) * time.Minute
+
+	entries := newScheduleEntries(input)
+
+	courtBusy := make(map[int][]timeRange)
+	teamBusy := make(map[string][]timeRange)
+	occupy := func(e *scheduleEntry) {
+		if e.courtID != nil {
+			courtBusy[*e.courtID] = append(courtBusy[*e.courtID], *e.slot)
+		}
+		for _, team := range scheduledTeams(e.match) {
+			teamBusy[team] = append(teamBusy[team], *e.slot)
+		}
+	}
+
+	var issues []ScheduleIssue
+	issue := func(e *scheduleEntry, reason ScheduleIssueReason, message string) {
+		e.failed = true
+		issues = append(issues, ScheduleIssue{MatchID: e.match.ID, TournamentID: e.match.TournamentID, Reason: reason, Message: message})
+	}
+
+	// 固定した試合同士の重なりを確認してから、固定した試合の時間を埋める
+	var fixed []*scheduleEntry
+	for _, e := range entries {
+		if e.fixed {
+			fixed = append(fixed, e)
+		}
+	}
+	sort.SliceStable(fixed, func(i, j int) bool { return fixed[i].slot.start.Before(fixed[j].slot.start) })
+	for i, e := range fixed {
+		for _, other := range fixed[:i] {
+			if !(e.match.SchedulePinned || other.match.SchedulePinned) || !e.slot.overlaps(*other.slot) {
+				continue
+			}
+			if sameCourt(e.courtID, other.courtID) || sharesTeam(e.match, other.match) {
+				issue(e, ScheduleIssuePinnedConflict, fmt.Sprintf("固定した試合（ID: %d）とコートまたはクラスが重なっています", other.match.ID))
+				break
+			}
+		}
+		occupy(e)
+	}
+
+	for _, e := range entries {
+		if e.fixed {
+			continue
+		}
+
+		earliest := w.start
+		blocked := false
+		for _, pred := range e.preds {
+			if pred.slot == nil {
+				blocked = true
+				break
+			}
+			if ready := pred.slot.end.Add(rest); ready.After(earliest) {
+				earliest = ready
+			}
+		}
+		if blocked {
+			issue(e, ScheduleIssueDependency, "勝者・敗者が進出してくる試合を割り当てられません")
+			continue
+		}
+
+		var courts []*Court
+		for _, court := range input.Courts {
+			if court.Supports(e.sport) {
+				courts = append(courts, court)
+			}
+		}
+		if len(courts) == 0 {
+			issue(e, ScheduleIssueNoCourt, fmt.Sprintf("%sを行えるコートがありません", e.sport))
+			continue
+		}
+
+		if !placeEntry(e, w, courts, earliest, rest, courtBusy, teamBusy) {
+			issue(e, ScheduleIssueNoTime, fmt.Sprintf("%sまでに空いているコートがありません", w.end.Format(scheduleTimeLayout)))
+			continue
+		}
+		occupy(e)
+	}
+
+	schedule := &Schedule{
+		Options:  input.Options,
+		Feasible: len(issues) == 0,
+		Matches:  []ScheduledMatch{},
+		Issues:   issues,
+	}
+	if schedule.Issues == nil {
+		schedule.Issues = []ScheduleIssue{}
+	}
+	for _, e := range entries {
+		if e.slot == nil || e.failed && !e.fixed {
+			continue
+		}
+		schedule.Matches = append(schedule.Matches, ScheduledMatch{
+			MatchID:      e.match.ID,
+			TournamentID: e.match.TournamentID,
+			Sport:        e.sport,
+			Round:        e.match.Round,
+			Team1:        e.match.Team1,
+			Team2:        e.match.Team2,
+			CourtID:      e.courtID,
+			StartsAt:     e.slot.start,
+			EndsAt:       e.slot.end,
+			Fixed:        e.fixed,
+		})
+	}
+	sort.SliceStable(schedule.Matches, func(i, j int) bool {
+		a, b := schedule.Matches[i], schedule.Matches[j]
+		if !a.StartsAt.Equal(b.StartsAt) {
+			return a.StartsAt.Before(b.StartsAt)
+		}
+		return a.MatchID < b.MatchID
+	})
+
+	return schedule, nil
+}
+
+// newScheduleEntries は中止した試合を除く試合を割り当て順（進行順）に並べる
+func newScheduleEntries(input ScheduleInput) []*scheduleEntry {
+	byID := make(map[int]*scheduleEntry)
+	var entries []*scheduleEntry
+	for _, match := range input.Matches {
+		// 中止した試合と、新しい開始時刻が決まっていない延期した試合は割り当てない
+		if match == nil || match.IsCancelled() || match.IsPostponed() {
+			continue
+		}
+		e := &scheduleEntry{match: match, sport: input.Sports[match.TournamentID]}
+		e.duration = input.Options.SlotDuration(e.sport)
+		// 未実施の試合のみ動かす（日程変更した試合は決めた開始時刻のまま）
+		e.fixed = match.SchedulePinned || !match.IsPending()
+		if e.fixed {
+			e.slot = &timeRange{start: match.ScheduledAt, end: match.ScheduledAt.Add(e.duration)}
+			e.courtID = match.CourtID
+		}
+		byID[match.ID] = e
+		entries = append(entries, e)
+	}
+
+	// 進出先の試合は進出元の試合の後に行う
+	// グループリーグ形式の決勝トーナメントは全てのグループリーグの試合の後に行う
+	groupStage := make(map[int][]*scheduleEntry)
+	for _, e := range entries {
+		if e.match.GetRound() == RoundGroupStageEnum {
+			groupStage[e.match.TournamentID] = append(groupStage[e.match.TournamentID], e)
+		}
+	}
+	for _, e := range entries {
+		for _, next := range []*int{e.match.NextMatchID, e.match.LoserNextMatchID} {
+			if next == nil {
+				continue
+			}
+			if successor := byID[*next]; successor != nil {
+				successor.preds = append(successor.preds, e)
+			}
+		}
+		if e.match.GetRound() != RoundGroupStageEnum {
+			e.preds = append(e.preds, groupStage[e.match.TournamentID]...)
+		}
+	}
+
+	var depth func(e *scheduleEntry, visiting map[*scheduleEntry]bool) int
+	depth = func(e *scheduleEntry, visiting map[*scheduleEntry]bool) int {
+		if e.depth > 0 || visiting[e] {
+			return e.depth
+		}
+		visiting[e] = true
+		d := 0
+		for _, pred := range e.preds {
+			if pd := depth(pred, visiting) + 1; pd > d {
+				d = pd
+			}
+		}
+		e.depth = d
+		return d
+	}
+	for _, e := range entries {
+		depth(e, map[*scheduleEntry]bool{})
+	}
+
+	// 同じ段階の試合はトーナメントを交互に並べ、各種目を並行して進める
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.depth != b.depth {
+			return a.depth < b.depth
+		}
+		if a.match.Position != b.match.Position {
+			return a.match.Position < b.match.Position
+		}
+		if a.match.TournamentID != b.match.TournamentID {
+			return a.match.TournamentID < b.match.TournamentID
+		}
+		return a.match.ID < b.match.ID
+	})
+	return entries
+}
+
+// placeEntry は earliest 以降で最も早く試合を行えるコートと時刻を割り当てる
+func placeEntry(e *scheduleEntry, w *scheduleWindow, courts []*Court, earliest time.Time, rest time.Duration, courtBusy map[int][]timeRange, teamBusy map[string][]timeRange) bool {
+	// 開始時刻は試合日の開始時刻からの刻みに揃える
+	start := w.start
+	if earliest.After(start) {
+		steps := (earliest.Sub(w.start) + scheduleStep - 1) / scheduleStep
+		start = w.start.Add(steps * scheduleStep)
+	}
+
+	teams := scheduledTeams(e.match)
+	for ; !start.Add(e.duration).After(w.end); start = start.Add(scheduleStep) {
+		slot := timeRange{start: start, end: start.Add(e.duration)}
+		if overlapsAny(slot, w.breaks) {
+			continue
+		}
+
+		withRest := timeRange{start: slot.start.Add(-rest), end: slot.end.Add(rest)}
+		available := true
+		for _, team := range teams {
+			if overlapsAny(withRest, teamBusy[team]) {
+				available = false
+				break
+			}
+		}
+		if !available {
+			continue
+		}
+
+		for _, court := range courts {
+			courtID := int(court.ID)
+			if overlapsAny(slot, courtBusy[courtID]) {
+				continue
+			}
+			e.slot = &slot
+			e.courtID = &courtID
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsAny は時間帯がいずれかの時間帯と重なるかどうかを返す
+func overlapsAny(r timeRange, ranges []timeRange) bool {
+	for _, other := range ranges {
+		if r.overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduledTeams は日程の重なりを確認する対象のチーム（未確定の枠・棄権を除く）を返す
+func scheduledTeams(match *Match) []string {
+	var teams []string
+	for _, team := range []string{match.Team1, match.Team2} {
+		if team == "" || team == TeamWithdrawn || IsUndecidedTeam(team) {
+			continue
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+// sharesTeam は2つの試合に同じチームが出場するかどうかを返す
+func sharesTeam(a, b *Match) bool {
+	for _, team := range scheduledTeams(a) {
+		for _, other := range scheduledTeams(b) {
+			if team == other {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameCourt は2つの試合が同じコートに割り当てられているかどうかを返す
+func sameCourt(a, b *int) bool {
+	return a != nil && b != nil && *a == *b
+}
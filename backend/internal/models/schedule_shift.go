@@ -0,0 +1,262 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxShiftMinutes は一度に後ろ倒しできる時間の上限（分）
+const maxShiftMinutes = 240
+
+// ScheduleShiftRequest は試合の遅れによる日程の後ろ倒しの条件
+// コートまたはトーナメントのどちらか一方を対象とする
+type ScheduleShiftRequest struct {
+	CourtID      *int       `json:"court_id,omitempty" example:"1"`                     // 対象のコート
+	TournamentID *int       `json:"tournament_id,omitempty" example:"1"`                // 対象のトーナメント
+	From         *time.Time `json:"from,omitempty" example:"2024-05-20T10:00:00+09:00"` // この時刻以降に開始予定の試合を後ろ倒しする（省略時はまだ始まっていない全ての試合）
+	DelayMinutes int        `json:"delay_minutes" example:"15"`                         // 後ろ倒しする時間（分）
+	Reason       string     `json:"reason,omitempty" example:"第1試合が延長になったため"`           // 後ろ倒しの理由（通知に含める）
+}
+
+// Validate は後ろ倒しの条件を検証する
+func (r *ScheduleShiftRequest) Validate() error {
+	if (r.CourtID == nil) == (r.TournamentID == nil) {
+		return errors.New("コートまたはトーナメントのどちらか一方を指定してください")
+	}
+	if r.DelayMinutes < 1 || r.DelayMinutes > maxShiftMinutes {
+		return fmt.Errorf("後ろ倒しする時間は1分から%d分である必要があります", maxShiftMinutes)
+	}
+	return nil
+}
+
+// inScope は試合が後ろ倒しの対象のコート・トーナメントの試合かどうかを返す
+func (r *ScheduleShiftRequest) inScope(match *Match) bool {
+	if r.CourtID != nil {
+		return sameCourt(match.CourtID, r.CourtID)
+	}
+	return match.TournamentID == *r.TournamentID
+}
+
+// ShiftedMatch は開始時刻を変えた試合
+type ShiftedMatch struct {
+	MatchID      int       `json:"match_id"`
+	TournamentID int       `json:"tournament_id"`
+	Sport        SportType `json:"sport"`
+	Round        string    `json:"round"`
+	Team1        string    `json:"team1"`
+	Team2        string    `json:"team2"`
+	CourtID      *int      `json:"court_id"`
+	PlannedAt    time.Time `json:"planned_at"`    // 最初に予定していた開始時刻
+	PreviousAt   time.Time `json:"previous_at"`   // 今回の後ろ倒しの前の開始時刻
+	ScheduledAt  time.Time `json:"scheduled_at"`  // 後ろ倒し後の開始時刻
+	DelayMinutes int       `json:"delay_minutes"` // 最初の予定からの遅れ（分）
+}
+
+// ScheduleShift は日程の後ろ倒しの結果
+// 遅れは起きてしまったものなので、Issues があっても後ろ倒しは反映する（Issues は管理者への警告）
+type ScheduleShift struct {
+	EventID int                  `json:"event_id"`
+	Request ScheduleShiftRequest `json:"request"`
+	Applied bool                 `json:"applied"` // 試合の日程に反映したかどうか（試行の場合はfalse）
+	Matches []ShiftedMatch       `json:"matches"` // 開始時刻を変えた試合（後ろ倒し後の開始時刻順）
+	Issues  []ScheduleIssue      `json:"issues"`
+}
+
+// ShiftInput は日程の後ろ倒しの対象
+type ShiftInput struct {
+	Request  ScheduleShiftRequest
+	Options  ScheduleOptions   // 休憩の時間帯・試合の枠の長さ・試合の間隔
+	Location *time.Location    // 大会のタイムゾーン
+	Matches  []*Match          // 大会の全ての試合
+	Sports   map[int]SportType // トーナメントIDごとの種目
+}
+
+// ShiftSchedule は対象のコート・トーナメントの試合を後ろ倒しする
+//
+// 対象の試合は DelayMinutes だけ遅らせ、それ以外の試合は遅らせた試合とコート・クラスが
+// 重なる場合や進出元の試合が終わらない場合のみ、重ならなくなるまで遅らせる。
+// 休憩の時間帯にかかる試合は休憩の後に移し、固定した試合・日程変更した試合・実施中または実施済みの試合は
+// 動かさずに、その後に続けて行う。コートは変えない
+func ShiftSchedule(input ShiftInput) (*ScheduleShift, error) {
+	loc := input.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	req := input.Request
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	w, err := input.Options.window(loc)
+	if err != nil {
+		return nil, err
+	}
+	delay := time.Duration(req.DelayMinutes) * time.Minute
+	rest := time.Duration(input.Options.RestMinutes) * time.Minute
+
+	// 開始時刻が決まっていない試合は後ろ倒しの対象外
+	var entries []*scheduleEntry
+	for _, e := range newScheduleEntries(ScheduleInput{Options: input.Options, Matches: input.Matches, Sports: input.Sports}) {
+		if e.match.ScheduledAt.IsZero() {
+			continue
+		}
+		e.slot = &timeRange{start: e.match.ScheduledAt, end: e.match.ScheduledAt.Add(e.duration)}
+		e.courtID = e.match.CourtID
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.slot.start.Equal(b.slot.start) {
+			return a.slot.start.Before(b.slot.start)
+		}
+		return a.match.ID < b.match.ID
+	})
+
+	movable := func(e *scheduleEntry) bool {
+		return !e.fixed && (req.From == nil || !e.slot.start.Before(*req.From))
+	}
+
+	// 最も早い対象の試合より前の試合は動かない
+	var anchor *time.Time
+	for _, e := range entries {
+		if movable(e) && req.inScope(e.match) {
+			anchor = &e.slot.start
+			break
+		}
+	}
+	if anchor == nil {
+		return nil, errors.New("後ろ倒しする試合がありません")
+	}
+
+	courtBusy := make(map[int][]timeRange)
+	teamBusy := make(map[string][]timeRange)
+	occupy := func(e *scheduleEntry) {
+		if e.courtID != nil {
+			courtBusy[*e.courtID] = append(courtBusy[*e.courtID], *e.slot)
+		}
+		for _, team := range scheduledTeams(e.match) {
+			teamBusy[team] = append(teamBusy[team], *e.slot)
+		}
+	}
+
+	var pending []*scheduleEntry
+	for _, e := range entries {
+		if movable(e) && !e.slot.start.Before(*anchor) {
+			pending = append(pending, e)
+			continue
+		}
+		occupy(e)
+	}
+
+	shift := &ScheduleShift{
+		Request: req,
+		Matches: []ShiftedMatch{},
+		Issues:  []ScheduleIssue{},
+	}
+	issue := func(e *scheduleEntry, reason ScheduleIssueReason, message string) {
+		shift.Issues = append(shift.Issues, ScheduleIssue{MatchID: e.match.ID, TournamentID: e.match.TournamentID, Reason: reason, Message: message})
+	}
+
+	shifted := make(map[*scheduleEntry]bool)
+	for _, e := range pending {
+		previous := e.slot.start
+		earliest := previous
+		if req.inScope(e.match) {
+			earliest = earliest.Add(delay)
+		}
+		for _, pred := range e.preds {
+			if ready := pred.slot.end.Add(rest); ready.After(earliest) {
+				earliest = ready
+			}
+		}
+
+		start := nextFreeStart(e, w, earliest, rest, courtBusy, teamBusy)
+		e.slot = &timeRange{start: start, end: start.Add(e.duration)}
+		occupy(e)
+		if start.Equal(previous) {
+			continue
+		}
+		shifted[e] = true
+
+		if e.slot.end.After(w.end) {
+			issue(e, ScheduleIssueNoTime, fmt.Sprintf("終了時刻（%s）を過ぎます", w.end.Format(scheduleTimeLayout)))
+		}
+		original := previous
+		if e.match.PlannedAt != nil {
+			original = *e.match.PlannedAt
+		}
+		shift.Matches = append(shift.Matches, ShiftedMatch{
+			MatchID:      e.match.ID,
+			TournamentID: e.match.TournamentID,
+			Sport:        e.sport,
+			Round:        e.match.Round,
+			Team1:        e.match.Team1,
+			Team2:        e.match.Team2,
+			CourtID:      e.courtID,
+			PlannedAt:    original,
+			PreviousAt:   previous,
+			ScheduledAt:  start,
+			DelayMinutes: int(start.Sub(original) / time.Minute),
+		})
+	}
+
+	// 固定した試合は動かさないため、後ろ倒しした進出元の試合が終わる前に始まる場合は警告する
+	for _, e := range entries {
+		if !e.match.SchedulePinned || !e.match.GetStatus().IsPlayable() {
+			continue
+		}
+		for _, pred := range e.preds {
+			if shifted[pred] && pred.slot.end.Add(rest).After(e.slot.start) {
+				issue(e, ScheduleIssuePinnedConflict, fmt.Sprintf("固定した試合の開始時刻までに進出元の試合（ID: %d）が終わりません", pred.match.ID))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(shift.Matches, func(i, j int) bool {
+		a, b := shift.Matches[i], shift.Matches[j]
+		if !a.ScheduledAt.Equal(b.ScheduledAt) {
+			return a.ScheduledAt.Before(b.ScheduledAt)
+		}
+		return a.MatchID < b.MatchID
+	})
+	return shift, nil
+}
+
+// nextFreeStart は earliest 以降で、休憩・同じコートの試合・同じクラスの試合と
+// 重ならない最も早い開始時刻を返す
+func nextFreeStart(e *scheduleEntry, w *scheduleWindow, earliest time.Time, rest time.Duration, courtBusy map[int][]timeRange, teamBusy map[string][]timeRange) time.Time {
+	teams := scheduledTeams(e.match)
+	start := earliest
+	for {
+		slot := timeRange{start: start, end: start.Add(e.duration)}
+		next := start
+
+		for _, b := range w.breaks {
+			if slot.overlaps(b) && b.end.After(next) {
+				next = b.end
+			}
+		}
+		if e.courtID != nil {
+			for _, busy := range courtBusy[*e.courtID] {
+				if slot.overlaps(busy) && busy.end.After(next) {
+					next = busy.end
+				}
+			}
+		}
+		withRest := timeRange{start: slot.start.Add(-rest), end: slot.end.Add(rest)}
+		for _, team := range teams {
+			for _, busy := range teamBusy[team] {
+				if withRest.overlaps(busy) && busy.end.Add(rest).After(next) {
+					next = busy.end.Add(rest)
+				}
+			}
+		}
+
+		if next.Equal(start) {
+			return start
+		}
+		start = next
+	}
+}
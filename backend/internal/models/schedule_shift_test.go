@@ -0,0 +1,199 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftSchedule(t *testing.T) {
+	clock := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 20, hour, minute, 0, 0, time.UTC)
+	}
+	options := ScheduleOptions{
+		Date:        "2024-05-20",
+		StartTime:   "09:00",
+		EndTime:     "16:00",
+		Breaks:      []ScheduleBreak{{Name: "昼休み", Start: "12:00", End: "13:00"}},
+		SlotMinutes: map[SportType]int{SportTypeVolleyball: 30, SportTypeTableTennis: 20},
+	}
+	sports := map[int]SportType{1: SportTypeVolleyball, 2: SportTypeTableTennis}
+
+	match := func(id, tournamentID, courtID int, hour, minute int, team1, team2 string) *Match {
+		return &Match{ID: id, TournamentID: tournamentID, Round: string(RoundQuarterfinalEnum), Team1: team1, Team2: team2, Status: MatchStatusPending, ScheduledAt: clock(hour, minute), CourtID: intPtr(courtID)}
+	}
+
+	tests := []struct {
+		name       string
+		request    ScheduleShiftRequest
+		rest       int
+		matches    func() []*Match
+		wantErr    bool
+		wantStarts map[int]time.Time // 開始時刻を変えた試合のみ
+		wantDelays map[int]int
+		wantIssues map[int]ScheduleIssueReason
+	}{
+		{
+			name:    "コートの以降の試合を全て遅らせる",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 15},
+			matches: func() []*Match {
+				return []*Match{
+					match(1, 1, 1, 9, 0, "IE4", "IS4"),
+					match(2, 1, 1, 9, 30, "IT4", "IC4"),
+					match(3, 1, 1, 10, 30, "IM4", "IE3"),
+				}
+			},
+			wantStarts: map[int]time.Time{1: clock(9, 15), 2: clock(9, 45), 3: clock(10, 45)},
+			wantDelays: map[int]int{1: 15, 2: 15, 3: 15},
+		},
+		{
+			name:    "休憩にかかる試合は休憩の後に移す",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 15},
+			matches: func() []*Match {
+				return []*Match{
+					match(1, 1, 1, 11, 30, "IE4", "IS4"),
+					match(2, 1, 1, 13, 0, "IT4", "IC4"),
+				}
+			},
+			wantStarts: map[int]time.Time{1: clock(13, 0), 2: clock(13, 30)},
+			wantDelays: map[int]int{1: 90, 2: 30},
+		},
+		{
+			name:    "固定した試合は動かさずにその後に続ける",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 15},
+			matches: func() []*Match {
+				pinned := match(2, 1, 1, 9, 30, "IT4", "IC4")
+				pinned.SchedulePinned = true
+				return []*Match{
+					match(1, 1, 1, 9, 0, "IE4", "IS4"),
+					pinned,
+					match(3, 1, 1, 10, 0, "IM4", "IE3"),
+				}
+			},
+			wantStarts: map[int]time.Time{1: clock(10, 0), 3: clock(10, 30)},
+			wantDelays: map[int]int{1: 60, 3: 30},
+		},
+		{
+			name:    "他のコートの進出先の試合は進出元の試合の後に移す",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 15},
+			matches: func() []*Match {
+				first := match(1, 1, 1, 9, 0, "IE4", "IS4")
+				first.NextMatchID = intPtr(2)
+				next := match(2, 1, 2, 9, 30, "IE4", "TBD")
+				next.Round = string(RoundSemifinalEnum)
+				return []*Match{first, next, match(3, 1, 2, 11, 0, "IT4", "IC4")}
+			},
+			wantStarts: map[int]time.Time{1: clock(9, 15), 2: clock(9, 45)},
+			wantDelays: map[int]int{1: 15, 2: 15},
+		},
+		{
+			name:    "同じクラスの他の種目の試合は間隔を空けて移す",
+			request: ScheduleShiftRequest{TournamentID: intPtr(1), DelayMinutes: 20},
+			rest:    10,
+			matches: func() []*Match {
+				return []*Match{
+					match(1, 1, 1, 9, 0, "IE4", "IS4"),
+					match(2, 2, 2, 9, 40, "IE4", "IM4"),
+					match(3, 2, 2, 10, 0, "IT4", "IC4"),
+				}
+			},
+			wantStarts: map[int]time.Time{1: clock(9, 20), 2: clock(10, 0), 3: clock(10, 20)},
+			wantDelays: map[int]int{1: 20, 2: 20, 3: 20},
+		},
+		{
+			name:    "指定した時刻より前の試合と実施済みの試合は動かさない",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), From: func() *time.Time { at := clock(9, 30); return &at }(), DelayMinutes: 10},
+			matches: func() []*Match {
+				done := match(3, 1, 1, 10, 0, "IM4", "IE3")
+				done.Status = string(MatchStatusCompletedEnum)
+				return []*Match{
+					match(1, 1, 1, 9, 0, "IE4", "IS4"),
+					match(2, 1, 1, 9, 30, "IT4", "IC4"),
+					done,
+					match(4, 1, 1, 10, 30, "IS3", "IT3"),
+				}
+			},
+			wantStarts: map[int]time.Time{2: clock(10, 30), 4: clock(11, 0)},
+			wantDelays: map[int]int{2: 60, 4: 30},
+		},
+		{
+			name:    "遅れは最初の予定からの時間で示す",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 10},
+			matches: func() []*Match {
+				delayed := match(1, 1, 1, 9, 15, "IE4", "IS4")
+				planned := clock(9, 0)
+				delayed.PlannedAt = &planned
+				return []*Match{delayed}
+			},
+			wantStarts: map[int]time.Time{1: clock(9, 25)},
+			wantDelays: map[int]int{1: 25},
+		},
+		{
+			name:    "終了時刻を過ぎる試合は警告する",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), DelayMinutes: 30},
+			matches: func() []*Match {
+				return []*Match{match(1, 1, 1, 15, 30, "IE4", "IS4")}
+			},
+			wantStarts: map[int]time.Time{1: clock(16, 0)},
+			wantDelays: map[int]int{1: 30},
+			wantIssues: map[int]ScheduleIssueReason{1: ScheduleIssueNoTime},
+		},
+		{
+			name:    "コートとトーナメントの両方の指定はエラー",
+			request: ScheduleShiftRequest{CourtID: intPtr(1), TournamentID: intPtr(1), DelayMinutes: 10},
+			matches: func() []*Match { return []*Match{match(1, 1, 1, 9, 0, "IE4", "IS4")} },
+			wantErr: true,
+		},
+		{
+			name:    "対象の試合がない場合はエラー",
+			request: ScheduleShiftRequest{CourtID: intPtr(2), DelayMinutes: 10},
+			matches: func() []*Match { return []*Match{match(1, 1, 1, 9, 0, "IE4", "IS4")} },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options
+			opts.RestMinutes = tt.rest
+			matches := tt.matches()
+			shift, err := ShiftSchedule(ShiftInput{Request: tt.request, Options: opts, Matches: matches, Sports: sports})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ShiftSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(shift.Matches) != len(tt.wantStarts) {
+				t.Errorf("shifted %d matches, want %d: %+v", len(shift.Matches), len(tt.wantStarts), shift.Matches)
+			}
+			for _, shifted := range shift.Matches {
+				want, ok := tt.wantStarts[shifted.MatchID]
+				if !ok {
+					t.Errorf("match %d shifted to %s unexpectedly", shifted.MatchID, shifted.ScheduledAt.Format("15:04"))
+					continue
+				}
+				if !shifted.ScheduledAt.Equal(want) {
+					t.Errorf("match %d starts at %s, want %s", shifted.MatchID, shifted.ScheduledAt.Format("15:04"), want.Format("15:04"))
+				}
+				if shifted.DelayMinutes != tt.wantDelays[shifted.MatchID] {
+					t.Errorf("match %d delay = %d, want %d", shifted.MatchID, shifted.DelayMinutes, tt.wantDelays[shifted.MatchID])
+				}
+			}
+			for i := 1; i < len(shift.Matches); i++ {
+				if shift.Matches[i].ScheduledAt.Before(shift.Matches[i-1].ScheduledAt) {
+					t.Errorf("matches are not ordered by start time")
+				}
+			}
+
+			if len(shift.Issues) != len(tt.wantIssues) {
+				t.Errorf("issues = %+v, want %v", shift.Issues, tt.wantIssues)
+			}
+			for _, issue := range shift.Issues {
+				if tt.wantIssues[issue.MatchID] != issue.Reason {
+					t.Errorf("match %d issue = %s, want %s", issue.MatchID, issue.Reason, tt.wantIssues[issue.MatchID])
+				}
+			}
+		})
+	}
+}
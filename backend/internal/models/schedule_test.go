@@ -0,0 +1,264 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleOptions_Validate(t *testing.T) {
+	valid := func() *ScheduleOptions {
+		return &ScheduleOptions{
+			Date:        "2024-05-20",
+			StartTime:   "09:00",
+			EndTime:     "16:00",
+			Breaks:      []ScheduleBreak{{Name: "昼休み", Start: "12:00", End: "13:00"}},
+			SlotMinutes: map[SportType]int{SportTypeVolleyball: 25},
+			RestMinutes: 10,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*ScheduleOptions)
+		wantErr bool
+	}{
+		{name: "有効な条件", modify: func(o *ScheduleOptions) {}},
+		{name: "休憩なし", modify: func(o *ScheduleOptions) { o.Breaks = nil }},
+		{name: "無効な試合日", modify: func(o *ScheduleOptions) { o.Date = "5/20" }, wantErr: true},
+		{name: "無効な開始時刻", modify: func(o *ScheduleOptions) { o.StartTime = "9時" }, wantErr: true},
+		{name: "終了時刻が開始時刻より前", modify: func(o *ScheduleOptions) { o.EndTime = "08:00" }, wantErr: true},
+		{name: "休憩の終了時刻が開始時刻より前", modify: func(o *ScheduleOptions) { o.Breaks[0].End = "11:00" }, wantErr: true},
+		{name: "無効な種目", modify: func(o *ScheduleOptions) { o.SlotMinutes["curling"] = 30 }, wantErr: true},
+		{name: "枠が短すぎる", modify: func(o *ScheduleOptions) { o.SlotMinutes[SportTypeVolleyball] = 3 }, wantErr: true},
+		{name: "枠が長すぎる", modify: func(o *ScheduleOptions) { o.SlotMinutes[SportTypeVolleyball] = 300 }, wantErr: true},
+		{name: "負の間隔", modify: func(o *ScheduleOptions) { o.RestMinutes = -5 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := valid()
+			tt.modify(options)
+			if err := options.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildSchedule(t *testing.T) {
+	clock := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 20, hour, minute, 0, 0, time.UTC)
+	}
+	options := ScheduleOptions{
+		Date:        "2024-05-20",
+		StartTime:   "09:00",
+		EndTime:     "12:00",
+		SlotMinutes: map[SportType]int{SportTypeVolleyball: 30, SportTypeTableTennis: 20},
+		RestMinutes: 10,
+	}
+	volleyballCourt := &Court{ID: 1, Name: "Aコート", Sports: []SportType{SportTypeVolleyball}}
+	tableTennisCourt := &Court{ID: 2, Name: "卓球台1", Sports: []SportType{SportTypeTableTennis}}
+	sports := map[int]SportType{1: SportTypeVolleyball, 2: SportTypeTableTennis}
+
+	match := func(id, tournamentID int, round RoundType, position int, team1, team2 string) *Match {
+		return &Match{ID: id, TournamentID: tournamentID, Round: string(round), Position: position, Team1: team1, Team2: team2, Status: MatchStatusPending}
+	}
+
+	tests := []struct {
+		name         string
+		options      func(*ScheduleOptions)
+		courts       []*Court
+		matches      func() []*Match
+		wantFeasible bool
+		wantStarts   map[int]time.Time
+		wantCourts   map[int]int
+		wantIssues   map[int]ScheduleIssueReason
+	}{
+		{
+			name:   "同じコートの試合は順に割り当てる",
+			courts: []*Court{volleyballCourt},
+			matches: func() []*Match {
+				return []*Match{
+					match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4"),
+					match(2, 1, RoundSemifinalEnum, 1, "IT4", "IC4"),
+				}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{1: clock(9, 0), 2: clock(9, 30)},
+			wantCourts:   map[int]int{1: 1, 2: 1},
+		},
+		{
+			name:   "同じクラスは種目をまたいで間隔を空ける",
+			courts: []*Court{volleyballCourt, tableTennisCourt},
+			matches: func() []*Match {
+				return []*Match{
+					match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4"),
+					match(2, 2, RoundSemifinalEnum, 0, "IE4", "IT4"),
+				}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{1: clock(9, 0), 2: clock(9, 40)},
+			wantCourts:   map[int]int{1: 1, 2: 2},
+		},
+		{
+			name:   "進出先の試合は進出元の試合の後",
+			courts: []*Court{volleyballCourt, {ID: 3, Name: "Bコート", Sports: []SportType{SportTypeVolleyball}}},
+			matches: func() []*Match {
+				semi1 := match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4")
+				semi2 := match(2, 1, RoundSemifinalEnum, 1, "IT4", "IC4")
+				final := match(3, 1, RoundFinalEnum, 0, TeamTBD, TeamTBD)
+				semi1.NextMatchID = intPtr(3)
+				semi2.NextMatchID = intPtr(3)
+				return []*Match{final, semi1, semi2}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{1: clock(9, 0), 2: clock(9, 0), 3: clock(9, 40)},
+			wantCourts:   map[int]int{1: 1, 2: 3, 3: 1},
+		},
+		{
+			name: "休憩の時間帯を避ける",
+			options: func(o *ScheduleOptions) {
+				o.Breaks = []ScheduleBreak{{Name: "開会式", Start: "09:00", End: "09:45"}}
+			},
+			courts: []*Court{volleyballCourt},
+			matches: func() []*Match {
+				return []*Match{match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4")}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{1: clock(9, 45)},
+		},
+		{
+			name:   "固定した試合の時間を避ける",
+			courts: []*Court{volleyballCourt},
+			matches: func() []*Match {
+				pinned := match(1, 1, RoundSemifinalEnum, 1, "IT4", "IC4")
+				pinned.ScheduledAt = clock(9, 0)
+				pinned.CourtID = intPtr(1)
+				pinned.SchedulePinned = true
+				return []*Match{pinned, match(2, 1, RoundSemifinalEnum, 0, "IE4", "IS4")}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{1: clock(9, 0), 2: clock(9, 30)},
+		},
+		{
+			name:   "中止した試合は割り当てない",
+			courts: []*Court{volleyballCourt},
+			matches: func() []*Match {
+				cancelled := match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4")
+				cancelled.Status = string(MatchStatusCancelledEnum)
+				return []*Match{cancelled, match(2, 1, RoundSemifinalEnum, 1, "IT4", "IC4")}
+			},
+			wantFeasible: true,
+			wantStarts:   map[int]time.Time{2: clock(9, 0)},
+		},
+		{
+			name:   "種目を行えるコートがない",
+			courts: []*Court{volleyballCourt},
+			matches: func() []*Match {
+				return []*Match{match(1, 2, RoundSemifinalEnum, 0, "IE4", "IS4")}
+			},
+			wantIssues: map[int]ScheduleIssueReason{1: ScheduleIssueNoCourt},
+		},
+		{
+			name:    "終了時刻までに空きがない",
+			options: func(o *ScheduleOptions) { o.EndTime = "10:10" },
+			courts:  []*Court{volleyballCourt},
+			matches: func() []*Match {
+				semi1 := match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4")
+				semi2 := match(2, 1, RoundSemifinalEnum, 1, "IT4", "IC4")
+				final := match(3, 1, RoundFinalEnum, 0, TeamTBD, TeamTBD)
+				semi1.NextMatchID = intPtr(3)
+				semi2.NextMatchID = intPtr(3)
+				return []*Match{semi1, semi2, final}
+			},
+			wantStarts: map[int]time.Time{1: clock(9, 0), 2: clock(9, 30)},
+			wantIssues: map[int]ScheduleIssueReason{3: ScheduleIssueNoTime},
+		},
+		{
+			name:   "固定した試合同士でクラスが重なる",
+			courts: []*Court{volleyballCourt, tableTennisCourt},
+			matches: func() []*Match {
+				volleyball := match(1, 1, RoundSemifinalEnum, 0, "IE4", "IS4")
+				tableTennis := match(2, 2, RoundSemifinalEnum, 0, "IE4", "IT4")
+				for i, m := range []*Match{volleyball, tableTennis} {
+					m.ScheduledAt = clock(10, 0)
+					m.CourtID = intPtr(i + 1)
+					m.SchedulePinned = true
+				}
+				return []*Match{volleyball, tableTennis}
+			},
+			wantStarts: map[int]time.Time{1: clock(10, 0), 2: clock(10, 0)},
+			wantIssues: map[int]ScheduleIssueReason{2: ScheduleIssuePinnedConflict},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options
+			if tt.options != nil {
+				tt.options(&opts)
+			}
+
+			schedule, err := BuildSchedule(ScheduleInput{Options: opts, Location: time.UTC, Courts: tt.courts, Matches: tt.matches(), Sports: sports})
+			if err != nil {
+				t.Fatalf("BuildSchedule() error = %v", err)
+			}
+			if schedule.Feasible != tt.wantFeasible {
+				t.Errorf("Feasible = %v, want %v (issues: %+v)", schedule.Feasible, tt.wantFeasible, schedule.Issues)
+			}
+
+			starts := make(map[int]time.Time)
+			courts := make(map[int]int)
+			for _, m := range schedule.Matches {
+				starts[m.MatchID] = m.StartsAt
+				if m.CourtID != nil {
+					courts[m.MatchID] = *m.CourtID
+				}
+			}
+			if len(starts) != len(tt.wantStarts) {
+				t.Errorf("scheduled %d matches, want %d", len(starts), len(tt.wantStarts))
+			}
+			for id, want := range tt.wantStarts {
+				if got, ok := starts[id]; !ok || !got.Equal(want) {
+					t.Errorf("match %d starts at %v, want %v", id, got, want)
+				}
+			}
+			for id, want := range tt.wantCourts {
+				if courts[id] != want {
+					t.Errorf("match %d court = %d, want %d", id, courts[id], want)
+				}
+			}
+
+			if len(schedule.Issues) != len(tt.wantIssues) {
+				t.Errorf("issues = %+v, want %v", schedule.Issues, tt.wantIssues)
+			}
+			for _, issue := range schedule.Issues {
+				if want, ok := tt.wantIssues[issue.MatchID]; !ok || issue.Reason != want {
+					t.Errorf("issue for match %d = %s, want %s", issue.MatchID, issue.Reason, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCourt_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		court   Court
+		wantErr bool
+	}{
+		{name: "有効なコート", court: Court{Name: "Aコート", Sports: []SportType{SportTypeVolleyball}}},
+		{name: "複数の種目", court: Court{Name: "グラウンド", Sports: []SportType{SportTypeSoccer, SportTypeVolleyball}}},
+		{name: "コート名なし", court: Court{Name: " ", Sports: []SportType{SportTypeVolleyball}}, wantErr: true},
+		{name: "種目なし", court: Court{Name: "Aコート"}, wantErr: true},
+		{name: "無効な種目", court: Court{Name: "Aコート", Sports: []SportType{"curling"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.court.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SetScore はセット制の試合の1セット分のスコア
+type SetScore struct {
+	SetNumber int `json:"set_number" example:"1"` // セット番号（1始まり）
+	Score1    int `json:"score1" example:"25"`
+	Score2    int `json:"score2" example:"20"`
+}
+
+// SetRules はセット制の試合の規則
+type SetRules struct {
+	BestOf            int `json:"best_of" example:"3"`              // 最大セット数（過半数のセットを先取したチームの勝ち）
+	PointsToWin       int `json:"points_to_win" example:"25"`       // 1セットを取るのに必要な得点
+	DecidingSetPoints int `json:"deciding_set_points" example:"15"` // 最終セットを取るのに必要な得点
+	WinBy             int `json:"win_by" example:"2"`               // セットを取るのに必要な点差
+}
+
+// SetRulesForSport はスポーツのセット制の規則を種目のレジストリから返す（セット制でない場合はfalse）
+func SetRulesForSport(sport SportType) (SetRules, bool) {
+	registered, ok := LookupSport(sport)
+	if !ok || registered.SetRules == nil {
+		return SetRules{}, false
+	}
+	return *registered.SetRules, true
+}
+
+// Validate はセット制の規則を検証する
+func (r SetRules) Validate() error {
+	if r.BestOf < 1 || r.BestOf%2 == 0 {
+		return errors.New("最大セット数は1以上の奇数である必要があります")
+	}
+	if r.PointsToWin < 1 {
+		return errors.New("セットを取るのに必要な得点は1以上である必要があります")
+	}
+	if r.DecidingSetPoints < 0 {
+		return errors.New("最終セットの得点は0以上である必要があります")
+	}
+	if r.WinBy < 1 {
+		return errors.New("セットを取るのに必要な点差は1以上である必要があります")
+	}
+	return nil
+}
+
+// SetsToWin は勝利に必要なセット数を返す
+func (r SetRules) SetsToWin() int {
+	return r.BestOf/2 + 1
+}
+
+// pointsForSet はセット番号に応じてセットを取るのに必要な得点を返す
+func (r SetRules) pointsForSet(setNumber int) int {
+	if setNumber == r.BestOf && r.DecidingSetPoints > 0 {
+		return r.DecidingSetPoints
+	}
+	return r.PointsToWin
+}
+
+// ValidateSets はセットのスコアを規則に照らして検証する
+// 各セットは必要な得点に達し、かつ必要な点差がついた時点で終了する。
+// どちらかのチームが勝利に必要なセット数を先取した時点で試合は終了する
+func (r SetRules) ValidateSets(sets []SetScore) error {
+	if len(sets) == 0 {
+		return errors.New("セットのスコアは必須です")
+	}
+	if len(sets) > r.BestOf {
+		return fmt.Errorf("セット数は%d以下である必要があります", r.BestOf)
+	}
+
+	won1, won2 := 0, 0
+	for i, set := range sets {
+		if won1 == r.SetsToWin() || won2 == r.SetsToWin() {
+			return errors.New("勝敗が決まった後のセットは記録できません")
+		}
+
+		setNumber := i + 1
+		high, low := set.Score1, set.Score2
+		if low > high {
+			high, low = low, high
+		}
+		points := r.pointsForSet(setNumber)
+		switch {
+		case high < points:
+			return fmt.Errorf("第%dセットはどちらのチームも%d点に達していません", setNumber, points)
+		case high-low < r.WinBy:
+			return fmt.Errorf("第%dセットは%d点差がついていません", setNumber, r.WinBy)
+		case high > points && high-low != r.WinBy:
+			return fmt.Errorf("第%dセットのスコアが不正です（%d点を超えた場合は%d点差がついた時点で終了します）", setNumber, points, r.WinBy)
+		}
+
+		if set.Score1 > set.Score2 {
+			won1++
+		} else {
+			won2++
+		}
+	}
+
+	if won1 < r.SetsToWin() && won2 < r.SetsToWin() {
+		return fmt.Errorf("どちらのチームも%dセットを先取していません", r.SetsToWin())
+	}
+	return nil
+}
+
+// CountSetsWon は各チームが取ったセット数を返す
+func CountSetsWon(sets []SetScore) (int, int) {
+	won1, won2 := 0, 0
+	for _, set := range sets {
+		switch {
+		case set.Score1 > set.Score2:
+			won1++
+		case set.Score2 > set.Score1:
+			won2++
+		}
+	}
+	return won1, won2
+}
+
+// validateSetScores はセットのスコアの基本的な整合性（0以上、同点のセットがない、番号が連続）を検証する
+func validateSetScores(sets []SetScore) error {
+	for i, set := range sets {
+		if set.Score1 < 0 || set.Score2 < 0 {
+			return errors.New("スコアは0以上である必要があります")
+		}
+		if set.Score1 == set.Score2 {
+			return fmt.Errorf("第%dセットが同点です", i+1)
+		}
+		if set.SetNumber != 0 && set.SetNumber != i+1 {
+			return errors.New("セット番号は1から順に指定する必要があります")
+		}
+	}
+	return nil
+}
+
+// numberSets はセット番号を1から順に振り直した複製を返す
+func numberSets(sets []SetScore) []SetScore {
+	if len(sets) == 0 {
+		return nil
+	}
+	numbered := make([]SetScore, len(sets))
+	for i, set := range sets {
+		set.SetNumber = i + 1
+		numbered[i] = set
+	}
+	return numbered
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSetRules_ValidateSets(t *testing.T) {
+	volleyball, _ := SetRulesForSport(SportTypeVolleyball)
+	tableTennis, _ := SetRulesForSport(SportTypeTableTennis)
+
+	tests := []struct {
+		name    string
+		rules   SetRules
+		sets    []SetScore
+		wantErr bool
+	}{
+		{name: "バレーボールのフルセット", rules: volleyball, sets: []SetScore{{Score1: 25, Score2: 20}, {Score1: 22, Score2: 25}, {Score1: 15, Score2: 13}}},
+		{name: "バレーボールのデュース", rules: volleyball, sets: []SetScore{{Score1: 27, Score2: 25}, {Score1: 25, Score2: 10}}},
+		{name: "卓球のストレート", rules: tableTennis, sets: []SetScore{{Score1: 11, Score2: 9}, {Score1: 13, Score2: 11}, {Score1: 11, Score2: 0}}},
+		{name: "必要な得点に未達", rules: tableTennis, sets: []SetScore{{Score1: 10, Score2: 8}, {Score1: 11, Score2: 9}, {Score1: 11, Score2: 9}}, wantErr: true},
+		{name: "点差が1点", rules: tableTennis, sets: []SetScore{{Score1: 11, Score2: 10}, {Score1: 11, Score2: 9}, {Score1: 11, Score2: 9}}, wantErr: true},
+		{name: "デュース後の点差が大きすぎる", rules: volleyball, sets: []SetScore{{Score1: 28, Score2: 20}, {Score1: 25, Score2: 20}}, wantErr: true},
+		{name: "最終セットは15点", rules: volleyball, sets: []SetScore{{Score1: 25, Score2: 20}, {Score1: 22, Score2: 25}, {Score1: 25, Score2: 13}}, wantErr: true},
+		{name: "勝敗が決まっていない", rules: volleyball, sets: []SetScore{{Score1: 25, Score2: 20}}, wantErr: true},
+		{name: "勝敗決定後のセット", rules: volleyball, sets: []SetScore{{Score1: 25, Score2: 20}, {Score1: 25, Score2: 20}, {Score1: 15, Score2: 10}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.ValidateSets(tt.sets); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchResult_Sets(t *testing.T) {
+	result := MatchResult{
+		Sets:   []SetScore{{Score1: 25, Score2: 20}, {Score1: 22, Score2: 25}, {Score1: 15, Score2: 13}},
+		Winner: "IE4",
+	}
+	result.DeriveScoreFromSets()
+	if result.Score1 != 2 || result.Score2 != 1 {
+		t.Fatalf("DeriveScoreFromSets() = %d-%d, want 2-1", result.Score1, result.Score2)
+	}
+	if err := result.ValidateForRound(RoundSemifinalEnum, "IE4", "IS4"); err != nil {
+		t.Errorf("ValidateForRound() error = %v", err)
+	}
+	if err := result.ValidateSetsForSport(SportTypeSoccer); err == nil {
+		t.Error("ValidateSetsForSport() should reject sets for soccer")
+	}
+
+	match := newPendingMatch(1, RoundSemifinalEnum, 0, "IE4", "IS4")
+	match.ApplyResult(result)
+	if match.Sets[2].SetNumber != 3 {
+		t.Errorf("ApplyResult() set numbers = %+v", match.Sets)
+	}
+	if got, want := match.ScoreDisplay(), "2-1 (25-20, 22-25, 15-13)"; got != want {
+		t.Errorf("ScoreDisplay() = %q, want %q", got, want)
+	}
+
+	// スコアとセットの結果が一致しない
+	result.Score1, result.Score2 = 2, 0
+	if err := result.ValidateForRound(RoundSemifinalEnum, "IE4", "IS4"); err == nil {
+		t.Error("ValidateForRound() should reject a score that differs from the sets")
+	}
+}
@@ -0,0 +1,277 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sport はスポーツ種目の定義（表示名・利用可能な形式・ラウンド・得点方式）
+// 種目は sports テーブルで管理し、起動時にレジストリへ読み込む
+type Sport struct {
+	Code            SportType          `json:"code" db:"code" example:"volleyball"`             // 種目コード（URL・購読で使用）
+	DisplayName     string             `json:"display_name" db:"display_name" example:"バレーボール"` // 表示名
+	Formats         []TournamentFormat `json:"formats" db:"formats"`                            // 利用可能なトーナメント形式
+	Rounds          []RoundType        `json:"rounds" db:"rounds"`                              // 利用可能なラウンド
+	SetRules        *SetRules          `json:"set_rules,omitempty" db:"set_rules"`              // セット制の規則（セット制でない場合はnull）
+	ForfeitScore    int                `json:"forfeit_score" db:"forfeit_score" example:"3"`    // 得点制の試合で不戦勝のチームに与える得点
+	PlacementPoints []int              `json:"placement_points" db:"placement_points"`          // 総合順位の順位点（1位から順に、未設定の場合は既定の順位点）
+	SortOrder       int                `json:"sort_order" db:"sort_order" example:"1"`          // 一覧の表示順
+	IsActive        bool               `json:"is_active" db:"is_active" example:"true"`         // 新しいトーナメントを作成できるかどうか
+	CreatedAt       time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// sportCodePattern は種目コードの形式（英小文字で始まる英小文字・数字・アンダースコア）
+var sportCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// DefaultPlacementPoints は総合順位の順位点の既定値（1位10点・2位7点・3位5点・4位3点）
+var DefaultPlacementPoints = []int{10, 7, 5, 3}
+
+// maxPlacementPoints は順位点を設定できる順位の数の上限
+const maxPlacementPoints = 16
+
+// allRounds は全ての形式で使用するラウンド
+var allRounds = []RoundType{
+	Round1stRoundEnum,
+	RoundQuarterfinalEnum,
+	RoundSemifinalEnum,
+	RoundThirdPlaceEnum,
+	RoundFinalEnum,
+	RoundLoserBracketEnum,    // 雨天時・ダブルイリミネーション形式のみ
+	RoundGrandFinalEnum,      // ダブルイリミネーション形式のみ
+	RoundGrandFinalResetEnum, // ダブルイリミネーション形式のみ
+	RoundLeagueEnum,          // 総当たり形式のみ
+	RoundGroupStageEnum,      // グループリーグ形式のみ
+	RoundSwissEnum,           // スイス式のみ
+}
+
+// DefaultSports は組み込みの種目（バレーボール・卓球・サッカー）を返す
+// sports テーブルの初期データと同じ内容で、テーブルを読み込むまでのレジストリに使用する
+func DefaultSports() []*Sport {
+	formats := []TournamentFormat{
+		TournamentFormatStandard,
+		TournamentFormatDoubleElimination,
+		TournamentFormatRoundRobin,
+		TournamentFormatGroupKnockout,
+		TournamentFormatSwiss,
+	}
+
+	return []*Sport{
+		{
+			Code:        SportTypeVolleyball,
+			DisplayName: "バレーボール",
+			Formats:     formats,
+			Rounds:      allRounds,
+			SetRules:    &SetRules{BestOf: 3, PointsToWin: 25, DecidingSetPoints: 15, WinBy: 2},
+			SortOrder:   1,
+			IsActive:    true,
+		},
+		{
+			Code:        SportTypeTableTennis,
+			DisplayName: "卓球",
+			Formats:     append([]TournamentFormat{TournamentFormatRainy}, formats...), // 雨天時は卓球のみ形式を切り替える
+			Rounds:      allRounds,
+			SetRules:    &SetRules{BestOf: 5, PointsToWin: 11, DecidingSetPoints: 11, WinBy: 2},
+			SortOrder:   2,
+			IsActive:    true,
+		},
+		{
+			Code:         SportTypeSoccer,
+			DisplayName:  "サッカー",
+			Formats:      formats,
+			Rounds:       allRounds,
+			ForfeitScore: forfeitGoals,
+			SortOrder:    3,
+			IsActive:     true,
+		},
+	}
+}
+
+// Validate は種目の定義を検証する
+func (s *Sport) Validate() error {
+	if !sportCodePattern.MatchString(string(s.Code)) {
+		return errors.New("種目コードは英小文字で始まる2〜50文字の英小文字・数字・アンダースコアである必要があります")
+	}
+
+	if strings.TrimSpace(s.DisplayName) == "" {
+		return errors.New("表示名は必須です")
+	}
+	if len(s.DisplayName) > 100 {
+		return errors.New("表示名は100文字以下である必要があります")
+	}
+
+	if len(s.Formats) == 0 {
+		return errors.New("利用可能な形式を1つ以上指定してください")
+	}
+	for _, format := range s.Formats {
+		if !format.IsValid() {
+			return fmt.Errorf("無効なトーナメント形式です: %s", format)
+		}
+	}
+
+	if len(s.Rounds) == 0 {
+		return errors.New("利用可能なラウンドを1つ以上指定してください")
+	}
+	for _, round := range s.Rounds {
+		if !round.IsValid() {
+			return fmt.Errorf("無効なラウンドです: %s", round)
+		}
+	}
+
+	if s.SetRules != nil {
+		if err := s.SetRules.Validate(); err != nil {
+			return err
+		}
+	} else if s.ForfeitScore < 1 {
+		return errors.New("セット制でない種目は不戦勝時の得点を1以上にする必要があります")
+	}
+
+	if len(s.PlacementPoints) > maxPlacementPoints {
+		return fmt.Errorf("順位点は%d位までしか設定できません", maxPlacementPoints)
+	}
+	for i, points := range s.PlacementPoints {
+		if points < 0 {
+			return errors.New("順位点は0以上である必要があります")
+		}
+		if i > 0 && points > s.PlacementPoints[i-1] {
+			return errors.New("順位点は下位の順位ほど同じか小さくする必要があります")
+		}
+	}
+
+	return nil
+}
+
+// PointsForPlace は順位に与える総合順位の順位点を返す（順位点が設定されていない順位は0点）
+func (s *Sport) PointsForPlace(place int) int {
+	scheme := s.PlacementPoints
+	if len(scheme) == 0 {
+		scheme = DefaultPlacementPoints
+	}
+	if place < 1 || place > len(scheme) {
+		return 0
+	}
+	return scheme[place-1]
+}
+
+// AllowsFormat は種目でトーナメント形式を利用できるかどうかを返す
+func (s *Sport) AllowsFormat(format TournamentFormat) bool {
+	for _, f := range s.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRound は種目でラウンドを利用できるかどうかを返す
+func (s *Sport) AllowsRound(round RoundType) bool {
+	for _, r := range s.Rounds {
+		if r == round {
+			return true
+		}
+	}
+	return false
+}
+
+// SportRegistry は登録済みの種目を管理する
+// 種目の判定・ラウンド・得点方式は全てレジストリを参照する
+type SportRegistry struct {
+	mu     sync.RWMutex
+	sports map[SportType]*Sport
+}
+
+// sportRegistry はアプリケーション全体で共有するレジストリ（組み込みの種目で初期化する）
+var sportRegistry = newSportRegistry(DefaultSports())
+
+// newSportRegistry は種目を登録したレジストリを作成する
+func newSportRegistry(sports []*Sport) *SportRegistry {
+	registry := &SportRegistry{sports: make(map[SportType]*Sport, len(sports))}
+	for _, sport := range sports {
+		registry.sports[sport.Code] = sport
+	}
+	return registry
+}
+
+// RegisterSports は登録済みの種目を全て置き換える（起動時に sports テーブルから読み込む）
+func RegisterSports(sports []*Sport) error {
+	if len(sports) == 0 {
+		return errors.New("種目が1つも登録されていません")
+	}
+	for _, sport := range sports {
+		if err := sport.Validate(); err != nil {
+			return fmt.Errorf("種目 %s: %w", sport.Code, err)
+		}
+	}
+
+	replaced := newSportRegistry(sports)
+	sportRegistry.mu.Lock()
+	sportRegistry.sports = replaced.sports
+	sportRegistry.mu.Unlock()
+	return nil
+}
+
+// RegisterSport は種目を追加または更新する
+func RegisterSport(sport *Sport) error {
+	if err := sport.Validate(); err != nil {
+		return err
+	}
+
+	sportRegistry.mu.Lock()
+	sportRegistry.sports[sport.Code] = sport
+	sportRegistry.mu.Unlock()
+	return nil
+}
+
+// LookupSport は登録済みの種目を返す（無効化された種目を含む）
+func LookupSport(code SportType) (*Sport, bool) {
+	sportRegistry.mu.RLock()
+	defer sportRegistry.mu.RUnlock()
+
+	sport, ok := sportRegistry.sports[code]
+	return sport, ok
+}
+
+// RegisteredSports は登録済みの種目を表示順に返す
+// activeOnly がtrueの場合は新しいトーナメントを作成できる種目のみを返す
+func RegisteredSports(activeOnly bool) []*Sport {
+	sportRegistry.mu.RLock()
+	sports := make([]*Sport, 0, len(sportRegistry.sports))
+	for _, sport := range sportRegistry.sports {
+		if activeOnly && !sport.IsActive {
+			continue
+		}
+		sports = append(sports, sport)
+	}
+	sportRegistry.mu.RUnlock()
+
+	sort.Slice(sports, func(i, j int) bool {
+		if sports[i].SortOrder != sports[j].SortOrder {
+			return sports[i].SortOrder < sports[j].SortOrder
+		}
+		return sports[i].Code < sports[j].Code
+	})
+	return sports
+}
+
+// RegisteredSportCodes は登録済みの種目コードを表示順に返す
+func RegisteredSportCodes() []string {
+	sports := RegisteredSports(false)
+	codes := make([]string, len(sports))
+	for i, sport := range sports {
+		codes[i] = string(sport.Code)
+	}
+	return codes
+}
+
+// SportDisplayName は種目の表示名を返す（未登録の場合は「スポーツ」）
+func SportDisplayName(code SportType) string {
+	if sport, ok := LookupSport(code); ok {
+		return sport.DisplayName
+	}
+	return "スポーツ"
+}
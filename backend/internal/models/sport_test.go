@@ -0,0 +1,131 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSport_Validate(t *testing.T) {
+	valid := func() *Sport {
+		return &Sport{
+			Code:         "basketball",
+			DisplayName:  "バスケットボール",
+			Formats:      []TournamentFormat{TournamentFormatStandard},
+			Rounds:       []RoundType{RoundSemifinalEnum, RoundFinalEnum},
+			ForfeitScore: 20,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Sport)
+		wantErr bool
+	}{
+		{name: "得点制の種目", modify: func(s *Sport) {}},
+		{name: "セット制の種目", modify: func(s *Sport) { s.SetRules = &SetRules{BestOf: 3, PointsToWin: 21, WinBy: 2}; s.ForfeitScore = 0 }},
+		{name: "大文字を含むコード", modify: func(s *Sport) { s.Code = "Basketball" }, wantErr: true},
+		{name: "表示名なし", modify: func(s *Sport) { s.DisplayName = " " }, wantErr: true},
+		{name: "無効な形式", modify: func(s *Sport) { s.Formats = []TournamentFormat{"knockout"} }, wantErr: true},
+		{name: "ラウンドなし", modify: func(s *Sport) { s.Rounds = nil }, wantErr: true},
+		{name: "偶数の最大セット数", modify: func(s *Sport) { s.SetRules = &SetRules{BestOf: 4, PointsToWin: 21, WinBy: 2} }, wantErr: true},
+		{name: "不戦勝の得点なし", modify: func(s *Sport) { s.ForfeitScore = 0 }, wantErr: true},
+		{name: "順位点", modify: func(s *Sport) { s.PlacementPoints = []int{8, 5, 5, 0} }},
+		{name: "負の順位点", modify: func(s *Sport) { s.PlacementPoints = []int{10, -1} }, wantErr: true},
+		{name: "下位の順位点が大きい", modify: func(s *Sport) { s.PlacementPoints = []int{7, 10} }, wantErr: true},
+		{name: "順位点の設定が多すぎる", modify: func(s *Sport) { s.PlacementPoints = make([]int, 17) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sport := valid()
+			tt.modify(sport)
+			if err := sport.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSport_PointsForPlace(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme []int
+		place  int
+		want   int
+	}{
+		{name: "既定の1位", place: 1, want: 10},
+		{name: "既定の3位", place: 3, want: 5},
+		{name: "既定の順位点のない順位", place: 5, want: 0},
+		{name: "設定した順位点", scheme: []int{20, 10}, place: 2, want: 10},
+		{name: "設定した順位点のない順位", scheme: []int{20, 10}, place: 3, want: 0},
+		{name: "順位なし", place: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sport := &Sport{PlacementPoints: tt.scheme}
+			if got := sport.PointsForPlace(tt.place); got != tt.want {
+				t.Errorf("PointsForPlace(%d) = %d, want %d", tt.place, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSportRegistry_Defaults(t *testing.T) {
+	for _, sport := range []SportType{SportTypeVolleyball, SportTypeTableTennis, SportTypeSoccer} {
+		if !sport.IsValid() {
+			t.Errorf("%s should be registered", sport)
+		}
+	}
+	if SportType("basketball").IsValid() {
+		t.Error("basketball should not be registered by default")
+	}
+
+	tableTennis, _ := LookupSport(SportTypeTableTennis)
+	volleyball, _ := LookupSport(SportTypeVolleyball)
+	if !tableTennis.AllowsFormat(TournamentFormatRainy) || volleyball.AllowsFormat(TournamentFormatRainy) {
+		t.Error("rainy format should only be available for table tennis")
+	}
+
+	if got := RegisteredSportCodes(); len(got) != 3 || got[0] != "volleyball" || got[1] != "table_tennis" || got[2] != "soccer" {
+		t.Errorf("RegisteredSportCodes() = %v", got)
+	}
+}
+
+func TestRegisterSport(t *testing.T) {
+	t.Cleanup(func() {
+		if err := RegisterSports(DefaultSports()); err != nil {
+			t.Fatalf("RegisterSports() error = %v", err)
+		}
+	})
+
+	basketball := &Sport{
+		Code:         "basketball",
+		DisplayName:  "バスケットボール",
+		Formats:      []TournamentFormat{TournamentFormatRoundRobin},
+		Rounds:       []RoundType{RoundLeagueEnum},
+		ForfeitScore: 20,
+		SortOrder:    4,
+		IsActive:     true,
+	}
+	if err := RegisterSport(basketball); err != nil {
+		t.Fatalf("RegisterSport() error = %v", err)
+	}
+
+	if !SportType("basketball").IsValid() {
+		t.Error("registered sport should be valid")
+	}
+	if !IsValidRoundForSport("basketball", RoundLeagueEnum) || IsValidRoundForSport("basketball", RoundFinalEnum) {
+		t.Error("rounds should come from the registry")
+	}
+	if won, lost, sets := DefaultForfeitScore("basketball"); won != 20 || lost != 0 || sets != nil {
+		t.Errorf("DefaultForfeitScore() = %d-%d %v, want 20-0", won, lost, sets)
+	}
+	if got := SportDisplayName("basketball"); got != "バスケットボール" {
+		t.Errorf("SportDisplayName() = %q", got)
+	}
+
+	basketball.IsActive = false
+	if len(RegisteredSports(true)) != 3 || len(RegisteredSports(false)) != 4 {
+		t.Error("inactive sports should only be listed when requested")
+	}
+}
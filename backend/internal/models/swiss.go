@@ -0,0 +1,177 @@
+package models
+
+import (
+	"errors"
+)
+
+// DefaultSwissRounds はチーム数から既定のスイス式の回戦数を返す
+// 全勝チームが1チームに絞られる回戦数（log2(チーム数) の切り上げ）とする
+func DefaultSwissRounds(teamCount int) int {
+	rounds := 0
+	for n := 1; n < teamCount; n *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// CurrentSwissRound は作成済みの最新の回戦番号（未作成の場合は0）と、その回戦の試合が全て完了しているかを返す
+func CurrentSwissRound(matches []*Match) (round int, complete bool) {
+	complete = true
+	for _, match := range matches {
+		if match == nil || match.GetRound() != RoundSwissEnum || match.SwissRound == nil {
+			continue
+		}
+		switch {
+		case *match.SwissRound > round:
+			round = *match.SwissRound
+			complete = match.IsCompleted()
+		case *match.SwissRound == round:
+			complete = complete && match.IsCompleted()
+		}
+	}
+	return round, complete
+}
+
+// swissByes は各回戦で試合のなかった（不戦勝の）チームの回数を返す
+func swissByes(teams []string, matches []*Match) map[string]int {
+	current, _ := CurrentSwissRound(matches)
+	playing := make(map[int]map[string]bool, current)
+	for _, match := range matches {
+		if match == nil || match.GetRound() != RoundSwissEnum || match.SwissRound == nil {
+			continue
+		}
+		round := *match.SwissRound
+		if playing[round] == nil {
+			playing[round] = make(map[string]bool)
+		}
+		playing[round][match.Team1] = true
+		playing[round][match.Team2] = true
+	}
+
+	byes := make(map[string]int)
+	for round := 1; round <= current; round++ {
+		for _, team := range teams {
+			if !playing[round][team] {
+				byes[team]++
+			}
+		}
+	}
+	return byes
+}
+
+// ComputeSwissStandings はスイス式の試合結果から順位表を作成する
+// 不戦勝は勝ちと同じ勝点とし、各回戦で試合のなかったチームを不戦勝とみなす
+func ComputeSwissStandings(teams []string, matches []*Match, rules LeagueRules) []Standing {
+	swiss := make([]*Match, 0, len(matches))
+	for _, match := range matches {
+		if match != nil && match.GetRound() == RoundSwissEnum {
+			swiss = append(swiss, match)
+		}
+	}
+	return computeTable(swiss, rules, swissByes(teams, swiss))
+}
+
+// PairSwissRound は現在の成績から次の回戦の組み合わせを作成する
+// 順位順に並べ、同じ勝点のチームの中では上位半分と下位半分を対戦させる（ダッチ方式）。
+// 既に対戦したチーム同士は組み合わせず、必要に応じて組み合わせをやり直す。
+// チーム数が奇数の場合は、不戦勝になっていない最下位のチームを不戦勝とする。
+// 最初の回戦は teams の順（シード順）に並べる
+func PairSwissRound(teams []string, matches []*Match, rules LeagueRules) (pairs [][2]string, bye string, err error) {
+	if len(teams) < 2 {
+		return nil, "", errors.New("2チーム以上が必要です")
+	}
+
+	points := make(map[string]int, len(teams))
+	hadBye := make(map[string]bool)
+	ranked := make([]string, 0, len(teams))
+	if current, _ := CurrentSwissRound(matches); current == 0 {
+		ranked = append(ranked, teams...)
+	} else {
+		for _, standing := range ComputeSwissStandings(teams, matches, rules) {
+			ranked = append(ranked, standing.Team)
+			points[standing.Team] = standing.Points
+			hadBye[standing.Team] = standing.Byes > 0
+		}
+	}
+
+	played := make(map[[2]string]bool)
+	for _, match := range matches {
+		if match == nil || match.GetRound() != RoundSwissEnum {
+			continue
+		}
+		played[[2]string{match.Team1, match.Team2}] = true
+		played[[2]string{match.Team2, match.Team1}] = true
+	}
+
+	if len(ranked)%2 == 0 {
+		if pairs, ok := pairSwiss(ranked, points, played); ok {
+			return pairs, "", nil
+		}
+		return nil, "", errors.New("再戦とならない組み合わせがありません")
+	}
+
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if hadBye[ranked[i]] {
+			continue
+		}
+		rest := append(append([]string{}, ranked[:i]...), ranked[i+1:]...)
+		if pairs, ok := pairSwiss(rest, points, played); ok {
+			return pairs, ranked[i], nil
+		}
+	}
+	return nil, "", errors.New("再戦とならない組み合わせがありません")
+}
+
+// pairSwiss は順位順のチームを再戦にならないように組み合わせる（バックトラック）
+// 先頭のチームの相手は、同じ勝点のグループの下位半分の先頭、グループ内の残り、下位のグループの順に試す
+func pairSwiss(ranked []string, points map[string]int, played map[[2]string]bool) ([][2]string, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+
+	first, rest := ranked[0], ranked[1:]
+	group := 0
+	for group < len(rest) && points[rest[group]] == points[first] {
+		group++
+	}
+	half := (group+1)/2 - 1
+	if half < 0 {
+		half = 0
+	}
+
+	candidates := make([]int, 0, len(rest))
+	for i := half; i < group; i++ {
+		candidates = append(candidates, i)
+	}
+	for i := 0; i < half; i++ {
+		candidates = append(candidates, i)
+	}
+	for i := group; i < len(rest); i++ {
+		candidates = append(candidates, i)
+	}
+
+	for _, i := range candidates {
+		opponent := rest[i]
+		if played[[2]string{first, opponent}] {
+			continue
+		}
+		remaining := append(append([]string{}, rest[:i]...), rest[i+1:]...)
+		if pairs, ok := pairSwiss(remaining, points, played); ok {
+			return append([][2]string{{first, opponent}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// NewSwissRoundMatches は組み合わせからスイス式の1回戦分の試合を作成する
+// 試合の位置は全回戦を通した通し番号とし、firstPosition から順に割り当てる
+func NewSwissRoundMatches(tournamentID, round, firstPosition int, pairs [][2]string) []*Match {
+	matches := make([]*Match, 0, len(pairs))
+	for i, pair := range pairs {
+		match := newPendingMatch(tournamentID, RoundSwissEnum, firstPosition+i, pair[0], pair[1])
+		swissRound := round
+		match.SwissRound = &swissRound
+		matches = append(matches, match)
+	}
+	return matches
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestPairSwissRoundFirstRound(t *testing.T) {
+	teams := []string{"S1", "S2", "S3", "S4", "S5", "S6"}
+
+	pairs, bye, err := PairSwissRound(teams, nil, DefaultSwissRules())
+	if err != nil {
+		t.Fatalf("PairSwissRound() error = %v", err)
+	}
+	if bye != "" {
+		t.Errorf("PairSwissRound() bye = %q, want none", bye)
+	}
+
+	// 上位半分と下位半分の対戦
+	want := [][2]string{{"S1", "S4"}, {"S2", "S5"}, {"S3", "S6"}}
+	for i := range want {
+		if pairs[i] != want[i] {
+			t.Errorf("PairSwissRound() pairs = %v, want %v", pairs, want)
+			break
+		}
+	}
+}
+
+func TestPairSwissRoundNoRematches(t *testing.T) {
+	teams := []string{"S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8"}
+
+	var matches []*Match
+	for round := 1; round <= 5; round++ {
+		matches = playSwissRound(t, matches, teams, round)
+	}
+
+	seen := make(map[[2]string]bool)
+	for _, match := range matches {
+		pair := [2]string{match.Team1, match.Team2}
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if seen[pair] {
+			t.Errorf("PairSwissRound() rematch %v", pair)
+		}
+		seen[pair] = true
+	}
+
+	current, complete := CurrentSwissRound(matches)
+	if current != 5 || !complete {
+		t.Errorf("CurrentSwissRound() = %d, %v, want 5, true", current, complete)
+	}
+
+	// 最強のチームは全勝で首位
+	table := ComputeSwissStandings(teams, matches, DefaultSwissRules())
+	if table[0].Team != "S1" || table[0].Won != 5 {
+		t.Errorf("ComputeSwissStandings() leader = %+v", table[0])
+	}
+}
+
+func TestPairSwissRoundByes(t *testing.T) {
+	teams := []string{"S1", "S2", "S3", "S4", "S5"}
+
+	var matches []*Match
+	byes := make(map[string]bool)
+	for round := 1; round <= 3; round++ {
+		_, bye, err := PairSwissRound(teams, matches, DefaultSwissRules())
+		if err != nil {
+			t.Fatalf("PairSwissRound(round %d) error = %v", round, err)
+		}
+		if bye == "" || byes[bye] {
+			t.Errorf("PairSwissRound(round %d) bye = %q, want a team without a previous bye", round, bye)
+		}
+		byes[bye] = true
+		matches = playSwissRound(t, matches, teams, round)
+	}
+
+	// 不戦勝は勝ちと同じ勝点
+	rules := DefaultSwissRules()
+	for _, standing := range ComputeSwissStandings(teams, matches, rules) {
+		if standing.Byes != 0 && !byes[standing.Team] {
+			t.Errorf("ComputeSwissStandings() %s byes = %d, want 0", standing.Team, standing.Byes)
+		}
+		if want := (standing.Won+standing.Byes)*rules.PointsForWin + standing.Drawn*rules.PointsForDraw; standing.Points != want {
+			t.Errorf("ComputeSwissStandings() %s points = %d, want %d", standing.Team, standing.Points, want)
+		}
+	}
+}
+
+func TestComputeStandingsBuchholz(t *testing.T) {
+	// Aが3勝、B・Cが1勝1敗、D・Eが全敗
+	matches := []*Match{
+		newLeagueResult("A", "B", 1, 0),
+		newLeagueResult("C", "D", 1, 0),
+		newLeagueResult("A", "C", 1, 0),
+		newLeagueResult("B", "D", 1, 0),
+		newLeagueResult("A", "E", 1, 0),
+	}
+	rules := DefaultSwissRules()
+	rules.Tiebreakers = []Tiebreaker{TiebreakerBuchholz}
+
+	table := computeTable(matches, rules, nil)
+	position := make(map[string]Standing)
+	for _, standing := range table {
+		position[standing.Team] = standing
+	}
+
+	// B・Cの相手はA(9)とD(0)、Dの相手はB(3)とC(3)、Eの相手はA(9)
+	if position["B"].Buchholz != 9 || position["C"].Buchholz != 9 {
+		t.Errorf("computeTable() buchholz B = %d, C = %d, want 9", position["B"].Buchholz, position["C"].Buchholz)
+	}
+	if position["E"].Buchholz != 9 || position["D"].Buchholz != 6 {
+		t.Errorf("computeTable() buchholz E = %d, D = %d, want 9, 6", position["E"].Buchholz, position["D"].Buchholz)
+	}
+	// 全敗のD・Eはブッフホルツの大きいEが上位
+	if position["E"].Rank >= position["D"].Rank {
+		t.Errorf("computeTable() rank E = %d, D = %d, want E above D", position["E"].Rank, position["D"].Rank)
+	}
+}
@@ -1,16 +1,77 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"time"
 )
 
-// Team represents a team in the tournament system
+// Team はトーナメントに登録されたチーム（クラス）を表すモデル
+// 試合・勝者・棄権したチームはチームIDで参照し、試合のチーム名は読み込み時に現在の名前で表示する。
+// チーム名を変更しても試合・結果訂正・抽選の記録は書き換えない
 type Team struct {
-	ID           uint      `json:"id" gorm:"primaryKey"`
-	Name         string    `json:"name" gorm:"not null;uniqueIndex"`
-	Description  string    `json:"description"`
-	TournamentID uint      `json:"tournament_id" gorm:"not null;index"`
-	Tournament   Tournament `json:"tournament" gorm:"foreignKey:TournamentID"`
-	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-}
\ No newline at end of file
+	ID           uint      `json:"id" db:"id"`
+	TournamentID uint      `json:"tournament_id" db:"tournament_id"`
+	Name         string    `json:"name" db:"name" example:"IE4"`           // チーム名（トーナメント内で一意）
+	Description  string    `json:"description,omitempty" db:"description"` // 補足（クラス名の正式表記など）
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate はチームデータの検証を行う
+func (t *Team) Validate() error {
+	if t.TournamentID == 0 {
+		return errors.New("トーナメントIDは必須です")
+	}
+
+	name := strings.TrimSpace(t.Name)
+	if name == "" {
+		return errors.New("チーム名は必須です")
+	}
+	if len(name) > 100 {
+		return errors.New("チーム名は100文字以下である必要があります")
+	}
+	// 未確定の枠や棄権の記録と区別できなくなるため予約済みの名前は使えない
+	if IsUndecidedTeam(name) || name == TeamWithdrawn {
+		return errors.New("チーム名 " + name + " は予約されているため使用できません")
+	}
+
+	if len(t.Description) > 255 {
+		return errors.New("補足は255文字以下である必要があります")
+	}
+
+	return nil
+}
+
+// TeamRef は試合の枠に入るチーム
+// 登録チームはIDで識別し、名前は表示用とする。未確定の枠・棄権の記録・未登録のチームはIDを持たない
+type TeamRef struct {
+	ID   *int
+	Name string
+}
+
+// Is は同じチームかどうかを返す（登録チームはIDで、IDのないチームは名前で比較する）
+func (t TeamRef) Is(other TeamRef) bool {
+	if t.ID != nil || other.ID != nil {
+		return t.ID != nil && other.ID != nil && *t.ID == *other.ID
+	}
+	return t.Name == other.Name
+}
+
+// AssignTeamIDs は登録チームの名前で作成した試合の枠に、そのチームのIDを設定する
+// 抽選・組み合わせはチーム名で行うため、試合を保存する前に呼び出してIDで参照させる
+func AssignTeamIDs(matches []*Match, teams []*Team) {
+	ids := make(map[string]int, len(teams))
+	for _, team := range teams {
+		ids[team.Name] = int(team.ID)
+	}
+
+	for _, match := range matches {
+		for _, slot := range []int{SlotTeam1, SlotTeam2} {
+			team := match.TeamInSlot(slot)
+			if id, ok := ids[team.Name]; ok && team.ID == nil {
+				match.SetTeamInSlot(slot, TeamRef{ID: &id, Name: team.Name})
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeam_Validate(t *testing.T) {
+	valid := func() *Team {
+		return &Team{
+			TournamentID: 1,
+			Name:         "IE4",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Team)
+		wantErr bool
+	}{
+		{name: "有効なチーム", modify: func(tm *Team) {}},
+		{name: "補足あり", modify: func(tm *Team) { tm.Description = "情報工学科4年" }},
+		{name: "トーナメントIDなし", modify: func(tm *Team) { tm.TournamentID = 0 }, wantErr: true},
+		{name: "チーム名なし", modify: func(tm *Team) { tm.Name = " " }, wantErr: true},
+		{name: "チーム名が長すぎる", modify: func(tm *Team) { tm.Name = strings.Repeat("a", 101) }, wantErr: true},
+		{name: "未確定の枠と同じ名前", modify: func(tm *Team) { tm.Name = TeamTBD }, wantErr: true},
+		{name: "棄権の記録と同じ名前", modify: func(tm *Team) { tm.Name = TeamWithdrawn }, wantErr: true},
+		{name: "グループ順位の枠と同じ名前", modify: func(tm *Team) { tm.Name = "A組1位" }, wantErr: true},
+		{name: "補足が長すぎる", modify: func(tm *Team) { tm.Description = strings.Repeat("a", 256) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := valid()
+			tt.modify(team)
+			if err := team.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatch_TeamsByID(t *testing.T) {
+	teams := []*Team{{ID: 11, TournamentID: 1, Name: "IE4"}, {ID: 12, TournamentID: 1, Name: "専・教"}}
+	match := newPendingMatch(1, RoundSemifinalEnum, 0, "IE4", "専・教")
+	AssignTeamIDs([]*Match{match}, teams)
+	if match.Team1ID == nil || *match.Team1ID != 11 || match.Team2ID == nil || *match.Team2ID != 12 {
+		t.Fatalf("AssignTeamIDs() team IDs = %v, %v, want 11, 12", match.Team1ID, match.Team2ID)
+	}
+
+	result, err := NewForfeitResult(SportTypeSoccer, ResultTypeForfeitEnum, "IE4", "専・教", "専・教")
+	if err != nil {
+		t.Fatalf("NewForfeitResult() error = %v", err)
+	}
+	match.ApplyResult(result)
+	match.SetStatus(MatchStatusCompletedEnum)
+	if match.WinnerID == nil || *match.WinnerID != 11 || match.ForfeitingTeamID == nil || *match.ForfeitingTeamID != 12 {
+		t.Fatalf("ApplyResult() winner ID = %v, forfeiting team ID = %v, want 11, 12", match.WinnerID, match.ForfeitingTeamID)
+	}
+
+	// 専・教を専攻科に変更すると、読み込んだ試合には現在の名前が表示され、記録した名前とは一致しなくなる
+	match.Team2 = "専攻科"
+	if match.WinnerSlot() != SlotTeam1 || match.ForfeitingSlot() != SlotTeam2 || !match.HasForfeited(SlotTeam2) {
+		t.Errorf("WinnerSlot() = %d, ForfeitingSlot() = %d, want 1, 2", match.WinnerSlot(), match.ForfeitingSlot())
+	}
+	if winner, loser := match.AdvancingTeams(); winner.ID == nil || *winner.ID != 11 || loser.Name != TeamWithdrawn {
+		t.Errorf("AdvancingTeams() = %+v, %+v, want team 11 and %s", winner, loser, TeamWithdrawn)
+	}
+	if !match.TeamInSlot(SlotTeam2).Is(TeamRef{ID: match.Team2ID, Name: "専・教"}) {
+		t.Error("TeamRef.Is() compared registered teams by name")
+	}
+	if match.TeamInSlot(SlotTeam1).Is(TeamRef{Name: "IE4"}) {
+		t.Error("TeamRef.Is() matched a registered team with an unregistered team of the same name")
+	}
+}
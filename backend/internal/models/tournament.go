@@ -9,6 +9,7 @@ import (
 // Tournament はトーナメントを表すモデル
 type Tournament struct {
 	ID        int       `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`   // 所属する大会
 	Sport     string    `json:"sport" db:"sport"`         // データベース互換性のため文字列型を維持
 	Format    string    `json:"format" db:"format"`       // データベース互換性のため文字列型を維持
 	Status    string    `json:"status" db:"status"`       // データベース互換性のため文字列型を維持
@@ -103,4 +104,19 @@ func (t *Tournament) IsRegistration() bool {
 // IsCancelled はトーナメントがキャンセルされているかどうかを返す
 func (t *Tournament) IsCancelled() bool {
 	return t.GetStatus() == TournamentStatusCancelledEnum
+}
+
+// IsDraft はトーナメントが準備中かどうかを返す
+func (t *Tournament) IsDraft() bool {
+	return t.GetStatus() == TournamentStatusDraftEnum
+}
+
+// IsSeeded はトーナメントの組み合わせが確定しているかどうかを返す
+func (t *Tournament) IsSeeded() bool {
+	return t.GetStatus() == TournamentStatusSeededEnum
+}
+
+// IsArchived はトーナメントが保管済み（読み取り専用）かどうかを返す
+func (t *Tournament) IsArchived() bool {
+	return t.GetStatus() == TournamentStatusArchivedEnum
 }
\ No newline at end of file
@@ -0,0 +1,259 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tournamentTransitions はトーナメントステータスの遷移表（遷移元 → 遷移できるステータス）
+//
+//	draft        → registration, cancelled
+//	registration → draft, seeded, cancelled
+//	seeded       → active, cancelled
+//	active       → completed, cancelled
+//	completed    → active（結果の訂正で決勝などをやり直す場合のみ）, archived
+//	cancelled    → archived
+//	archived     → なし
+var tournamentTransitions = map[TournamentStatus][]TournamentStatus{
+	TournamentStatusDraftEnum: {
+		TournamentStatusRegistrationEnum, TournamentStatusCancelledEnum,
+	},
+	TournamentStatusRegistrationEnum: {
+		TournamentStatusDraftEnum, TournamentStatusSeededEnum, TournamentStatusCancelledEnum,
+	},
+	TournamentStatusSeededEnum: {
+		TournamentStatusActiveEnum, TournamentStatusCancelledEnum,
+	},
+	TournamentStatusActiveEnum: {
+		TournamentStatusCompletedEnum, TournamentStatusCancelledEnum,
+	},
+	TournamentStatusCompletedEnum: {
+		TournamentStatusActiveEnum, TournamentStatusArchivedEnum,
+	},
+	TournamentStatusCancelledEnum: {
+		TournamentStatusArchivedEnum,
+	},
+	TournamentStatusArchivedEnum: {},
+}
+
+// TournamentStatuses は全てのトーナメントステータスをデータベースのENUMの定義順に返す
+func TournamentStatuses() []TournamentStatus {
+	return []TournamentStatus{
+		TournamentStatusDraftEnum,
+		TournamentStatusRegistrationEnum,
+		TournamentStatusSeededEnum,
+		TournamentStatusActiveEnum,
+		TournamentStatusCompletedEnum,
+		TournamentStatusCancelledEnum,
+		TournamentStatusArchivedEnum,
+	}
+}
+
+// Label はトーナメントステータスの表示名を返す
+func (t TournamentStatus) Label() string {
+	switch t {
+	case TournamentStatusDraftEnum:
+		return "準備中"
+	case TournamentStatusRegistrationEnum:
+		return "参加登録中"
+	case TournamentStatusSeededEnum:
+		return "組み合わせ確定"
+	case TournamentStatusActiveEnum:
+		return "開催中"
+	case TournamentStatusCompletedEnum:
+		return "終了"
+	case TournamentStatusCancelledEnum:
+		return "中止"
+	case TournamentStatusArchivedEnum:
+		return "保管済み"
+	default:
+		return string(t)
+	}
+}
+
+// CanTransitionTo は遷移表で to への遷移が許されているかどうかを返す（ガード条件は含まない）
+func (t TournamentStatus) CanTransitionTo(to TournamentStatus) bool {
+	for _, next := range tournamentTransitions[t] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TournamentTransitionError は許可されていないトーナメントステータスの変更を表すエラー
+type TournamentTransitionError struct {
+	TournamentID int              `json:"tournament_id"`
+	From         TournamentStatus `json:"from"`
+	To           TournamentStatus `json:"to"`
+	Reason       string           `json:"reason"`
+}
+
+// Error はerrorインターフェースを実装する
+func (e *TournamentTransitionError) Error() string {
+	return fmt.Sprintf("トーナメント（ID: %d）を%sから%sにできません: %s", e.TournamentID, e.From.Label(), e.To.Label(), e.Reason)
+}
+
+// newTournamentTransitionError はトーナメントステータスの変更のエラーを作成する
+func newTournamentTransitionError(t *Tournament, to TournamentStatus, reason string) *TournamentTransitionError {
+	return &TournamentTransitionError{TournamentID: t.ID, From: t.GetStatus(), To: to, Reason: reason}
+}
+
+// TournamentTransitionInput はトーナメントステータスのガード条件の確認に使う登録チームと試合
+type TournamentTransitionInput struct {
+	Teams   []string // 登録チームの名前
+	Matches []*Match // トーナメントの全ての試合（組み合わせの確定時は作成する試合）
+}
+
+// CheckTransition はトーナメントを to に変更できるかを遷移表で確認する（ガード条件は TransitionTo で確認する）
+func (t *Tournament) CheckTransition(to TournamentStatus) error {
+	if !to.IsValid() {
+		return newTournamentTransitionError(t, to, "無効なトーナメントステータスです")
+	}
+	if !t.GetStatus().CanTransitionTo(to) {
+		return newTournamentTransitionError(t, to, "この変更は許可されていません")
+	}
+	return nil
+}
+
+// TransitionTo はトーナメントステータスを to に変更する
+// 遷移表にない変更や、次のガード条件を満たさない変更は *TournamentTransitionError を返し、トーナメントは変更しない
+//   - 組み合わせを確定するには2チーム以上が登録され、試合に登録されていないチームがいないこと
+//   - 開催中にするには試合が作成されていること
+//   - 終了にするには優勝を決める試合（決勝・3位決定戦など）が終わっていること
+//   - 終了から開催中に戻すのは優勝を決める試合がやり直しになった場合のみ
+func (t *Tournament) TransitionTo(to TournamentStatus, input TournamentTransitionInput) error {
+	if err := t.CheckTransition(to); err != nil {
+		return err
+	}
+
+	switch {
+	case to == TournamentStatusSeededEnum:
+		if len(input.Teams) < 2 {
+			return newTournamentTransitionError(t, to, "2チーム以上の登録が必要です")
+		}
+		if unregistered := UnregisteredTeams(input.Teams, input.Matches); len(unregistered) > 0 {
+			return newTournamentTransitionError(t, to, "登録されていないチームが含まれています: "+strings.Join(unregistered, ", "))
+		}
+		if len(input.Matches) == 0 {
+			return newTournamentTransitionError(t, to, "試合が作成されていません")
+		}
+	case to == TournamentStatusActiveEnum && t.IsSeeded():
+		if len(input.Matches) == 0 {
+			return newTournamentTransitionError(t, to, "試合が作成されていません")
+		}
+	case to == TournamentStatusActiveEnum && t.IsCompleted():
+		if t.IsDecided(input.Matches) {
+			return newTournamentTransitionError(t, to, "優勝を決める試合は全て終わっています")
+		}
+	case to == TournamentStatusCompletedEnum:
+		if !t.IsDecided(input.Matches) {
+			return newTournamentTransitionError(t, to, "優勝を決める試合が終わっていません")
+		}
+	}
+
+	t.SetStatus(to)
+	return nil
+}
+
+// AutoTransition は試合の状況から自動で変更するステータスを返す（変更しない場合はfalse）
+//   - 組み合わせ確定のトーナメントは、試合が始まるか終わると開催中にする
+//   - 開催中のトーナメントは、優勝を決める試合が終わると終了にする（スイス式は回戦数を管理者が決めるため除く）
+//   - 終了したトーナメントは、結果の訂正で優勝を決める試合がやり直しになると開催中に戻す
+func (t *Tournament) AutoTransition(matches []*Match) (TournamentStatus, bool) {
+	switch t.GetStatus() {
+	case TournamentStatusSeededEnum:
+		for _, match := range matches {
+			if match.IsInProgress() || match.IsSuspended() || match.IsCompleted() {
+				return TournamentStatusActiveEnum, true
+			}
+		}
+	case TournamentStatusActiveEnum:
+		if t.GetFormat() != TournamentFormatSwiss && t.IsDecided(matches) {
+			return TournamentStatusCompletedEnum, true
+		}
+	case TournamentStatusCompletedEnum:
+		if !t.IsDecided(matches) {
+			return TournamentStatusActiveEnum, true
+		}
+	}
+	return "", false
+}
+
+// IsDecided は優勝を決める試合が全て終わったかどうかを返す
+//   - ノックアウト方式・グループステージ: 決勝と（ある場合は）3位決定戦
+//   - 雨天時形式: さらに中止した試合を除く敗者復活戦
+//   - ダブルイリミネーション: グランドファイナルと（必要な場合は）リセットマッチ
+//   - 総当たり・スイス式: 中止した試合を除く全ての試合
+func (t *Tournament) IsDecided(matches []*Match) bool {
+	byRound := groupMatchesByRound(matches)
+	finished := func(round RoundType) bool {
+		if len(byRound[round]) == 0 {
+			return false
+		}
+		for _, match := range byRound[round] {
+			if !match.IsCompleted() {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch t.GetFormat().BracketStructure() {
+	case "double_elimination":
+		if !finished(RoundGrandFinalEnum) {
+			return false
+		}
+		return !byRound[RoundGrandFinalEnum][0].NeedsGrandFinalReset() || finished(RoundGrandFinalResetEnum)
+	case "round_robin", "swiss":
+		completed := 0
+		for _, match := range matches {
+			switch {
+			case match.IsCompleted():
+				completed++
+			case !match.IsCancelled():
+				return false
+			}
+		}
+		return completed > 0
+	default:
+		if !finished(RoundFinalEnum) {
+			return false
+		}
+		if len(byRound[RoundThirdPlaceEnum]) > 0 && !finished(RoundThirdPlaceEnum) {
+			return false
+		}
+		if t.GetFormat() == TournamentFormatRainy {
+			for _, match := range byRound[RoundLoserBracketEnum] {
+				if !match.IsCompleted() && !match.IsCancelled() {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// UnregisteredTeams は試合に出場するチームのうち登録チームにないチームを名前順に返す
+// 未確定の枠・グループ順位の枠・棄権の記録は除く
+func UnregisteredTeams(teams []string, matches []*Match) []string {
+	registered := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		registered[team] = true
+	}
+
+	seen := make(map[string]bool)
+	var unregistered []string
+	for _, match := range matches {
+		for _, team := range scheduledTeams(match) {
+			if registered[team] || seen[team] {
+				continue
+			}
+			seen[team] = true
+			unregistered = append(unregistered, team)
+		}
+	}
+	sort.Strings(unregistered)
+	return unregistered
+}
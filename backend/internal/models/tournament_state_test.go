@@ -0,0 +1,197 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTournament_TransitionTo(t *testing.T) {
+	teams := []string{"IE4", "IS4", "IT4", "IC4"}
+	match := func(round RoundType, team1, team2 string, status MatchStatus) *Match {
+		return &Match{TournamentID: 1, Round: string(round), Team1: team1, Team2: team2, Status: string(status)}
+	}
+	bracket := []*Match{
+		match(RoundSemifinalEnum, "IE4", "IS4", MatchStatusCompletedEnum),
+		match(RoundSemifinalEnum, "IT4", "IC4", MatchStatusCompletedEnum),
+		match(RoundThirdPlaceEnum, "IS4", "IC4", MatchStatusCompletedEnum),
+		match(RoundFinalEnum, "IE4", "IT4", MatchStatusCompletedEnum),
+	}
+	pendingFinal := []*Match{
+		match(RoundSemifinalEnum, "IE4", "IS4", MatchStatusCompletedEnum),
+		match(RoundThirdPlaceEnum, "IS4", "TBD", MatchStatusPendingEnum),
+		match(RoundFinalEnum, "IE4", "TBD", MatchStatusPendingEnum),
+	}
+
+	tests := []struct {
+		name    string
+		status  TournamentStatus
+		to      TournamentStatus
+		input   TournamentTransitionInput
+		wantErr bool
+	}{
+		{name: "準備中から参加登録", status: TournamentStatusDraftEnum, to: TournamentStatusRegistrationEnum},
+		{name: "参加登録から組み合わせ確定", status: TournamentStatusRegistrationEnum, to: TournamentStatusSeededEnum, input: TournamentTransitionInput{Teams: teams, Matches: pendingFinal}},
+		{name: "組み合わせ確定から開催中", status: TournamentStatusSeededEnum, to: TournamentStatusActiveEnum, input: TournamentTransitionInput{Matches: pendingFinal}},
+		{name: "決勝と3位決定戦が終われば終了", status: TournamentStatusActiveEnum, to: TournamentStatusCompletedEnum, input: TournamentTransitionInput{Matches: bracket}},
+		{name: "終了から保管", status: TournamentStatusCompletedEnum, to: TournamentStatusArchivedEnum},
+		{name: "開催中から中止", status: TournamentStatusActiveEnum, to: TournamentStatusCancelledEnum},
+		{name: "決勝がやり直しになれば開催中に戻す", status: TournamentStatusCompletedEnum, to: TournamentStatusActiveEnum, input: TournamentTransitionInput{Matches: pendingFinal}},
+		{name: "未登録のチームがいると組み合わせを確定できない", status: TournamentStatusRegistrationEnum, to: TournamentStatusSeededEnum, input: TournamentTransitionInput{Teams: teams, Matches: []*Match{match(RoundFinalEnum, "IE4", "IM4", MatchStatusPendingEnum)}}, wantErr: true},
+		{name: "2チーム未満では組み合わせを確定できない", status: TournamentStatusRegistrationEnum, to: TournamentStatusSeededEnum, input: TournamentTransitionInput{Teams: []string{"IE4"}, Matches: pendingFinal}, wantErr: true},
+		{name: "試合がないと組み合わせを確定できない", status: TournamentStatusRegistrationEnum, to: TournamentStatusSeededEnum, input: TournamentTransitionInput{Teams: teams}, wantErr: true},
+		{name: "決勝が終わっていないと終了にできない", status: TournamentStatusActiveEnum, to: TournamentStatusCompletedEnum, input: TournamentTransitionInput{Matches: pendingFinal}, wantErr: true},
+		{name: "決勝が終わっていれば開催中に戻せない", status: TournamentStatusCompletedEnum, to: TournamentStatusActiveEnum, input: TournamentTransitionInput{Matches: bracket}, wantErr: true},
+		{name: "参加登録から開催中は不可", status: TournamentStatusRegistrationEnum, to: TournamentStatusActiveEnum, input: TournamentTransitionInput{Matches: pendingFinal}, wantErr: true},
+		{name: "中止から開催中は不可", status: TournamentStatusCancelledEnum, to: TournamentStatusActiveEnum, wantErr: true},
+		{name: "保管からは変更不可", status: TournamentStatusArchivedEnum, to: TournamentStatusCompletedEnum, input: TournamentTransitionInput{Matches: bracket}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &Tournament{ID: 1, Sport: string(SportTypeVolleyball), Format: string(TournamentFormatStandard), Status: string(tt.status)}
+			err := tournament.TransitionTo(tt.to, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo(%s) error = %v, wantErr %v", tt.to, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var transitionErr *TournamentTransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("error = %T, want *TournamentTransitionError", err)
+				}
+				if transitionErr.From != tt.status || transitionErr.To != tt.to {
+					t.Errorf("error from %s to %s, want from %s to %s", transitionErr.From, transitionErr.To, tt.status, tt.to)
+				}
+				if got := tournament.GetStatus(); got != tt.status {
+					t.Errorf("status = %s after refused transition, want %s", got, tt.status)
+				}
+				return
+			}
+			if got := tournament.GetStatus(); got != tt.to {
+				t.Errorf("status = %s, want %s", got, tt.to)
+			}
+		})
+	}
+}
+
+func TestTournament_AutoTransition(t *testing.T) {
+	match := func(round RoundType, status MatchStatus) *Match {
+		m := &Match{TournamentID: 1, Round: string(round), Team1: "IE4", Team2: "IS4", Status: string(status)}
+		if status == MatchStatusCompletedEnum {
+			m.ApplyResult(MatchResult{Score1: 2, Score2: 1, Winner: "IE4"})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		status  TournamentStatus
+		format  TournamentFormat
+		matches []*Match
+		want    TournamentStatus // 空の場合は変更しない
+	}{
+		{
+			name:    "試合が始まると開催中",
+			status:  TournamentStatusSeededEnum,
+			matches: []*Match{match(RoundSemifinalEnum, MatchStatusInProgressEnum), match(RoundFinalEnum, MatchStatusPendingEnum)},
+			want:    TournamentStatusActiveEnum,
+		},
+		{
+			name:    "試合が始まるまでは組み合わせ確定のまま",
+			status:  TournamentStatusSeededEnum,
+			matches: []*Match{match(RoundSemifinalEnum, MatchStatusPendingEnum), match(RoundFinalEnum, MatchStatusPendingEnum)},
+		},
+		{
+			name:    "決勝と3位決定戦が終わると終了",
+			status:  TournamentStatusActiveEnum,
+			matches: []*Match{match(RoundSemifinalEnum, MatchStatusCompletedEnum), match(RoundThirdPlaceEnum, MatchStatusCompletedEnum), match(RoundFinalEnum, MatchStatusCompletedEnum)},
+			want:    TournamentStatusCompletedEnum,
+		},
+		{
+			name:    "3位決定戦が残っていれば開催中のまま",
+			status:  TournamentStatusActiveEnum,
+			matches: []*Match{match(RoundThirdPlaceEnum, MatchStatusPendingEnum), match(RoundFinalEnum, MatchStatusCompletedEnum)},
+		},
+		{
+			name:   "リセットマッチが必要なダブルイリミネーションは開催中のまま",
+			status: TournamentStatusActiveEnum,
+			format: TournamentFormatDoubleElimination,
+			matches: func() []*Match {
+				grandFinal := match(RoundGrandFinalEnum, MatchStatusCompletedEnum)
+				grandFinal.ApplyResult(MatchResult{Score1: 1, Score2: 2, Winner: "IS4"})
+				return []*Match{grandFinal, match(RoundGrandFinalResetEnum, MatchStatusPendingEnum)}
+			}(),
+		},
+		{
+			name:    "総当たりは中止した試合を除く全ての試合が終わると終了",
+			status:  TournamentStatusActiveEnum,
+			format:  TournamentFormatRoundRobin,
+			matches: []*Match{match(RoundLeagueEnum, MatchStatusCompletedEnum), match(RoundLeagueEnum, MatchStatusCancelledEnum)},
+			want:    TournamentStatusCompletedEnum,
+		},
+		{
+			name:    "スイス式は自動で終了しない",
+			status:  TournamentStatusActiveEnum,
+			format:  TournamentFormatSwiss,
+			matches: []*Match{match(RoundSwissEnum, MatchStatusCompletedEnum)},
+		},
+		{
+			name:    "訂正で決勝がやり直しになると開催中に戻す",
+			status:  TournamentStatusCompletedEnum,
+			matches: []*Match{match(RoundSemifinalEnum, MatchStatusCompletedEnum), match(RoundFinalEnum, MatchStatusPendingEnum)},
+			want:    TournamentStatusActiveEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := tt.format
+			if format == "" {
+				format = TournamentFormatStandard
+			}
+			tournament := &Tournament{ID: 1, Sport: string(SportTypeVolleyball), Format: string(format), Status: string(tt.status)}
+			got, ok := tournament.AutoTransition(tt.matches)
+			if ok != (tt.want != "") || got != tt.want {
+				t.Fatalf("AutoTransition() = %q, %v, want %q", got, ok, tt.want)
+			}
+			if ok {
+				if err := tournament.TransitionTo(got, TournamentTransitionInput{Matches: tt.matches}); err != nil {
+					t.Errorf("automatic transition refused by guards: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnregisteredTeams(t *testing.T) {
+	matches := []*Match{
+		{Team1: "IE4", Team2: "IM4"},
+		{Team1: "A組1位", Team2: TeamTBD},
+		{Team1: TeamWithdrawn, Team2: "IC4"},
+		{Team1: "IM4", Team2: "IS4"},
+	}
+	got := UnregisteredTeams([]string{"IE4", "IS4"}, matches)
+	want := []string{"IC4", "IM4"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("UnregisteredTeams() = %v, want %v", got, want)
+	}
+}
+
+// TestTournamentStatuses_MatchMigrations は遷移表のステータスがマイグレーションのENUMと一致することを確認する
+func TestTournamentStatuses_MatchMigrations(t *testing.T) {
+	want := TournamentStatuses()
+	got := migrationStatusEnum(t, "tournaments")
+	if len(got) != len(want) {
+		t.Fatalf("migration ENUM = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != string(want[i]) {
+			t.Errorf("migration ENUM = %v, want %v", got, want)
+			break
+		}
+	}
+	for _, status := range want {
+		if _, ok := tournamentTransitions[status]; !ok {
+			t.Errorf("status %s has no entry in the transition table", status)
+		}
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"time"
 )
 
-// SportType はスポーツ種目を表す列挙型
+// SportType はスポーツ種目を表す型
+// 有効な種目は sports テーブル（種目のレジストリ）で管理し、以下は組み込みの種目
 type SportType string
 
 const (
@@ -21,14 +22,10 @@ func (s SportType) String() string {
 	return string(s)
 }
 
-// IsValid はSportTypeが有効かどうかを判定する
+// IsValid はSportTypeが種目のレジストリに登録されているかどうかを判定する
 func (s SportType) IsValid() bool {
-	switch s {
-	case SportTypeVolleyball, SportTypeTableTennis, SportTypeSoccer:
-		return true
-	default:
-		return false
-	}
+	_, ok := LookupSport(s)
+	return ok
 }
 
 // Value はdatabase/sql/driverインターフェースを実装する
@@ -58,11 +55,15 @@ func (s *SportType) Scan(value interface{}) error {
 // TournamentStatus はトーナメントステータスを表す列挙型
 type TournamentStatus string
 
+// トーナメントステータスの遷移は tournament_state.go の遷移表で定める
 const (
+	TournamentStatusDraftEnum        TournamentStatus = "draft"
 	TournamentStatusRegistrationEnum TournamentStatus = "registration"
+	TournamentStatusSeededEnum       TournamentStatus = "seeded"
 	TournamentStatusActiveEnum       TournamentStatus = "active"
 	TournamentStatusCompletedEnum    TournamentStatus = "completed"
 	TournamentStatusCancelledEnum    TournamentStatus = "cancelled"
+	TournamentStatusArchivedEnum     TournamentStatus = "archived"
 )
 
 // String はTournamentStatusの文字列表現を返す
@@ -73,7 +74,8 @@ func (t TournamentStatus) String() string {
 // IsValid はTournamentStatusが有効かどうかを判定する
 func (t TournamentStatus) IsValid() bool {
 	switch t {
-	case TournamentStatusRegistrationEnum, TournamentStatusActiveEnum, TournamentStatusCompletedEnum, TournamentStatusCancelledEnum:
+	case TournamentStatusDraftEnum, TournamentStatusRegistrationEnum, TournamentStatusSeededEnum, TournamentStatusActiveEnum,
+		TournamentStatusCompletedEnum, TournamentStatusCancelledEnum, TournamentStatusArchivedEnum:
 		return true
 	default:
 		return false
@@ -107,11 +109,15 @@ func (t *TournamentStatus) Scan(value interface{}) error {
 // MatchStatus は試合ステータスを表す列挙型
 type MatchStatus string
 
+// 試合ステータスの遷移は match_state.go の遷移表で定める
 const (
-	MatchStatusPendingEnum    MatchStatus = "pending"
-	MatchStatusInProgressEnum MatchStatus = "in_progress"
-	MatchStatusCompletedEnum  MatchStatus = "completed"
-	MatchStatusCancelledEnum  MatchStatus = "cancelled"
+	MatchStatusPendingEnum     MatchStatus = "pending"
+	MatchStatusInProgressEnum  MatchStatus = "in_progress"
+	MatchStatusCompletedEnum   MatchStatus = "completed"
+	MatchStatusCancelledEnum   MatchStatus = "cancelled"
+	MatchStatusPostponedEnum   MatchStatus = "postponed"   // 延期（新しい開始時刻は未定）
+	MatchStatusSuspendedEnum   MatchStatus = "suspended"   // 試合中に中断（再開待ち）
+	MatchStatusRescheduledEnum MatchStatus = "rescheduled" // 延期・中断の後に新しい開始時刻を決めた
 )
 
 // String はMatchStatusの文字列表現を返す
@@ -122,7 +128,8 @@ func (m MatchStatus) String() string {
 // IsValid はMatchStatusが有効かどうかを判定する
 func (m MatchStatus) IsValid() bool {
 	switch m {
-	case MatchStatusPendingEnum, MatchStatusInProgressEnum, MatchStatusCompletedEnum, MatchStatusCancelledEnum:
+	case MatchStatusPendingEnum, MatchStatusInProgressEnum, MatchStatusCompletedEnum, MatchStatusCancelledEnum,
+		MatchStatusPostponedEnum, MatchStatusSuspendedEnum, MatchStatusRescheduledEnum:
 		return true
 	default:
 		return false
@@ -157,8 +164,12 @@ func (m *MatchStatus) Scan(value interface{}) error {
 type TournamentFormat string
 
 const (
-	TournamentFormatStandard TournamentFormat = "standard"
-	TournamentFormatRainy    TournamentFormat = "rainy"
+	TournamentFormatStandard          TournamentFormat = "standard"
+	TournamentFormatRainy             TournamentFormat = "rainy"
+	TournamentFormatDoubleElimination TournamentFormat = "double_elimination" // 敗者復活のあるダブルイリミネーション
+	TournamentFormatRoundRobin        TournamentFormat = "round_robin"        // 引き分けのある総当たり戦（リーグ戦）
+	TournamentFormatGroupKnockout     TournamentFormat = "group_knockout"     // グループリーグの上位チームによる決勝トーナメント
+	TournamentFormatSwiss             TournamentFormat = "swiss"              // 成績の近いチーム同士を毎回戦組み合わせるスイス式
 )
 
 // String はTournamentFormatの文字列表現を返す
@@ -169,7 +180,38 @@ func (f TournamentFormat) String() string {
 // IsValid はTournamentFormatが有効かどうかを判定する
 func (f TournamentFormat) IsValid() bool {
 	switch f {
-	case TournamentFormatStandard, TournamentFormatRainy:
+	case TournamentFormatStandard, TournamentFormatRainy, TournamentFormatDoubleElimination, TournamentFormatRoundRobin,
+		TournamentFormatGroupKnockout, TournamentFormatSwiss:
+		return true
+	default:
+		return false
+	}
+}
+
+// BracketStructure は形式が生成する試合の構造を返す
+// 構造が異なる形式への切り替えは、試合を生成し直す必要がある
+func (f TournamentFormat) BracketStructure() string {
+	switch f {
+	case TournamentFormatDoubleElimination:
+		return "double_elimination"
+	case TournamentFormatRoundRobin:
+		return "round_robin"
+	case TournamentFormatGroupKnockout:
+		return "group_knockout"
+	case TournamentFormatSwiss:
+		return "swiss"
+	default:
+		return "knockout"
+	}
+}
+
+// CanMigrateMatchesTo は試合の作成後に、作成済みの試合を移行して形式を切り替えられるかどうかを判定する
+// 移行に対応しているのは標準と雨天時形式の間のみで、それ以外の形式はブラケット生成前に変更する
+func (f TournamentFormat) CanMigrateMatchesTo(to TournamentFormat) bool {
+	switch {
+	case f == TournamentFormatStandard && to == TournamentFormatRainy:
+		return true
+	case f == TournamentFormatRainy && to == TournamentFormatStandard:
 		return true
 	default:
 		return false
@@ -213,6 +255,16 @@ const (
 	RoundThirdPlaceEnum   RoundType = "third_place"
 	RoundFinalEnum        RoundType = "final"
 	RoundLoserBracketEnum RoundType = "loser_bracket"
+	// ダブルイリミネーション形式のグランドファイナル（勝者側の優勝チーム対敗者側の優勝チーム）
+	RoundGrandFinalEnum RoundType = "grand_final"
+	// 敗者側のチームがグランドファイナルに勝った場合のみ行うリセットマッチ
+	RoundGrandFinalResetEnum RoundType = "grand_final_reset"
+	// 総当たり戦（リーグ戦）の試合
+	RoundLeagueEnum RoundType = "league"
+	// グループリーグの試合（グループ名は試合のgroup_nameに記録する）
+	RoundGroupStageEnum RoundType = "group_stage"
+	// スイス式の試合（回戦番号は試合のswiss_roundに記録する）
+	RoundSwissEnum RoundType = "swiss"
 )
 
 // String はRoundTypeの文字列表現を返す
@@ -224,13 +276,55 @@ func (r RoundType) String() string {
 func (r RoundType) IsValid() bool {
 	switch r {
 	case Round1stRoundEnum, Round2ndRoundEnum, Round3rdRoundEnum, Round4thRoundEnum,
-		 RoundQuarterfinalEnum, RoundSemifinalEnum, RoundThirdPlaceEnum, RoundFinalEnum, RoundLoserBracketEnum:
+		 RoundQuarterfinalEnum, RoundSemifinalEnum, RoundThirdPlaceEnum, RoundFinalEnum, RoundLoserBracketEnum,
+		 RoundGrandFinalEnum, RoundGrandFinalResetEnum, RoundLeagueEnum, RoundGroupStageEnum, RoundSwissEnum:
 		return true
 	default:
 		return false
 	}
 }
 
+// AllowsDraw はラウンドで引き分けが認められるかどうかを返す（総当たり戦・グループリーグ・スイス式のみ）
+func (r RoundType) AllowsDraw() bool {
+	return r == RoundLeagueEnum || r == RoundGroupStageEnum || r == RoundSwissEnum
+}
+
+// Label はラウンドの表示名を返す
+func (r RoundType) Label() string {
+	switch r {
+	case Round1stRoundEnum:
+		return "1回戦"
+	case Round2ndRoundEnum:
+		return "2回戦"
+	case Round3rdRoundEnum:
+		return "3回戦"
+	case Round4thRoundEnum:
+		return "4回戦"
+	case RoundQuarterfinalEnum:
+		return "準々決勝"
+	case RoundSemifinalEnum:
+		return "準決勝"
+	case RoundThirdPlaceEnum:
+		return "3位決定戦"
+	case RoundFinalEnum:
+		return "決勝"
+	case RoundLoserBracketEnum:
+		return "敗者復活戦"
+	case RoundGrandFinalEnum:
+		return "グランドファイナル"
+	case RoundGrandFinalResetEnum:
+		return "グランドファイナル（リセットマッチ）"
+	case RoundLeagueEnum:
+		return "リーグ戦"
+	case RoundGroupStageEnum:
+		return "グループリーグ"
+	case RoundSwissEnum:
+		return "スイス式"
+	default:
+		return string(r)
+	}
+}
+
 // Value はdatabase/sql/driverインターフェースを実装する
 func (r RoundType) Value() (driver.Value, error) {
 	return string(r), nil
@@ -255,6 +349,175 @@ func (r *RoundType) Scan(value interface{}) error {
 	return nil
 }
 
+// DecisionMethod は試合の決着方法を表す列挙型
+type DecisionMethod string
+
+const (
+	DecisionRegulationEnum DecisionMethod = "regulation" // 正規時間で決着
+	DecisionExtraTimeEnum  DecisionMethod = "extra_time" // 延長戦で決着
+	DecisionPenaltiesEnum  DecisionMethod = "penalties"  // PK戦で決着
+)
+
+// String はDecisionMethodの文字列表現を返す
+func (d DecisionMethod) String() string {
+	return string(d)
+}
+
+// IsValid はDecisionMethodが有効かどうかを判定する
+func (d DecisionMethod) IsValid() bool {
+	switch d {
+	case DecisionRegulationEnum, DecisionExtraTimeEnum, DecisionPenaltiesEnum:
+		return true
+	default:
+		return false
+	}
+}
+
+// Value はdatabase/sql/driverインターフェースを実装する
+func (d DecisionMethod) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+// Scan はdatabase/sql/driverインターフェースを実装する
+func (d *DecisionMethod) Scan(value interface{}) error {
+	if value == nil {
+		*d = ""
+		return nil
+	}
+	
+	switch v := value.(type) {
+	case string:
+		*d = DecisionMethod(v)
+	case []byte:
+		*d = DecisionMethod(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DecisionMethod", value)
+	}
+	
+	return nil
+}
+
+// ResultType は試合結果の種類を表す列挙型
+// 試合を行わずに決着した結果（不戦勝・棄権・失格）はスポーツごとの既定のスコアを記録する
+type ResultType string
+
+const (
+	ResultTypePlayedEnum           ResultType = "played"           // 試合を行って決着
+	ResultTypeWalkoverEnum         ResultType = "walkover"         // 対戦相手の不出場による不戦勝
+	ResultTypeForfeitEnum          ResultType = "forfeit"          // 棄権（試合の途中・開始前の辞退）
+	ResultTypeDoubleForfeitEnum    ResultType = "double_forfeit"   // 両チームの棄権・不出場（両チームの負け）
+	ResultTypeDisqualificationEnum ResultType = "disqualification" // 失格
+)
+
+// String はResultTypeの文字列表現を返す
+func (r ResultType) String() string {
+	return string(r)
+}
+
+// IsValid はResultTypeが有効かどうかを判定する
+func (r ResultType) IsValid() bool {
+	switch r {
+	case ResultTypePlayedEnum, ResultTypeWalkoverEnum, ResultTypeForfeitEnum, ResultTypeDoubleForfeitEnum, ResultTypeDisqualificationEnum:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsForfeit は試合を行わずに決着した結果かどうかを返す
+func (r ResultType) IsForfeit() bool {
+	return r != "" && r != ResultTypePlayedEnum
+}
+
+// Label は結果の種類の表示用文字列を返す（試合を行った場合は空文字）
+func (r ResultType) Label() string {
+	switch r {
+	case ResultTypeWalkoverEnum:
+		return "不戦勝"
+	case ResultTypeForfeitEnum:
+		return "棄権"
+	case ResultTypeDoubleForfeitEnum:
+		return "両チーム棄権"
+	case ResultTypeDisqualificationEnum:
+		return "失格"
+	default:
+		return ""
+	}
+}
+
+// Value はdatabase/sql/driverインターフェースを実装する
+func (r ResultType) Value() (driver.Value, error) {
+	return string(r), nil
+}
+
+// Scan はdatabase/sql/driverインターフェースを実装する
+func (r *ResultType) Scan(value interface{}) error {
+	if value == nil {
+		*r = ""
+		return nil
+	}
+	
+	switch v := value.(type) {
+	case string:
+		*r = ResultType(v)
+	case []byte:
+		*r = ResultType(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ResultType", value)
+	}
+	
+	return nil
+}
+
+// EventStatus は大会（スポーツ大会の開催回）のステータスを表す列挙型
+// 終了した大会はアーカイブとして読み取り専用になる
+type EventStatus string
+
+const (
+	EventStatusPlannedEnum  EventStatus = "planned"  // 開催予定
+	EventStatusOngoingEnum  EventStatus = "ongoing"  // 開催中
+	EventStatusArchivedEnum EventStatus = "archived" // 終了（読み取り専用）
+)
+
+// String はEventStatusの文字列表現を返す
+func (e EventStatus) String() string {
+	return string(e)
+}
+
+// IsValid はEventStatusが有効かどうかを判定する
+func (e EventStatus) IsValid() bool {
+	switch e {
+	case EventStatusPlannedEnum, EventStatusOngoingEnum, EventStatusArchivedEnum:
+		return true
+	default:
+		return false
+	}
+}
+
+// Value はdatabase/sql/driverインターフェースを実装する
+func (e EventStatus) Value() (driver.Value, error) {
+	return string(e), nil
+}
+
+// Scan はdatabase/sql/driverインターフェースを実装する
+func (e *EventStatus) Scan(value interface{}) error {
+	if value == nil {
+		*e = ""
+		return nil
+	}
+	
+	switch v := value.(type) {
+	case string:
+		*e = EventStatus(v)
+	case []byte:
+		*e = EventStatus(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EventStatus", value)
+	}
+	
+	return nil
+}
+
 // DateTime はISO 8601形式の日時を扱うカスタム型
 type DateTime struct {
 	time.Time
@@ -420,37 +683,13 @@ func (ndt NullableDateTime) String() string {
 	return ndt.DateTime.String()
 }
 
-// GetValidRoundsForSportType はスポーツに応じた有効なラウンドを取得する
+// GetValidRoundsForSportType はスポーツに応じた有効なラウンドを種目のレジストリから取得する
 func GetValidRoundsForSportType(sport SportType) []RoundType {
-	switch sport {
-	case SportTypeVolleyball:
-		return []RoundType{
-			Round1stRoundEnum,
-			RoundQuarterfinalEnum,
-			RoundSemifinalEnum,
-			RoundThirdPlaceEnum,
-			RoundFinalEnum,
-		}
-	case SportTypeTableTennis:
-		return []RoundType{
-			Round1stRoundEnum,
-			RoundQuarterfinalEnum,
-			RoundSemifinalEnum,
-			RoundThirdPlaceEnum,
-			RoundFinalEnum,
-			RoundLoserBracketEnum, // 雨天時のみ
-		}
-	case SportTypeSoccer:
-		return []RoundType{
-			Round1stRoundEnum,
-			RoundQuarterfinalEnum,
-			RoundSemifinalEnum,
-			RoundThirdPlaceEnum,
-			RoundFinalEnum,
-		}
-	default:
+	registered, ok := LookupSport(sport)
+	if !ok {
 		return []RoundType{}
 	}
+	return append([]RoundType{}, registered.Rounds...)
 }
 
 // IsValidRoundForSport は指定されたスポーツで有効なラウンドかどうかを判定する
@@ -408,11 +408,7 @@ func (v *Validator) ValidateEnum(value string, validValues []string, fieldName s
 // ValidateSportType はスポーツタイプの検証を行う
 func (v *Validator) ValidateSportType(value SportType, fieldName string) *ValidationError {
 	if !value.IsValid() {
-		validValues := []string{
-			string(SportTypeVolleyball),
-			string(SportTypeTableTennis),
-			string(SportTypeSoccer),
-		}
+		validValues := RegisteredSportCodes()
 		return &ValidationError{
 			Field:   fieldName,
 			Message: v.getLocalizedMessage("invalid_sport_type", fieldName, strings.Join(validValues, ", ")),
@@ -427,11 +423,9 @@ func (v *Validator) ValidateSportType(value SportType, fieldName string) *Valida
 // ValidateTournamentStatus はトーナメントステータスの検証を行う
 func (v *Validator) ValidateTournamentStatus(value TournamentStatus, fieldName string) *ValidationError {
 	if !value.IsValid() {
-		validValues := []string{
-			string(TournamentStatusRegistrationEnum),
-			string(TournamentStatusActiveEnum),
-			string(TournamentStatusCompletedEnum),
-			string(TournamentStatusCancelledEnum),
+		var validValues []string
+		for _, status := range TournamentStatuses() {
+			validValues = append(validValues, string(status))
 		}
 		return &ValidationError{
 			Field:   fieldName,
@@ -447,11 +441,9 @@ func (v *Validator) ValidateTournamentStatus(value TournamentStatus, fieldName s
 // ValidateMatchStatus は試合ステータスの検証を行う
 func (v *Validator) ValidateMatchStatus(value MatchStatus, fieldName string) *ValidationError {
 	if !value.IsValid() {
-		validValues := []string{
-			string(MatchStatusPendingEnum),
-			string(MatchStatusInProgressEnum),
-			string(MatchStatusCompletedEnum),
-			string(MatchStatusCancelledEnum),
+		var validValues []string
+		for _, status := range MatchStatuses() {
+			validValues = append(validValues, string(status))
 		}
 		return &ValidationError{
 			Field:   fieldName,
@@ -888,7 +880,7 @@ func ValidateCreateTournamentRequest(req *CreateTournamentRequest) ValidationErr
 		errors.AddError(*err)
 	}
 	
-	if err := validator.ValidateEnum(string(req.Format), []string{string(TournamentFormatStandard), string(TournamentFormatRainy)}, "format"); err != nil {
+	if err := validator.ValidateEnum(string(req.Format), []string{string(TournamentFormatStandard), string(TournamentFormatRainy), string(TournamentFormatDoubleElimination), string(TournamentFormatRoundRobin), string(TournamentFormatGroupKnockout), string(TournamentFormatSwiss)}, "format"); err != nil {
 		errors.AddError(*err)
 	}
 	
@@ -280,11 +280,7 @@ type SportTypeRule struct{}
 func (r SportTypeRule) Validate(value interface{}, fieldName string) *ValidationError {
 	if sport, ok := value.(SportType); ok {
 		if !sport.IsValid() {
-			validValues := []string{
-				string(SportTypeVolleyball),
-				string(SportTypeTableTennis),
-				string(SportTypeSoccer),
-			}
+			validValues := RegisteredSportCodes()
 			return &ValidationError{
 				Field:   fieldName,
 				Message: fmt.Sprintf("%sは無効なスポーツです。有効な値: %s", fieldName, strings.Join(validValues, ", ")),
@@ -307,11 +303,9 @@ type TournamentStatusRule struct{}
 func (r TournamentStatusRule) Validate(value interface{}, fieldName string) *ValidationError {
 	if status, ok := value.(TournamentStatus); ok {
 		if !status.IsValid() {
-			validValues := []string{
-				string(TournamentStatusRegistrationEnum),
-				string(TournamentStatusActiveEnum),
-				string(TournamentStatusCompletedEnum),
-				string(TournamentStatusCancelledEnum),
+			var validValues []string
+			for _, status := range TournamentStatuses() {
+				validValues = append(validValues, string(status))
 			}
 			return &ValidationError{
 				Field:   fieldName,
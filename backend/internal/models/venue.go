@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Venue は試合会場（体育館・卓球場・グラウンドなど）を表すモデル
+// 会場は大会ごとに登録し、会場内のコート（卓球台・グラウンドの面を含む）に試合を割り当てる
+type Venue struct {
+	ID        uint      `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`                 // 所属する大会
+	Name      string    `json:"name" db:"name" example:"第1体育館"`         // 会場名（大会内で一意）
+	SortOrder int       `json:"sort_order" db:"sort_order" example:"1"` // 一覧の表示順
+	Courts    []*Court  `json:"courts,omitempty" db:"-"`                // 会場のコート
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate は会場データの検証を行う
+func (v *Venue) Validate() error {
+	name := strings.TrimSpace(v.Name)
+	if name == "" {
+		return errors.New("会場名は必須です")
+	}
+	if len(name) > 100 {
+		return errors.New("会場名は100文字以下である必要があります")
+	}
+	return nil
+}
+
+// Court は試合を行うコート（バレーボールのコート・卓球台・グラウンドなど）を表すモデル
+type Court struct {
+	ID        uint        `json:"id" db:"id"`
+	VenueID   uint        `json:"venue_id" db:"venue_id"`
+	Name      string      `json:"name" db:"name" example:"Aコート"`          // コート名（会場内で一意）
+	Sports    []SportType `json:"sports" db:"sports"`                     // コートで行える種目
+	SortOrder int         `json:"sort_order" db:"sort_order" example:"1"` // 会場内の表示順・日程の割り当て順
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// Validate はコートデータの検証を行う
+func (c *Court) Validate() error {
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return errors.New("コート名は必須です")
+	}
+	if len(name) > 100 {
+		return errors.New("コート名は100文字以下である必要があります")
+	}
+
+	if len(c.Sports) == 0 {
+		return errors.New("コートで行える種目を1つ以上指定してください")
+	}
+	for _, sport := range c.Sports {
+		if !sport.IsValid() {
+			return fmt.Errorf("無効な種目です: %s", sport)
+		}
+	}
+	return nil
+}
+
+// Supports はコートで種目の試合を行えるかどうかを返す
+func (c *Court) Supports(sport SportType) bool {
+	for _, s := range c.Sports {
+		if s == sport {
+			return true
+		}
+	}
+	return false
+}
+
+// courtBoardRecent はコートの試合の順番に表示する終了した試合の数
+const courtBoardRecent = 3
+
+// CourtBoard はコートの試合の順番（コートの掲示板）
+type CourtBoard struct {
+	Court      *Court   `json:"court"`
+	Venue      string   `json:"venue" example:"体育館"` // 会場名
+	NowPlaying *Match   `json:"now_playing"`         // 試合中の試合（ない場合はnull）
+	UpNext     *Match   `json:"up_next"`             // 次の試合（ない場合はnull）
+	Queue      []*Match `json:"queue"`               // 試合中の試合の後に行う試合（開始時刻順、次の試合を含む）
+	Recent     []*Match `json:"recent"`              // 直近に終了した試合（新しい順）
+}
+
+// BuildCourtBoard はコートに割り当てた試合から試合の順番を作成する
+// 実施中（中断中を含む）の試合を試合中とし、実施中の試合がない場合は開始時刻を過ぎた最初の未実施の試合を試合中とする。
+// 中止した試合と開始時刻が未定の延期した試合は含めない。queueLimit が正の場合は Queue をその数までとする
+func BuildCourtBoard(court *Court, venue string, matches []*Match, now time.Time, queueLimit int) *CourtBoard {
+	ordered := make([]*Match, 0, len(matches))
+	for _, match := range matches {
+		if match != nil && !match.IsCancelled() && !match.IsPostponed() {
+			ordered = append(ordered, match)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].ScheduledAt.Equal(ordered[j].ScheduledAt) {
+			return ordered[i].ScheduledAt.Before(ordered[j].ScheduledAt)
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	board := &CourtBoard{Court: court, Venue: venue, Queue: []*Match{}, Recent: []*Match{}}
+	var pending []*Match
+	for _, match := range ordered {
+		switch {
+		case match.IsCompleted():
+			board.Recent = append([]*Match{match}, board.Recent...)
+		case (match.IsInProgress() || match.IsSuspended()) && board.NowPlaying == nil:
+			board.NowPlaying = match
+		default:
+			pending = append(pending, match)
+		}
+	}
+	if board.NowPlaying == nil && len(pending) > 0 && !pending[0].ScheduledAt.After(now) {
+		board.NowPlaying = pending[0]
+		pending = pending[1:]
+	}
+
+	if len(pending) > 0 {
+		board.UpNext = pending[0]
+	}
+	if queueLimit > 0 && len(pending) > queueLimit {
+		pending = pending[:queueLimit]
+	}
+	board.Queue = append(board.Queue, pending...)
+	if len(board.Recent) > courtBoardRecent {
+		board.Recent = board.Recent[:courtBoardRecent]
+	}
+
+	return board
+}
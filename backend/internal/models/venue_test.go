@@ -0,0 +1,134 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildCourtBoard(t *testing.T) {
+	clock := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 20, hour, minute, 0, 0, time.UTC)
+	}
+	court := &Court{ID: 1, Name: "Aコート", Sports: []SportType{SportTypeVolleyball}}
+	match := func(id int, status MatchStatus, hour, minute int) *Match {
+		return &Match{ID: id, TournamentID: 1, Team1: "IE4", Team2: "IS4", Status: string(status), ScheduledAt: clock(hour, minute), CourtID: intPtr(1)}
+	}
+
+	tests := []struct {
+		name           string
+		matches        []*Match
+		now            time.Time
+		queueLimit     int
+		wantNowPlaying int // 0 は試合中の試合なし
+		wantUpNext     int // 0 は次の試合なし
+		wantQueue      []int
+		wantRecent     []int
+	}{
+		{
+			name: "実施中の試合を試合中とする",
+			matches: []*Match{
+				match(1, MatchStatusCompletedEnum, 9, 0),
+				match(2, MatchStatusInProgressEnum, 9, 30),
+				match(3, MatchStatusPendingEnum, 10, 0),
+				match(4, MatchStatusPendingEnum, 10, 30),
+			},
+			now:            clock(9, 40),
+			wantNowPlaying: 2,
+			wantUpNext:     3,
+			wantQueue:      []int{3, 4},
+			wantRecent:     []int{1},
+		},
+		{
+			name: "開始時刻を過ぎた未実施の試合を試合中とする",
+			matches: []*Match{
+				match(2, MatchStatusPendingEnum, 10, 0),
+				match(1, MatchStatusPendingEnum, 9, 30),
+			},
+			now:            clock(9, 35),
+			wantNowPlaying: 1,
+			wantUpNext:     2,
+			wantQueue:      []int{2},
+			wantRecent:     []int{},
+		},
+		{
+			name: "開始時刻前は試合中の試合なし",
+			matches: []*Match{
+				match(1, MatchStatusPendingEnum, 9, 30),
+				match(2, MatchStatusPendingEnum, 10, 0),
+			},
+			now:        clock(9, 0),
+			wantUpNext: 1,
+			wantQueue:  []int{1, 2},
+			wantRecent: []int{},
+		},
+		{
+			name: "中止した試合を除き、終了した試合は新しい順",
+			matches: []*Match{
+				match(1, MatchStatusCompletedEnum, 9, 0),
+				match(2, MatchStatusCompletedEnum, 9, 30),
+				match(3, MatchStatusCancelledEnum, 10, 0),
+			},
+			now:        clock(10, 5),
+			wantQueue:  []int{},
+			wantRecent: []int{2, 1},
+		},
+		{
+			name: "次の試合以降の件数を制限する",
+			matches: []*Match{
+				match(1, MatchStatusPendingEnum, 9, 0),
+				match(2, MatchStatusPendingEnum, 9, 30),
+				match(3, MatchStatusPendingEnum, 10, 0),
+				match(4, MatchStatusPendingEnum, 10, 30),
+			},
+			now:            clock(9, 10),
+			queueLimit:     2,
+			wantNowPlaying: 1,
+			wantUpNext:     2,
+			wantQueue:      []int{2, 3},
+			wantRecent:     []int{},
+		},
+	}
+
+	ids := func(matches []*Match) []int {
+		result := []int{}
+		for _, m := range matches {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+	idOf := func(m *Match) int {
+		if m == nil {
+			return 0
+		}
+		return m.ID
+	}
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := BuildCourtBoard(court, "体育館", tt.matches, tt.now, tt.queueLimit)
+			if got := idOf(board.NowPlaying); got != tt.wantNowPlaying {
+				t.Errorf("NowPlaying = %d, want %d", got, tt.wantNowPlaying)
+			}
+			if got := idOf(board.UpNext); got != tt.wantUpNext {
+				t.Errorf("UpNext = %d, want %d", got, tt.wantUpNext)
+			}
+			if got := ids(board.Queue); !equal(got, tt.wantQueue) {
+				t.Errorf("Queue = %v, want %v", got, tt.wantQueue)
+			}
+			if got := ids(board.Recent); !equal(got, tt.wantRecent) {
+				t.Errorf("Recent = %v, want %v", got, tt.wantRecent)
+			}
+		})
+	}
+}
@@ -48,10 +48,14 @@ const (
 	MessageTypeUnsubscribe WebSocketMessageType = "unsubscribe"
 	
 	// 更新通知
-	MessageTypeTournamentUpdate WebSocketMessageType = "tournament_update"
-	MessageTypeMatchUpdate      WebSocketMessageType = "match_update"
-	MessageTypeMatchResult      WebSocketMessageType = "match_result"
-	MessageTypeBracketUpdate    WebSocketMessageType = "bracket_update"
+	MessageTypeTournamentUpdate   WebSocketMessageType = "tournament_update"
+	MessageTypeMatchUpdate        WebSocketMessageType = "match_update"
+	MessageTypeMatchResult        WebSocketMessageType = "match_result"
+	MessageTypeBracketUpdate      WebSocketMessageType = "bracket_update"
+	MessageTypeMatchEvent         WebSocketMessageType = "match_event"         // 試合経過（ライブスコア）
+	MessageTypeChampionshipUpdate WebSocketMessageType = "championship_update" // 総合順位（種目の最終順位の確定・変更）
+	MessageTypeCourtUpdate        WebSocketMessageType = "court_update"        // コートの試合の順番（試合中・次の試合）
+	MessageTypeScheduleUpdate     WebSocketMessageType = "schedule_update"     // 試合の遅れによる日程の後ろ倒し
 	
 	// エラー
 	MessageTypeError WebSocketMessageType = "error"
@@ -66,13 +70,16 @@ func (t WebSocketMessageType) String() string {
 }
 
 // SubscribeRequest は購読リクエストの構造体
+// 種目とコートのいずれか（または両方）を指定する
 type SubscribeRequest struct {
-	Sports []SportType `json:"sports" validate:"required,min=1,dive,oneof=volleyball table_tennis soccer"`
+	Sports []SportType `json:"sports" validate:"required_without=Courts,dive,sport"` // 種目のレジストリに登録された種目
+	Courts []int       `json:"courts" validate:"required_without=Sports,dive,min=1"` // コートID（コートの試合の通知のみを受け取る）
 }
 
 // UnsubscribeRequest は購読解除リクエストの構造体
 type UnsubscribeRequest struct {
-	Sports []SportType `json:"sports" validate:"required,min=1,dive,oneof=volleyball table_tennis soccer"`
+	Sports []SportType `json:"sports" validate:"required_without=Courts,dive,sport"`
+	Courts []int       `json:"courts" validate:"required_without=Sports,dive,min=1"`
 }
 
 // AuthRequest はWebSocket認証リクエストの構造体
@@ -100,14 +107,26 @@ func NewUpdateNotification(messageType WebSocketMessageType, sport SportType, da
 
 // TournamentUpdateData はトーナメント更新データの構造体
 type TournamentUpdateData struct {
-	Tournament *Tournament `json:"tournament"`
-	Action     string      `json:"action"` // "created", "updated", "deleted", "status_changed"
+	Tournament     *Tournament      `json:"tournament"`
+	Action         string           `json:"action"`                    // "created", "updated", "deleted", "status_changed"
+	PreviousStatus TournamentStatus `json:"previous_status,omitempty"` // status_changed の場合の変更前のステータス
 }
 
 // MatchUpdateData は試合更新データの構造体
 type MatchUpdateData struct {
-	Match  *Match `json:"match"`
-	Action string `json:"action"` // "created", "updated", "deleted", "result_updated"
+	Match      *Match            `json:"match"`
+	Action     string            `json:"action"`               // "created", "updated", "deleted", "result_updated", "result_corrected"
+	Correction *ResultCorrection `json:"correction,omitempty"` // 結果訂正の場合の訂正記録
+}
+
+// MatchEventData は試合経過の通知データの構造体
+type MatchEventData struct {
+	MatchID      int         `json:"match_id"`
+	TournamentID int         `json:"tournament_id"`
+	Team1        string      `json:"team1"`
+	Team2        string      `json:"team2"`
+	Event        *MatchEvent `json:"event"`
+	LiveScore    *LiveScore  `json:"live_score"` // 試合経過から算出した現在のスコア
 }
 
 // BracketUpdateData はブラケット更新データの構造体
@@ -117,6 +136,30 @@ type BracketUpdateData struct {
 	Action  string    `json:"action"` // "updated", "regenerated"
 }
 
+// ChampionshipUpdateData は総合順位の更新データの構造体
+type ChampionshipUpdateData struct {
+	TournamentID int           `json:"tournament_id"` // 最終順位が変わったトーナメント
+	Placements   []Placement   `json:"placements"`    // トーナメントの最終順位
+	Championship *Championship `json:"championship"`  // 更新後の総合順位
+}
+
+// CourtUpdateData はコートの試合の順番の更新データの構造体
+type CourtUpdateData struct {
+	CourtID int         `json:"court_id"`
+	Board   *CourtBoard `json:"board"` // 更新後のコートの試合の順番
+}
+
+// ScheduleUpdateData は日程の後ろ倒しの更新データの構造体
+// 開始時刻を変えた全ての試合を1つのメッセージで送る
+type ScheduleUpdateData struct {
+	EventID      int            `json:"event_id"`
+	CourtID      *int           `json:"court_id,omitempty"`      // 後ろ倒しの対象のコート
+	TournamentID *int           `json:"tournament_id,omitempty"` // 後ろ倒しの対象のトーナメント
+	DelayMinutes int            `json:"delay_minutes"`
+	Reason       string         `json:"reason,omitempty"`
+	Matches      []ShiftedMatch `json:"matches"` // 開始時刻を変えた試合（後ろ倒し後の開始時刻順）
+}
+
 // ErrorNotification はエラー通知の構造体
 type ErrorNotification struct {
 	Code    string `json:"code"`
@@ -138,6 +181,7 @@ type ConnectionInfo struct {
 	Username       string      `json:"username"`        // ユーザー名
 	Role           string      `json:"role"`            // ユーザーロール
 	Sports         []SportType `json:"sports"`          // 購読中のスポーツ
+	Courts         []int       `json:"courts"`          // 購読中のコート
 	ConnectedAt    string      `json:"connected_at"`    // 接続時刻
 	LastActiveAt   string      `json:"last_active_at"`  // 最終アクティブ時刻
 	RemoteAddr     string      `json:"remote_addr"`     // リモートアドレス
@@ -153,6 +197,7 @@ func NewConnectionInfo(id string, userID int, username, role, remoteAddr, userAg
 		Username:     username,
 		Role:         role,
 		Sports:       make([]SportType, 0),
+		Courts:       make([]int, 0),
 		ConnectedAt:  now,
 		LastActiveAt: now,
 		RemoteAddr:   remoteAddr,
@@ -220,4 +265,31 @@ func NewWebSocketStats() *WebSocketStats {
 // UpdateStats は統計情報を更新する
 func (s *WebSocketStats) UpdateStats() {
 	s.LastUpdated = time.Now().UTC().Format(time.RFC3339)
-}
\ No newline at end of file
+}
+
+// AddCourt はコートを購読リストに追加する
+func (c *ConnectionInfo) AddCourt(courtID int) {
+	if !c.IsSubscribedToCourt(courtID) {
+		c.Courts = append(c.Courts, courtID)
+	}
+}
+
+// RemoveCourt はコートを購読リストから削除する
+func (c *ConnectionInfo) RemoveCourt(courtID int) {
+	for i, id := range c.Courts {
+		if id == courtID {
+			c.Courts = append(c.Courts[:i], c.Courts[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsSubscribedToCourt は指定されたコートを購読しているかチェックする
+func (c *ConnectionInfo) IsSubscribedToCourt(courtID int) bool {
+	for _, id := range c.Courts {
+		if id == courtID {
+			return true
+		}
+	}
+	return false
+}
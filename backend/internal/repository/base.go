@@ -2,6 +2,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"time"
@@ -18,6 +19,7 @@ type BaseRepository interface {
 	
 	// トランザクション管理
 	BeginTx() (*sql.Tx, error)
+	BeginTxContext(ctx context.Context) (*sql.Tx, error)
 	CommitTx(tx *sql.Tx) error
 	RollbackTx(tx *sql.Tx) error
 	
@@ -93,6 +95,21 @@ func (r *baseRepositoryImpl) BeginTx() (*sql.Tx, error) {
 	return tx, nil
 }
 
+// BeginTxContext はコンテキスト付きでトランザクションを開始する
+func (r *baseRepositoryImpl) BeginTxContext(ctx context.Context) (*sql.Tx, error) {
+	if r.db == nil {
+		return nil, NewRepositoryError(ErrTypeConnection, "データベース接続が初期化されていません", nil)
+	}
+	
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return nil, NewRepositoryError(ErrTypeTransaction, "トランザクションの開始に失敗しました", err)
+	}
+	
+	log.Println("トランザクションを開始しました")
+	return tx, nil
+}
+
 // CommitTx はトランザクションをコミットする
 func (r *baseRepositoryImpl) CommitTx(tx *sql.Tx) error {
 	if tx == nil {
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// EventRepository defines the interface for event data operations
+type EventRepository interface {
+	Create(ctx context.Context, event *models.Event) error
+	GetByID(ctx context.Context, id uint) (*models.Event, error)
+	GetAll(ctx context.Context) ([]*models.Event, error)
+	GetCurrent(ctx context.Context) (*models.Event, error)
+	Update(ctx context.Context, event *models.Event) error
+	SaveScheduleOptions(ctx context.Context, eventID int, options *models.ScheduleOptions) error
+	GetScheduleOptions(ctx context.Context, eventID int) (*models.ScheduleOptions, error)
+}
+
+// eventColumns は大会テーブルのSELECT対象カラム
+const eventColumns = `id, name, start_date, end_date, timezone, status, created_at, updated_at`
+
+// eventRepository implements EventRepository
+type eventRepository struct {
+	base BaseRepository
+}
+
+// NewEventRepository creates a new event repository
+func NewEventRepository(db *database.DB) EventRepository {
+	if db == nil {
+		log.Fatal("データベース接続がnilです")
+	}
+
+	baseRepo := NewBaseRepository(db)
+	return &eventRepository{
+		base: baseRepo,
+	}
+}
+
+// Create creates a new event
+func (r *eventRepository) Create(ctx context.Context, event *models.Event) error {
+	query := `
+		INSERT INTO events (name, start_date, end_date, timezone, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.base.ExecQuery(query,
+		event.Name,
+		models.FormatEventDate(event.StartDate),
+		models.FormatEventDate(event.EndDate),
+		event.Timezone,
+		event.Status,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	event.ID = int(id)
+	return nil
+}
+
+// GetByID retrieves an event by ID (nil when not found)
+func (r *eventRepository) GetByID(ctx context.Context, id uint) (*models.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE id = ?
+	`
+
+	event, err := r.scanEvent(r.base.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// GetAll retrieves every event, newest first
+func (r *eventRepository) GetAll(ctx context.Context) ([]*models.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		ORDER BY start_date DESC, id DESC
+	`
+
+	rows, err := r.base.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event, err := r.scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetCurrent retrieves the event that requests without an explicit event refer to:
+// the ongoing event, otherwise the next planned one, otherwise the latest archive
+// (nil when no event exists)
+func (r *eventRepository) GetCurrent(ctx context.Context) (*models.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		ORDER BY
+			FIELD(status, 'ongoing', 'planned', 'archived') ASC,
+			CASE WHEN status = 'planned' THEN start_date END ASC,
+			start_date DESC,
+			id DESC
+		LIMIT 1
+	`
+
+	event, err := r.scanEvent(r.base.QueryRow(query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Update updates an existing event
+func (r *eventRepository) Update(ctx context.Context, event *models.Event) error {
+	query := `
+		UPDATE events
+		SET name = ?, start_date = ?, end_date = ?, timezone = ?, status = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.base.ExecQuery(query,
+		event.Name,
+		models.FormatEventDate(event.StartDate),
+		models.FormatEventDate(event.EndDate),
+		event.Timezone,
+		event.Status,
+		event.ID,
+	)
+
+	return err
+}
+
+// SaveScheduleOptions records the conditions the event's schedule was last generated with
+func (r *eventRepository) SaveScheduleOptions(ctx context.Context, eventID int, options *models.ScheduleOptions) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE events
+		SET schedule_options = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err = r.base.ExecQuery(query, string(data), eventID)
+	return err
+}
+
+// GetScheduleOptions retrieves the recorded schedule conditions of an event (nil when none are recorded)
+func (r *eventRepository) GetScheduleOptions(ctx context.Context, eventID int) (*models.ScheduleOptions, error) {
+	query := `SELECT schedule_options FROM events WHERE id = ?`
+
+	var data sql.NullString
+	if err := r.base.QueryRow(query, eventID).Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !data.Valid {
+		return nil, nil
+	}
+
+	options := &models.ScheduleOptions{}
+	if err := json.Unmarshal([]byte(data.String), options); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// scanEvent scans a single event row
+func (r *eventRepository) scanEvent(row rowScanner) (*models.Event, error) {
+	event := &models.Event{}
+
+	err := row.Scan(
+		&event.ID,
+		&event.Name,
+		&event.StartDate,
+		&event.EndDate,
+		&event.Timezone,
+		&event.Status,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
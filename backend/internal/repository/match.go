@@ -3,7 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log"
+	"strings"
+	"time"
 
 	"backend/internal/database"
 	"backend/internal/models"
@@ -15,11 +18,59 @@ type MatchRepository interface {
 	GetByID(ctx context.Context, id uint) (*models.Match, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*models.Match, error)
 	GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Match, error)
-	GetByRoundAndPosition(ctx context.Context, tournamentID uint, round, position int) (*models.Match, error)
+	GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]*models.Match, error)
+	GetByEvent(ctx context.Context, eventID uint) ([]*models.Match, error)
+	GetByCourt(ctx context.Context, courtID uint) ([]*models.Match, error)
+	GetByRound(ctx context.Context, tournamentID uint, round string) ([]*models.Match, error)
+	GetByRoundAndPosition(ctx context.Context, tournamentID uint, round string, position int) (*models.Match, error)
 	Update(ctx context.Context, match *models.Match) error
+	UpdateMany(ctx context.Context, matches []*models.Match) error
+	UpdateSchedule(ctx context.Context, matches []*models.Match) error
 	Delete(ctx context.Context, id uint) error
+
+	// ブラケット構造（進出先）の操作
+	CreateBracket(ctx context.Context, matches []*models.Match) error
+	DrawBracket(ctx context.Context, tournament *models.Tournament, draw *models.DrawResult, matches []*models.Match) error
+	CreateSwissRound(ctx context.Context, tournamentID uint, round int, matches []*models.Match) error
+	SwitchFormat(ctx context.Context, tournamentID int, format models.TournamentFormat, layout, retired []*models.Match) error
+	GetNextMatches(ctx context.Context, matchID uint) (winnerNext, loserNext *models.Match, err error)
+	GetFeederMatches(ctx context.Context, matchID uint) ([]*models.Match, error)
+	AdvanceBracket(ctx context.Context, tournamentID uint, advance func(tournament *models.Tournament, matches []*models.Match) ([]*models.Match, error)) error
+
+	// 結果訂正の操作
+	CorrectBracket(ctx context.Context, tournamentID uint, correct func(tournament *models.Tournament, matches []*models.Match) (*models.ResultCorrection, []*models.Match, error)) error
+	GetCorrections(ctx context.Context, matchID uint) ([]*models.ResultCorrection, error)
+
+	// 試合経過の操作
+	CreateEvent(ctx context.Context, event *models.MatchEvent) error
+	GetEvents(ctx context.Context, matchID uint) ([]*models.MatchEvent, error)
 }
 
+// matchColumns は試合テーブルのSELECT対象カラム
+// 登録チーム（チーム・勝者・棄権したチーム）はIDを正とし、名前はteamsテーブルの現在の名前を表示する
+// IDのない枠（未確定の枠・棄権の記録・未登録のチーム）は試合に保存した名前を表示する
+const matchColumns = `id, tournament_id, round, position, group_name, swiss_round,
+		COALESCE((SELECT name FROM teams WHERE teams.id = matches.team1_id), matches.team1) AS team1,
+		COALESCE((SELECT name FROM teams WHERE teams.id = matches.team2_id), matches.team2) AS team2,
+		team1_id, team2_id, score1, score2,
+		extra_time_score1, extra_time_score2, penalty_score1, penalty_score2, decision_method, result_type,
+		COALESCE((SELECT name FROM teams WHERE teams.id = matches.forfeiting_team_id), matches.forfeiting_team) AS forfeiting_team, forfeiting_team_id,
+		COALESCE((SELECT name FROM teams WHERE teams.id = matches.winner_id), matches.winner) AS winner, winner_id,
+		next_match_id, next_slot, loser_next_match_id, loser_next_slot,
+		status, scheduled_at, planned_at, court_id, schedule_pinned, completed_at, created_at, updated_at`
+
+// matchInsertQuery inserts a match
+const matchInsertQuery = `
+	INSERT INTO matches (tournament_id, round, position, group_name, swiss_round, team1, team2, team1_id, team2_id, score1, score2,
+		extra_time_score1, extra_time_score2, penalty_score1, penalty_score2, decision_method, result_type, forfeiting_team, forfeiting_team_id, winner, winner_id,
+		next_match_id, next_slot, loser_next_match_id, loser_next_slot,
+		status, scheduled_at, planned_at, court_id, schedule_pinned, completed_at, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+		?, ?, ?, ?,
+		?, ?, ?, ?, ?, ?, NOW(), NOW())
+`
+
 // matchRepository implements MatchRepository
 type matchRepository struct {
 	base BaseRepository
@@ -39,24 +90,7 @@ func NewMatchRepository(db *database.DB) MatchRepository {
 
 // Create creates a new match
 func (r *matchRepository) Create(ctx context.Context, match *models.Match) error {
-	query := `
-		INSERT INTO matches (tournament_id, round, team1, team2, score1, score2, winner, status, scheduled_at, completed_at, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
-	`
-	
-	result, err := r.base.ExecQuery(query,
-		match.TournamentID,
-		match.Round,
-		match.Team1,
-		match.Team2,
-		match.Score1,
-		match.Score2,
-		match.Winner,
-		match.Status,
-		match.ScheduledAt,
-		match.CompletedAt,
-	)
-	
+	result, err := r.base.ExecQuery(matchInsertQuery, matchInsertArgs(match)...)
 	if err != nil {
 		return err
 	}
@@ -70,33 +104,186 @@ func (r *matchRepository) Create(ctx context.Context, match *models.Match) error
 	return nil
 }
 
+// CreateBracket creates all matches of a bracket and stores their winner/loser
+// destinations in a single transaction
+func (r *matchRepository) CreateBracket(ctx context.Context, matches []*models.Match) error {
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		return r.createBracketTx(tx, matches)
+	})
+}
+
+// DrawBracket creates the bracket of a draw and records the draw and the new
+// tournament status in a single transaction. The tournament row is locked
+// first, and a tournament that already has matches is refused with an
+// ErrTypeDuplicate error, so two concurrent draws cannot both create a bracket.
+func (r *matchRepository) DrawBracket(ctx context.Context, tournament *models.Tournament, draw *models.DrawResult, matches []*models.Match) error {
+	data, err := json.Marshal(draw)
+	if err != nil {
+		return err
+	}
+	
+	lockQuery := `SELECT id FROM tournaments WHERE id = ? FOR UPDATE`
+	countQuery := `SELECT COUNT(*) FROM matches WHERE tournament_id = ?`
+	tournamentQuery := `
+		UPDATE tournaments
+		SET status = ?, draw_seed = ?, draw_result = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		var id int
+		if err := r.base.QueryRowTx(tx, lockQuery, tournament.ID).Scan(&id); err != nil {
+			return HandleSQLError(err, "トーナメントのロック")
+		}
+		var count int
+		if err := r.base.QueryRowTx(tx, countQuery, tournament.ID).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return NewRepositoryError(ErrTypeDuplicate, "トーナメントには既に試合が作成されています", nil)
+		}
+		
+		if err := r.createBracketTx(tx, matches); err != nil {
+			return err
+		}
+		
+		_, err := r.base.ExecQueryTx(tx, tournamentQuery, tournament.Status, draw.Options.RandomSeed, string(data), tournament.ID)
+		return err
+	})
+}
+
+// CreateSwissRound creates the matches of a Swiss round in a single
+// transaction. The tournament row is locked first like DrawBracket, and a round
+// that already has matches is refused with an ErrTypeDuplicate error, so two
+// concurrent requests cannot both pair the same round.
+func (r *matchRepository) CreateSwissRound(ctx context.Context, tournamentID uint, round int, matches []*models.Match) error {
+	lockQuery := `SELECT id FROM tournaments WHERE id = ? FOR UPDATE`
+	countQuery := `SELECT COUNT(*) FROM matches WHERE tournament_id = ? AND swiss_round = ?`
+	
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		var id int
+		if err := r.base.QueryRowTx(tx, lockQuery, tournamentID).Scan(&id); err != nil {
+			return HandleSQLError(err, "トーナメントのロック")
+		}
+		var count int
+		if err := r.base.QueryRowTx(tx, countQuery, tournamentID, round).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return NewRepositoryError(ErrTypeDuplicate, "この回戦の試合は既に作成されています", nil)
+		}
+		
+		return r.createBracketTx(tx, matches)
+	})
+}
+
+// createBracketTx creates the matches of a bracket and stores their
+// winner/loser destinations within a transaction
+func (r *matchRepository) createBracketTx(tx *sql.Tx, matches []*models.Match) error {
+	linkQuery := `
+		UPDATE matches
+		SET next_match_id = ?, next_slot = ?, loser_next_match_id = ?, loser_next_slot = ?
+		WHERE id = ?
+	`
+	
+	// 進出先のIDを確定させるため、先に全ての試合を作成する
+	for _, match := range matches {
+		result, err := r.base.ExecQueryTx(tx, matchInsertQuery, matchInsertArgs(match)...)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		match.ID = int(id)
+	}
+	
+	models.LinkBracket(matches)
+	
+	for _, match := range matches {
+		_, err := r.base.ExecQueryTx(tx, linkQuery,
+			match.NextMatchID,
+			match.NextSlot,
+			match.LoserNextMatchID,
+			match.LoserNextSlot,
+			match.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SwitchFormat changes the format of a tournament and migrates its matches in
+// a single transaction: the new matches of the layout are created, the retired
+// matches are saved with their status and cleared destinations, and the
+// winner/loser destinations of the whole layout are derived again
+func (r *matchRepository) SwitchFormat(ctx context.Context, tournamentID int, format models.TournamentFormat, layout, retired []*models.Match) error {
+	linkQuery := `
+		UPDATE matches
+		SET next_match_id = ?, next_slot = ?, loser_next_match_id = ?, loser_next_slot = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	formatQuery := `UPDATE tournaments SET format = ?, updated_at = NOW() WHERE id = ?`
+	
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		for _, match := range retired {
+			if err := r.updateMatchTx(tx, match); err != nil {
+				return err
+			}
+		}
+		
+		// 進出先のIDを確定させるため、先に新しい試合を作成する
+		for _, match := range layout {
+			if match.ID != 0 {
+				continue
+			}
+			result, err := r.base.ExecQueryTx(tx, matchInsertQuery, matchInsertArgs(match)...)
+			if err != nil {
+				return err
+			}
+			id, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			match.ID = int(id)
+		}
+		
+		models.LinkBracket(layout)
+		
+		for _, match := range layout {
+			_, err := r.base.ExecQueryTx(tx, linkQuery,
+				match.NextMatchID,
+				match.NextSlot,
+				match.LoserNextMatchID,
+				match.LoserNextSlot,
+				match.ID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		
+		_, err := r.base.ExecQueryTx(tx, formatQuery, format, tournamentID)
+		return err
+	})
+}
+
 // GetByID retrieves a match by ID
 func (r *matchRepository) GetByID(ctx context.Context, id uint) (*models.Match, error) {
 	query := `
-		SELECT id, tournament_id, round, team1, team2, score1, score2, winner, status, scheduled_at, completed_at, created_at, updated_at
+		SELECT ` + matchColumns + `
 		FROM matches
 		WHERE id = ?
 	`
 	
-	row := r.base.QueryRow(query, id)
-	
-	match := &models.Match{}
-	err := row.Scan(
-		&match.ID,
-		&match.TournamentID,
-		&match.Round,
-		&match.Team1,
-		&match.Team2,
-		&match.Score1,
-		&match.Score2,
-		&match.Winner,
-		&match.Status,
-		&match.ScheduledAt,
-		&match.CompletedAt,
-		&match.CreatedAt,
-		&match.UpdatedAt,
-	)
-	
+	match, err := r.scanMatch(r.base.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -104,13 +291,17 @@ func (r *matchRepository) GetByID(ctx context.Context, id uint) (*models.Match,
 		return nil, err
 	}
 	
+	if err := r.loadSets([]*models.Match{match}); err != nil {
+		return nil, err
+	}
+	
 	return match, nil
 }
 
 // GetAll retrieves all matches with pagination
 func (r *matchRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Match, error) {
 	query := `
-		SELECT id, tournament_id, round, team1, team2, score1, score2, winner, status, scheduled_at, completed_at, created_at, updated_at
+		SELECT ` + matchColumns + `
 		FROM matches
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
@@ -128,10 +319,10 @@ func (r *matchRepository) GetAll(ctx context.Context, limit, offset int) ([]*mod
 // GetByTournamentID retrieves matches by tournament ID
 func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
 	query := `
-		SELECT id, tournament_id, round, team1, team2, score1, score2, winner, status, scheduled_at, completed_at, created_at, updated_at
+		SELECT ` + matchColumns + `
 		FROM matches
 		WHERE tournament_id = ?
-		ORDER BY scheduled_at ASC
+		ORDER BY scheduled_at ASC, position ASC, id ASC
 	`
 	
 	rows, err := r.base.Query(query, tournamentID)
@@ -143,35 +334,88 @@ func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID ui
 	return r.scanMatches(rows)
 }
 
-// GetByRoundAndPosition retrieves a match by tournament, round and position
-// Note: This method is kept for interface compatibility but position is not used in the current model
-func (r *matchRepository) GetByRoundAndPosition(ctx context.Context, tournamentID uint, round, position int) (*models.Match, error) {
+// GetByEventAndSport retrieves the matches of a sport's tournament in an event
+func (r *matchRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]*models.Match, error) {
 	query := `
-		SELECT id, tournament_id, round, team1, team2, score1, score2, winner, status, scheduled_at, completed_at, created_at, updated_at
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE tournament_id IN (SELECT id FROM tournaments WHERE event_id = ? AND sport = ?)
+		ORDER BY scheduled_at ASC, position ASC, id ASC
+	`
+	
+	rows, err := r.base.Query(query, eventID, sport)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	return r.scanMatches(rows)
+}
+
+// GetByEvent retrieves the matches of every tournament in an event
+func (r *matchRepository) GetByEvent(ctx context.Context, eventID uint) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE tournament_id IN (SELECT id FROM tournaments WHERE event_id = ?)
+		ORDER BY scheduled_at ASC, tournament_id ASC, position ASC, id ASC
+	`
+	
+	rows, err := r.base.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	return r.scanMatches(rows)
+}
+
+// GetByCourt retrieves the matches assigned to a court in start time order
+func (r *matchRepository) GetByCourt(ctx context.Context, courtID uint) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE court_id = ?
+		ORDER BY scheduled_at ASC, id ASC
+	`
+	
+	rows, err := r.base.Query(query, courtID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	return r.scanMatches(rows)
+}
+
+// GetByRound retrieves matches of a round ordered by their bracket position
+func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uint, round string) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
 		FROM matches
 		WHERE tournament_id = ? AND round = ?
-		LIMIT 1
+		ORDER BY position ASC, id ASC
 	`
 	
-	row := r.base.QueryRow(query, tournamentID, round)
+	rows, err := r.base.Query(query, tournamentID, round)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	
-	match := &models.Match{}
-	err := row.Scan(
-		&match.ID,
-		&match.TournamentID,
-		&match.Round,
-		&match.Team1,
-		&match.Team2,
-		&match.Score1,
-		&match.Score2,
-		&match.Winner,
-		&match.Status,
-		&match.ScheduledAt,
-		&match.CompletedAt,
-		&match.CreatedAt,
-		&match.UpdatedAt,
-	)
+	return r.scanMatches(rows)
+}
+
+// GetByRoundAndPosition retrieves a match by tournament, round and position
+func (r *matchRepository) GetByRoundAndPosition(ctx context.Context, tournamentID uint, round string, position int) (*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE tournament_id = ? AND round = ? AND position = ?
+		LIMIT 1
+	`
 	
+	match, err := r.scanMatch(r.base.QueryRow(query, tournamentID, round, position))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -179,64 +423,622 @@ func (r *matchRepository) GetByRoundAndPosition(ctx context.Context, tournamentI
 		return nil, err
 	}
 	
+	if err := r.loadSets([]*models.Match{match}); err != nil {
+		return nil, err
+	}
+	
 	return match, nil
 }
 
-// Update updates an existing match
+// GetNextMatches retrieves the matches the winner and loser of a match advance to.
+// Either result is nil when the match has no such destination.
+func (r *matchRepository) GetNextMatches(ctx context.Context, matchID uint) (winnerNext, loserNext *models.Match, err error) {
+	match, err := r.GetByID(ctx, matchID)
+	if err != nil || match == nil {
+		return nil, nil, err
+	}
+	
+	if match.NextMatchID != nil {
+		if winnerNext, err = r.GetByID(ctx, uint(*match.NextMatchID)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if match.LoserNextMatchID != nil {
+		if loserNext, err = r.GetByID(ctx, uint(*match.LoserNextMatchID)); err != nil {
+			return nil, nil, err
+		}
+	}
+	
+	return winnerNext, loserNext, nil
+}
+
+// GetFeederMatches retrieves the matches whose winner or loser advances into the given match
+func (r *matchRepository) GetFeederMatches(ctx context.Context, matchID uint) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE next_match_id = ? OR loser_next_match_id = ?
+		ORDER BY position ASC, id ASC
+	`
+	
+	rows, err := r.base.Query(query, matchID, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	return r.scanMatches(rows)
+}
+
+// AdvanceBracket saves a result and the bracket slots it fills in a single
+// transaction. The tournament row and then its matches are read with
+// SELECT ... FOR UPDATE and passed to advance, which returns the matches to
+// save; results submitted at the same time for matches feeding the same
+// next-round match are therefore applied one after the other instead of
+// overwriting each other's slot. A status change advance makes to the
+// tournament is saved in the same transaction. Errors returned by advance roll
+// the transaction back and are wrapped.
+func (r *matchRepository) AdvanceBracket(ctx context.Context, tournamentID uint, advance func(tournament *models.Tournament, matches []*models.Match) ([]*models.Match, error)) error {
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		tournament, matches, err := r.lockBracketTx(tx, tournamentID)
+		if err != nil {
+			return err
+		}
+		status := tournament.Status
+		
+		updates, err := advance(tournament, matches)
+		if err != nil {
+			return err
+		}
+		for _, match := range updates {
+			if err := r.updateMatchTx(tx, match); err != nil {
+				return err
+			}
+		}
+		return r.updateTournamentStatusTx(tx, tournament, status)
+	})
+}
+
+// Update updates an existing match together with its set scores
 func (r *matchRepository) Update(ctx context.Context, match *models.Match) error {
+	return r.UpdateMany(ctx, []*models.Match{match})
+}
+
+// UpdateMany updates multiple matches atomically in a single transaction
+func (r *matchRepository) UpdateMany(ctx context.Context, matches []*models.Match) error {
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		for _, match := range matches {
+			if err := r.updateMatchTx(tx, match); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateSchedule updates only the start time, planned start time, court and pin
+// of multiple matches atomically, leaving results written concurrently untouched
+func (r *matchRepository) UpdateSchedule(ctx context.Context, matches []*models.Match) error {
 	query := `
 		UPDATE matches
-		SET tournament_id = ?, round = ?, team1 = ?, team2 = ?, score1 = ?, score2 = ?, winner = ?, status = ?, scheduled_at = ?, completed_at = ?, updated_at = NOW()
+		SET scheduled_at = ?, planned_at = ?, court_id = ?, schedule_pinned = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		for _, match := range matches {
+			if _, err := r.base.ExecQueryTx(tx, query, match.ScheduledAt, match.PlannedAt, match.CourtID, match.SchedulePinned, match.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CorrectBracket corrects a result in a single transaction that locks the
+// tournament and its matches like AdvanceBracket. The locked rows are passed to
+// correct, which returns the correction to record and the matches to save, so
+// a result recorded at the same time on a downstream match is never reset or
+// overwritten from a stale copy of the bracket. Errors returned by correct
+// roll the transaction back and are wrapped.
+func (r *matchRepository) CorrectBracket(ctx context.Context, tournamentID uint, correct func(tournament *models.Tournament, matches []*models.Match) (*models.ResultCorrection, []*models.Match, error)) error {
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		tournament, matches, err := r.lockBracketTx(tx, tournamentID)
+		if err != nil {
+			return err
+		}
+		status := tournament.Status
+		
+		correction, updates, err := correct(tournament, matches)
+		if err != nil {
+			return err
+		}
+		for _, match := range updates {
+			if err := r.updateMatchTx(tx, match); err != nil {
+				return err
+			}
+		}
+		if err := r.insertCorrectionTx(tx, correction); err != nil {
+			return err
+		}
+		return r.updateTournamentStatusTx(tx, tournament, status)
+	})
+}
+
+// lockBracketTx reads a tournament and its matches with their set scores and
+// locks them for the rest of the transaction. The tournament row is locked
+// first, in the same order as DrawBracket.
+func (r *matchRepository) lockBracketTx(tx *sql.Tx, tournamentID uint) (*models.Tournament, []*models.Match, error) {
+	tournamentQuery := `
+		SELECT id, event_id, sport, format, status, created_at, updated_at
+		FROM tournaments
+		WHERE id = ?
+		FOR UPDATE
+	`
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE tournament_id = ?
+		ORDER BY scheduled_at ASC, position ASC, id ASC
+		FOR UPDATE
+	`
+	
+	tournament := &models.Tournament{}
+	err := r.base.QueryRowTx(tx, tournamentQuery, tournamentID).Scan(
+		&tournament.ID,
+		&tournament.EventID,
+		&tournament.Sport,
+		&tournament.Format,
+		&tournament.Status,
+		&tournament.CreatedAt,
+		&tournament.UpdatedAt,
+	)
+	if err != nil {
+		return nil, nil, HandleSQLError(err, "トーナメントのロック")
+	}
+	
+	rows, err := r.base.QueryTx(tx, query, tournamentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	matches, err := r.scanMatchRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	// セットのスコアで勝者が決まるため、ロックした試合と同じトランザクションで読む
+	if err := r.loadSetsTx(tx, matches); err != nil {
+		return nil, nil, err
+	}
+	return tournament, matches, nil
+}
+
+// updateTournamentStatusTx saves the status of a locked tournament within a
+// transaction when it differs from the status it was read with
+func (r *matchRepository) updateTournamentStatusTx(tx *sql.Tx, tournament *models.Tournament, status string) error {
+	if tournament.Status == status {
+		return nil
+	}
+	query := `
+		UPDATE tournaments
+		SET status = ?, updated_at = NOW()
 		WHERE id = ?
 	`
+	_, err := r.base.ExecQueryTx(tx, query, tournament.Status, tournament.ID)
+	return err
+}
+
+// insertCorrectionTx records a result correction within a transaction
+func (r *matchRepository) insertCorrectionTx(tx *sql.Tx, correction *models.ResultCorrection) error {
+	impacts, err := json.Marshal(correction.Impacts)
+	if err != nil {
+		return err
+	}
+	warnings, err := json.Marshal(correction.Warnings)
+	if err != nil {
+		return err
+	}
+	
+	insertQuery := `
+		INSERT INTO match_corrections (match_id, tournament_id, previous_score1, previous_score2, previous_winner,
+			score1, score2, winner, reason, corrected_by, impacts, warnings, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	
+	result, err := r.base.ExecQueryTx(tx, insertQuery,
+		correction.MatchID,
+		correction.TournamentID,
+		correction.PreviousScore1,
+		correction.PreviousScore2,
+		correction.PreviousWinner,
+		correction.Score1,
+		correction.Score2,
+		correction.Winner,
+		correction.Reason,
+		correction.CorrectedBy,
+		string(impacts),
+		string(warnings),
+	)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	correction.ID = int(id)
+	return nil
+}
+
+// GetCorrections retrieves the result corrections of a match, newest first
+func (r *matchRepository) GetCorrections(ctx context.Context, matchID uint) ([]*models.ResultCorrection, error) {
+	query := `
+		SELECT id, match_id, tournament_id, previous_score1, previous_score2, previous_winner,
+			score1, score2, winner, reason, corrected_by, impacts, warnings, created_at
+		FROM match_corrections
+		WHERE match_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	
+	rows, err := r.base.Query(query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	var corrections []*models.ResultCorrection
+	for rows.Next() {
+		correction := &models.ResultCorrection{}
+		var impacts, warnings sql.NullString
+		err := rows.Scan(
+			&correction.ID,
+			&correction.MatchID,
+			&correction.TournamentID,
+			&correction.PreviousScore1,
+			&correction.PreviousScore2,
+			&correction.PreviousWinner,
+			&correction.Score1,
+			&correction.Score2,
+			&correction.Winner,
+			&correction.Reason,
+			&correction.CorrectedBy,
+			&impacts,
+			&warnings,
+			&correction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if impacts.Valid {
+			if err := json.Unmarshal([]byte(impacts.String), &correction.Impacts); err != nil {
+				return nil, err
+			}
+		}
+		if warnings.Valid {
+			if err := json.Unmarshal([]byte(warnings.String), &correction.Warnings); err != nil {
+				return nil, err
+			}
+		}
+		corrections = append(corrections, correction)
+	}
+	
+	return corrections, rows.Err()
+}
+
+// CreateEvent appends an event to a match timeline, numbering it after the
+// events already recorded for the match
+func (r *matchRepository) CreateEvent(ctx context.Context, event *models.MatchEvent) error {
+	sequenceQuery := `SELECT COALESCE(MAX(sequence), 0) + 1 FROM match_events WHERE match_id = ? FOR UPDATE`
+	insertQuery := `
+		INSERT INTO match_events (match_id, sequence, event_type, slot, minute, set_score1, set_score2,
+			card, player_id, note, recorded_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	
+	var setScore1, setScore2 *int
+	if event.SetScore != nil {
+		setScore1, setScore2 = &event.SetScore.Score1, &event.SetScore.Score2
+	}
+	
+	tm := NewTransactionManager(r.base)
+	return tm.ExecuteInTransactionContext(ctx, func(tx *sql.Tx) error {
+		if err := r.base.QueryRowTx(tx, sequenceQuery, event.MatchID).Scan(&event.Sequence); err != nil {
+			return err
+		}
+		
+		result, err := r.base.ExecQueryTx(tx, insertQuery,
+			event.MatchID,
+			event.Sequence,
+			event.Type,
+			event.Slot,
+			event.Minute,
+			setScore1,
+			setScore2,
+			event.Card,
+			event.PlayerID,
+			event.Note,
+			event.RecordedBy,
+		)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		event.ID = int(id)
+		event.CreatedAt = time.Now()
+		return nil
+	})
+}
+
+// GetEvents retrieves the timeline of a match in the order it was recorded,
+// with the names of the players the events are attributed to
+func (r *matchRepository) GetEvents(ctx context.Context, matchID uint) ([]*models.MatchEvent, error) {
+	query := `
+		SELECT e.id, e.match_id, e.sequence, e.event_type, e.slot, e.minute, e.set_score1, e.set_score2,
+			e.card, e.player_id, p.name, e.note, e.recorded_by, e.created_at
+		FROM match_events e
+		LEFT JOIN players p ON p.id = e.player_id
+		WHERE e.match_id = ?
+		ORDER BY e.sequence ASC
+	`
+	
+	rows, err := r.base.Query(query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	var events []*models.MatchEvent
+	for rows.Next() {
+		event := &models.MatchEvent{}
+		var setScore1, setScore2 sql.NullInt64
+		var playerName, note sql.NullString
+		err := rows.Scan(
+			&event.ID,
+			&event.MatchID,
+			&event.Sequence,
+			&event.Type,
+			&event.Slot,
+			&event.Minute,
+			&setScore1,
+			&setScore2,
+			&event.Card,
+			&event.PlayerID,
+			&playerName,
+			&note,
+			&event.RecordedBy,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if setScore1.Valid && setScore2.Valid {
+			event.SetScore = &models.SetScore{Score1: int(setScore1.Int64), Score2: int(setScore2.Int64)}
+		}
+		event.PlayerName = playerName.String
+		event.Note = note.String
+		events = append(events, event)
+	}
+	
+	return events, rows.Err()
+}
+
+// Delete deletes a match
+func (r *matchRepository) Delete(ctx context.Context, id uint) error {
+	query := `DELETE FROM matches WHERE id = ?`
+	
+	_, err := r.base.ExecQuery(query, id)
+	return err
+}
+
+// matchUpdateQuery updates every column of a match by ID
+const matchUpdateQuery = `
+	UPDATE matches
+	SET tournament_id = ?, round = ?, position = ?, group_name = ?, swiss_round = ?, team1 = ?, team2 = ?,
+		team1_id = ?, team2_id = ?, score1 = ?, score2 = ?,
+		extra_time_score1 = ?, extra_time_score2 = ?, penalty_score1 = ?, penalty_score2 = ?, decision_method = ?, result_type = ?,
+		forfeiting_team = ?, forfeiting_team_id = ?, winner = ?, winner_id = ?,
+		next_match_id = ?, next_slot = ?, loser_next_match_id = ?, loser_next_slot = ?,
+		status = ?, scheduled_at = ?, planned_at = ?, court_id = ?, schedule_pinned = ?, completed_at = ?, updated_at = NOW()
+	WHERE id = ?
+`
+
+// updateMatchTx updates a match and replaces its set scores within a transaction
+func (r *matchRepository) updateMatchTx(tx *sql.Tx, match *models.Match) error {
+	if _, err := r.base.ExecQueryTx(tx, matchUpdateQuery, matchUpdateArgs(match)...); err != nil {
+		return err
+	}
+	
+	if _, err := r.base.ExecQueryTx(tx, `DELETE FROM match_sets WHERE match_id = ?`, match.ID); err != nil {
+		return err
+	}
+	
+	insertQuery := `
+		INSERT INTO match_sets (match_id, set_number, score1, score2, created_at)
+		VALUES (?, ?, ?, ?, NOW())
+	`
+	for _, set := range match.Sets {
+		if _, err := r.base.ExecQueryTx(tx, insertQuery, match.ID, set.SetNumber, set.Score1, set.Score2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSets attaches the per-set scores to the given matches
+func (r *matchRepository) loadSets(matches []*models.Match) error {
+	return r.loadSetsWith(r.base.Query, matches)
+}
+
+// loadSetsTx attaches the per-set scores to the given matches within a transaction
+func (r *matchRepository) loadSetsTx(tx *sql.Tx, matches []*models.Match) error {
+	return r.loadSetsWith(func(query string, args ...interface{}) (*sql.Rows, error) {
+		return r.base.QueryTx(tx, query, args...)
+	}, matches)
+}
+
+// loadSetsWith attaches the per-set scores to the given matches, reading them with query
+func (r *matchRepository) loadSetsWith(query func(query string, args ...interface{}) (*sql.Rows, error), matches []*models.Match) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	
+	byID := make(map[int]*models.Match, len(matches))
+	placeholders := make([]string, 0, len(matches))
+	args := make([]interface{}, 0, len(matches))
+	for _, match := range matches {
+		match.Sets = nil
+		byID[match.ID] = match
+		placeholders = append(placeholders, "?")
+		args = append(args, match.ID)
+	}
+	
+	setsQuery := `
+		SELECT match_id, set_number, score1, score2
+		FROM match_sets
+		WHERE match_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY match_id ASC, set_number ASC
+	`
+	
+	rows, err := query(setsQuery, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 	
-	_, err := r.base.ExecQuery(query,
+	for rows.Next() {
+		var matchID int
+		var set models.SetScore
+		if err := rows.Scan(&matchID, &set.SetNumber, &set.Score1, &set.Score2); err != nil {
+			return err
+		}
+		if match := byID[matchID]; match != nil {
+			match.Sets = append(match.Sets, set)
+		}
+	}
+	
+	return rows.Err()
+}
+
+// matchInsertArgs returns the INSERT arguments for a match
+func matchInsertArgs(match *models.Match) []interface{} {
+	return []interface{}{
 		match.TournamentID,
 		match.Round,
+		match.Position,
+		match.GroupName,
+		match.SwissRound,
 		match.Team1,
 		match.Team2,
+		match.Team1ID,
+		match.Team2ID,
 		match.Score1,
 		match.Score2,
+		match.ExtraTimeScore1,
+		match.ExtraTimeScore2,
+		match.PenaltyScore1,
+		match.PenaltyScore2,
+		match.DecisionMethod,
+		match.ResultType,
+		match.ForfeitingTeam,
+		match.ForfeitingTeamID,
 		match.Winner,
+		match.WinnerID,
+		match.NextMatchID,
+		match.NextSlot,
+		match.LoserNextMatchID,
+		match.LoserNextSlot,
 		match.Status,
 		match.ScheduledAt,
+		match.PlannedAt,
+		match.CourtID,
+		match.SchedulePinned,
 		match.CompletedAt,
-		match.ID,
+	}
+}
+
+// matchUpdateArgs returns the UPDATE arguments for a match, ending with its ID
+func matchUpdateArgs(match *models.Match) []interface{} {
+	return append(matchInsertArgs(match), match.ID)
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMatch scans a single match row
+func (r *matchRepository) scanMatch(row rowScanner) (*models.Match, error) {
+	match := &models.Match{}
+	err := row.Scan(
+		&match.ID,
+		&match.TournamentID,
+		&match.Round,
+		&match.Position,
+		&match.GroupName,
+		&match.SwissRound,
+		&match.Team1,
+		&match.Team2,
+		&match.Team1ID,
+		&match.Team2ID,
+		&match.Score1,
+		&match.Score2,
+		&match.ExtraTimeScore1,
+		&match.ExtraTimeScore2,
+		&match.PenaltyScore1,
+		&match.PenaltyScore2,
+		&match.DecisionMethod,
+		&match.ResultType,
+		&match.ForfeitingTeam,
+		&match.ForfeitingTeamID,
+		&match.Winner,
+		&match.WinnerID,
+		&match.NextMatchID,
+		&match.NextSlot,
+		&match.LoserNextMatchID,
+		&match.LoserNextSlot,
+		&match.Status,
+		&match.ScheduledAt,
+		&match.PlannedAt,
+		&match.CourtID,
+		&match.SchedulePinned,
+		&match.CompletedAt,
+		&match.CreatedAt,
+		&match.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
 	
-	return err
+	return match, nil
 }
 
-// Delete deletes a match
-func (r *matchRepository) Delete(ctx context.Context, id uint) error {
-	query := `DELETE FROM matches WHERE id = ?`
+// scanMatches scans multiple match rows together with their set scores
+func (r *matchRepository) scanMatches(rows *sql.Rows) ([]*models.Match, error) {
+	matches, err := r.scanMatchRows(rows)
+	if err != nil {
+		return nil, err
+	}
 	
-	_, err := r.base.ExecQuery(query, id)
-	return err
+	if err := r.loadSets(matches); err != nil {
+		return nil, err
+	}
+	
+	return matches, nil
 }
 
-// scanMatches scans multiple match rows
-func (r *matchRepository) scanMatches(rows *sql.Rows) ([]*models.Match, error) {
+// scanMatchRows scans multiple match rows without their set scores
+func (r *matchRepository) scanMatchRows(rows *sql.Rows) ([]*models.Match, error) {
 	var matches []*models.Match
 	
 	for rows.Next() {
-		match := &models.Match{}
-		err := rows.Scan(
-			&match.ID,
-			&match.TournamentID,
-			&match.Round,
-			&match.Team1,
-			&match.Team2,
-			&match.Score1,
-			&match.Score2,
-			&match.Winner,
-			&match.Status,
-			&match.ScheduledAt,
-			&match.CompletedAt,
-			&match.CreatedAt,
-			&match.UpdatedAt,
-		)
-		
+		match, err := r.scanMatch(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// testDatabaseName はMySQLを使うリポジトリのテスト用データベース（毎回作り直す）
+const testDatabaseName = "tournament_repository_test"
+
+// openTestDatabase はテスト用データベースを作り直してマイグレーションを実行する
+// MySQLに接続できない環境ではテストをスキップする
+func openTestDatabase(t *testing.T) *database.DB {
+	t.Helper()
+
+	config := database.Config{
+		Host:     envOrDefault("DB_HOST", "localhost"),
+		Port:     envOrDefault("DB_PORT", "3306"),
+		User:     envOrDefault("DB_USER", "root"),
+		Password: envOrDefault("DB_PASSWORD", "test_password"),
+	}
+	server, err := database.NewConnection(config)
+	if err != nil {
+		t.Skipf("MySQLに接続できないためスキップします: %v", err)
+	}
+	migrations := database.NewMigrationManager(server, "../../migrations")
+	if err := migrations.DropDatabase(testDatabaseName); err != nil {
+		t.Fatalf("テスト用データベースの削除に失敗しました: %v", err)
+	}
+	if err := migrations.CreateDatabase(testDatabaseName); err != nil {
+		t.Fatalf("テスト用データベースの作成に失敗しました: %v", err)
+	}
+	server.Close()
+
+	config.Database = testDatabaseName
+	db, err := database.NewConnection(config)
+	if err != nil {
+		t.Fatalf("テスト用データベースへの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.NewMigrationManager(db, "../../migrations").RunMigrations(); err != nil {
+		t.Fatalf("マイグレーションの実行に失敗しました: %v", err)
+	}
+	return db
+}
+
+// envOrDefault は環境変数を取得し、存在しない場合はデフォルト値を返す
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// TestMatchRepository_SwitchFormat_CancelsMatches は雨天時形式からの切り替えで、
+// 終了していない敗者復活戦が中止（cancelled）として保存されることをテストする
+func TestMatchRepository_SwitchFormat_CancelsMatches(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewMatchRepository(db)
+	ctx := context.Background()
+
+	result, err := db.Exec(`INSERT INTO events (name, start_date, end_date, status) VALUES ('形式切り替えテスト', CURDATE(), CURDATE(), 'ongoing')`)
+	if err != nil {
+		t.Fatalf("大会の作成に失敗しました: %v", err)
+	}
+	eventID, _ := result.LastInsertId()
+	result, err = db.Exec(`INSERT INTO tournaments (event_id, sport, format, status) VALUES (?, 'table_tennis', 'rainy', 'active')`, eventID)
+	if err != nil {
+		t.Fatalf("トーナメントの作成に失敗しました: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	tournament := &models.Tournament{ID: int(id), EventID: int(eventID), Sport: string(models.SportTypeTableTennis), Format: string(models.TournamentFormatRainy), Status: string(models.TournamentStatusActiveEnum)}
+
+	matches, err := models.NewRainyMatches(tournament.ID, [][2]string{{"A", "H"}, {"B", "G"}, {"C", "F"}, {"D", "E"}})
+	if err != nil {
+		t.Fatalf("NewRainyMatches() error = %v", err)
+	}
+	for _, match := range matches {
+		match.ScheduledAt = time.Now()
+	}
+	if err := repo.CreateBracket(ctx, matches); err != nil {
+		t.Fatalf("CreateBracket() error = %v", err)
+	}
+
+	plan, err := models.PlanFormatSwitch(tournament, models.TournamentFormatStandard, matches)
+	if err != nil {
+		t.Fatalf("PlanFormatSwitch() error = %v", err)
+	}
+	if len(plan.Cancelled) == 0 {
+		t.Fatal("中止する敗者復活戦がありません")
+	}
+	if err := repo.SwitchFormat(ctx, tournament.ID, models.TournamentFormatStandard, plan.Layout(), plan.Retired()); err != nil {
+		t.Fatalf("SwitchFormat() error = %v", err)
+	}
+
+	saved, err := repo.GetByTournamentID(ctx, uint(tournament.ID))
+	if err != nil {
+		t.Fatalf("GetByTournamentID() error = %v", err)
+	}
+	cancelled := 0
+	for _, match := range saved {
+		if match.GetRound() != models.RoundLoserBracketEnum {
+			continue
+		}
+		if !match.IsCancelled() {
+			t.Errorf("敗者復活戦（ID %d）のステータス = %s, want cancelled", match.ID, match.Status)
+		}
+		cancelled++
+	}
+	if cancelled != len(plan.Cancelled) {
+		t.Errorf("中止した試合数 = %d, want %d", cancelled, len(plan.Cancelled))
+	}
+
+	var format string
+	if err := db.QueryRow(`SELECT format FROM tournaments WHERE id = ?`, tournament.ID).Scan(&format); err != nil {
+		t.Fatalf("トーナメントの取得に失敗しました: %v", err)
+	}
+	if format != string(models.TournamentFormatStandard) {
+		t.Errorf("format = %s, want standard", format)
+	}
+}
+
+// TestTeamRepository_Update_KeepsMatchHistory はチーム名を変更しても試合の記録を書き換えず、
+// 読み込み時に現在のチーム名が表示されることをテストする
+func TestTeamRepository_Update_KeepsMatchHistory(t *testing.T) {
+	db := openTestDatabase(t)
+	teams := NewTeamRepository(db)
+	matches := NewMatchRepository(db)
+	ctx := context.Background()
+
+	result, err := db.Exec(`INSERT INTO events (name, start_date, end_date, status) VALUES ('チーム名変更テスト', CURDATE(), CURDATE(), 'ongoing')`)
+	if err != nil {
+		t.Fatalf("大会の作成に失敗しました: %v", err)
+	}
+	eventID, _ := result.LastInsertId()
+	result, err = db.Exec(`INSERT INTO tournaments (event_id, sport, format, status) VALUES (?, 'volleyball', 'standard', 'active')`, eventID)
+	if err != nil {
+		t.Fatalf("トーナメントの作成に失敗しました: %v", err)
+	}
+	tournamentID, _ := result.LastInsertId()
+
+	team := &models.Team{TournamentID: uint(tournamentID), Name: "IE4"}
+	if err := teams.Create(ctx, team); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	teamID := int(team.ID)
+	winner := "IE4"
+	match := &models.Match{
+		TournamentID: int(tournamentID),
+		Round:        string(models.RoundFinalEnum),
+		Team1:        "IE4",
+		Team1ID:      &teamID,
+		Team2:        "IS4",
+		Winner:       &winner,
+		WinnerID:     &teamID,
+		Status:       string(models.MatchStatusCompletedEnum),
+		ScheduledAt:  time.Now(),
+	}
+	if err := matches.Create(ctx, match); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	team.Name = "IE4A"
+	if err := teams.Update(ctx, team); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	saved, err := matches.GetByID(ctx, uint(match.ID))
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if saved.Team1 != "IE4A" || saved.Winner == nil || *saved.Winner != "IE4A" || saved.Team2 != "IS4" {
+		t.Errorf("表示されるチーム = %s / %s (勝者 %v), want IE4A / IS4 (勝者 IE4A)", saved.Team1, saved.Team2, saved.Winner)
+	}
+
+	var recorded string
+	if err := db.QueryRow(`SELECT team1 FROM matches WHERE id = ?`, match.ID).Scan(&recorded); err != nil {
+		t.Fatalf("試合の取得に失敗しました: %v", err)
+	}
+	if recorded != "IE4" {
+		t.Errorf("記録されたチーム名 = %s, want IE4", recorded)
+	}
+}
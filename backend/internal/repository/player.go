@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// PlayerRepository defines the interface for player, roster and player statistics data operations
+type PlayerRepository interface {
+	Create(ctx context.Context, player *models.Player) error
+	GetByID(ctx context.Context, id uint) (*models.Player, error)
+	GetByStudentNumber(ctx context.Context, studentNumber string) (*models.Player, error)
+	List(ctx context.Context, className string, limit, offset int) ([]*models.Player, error)
+	Update(ctx context.Context, player *models.Player) error
+	Delete(ctx context.Context, id uint) error
+
+	AddToRoster(ctx context.Context, entry *models.RosterEntry) error
+	RemoveFromRoster(ctx context.Context, teamID, playerID uint) error
+	GetRoster(ctx context.Context, teamID uint) ([]*models.RosterEntry, error)
+	GetRosterEntry(ctx context.Context, tournamentID, playerID uint) (*models.RosterEntry, error)
+	CountRecords(ctx context.Context, tournamentID, playerID uint) (int, error)
+
+	SetMatchMVP(ctx context.Context, matchID int, playerID uint, selectedBy *int) error
+	ClearMatchMVP(ctx context.Context, matchID int) error
+	GetMatchMVP(ctx context.Context, matchID int) (*models.Player, error)
+
+	GetStatsByTournament(ctx context.Context, tournamentID uint) ([]*models.PlayerStats, error)
+	GetStatsByEvent(ctx context.Context, eventID uint) ([]*models.PlayerStats, error)
+}
+
+// playerColumns は選手テーブルのSELECT対象カラム
+const playerColumns = `id, student_number, name, class_name, created_at, updated_at`
+
+// playerStatsColumns は選手の成績の集計カラム
+// 試合の範囲（トーナメントまたは大会）の条件を %[1]s に埋め込み、引数は集計ごとに1つずつ渡す
+const playerStatsColumns = `
+	(SELECT COUNT(*) FROM match_events e JOIN matches m ON m.id = e.match_id
+		WHERE e.player_id = p.id AND e.event_type = 'goal' AND %[1]s) AS goals,
+	(SELECT COUNT(*) FROM match_events e JOIN matches m ON m.id = e.match_id
+		WHERE e.player_id = p.id AND e.event_type = 'card' AND e.card = 'yellow' AND %[1]s) AS yellow_cards,
+	(SELECT COUNT(*) FROM match_events e JOIN matches m ON m.id = e.match_id
+		WHERE e.player_id = p.id AND e.event_type = 'card' AND e.card = 'red' AND %[1]s) AS red_cards,
+	(SELECT COUNT(*) FROM match_mvps v JOIN matches m ON m.id = v.match_id
+		WHERE v.player_id = p.id AND %[1]s) AS mvp_awards`
+
+// playerStatsAggregates は playerStatsColumns の集計の数
+const playerStatsAggregates = 4
+
+// playerRepository implements PlayerRepository
+type playerRepository struct {
+	base BaseRepository
+}
+
+// NewPlayerRepository creates a new player repository
+func NewPlayerRepository(db *database.DB) PlayerRepository {
+	if db == nil {
+		log.Fatal("データベース接続がnilです")
+	}
+
+	baseRepo := NewBaseRepository(db)
+	return &playerRepository{
+		base: baseRepo,
+	}
+}
+
+// Create creates a new player
+func (r *playerRepository) Create(ctx context.Context, player *models.Player) error {
+	query := `
+		INSERT INTO players (student_number, name, class_name, created_at, updated_at)
+		VALUES (?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.base.ExecQuery(query,
+		player.StudentNumber,
+		player.Name,
+		player.ClassName,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	player.ID = uint(id)
+	return nil
+}
+
+// GetByID retrieves a player by ID (nil when not found)
+func (r *playerRepository) GetByID(ctx context.Context, id uint) (*models.Player, error) {
+	query := `
+		SELECT ` + playerColumns + `
+		FROM players
+		WHERE id = ?
+	`
+
+	return r.getOne(query, id)
+}
+
+// GetByStudentNumber retrieves a player by student number (nil when not found)
+func (r *playerRepository) GetByStudentNumber(ctx context.Context, studentNumber string) (*models.Player, error) {
+	query := `
+		SELECT ` + playerColumns + `
+		FROM players
+		WHERE student_number = ?
+	`
+
+	return r.getOne(query, studentNumber)
+}
+
+// List retrieves players ordered by student number, optionally filtered by class
+func (r *playerRepository) List(ctx context.Context, className string, limit, offset int) ([]*models.Player, error) {
+	query := `
+		SELECT ` + playerColumns + `
+		FROM players
+		WHERE (? = '' OR class_name = ?)
+		ORDER BY student_number ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.base.Query(query, className, className, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []*models.Player
+	for rows.Next() {
+		player, err := r.scanPlayer(rows)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+
+	return players, rows.Err()
+}
+
+// Update updates a player
+func (r *playerRepository) Update(ctx context.Context, player *models.Player) error {
+	query := `
+		UPDATE players
+		SET student_number = ?, name = ?, class_name = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.base.ExecQuery(query,
+		player.StudentNumber,
+		player.Name,
+		player.ClassName,
+		player.ID,
+	)
+	return err
+}
+
+// Delete deletes a player together with the player's roster entries and MVP awards
+func (r *playerRepository) Delete(ctx context.Context, id uint) error {
+	query := `DELETE FROM players WHERE id = ?`
+
+	_, err := r.base.ExecQuery(query, id)
+	return err
+}
+
+// AddToRoster adds a player to a team roster
+func (r *playerRepository) AddToRoster(ctx context.Context, entry *models.RosterEntry) error {
+	query := `
+		INSERT INTO team_players (team_id, player_id, tournament_id, shirt_number, created_at)
+		VALUES (?, ?, ?, ?, NOW())
+	`
+
+	result, err := r.base.ExecQuery(query,
+		entry.TeamID,
+		entry.PlayerID,
+		entry.TournamentID,
+		entry.ShirtNumber,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = uint(id)
+	return nil
+}
+
+// RemoveFromRoster removes a player from a team roster
+func (r *playerRepository) RemoveFromRoster(ctx context.Context, teamID, playerID uint) error {
+	query := `DELETE FROM team_players WHERE team_id = ? AND player_id = ?`
+
+	_, err := r.base.ExecQuery(query, teamID, playerID)
+	return err
+}
+
+// GetRoster retrieves the players registered to a team, ordered by shirt number
+func (r *playerRepository) GetRoster(ctx context.Context, teamID uint) ([]*models.RosterEntry, error) {
+	query := `
+		SELECT r.id, r.team_id, r.player_id, r.tournament_id, r.shirt_number, r.created_at,
+			p.id, p.student_number, p.name, p.class_name, p.created_at, p.updated_at
+		FROM team_players r
+		JOIN players p ON p.id = r.player_id
+		WHERE r.team_id = ?
+		ORDER BY r.shirt_number IS NULL, r.shirt_number ASC, p.student_number ASC
+	`
+
+	rows, err := r.base.Query(query, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roster []*models.RosterEntry
+	for rows.Next() {
+		entry := &models.RosterEntry{Player: &models.Player{}}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TeamID,
+			&entry.PlayerID,
+			&entry.TournamentID,
+			&entry.ShirtNumber,
+			&entry.CreatedAt,
+			&entry.Player.ID,
+			&entry.Player.StudentNumber,
+			&entry.Player.Name,
+			&entry.Player.ClassName,
+			&entry.Player.CreatedAt,
+			&entry.Player.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roster = append(roster, entry)
+	}
+
+	return roster, rows.Err()
+}
+
+// GetRosterEntry retrieves the roster entry of a player in a tournament (nil
+// when the player is not on any team of the tournament)
+func (r *playerRepository) GetRosterEntry(ctx context.Context, tournamentID, playerID uint) (*models.RosterEntry, error) {
+	query := `
+		SELECT id, team_id, player_id, tournament_id, shirt_number, created_at
+		FROM team_players
+		WHERE tournament_id = ? AND player_id = ?
+	`
+
+	entry := &models.RosterEntry{}
+	err := r.base.QueryRow(query, tournamentID, playerID).Scan(
+		&entry.ID,
+		&entry.TeamID,
+		&entry.PlayerID,
+		&entry.TournamentID,
+		&entry.ShirtNumber,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CountRecords counts the match events and MVP awards of a player in a tournament
+func (r *playerRepository) CountRecords(ctx context.Context, tournamentID, playerID uint) (int, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM match_events e JOIN matches m ON m.id = e.match_id
+				WHERE e.player_id = ? AND m.tournament_id = ?) +
+			(SELECT COUNT(*) FROM match_mvps v JOIN matches m ON m.id = v.match_id
+				WHERE v.player_id = ? AND m.tournament_id = ?)
+	`
+
+	var count int
+	if err := r.base.QueryRow(query, playerID, tournamentID, playerID, tournamentID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SetMatchMVP selects the MVP of a match, replacing any previous selection
+func (r *playerRepository) SetMatchMVP(ctx context.Context, matchID int, playerID uint, selectedBy *int) error {
+	query := `
+		INSERT INTO match_mvps (match_id, player_id, selected_by, created_at)
+		VALUES (?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE player_id = VALUES(player_id), selected_by = VALUES(selected_by), created_at = NOW()
+	`
+
+	_, err := r.base.ExecQuery(query, matchID, playerID, selectedBy)
+	return err
+}
+
+// ClearMatchMVP removes the MVP selection of a match
+func (r *playerRepository) ClearMatchMVP(ctx context.Context, matchID int) error {
+	query := `DELETE FROM match_mvps WHERE match_id = ?`
+
+	_, err := r.base.ExecQuery(query, matchID)
+	return err
+}
+
+// GetMatchMVP retrieves the MVP of a match (nil when not selected)
+func (r *playerRepository) GetMatchMVP(ctx context.Context, matchID int) (*models.Player, error) {
+	query := `
+		SELECT p.id, p.student_number, p.name, p.class_name, p.created_at, p.updated_at
+		FROM match_mvps v
+		JOIN players p ON p.id = v.player_id
+		WHERE v.match_id = ?
+	`
+
+	return r.getOne(query, matchID)
+}
+
+// GetStatsByTournament retrieves the statistics of the players on the rosters of a tournament
+func (r *playerRepository) GetStatsByTournament(ctx context.Context, tournamentID uint) ([]*models.PlayerStats, error) {
+	query := `
+		SELECT p.id, p.name, p.class_name, t.id, t.name,` +
+		fmt.Sprintf(playerStatsColumns, "m.tournament_id = ?") + `
+		FROM team_players r
+		JOIN players p ON p.id = r.player_id
+		JOIN teams t ON t.id = r.team_id
+		WHERE r.tournament_id = ?
+	`
+
+	return r.queryStats(query, tournamentID, true)
+}
+
+// GetStatsByEvent retrieves the statistics of the players on the rosters of an
+// event's tournaments, added up over all the sports a player takes part in
+func (r *playerRepository) GetStatsByEvent(ctx context.Context, eventID uint) ([]*models.PlayerStats, error) {
+	query := `
+		SELECT p.id, p.name, p.class_name, NULL, '',` +
+		fmt.Sprintf(playerStatsColumns, "m.tournament_id IN (SELECT id FROM tournaments WHERE event_id = ?)") + `
+		FROM players p
+		WHERE EXISTS (
+			SELECT 1 FROM team_players r
+			JOIN tournaments tr ON tr.id = r.tournament_id
+			WHERE r.player_id = p.id AND tr.event_id = ?
+		)
+	`
+
+	return r.queryStats(query, eventID, false)
+}
+
+// queryStats runs a player statistics query whose scope is given by a single ID
+// used once per aggregate and once for the roster condition
+func (r *playerRepository) queryStats(query string, scopeID uint, withTeam bool) ([]*models.PlayerStats, error) {
+	args := make([]interface{}, playerStatsAggregates+1)
+	for i := range args {
+		args[i] = scopeID
+	}
+
+	rows, err := r.base.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*models.PlayerStats
+	for rows.Next() {
+		s := &models.PlayerStats{}
+		var teamID sql.NullInt64
+		err := rows.Scan(
+			&s.PlayerID,
+			&s.PlayerName,
+			&s.ClassName,
+			&teamID,
+			&s.TeamName,
+			&s.Goals,
+			&s.YellowCards,
+			&s.RedCards,
+			&s.MVPAwards,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if withTeam && teamID.Valid {
+			id := uint(teamID.Int64)
+			s.TeamID = &id
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// getOne runs a query returning at most one player (nil when not found)
+func (r *playerRepository) getOne(query string, args ...interface{}) (*models.Player, error) {
+	player, err := r.scanPlayer(r.base.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return player, nil
+}
+
+// scanPlayer scans a single player row
+func (r *playerRepository) scanPlayer(row rowScanner) (*models.Player, error) {
+	player := &models.Player{}
+
+	err := row.Scan(
+		&player.ID,
+		&player.StudentNumber,
+		&player.Name,
+		&player.ClassName,
+		&player.CreatedAt,
+		&player.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
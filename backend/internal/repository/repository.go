@@ -11,6 +11,8 @@ type Repository struct {
 	User       UserRepository
 	Tournament TournamentRepository
 	Match      MatchRepository
+	Team       TeamRepository
+	Event      EventRepository
 }
 
 // NewRepository は新しいRepositoryインスタンスを作成する
@@ -19,12 +21,16 @@ func NewRepository(db *database.DB) *Repository {
 	userRepo := NewUserRepository(db)
 	tournamentRepo := NewTournamentRepository(db)
 	matchRepo := NewMatchRepository(db)
+	teamRepo := NewTeamRepository(db)
+	eventRepo := NewEventRepository(db)
 	
 	return &Repository{
 		Base:       baseRepo,
 		User:       userRepo,
 		Tournament: tournamentRepo,
 		Match:      matchRepo,
+		Team:       teamRepo,
+		Event:      eventRepo,
 	}
 }
 
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// SportRepository defines the interface for sport registry data operations
+type SportRepository interface {
+	GetAll(ctx context.Context) ([]*models.Sport, error)
+	GetByCode(ctx context.Context, code models.SportType) (*models.Sport, error)
+	Create(ctx context.Context, sport *models.Sport) error
+	Update(ctx context.Context, sport *models.Sport) error
+}
+
+// sportColumns は種目テーブルのSELECT対象カラム
+const sportColumns = `code, display_name, formats, rounds, set_rules, forfeit_score, placement_points, sort_order, is_active, created_at, updated_at`
+
+// sportRepository implements SportRepository
+type sportRepository struct {
+	base BaseRepository
+}
+
+// NewSportRepository creates a new sport repository
+func NewSportRepository(db *database.DB) SportRepository {
+	if db == nil {
+		log.Fatal("データベース接続がnilです")
+	}
+
+	baseRepo := NewBaseRepository(db)
+	return &sportRepository{
+		base: baseRepo,
+	}
+}
+
+// GetAll retrieves every registered sport in display order
+func (r *sportRepository) GetAll(ctx context.Context) ([]*models.Sport, error) {
+	query := `
+		SELECT ` + sportColumns + `
+		FROM sports
+		ORDER BY sort_order ASC, code ASC
+	`
+
+	rows, err := r.base.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sports []*models.Sport
+	for rows.Next() {
+		sport, err := r.scanSport(rows)
+		if err != nil {
+			return nil, err
+		}
+		sports = append(sports, sport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sports, nil
+}
+
+// GetByCode retrieves a sport by its code (nil when not registered)
+func (r *sportRepository) GetByCode(ctx context.Context, code models.SportType) (*models.Sport, error) {
+	query := `
+		SELECT ` + sportColumns + `
+		FROM sports
+		WHERE code = ?
+	`
+
+	sport, err := r.scanSport(r.base.QueryRow(query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return sport, nil
+}
+
+// Create registers a new sport
+func (r *sportRepository) Create(ctx context.Context, sport *models.Sport) error {
+	query := `
+		INSERT INTO sports (code, display_name, formats, rounds, set_rules, forfeit_score, placement_points, sort_order, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+
+	args, err := sportArgs(sport)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.base.ExecQuery(query, append([]interface{}{sport.Code}, args...)...)
+	return err
+}
+
+// Update updates the definition of a registered sport
+func (r *sportRepository) Update(ctx context.Context, sport *models.Sport) error {
+	query := `
+		UPDATE sports
+		SET display_name = ?, formats = ?, rounds = ?, set_rules = ?, forfeit_score = ?, placement_points = ?, sort_order = ?, is_active = ?, updated_at = NOW()
+		WHERE code = ?
+	`
+
+	args, err := sportArgs(sport)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.base.ExecQuery(query, append(args, sport.Code)...)
+	return err
+}
+
+// sportArgs returns the column values of a sport after its code, encoding
+// formats, rounds, set rules and placement points as JSON
+func sportArgs(sport *models.Sport) ([]interface{}, error) {
+	formats, err := json.Marshal(sport.Formats)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds, err := json.Marshal(sport.Rounds)
+	if err != nil {
+		return nil, err
+	}
+
+	var setRules interface{}
+	if sport.SetRules != nil {
+		data, err := json.Marshal(sport.SetRules)
+		if err != nil {
+			return nil, err
+		}
+		setRules = string(data)
+	}
+
+	// 順位点が未設定の場合はNULLとし、既定の順位点を使用する
+	var placementPoints interface{}
+	if len(sport.PlacementPoints) > 0 {
+		data, err := json.Marshal(sport.PlacementPoints)
+		if err != nil {
+			return nil, err
+		}
+		placementPoints = string(data)
+	}
+
+	return []interface{}{
+		sport.DisplayName,
+		string(formats),
+		string(rounds),
+		setRules,
+		sport.ForfeitScore,
+		placementPoints,
+		sport.SortOrder,
+		sport.IsActive,
+	}, nil
+}
+
+// scanSport scans a single sport row
+func (r *sportRepository) scanSport(row rowScanner) (*models.Sport, error) {
+	sport := &models.Sport{}
+	var formats, rounds string
+	var setRules, placementPoints sql.NullString
+
+	err := row.Scan(
+		&sport.Code,
+		&sport.DisplayName,
+		&formats,
+		&rounds,
+		&setRules,
+		&sport.ForfeitScore,
+		&placementPoints,
+		&sport.SortOrder,
+		&sport.IsActive,
+		&sport.CreatedAt,
+		&sport.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(formats), &sport.Formats); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(rounds), &sport.Rounds); err != nil {
+		return nil, err
+	}
+	if setRules.Valid {
+		sport.SetRules = &models.SetRules{}
+		if err := json.Unmarshal([]byte(setRules.String), sport.SetRules); err != nil {
+			return nil, err
+		}
+	}
+	if placementPoints.Valid {
+		if err := json.Unmarshal([]byte(placementPoints.String), &sport.PlacementPoints); err != nil {
+			return nil, err
+		}
+	}
+
+	return sport, nil
+}
@@ -13,13 +13,17 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, team *models.Team) error
 	GetByID(ctx context.Context, id uint) (*models.Team, error)
-	GetByName(ctx context.Context, name string) (*models.Team, error)
+	GetByTournamentAndName(ctx context.Context, tournamentID uint, name string) (*models.Team, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*models.Team, error)
 	GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Team, error)
 	Update(ctx context.Context, team *models.Team) error
 	Delete(ctx context.Context, id uint) error
+	CountMatches(ctx context.Context, id uint) (int, error)
 }
 
+// teamColumns はチームテーブルのSELECT対象カラム
+const teamColumns = `id, tournament_id, name, description, created_at, updated_at`
+
 // teamRepository implements TeamRepository
 type teamRepository struct {
 	base BaseRepository
@@ -30,7 +34,7 @@ func NewTeamRepository(db *database.DB) TeamRepository {
 	if db == nil {
 		log.Fatal("データベース接続がnilです")
 	}
-	
+
 	baseRepo := NewBaseRepository(db)
 	return &teamRepository{
 		base: baseRepo,
@@ -40,176 +44,174 @@ func NewTeamRepository(db *database.DB) TeamRepository {
 // Create creates a new team
 func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
 	query := `
-		INSERT INTO teams (name, description, tournament_id, created_at, updated_at)
+		INSERT INTO teams (tournament_id, name, description, created_at, updated_at)
 		VALUES (?, ?, ?, NOW(), NOW())
 	`
-	
+
 	result, err := r.base.ExecQuery(query,
+		team.TournamentID,
 		team.Name,
 		team.Description,
-		team.TournamentID,
 	)
-	
 	if err != nil {
 		return err
 	}
-	
+
 	id, err := result.LastInsertId()
 	if err != nil {
 		return err
 	}
-	
+
 	team.ID = uint(id)
 	return nil
 }
 
-// GetByID retrieves a team by ID
+// GetByID retrieves a team by ID (nil when not found)
 func (r *teamRepository) GetByID(ctx context.Context, id uint) (*models.Team, error) {
 	query := `
-		SELECT id, name, description, tournament_id, created_at, updated_at
+		SELECT ` + teamColumns + `
 		FROM teams
 		WHERE id = ?
 	`
-	
-	row := r.base.QueryRow(query, id)
-	
-	team := &models.Team{}
-	err := row.Scan(
-		&team.ID,
-		&team.Name,
-		&team.Description,
-		&team.TournamentID,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
-	
+
+	team, err := r.scanTeam(r.base.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	
+
 	return team, nil
 }
 
-// GetByName retrieves a team by name
-func (r *teamRepository) GetByName(ctx context.Context, name string) (*models.Team, error) {
+// GetByTournamentAndName retrieves the team registered in a tournament under a name (nil when not found)
+func (r *teamRepository) GetByTournamentAndName(ctx context.Context, tournamentID uint, name string) (*models.Team, error) {
 	query := `
-		SELECT id, name, description, tournament_id, created_at, updated_at
+		SELECT ` + teamColumns + `
 		FROM teams
-		WHERE name = ?
+		WHERE tournament_id = ? AND name = ?
 	`
-	
-	row := r.base.QueryRow(query, name)
-	
-	team := &models.Team{}
-	err := row.Scan(
-		&team.ID,
-		&team.Name,
-		&team.Description,
-		&team.TournamentID,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
-	
+
+	team, err := r.scanTeam(r.base.QueryRow(query, tournamentID, name))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	
+
 	return team, nil
 }
 
 // GetAll retrieves all teams with pagination
 func (r *teamRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Team, error) {
 	query := `
-		SELECT id, name, description, tournament_id, created_at, updated_at
+		SELECT ` + teamColumns + `
 		FROM teams
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
-	
+
 	rows, err := r.base.Query(query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	
+
 	return r.scanTeams(rows)
 }
 
 // GetByTournamentID retrieves teams by tournament ID
 func (r *teamRepository) GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Team, error) {
 	query := `
-		SELECT id, name, description, tournament_id, created_at, updated_at
+		SELECT ` + teamColumns + `
 		FROM teams
 		WHERE tournament_id = ?
 		ORDER BY name ASC
 	`
-	
+
 	rows, err := r.base.Query(query, tournamentID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	
+
 	return r.scanTeams(rows)
 }
 
-// Update updates an existing team
+// Update updates a team. Matches reference the team by ID and show its current
+// name, so a rename leaves the match history, result corrections and the
+// recorded draw unchanged.
 func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
 	query := `
 		UPDATE teams
-		SET name = ?, description = ?, tournament_id = ?, updated_at = NOW()
+		SET name = ?, description = ?, updated_at = NOW()
 		WHERE id = ?
 	`
-	
-	_, err := r.base.ExecQuery(query,
-		team.Name,
-		team.Description,
-		team.TournamentID,
-		team.ID,
-	)
-	
+
+	_, err := r.base.ExecQuery(query, team.Name, team.Description, team.ID)
 	return err
 }
 
 // Delete deletes a team
 func (r *teamRepository) Delete(ctx context.Context, id uint) error {
 	query := `DELETE FROM teams WHERE id = ?`
-	
+
 	_, err := r.base.ExecQuery(query, id)
 	return err
 }
 
+// CountMatches counts the matches that reference a team
+func (r *teamRepository) CountMatches(ctx context.Context, id uint) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM matches
+		WHERE team1_id = ? OR team2_id = ? OR winner_id = ? OR forfeiting_team_id = ?
+	`
+
+	var count int
+	if err := r.base.QueryRow(query, id, id, id, id).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// scanTeam scans a single team row
+func (r *teamRepository) scanTeam(row rowScanner) (*models.Team, error) {
+	team := &models.Team{}
+
+	err := row.Scan(
+		&team.ID,
+		&team.TournamentID,
+		&team.Name,
+		&team.Description,
+		&team.CreatedAt,
+		&team.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
 // scanTeams scans multiple team rows
 func (r *teamRepository) scanTeams(rows *sql.Rows) ([]*models.Team, error) {
 	var teams []*models.Team
-	
+
 	for rows.Next() {
-		team := &models.Team{}
-		err := rows.Scan(
-			&team.ID,
-			&team.Name,
-			&team.Description,
-			&team.TournamentID,
-			&team.CreatedAt,
-			&team.UpdatedAt,
-		)
-		
+		team, err := r.scanTeam(rows)
 		if err != nil {
 			return nil, err
 		}
-		
+
 		teams = append(teams, team)
 	}
-	
+
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	
+
 	return teams, nil
-}
\ No newline at end of file
+}
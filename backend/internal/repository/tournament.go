@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log"
 
 	"backend/internal/database"
@@ -17,8 +18,14 @@ type TournamentRepository interface {
 	GetAll(ctx context.Context, limit, offset int) ([]*models.Tournament, error)
 	GetByStatus(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error)
 	GetBySport(ctx context.Context, sport string, limit, offset int) ([]*models.Tournament, error)
+	GetByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error)
+	GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error)
 	Update(ctx context.Context, tournament *models.Tournament) error
 	Delete(ctx context.Context, id uint) error
+	SaveDraw(ctx context.Context, tournamentID uint, draw *models.DrawResult) error
+	GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error)
+	SaveLeagueRules(ctx context.Context, tournamentID uint, rules *models.LeagueRules) error
+	GetLeagueRules(ctx context.Context, tournamentID uint) (*models.LeagueRules, error)
 }
 
 // tournamentRepository implements TournamentRepository
@@ -41,11 +48,12 @@ func NewTournamentRepository(db *database.DB) TournamentRepository {
 // Create creates a new tournament
 func (r *tournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
 	query := `
-		INSERT INTO tournaments (sport, format, status, created_at, updated_at)
-		VALUES (?, ?, ?, NOW(), NOW())
+		INSERT INTO tournaments (event_id, sport, format, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
 	`
 	
 	result, err := r.base.ExecQuery(query,
+		tournament.EventID,
 		tournament.Sport,
 		tournament.Format,
 		tournament.Status,
@@ -67,7 +75,7 @@ func (r *tournamentRepository) Create(ctx context.Context, tournament *models.To
 // GetByID retrieves a tournament by ID
 func (r *tournamentRepository) GetByID(ctx context.Context, id uint) (*models.Tournament, error) {
 	query := `
-		SELECT id, sport, format, status, created_at, updated_at
+		SELECT id, event_id, sport, format, status, created_at, updated_at
 		FROM tournaments
 		WHERE id = ?
 	`
@@ -77,6 +85,7 @@ func (r *tournamentRepository) GetByID(ctx context.Context, id uint) (*models.To
 	tournament := &models.Tournament{}
 	err := row.Scan(
 		&tournament.ID,
+		&tournament.EventID,
 		&tournament.Sport,
 		&tournament.Format,
 		&tournament.Status,
@@ -97,7 +106,7 @@ func (r *tournamentRepository) GetByID(ctx context.Context, id uint) (*models.To
 // GetByName retrieves a tournament by name (using sport as identifier)
 func (r *tournamentRepository) GetByName(ctx context.Context, name string) (*models.Tournament, error) {
 	query := `
-		SELECT id, sport, format, status, created_at, updated_at
+		SELECT id, event_id, sport, format, status, created_at, updated_at
 		FROM tournaments
 		WHERE sport = ?
 		LIMIT 1
@@ -108,6 +117,7 @@ func (r *tournamentRepository) GetByName(ctx context.Context, name string) (*mod
 	tournament := &models.Tournament{}
 	err := row.Scan(
 		&tournament.ID,
+		&tournament.EventID,
 		&tournament.Sport,
 		&tournament.Format,
 		&tournament.Status,
@@ -128,7 +138,7 @@ func (r *tournamentRepository) GetByName(ctx context.Context, name string) (*mod
 // GetAll retrieves all tournaments with pagination
 func (r *tournamentRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Tournament, error) {
 	query := `
-		SELECT id, sport, format, status, created_at, updated_at
+		SELECT id, event_id, sport, format, status, created_at, updated_at
 		FROM tournaments
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
@@ -146,7 +156,7 @@ func (r *tournamentRepository) GetAll(ctx context.Context, limit, offset int) ([
 // GetByStatus retrieves tournaments by status with pagination
 func (r *tournamentRepository) GetByStatus(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error) {
 	query := `
-		SELECT id, sport, format, status, created_at, updated_at
+		SELECT id, event_id, sport, format, status, created_at, updated_at
 		FROM tournaments
 		WHERE status = ?
 		ORDER BY created_at DESC
@@ -166,11 +176,12 @@ func (r *tournamentRepository) GetByStatus(ctx context.Context, status string, l
 func (r *tournamentRepository) Update(ctx context.Context, tournament *models.Tournament) error {
 	query := `
 		UPDATE tournaments
-		SET sport = ?, format = ?, status = ?, updated_at = NOW()
+		SET event_id = ?, sport = ?, format = ?, status = ?, updated_at = NOW()
 		WHERE id = ?
 	`
 	
 	_, err := r.base.ExecQuery(query,
+		tournament.EventID,
 		tournament.Sport,
 		tournament.Format,
 		tournament.Status,
@@ -180,10 +191,135 @@ func (r *tournamentRepository) Update(ctx context.Context, tournament *models.To
 	return err
 }
 
-// GetBySport retrieves tournaments by sport with pagination
+// SaveDraw records the draw (options, random seed and pairings) of a tournament
+func (r *tournamentRepository) SaveDraw(ctx context.Context, tournamentID uint, draw *models.DrawResult) error {
+	data, err := json.Marshal(draw)
+	if err != nil {
+		return err
+	}
+	
+	query := `
+		UPDATE tournaments
+		SET draw_seed = ?, draw_result = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	
+	_, err = r.base.ExecQuery(query, draw.Options.RandomSeed, string(data), tournamentID)
+	return err
+}
+
+// GetDraw retrieves the recorded draw of a tournament
+func (r *tournamentRepository) GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error) {
+	query := `SELECT draw_result FROM tournaments WHERE id = ?`
+	
+	var data sql.NullString
+	if err := r.base.QueryRow(query, tournamentID).Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !data.Valid {
+		return nil, nil
+	}
+	
+	draw := &models.DrawResult{}
+	if err := json.Unmarshal([]byte(data.String), draw); err != nil {
+		return nil, err
+	}
+	
+	return draw, nil
+}
+
+// SaveLeagueRules records the points and tiebreakers used for the standings
+func (r *tournamentRepository) SaveLeagueRules(ctx context.Context, tournamentID uint, rules *models.LeagueRules) error {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	
+	query := `UPDATE tournaments SET league_rules = ?, updated_at = NOW() WHERE id = ?`
+	
+	_, err = r.base.ExecQuery(query, string(data), tournamentID)
+	return err
+}
+
+// GetLeagueRules retrieves the standings rules of a tournament (nil when not configured)
+func (r *tournamentRepository) GetLeagueRules(ctx context.Context, tournamentID uint) (*models.LeagueRules, error) {
+	query := `SELECT league_rules FROM tournaments WHERE id = ?`
+	
+	var data sql.NullString
+	if err := r.base.QueryRow(query, tournamentID).Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !data.Valid {
+		return nil, nil
+	}
+	
+	rules := &models.LeagueRules{}
+	if err := json.Unmarshal([]byte(data.String), rules); err != nil {
+		return nil, err
+	}
+	
+	return rules, nil
+}
+
+// GetByEvent retrieves the tournaments of an event
+func (r *tournamentRepository) GetByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error) {
+	query := `
+		SELECT id, event_id, sport, format, status, created_at, updated_at
+		FROM tournaments
+		WHERE event_id = ?
+		ORDER BY id ASC
+	`
+	
+	rows, err := r.base.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	return r.scanTournaments(rows)
+}
+
+// GetByEventAndSport retrieves the tournament of a sport in an event (nil when not found)
+func (r *tournamentRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	query := `
+		SELECT id, event_id, sport, format, status, created_at, updated_at
+		FROM tournaments
+		WHERE event_id = ? AND sport = ?
+	`
+	
+	row := r.base.QueryRow(query, eventID, sport)
+	
+	tournament := &models.Tournament{}
+	err := row.Scan(
+		&tournament.ID,
+		&tournament.EventID,
+		&tournament.Sport,
+		&tournament.Format,
+		&tournament.Status,
+		&tournament.CreatedAt,
+		&tournament.UpdatedAt,
+	)
+	
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	
+	return tournament, nil
+}
+
+// GetBySport retrieves tournaments by sport across all events with pagination
 func (r *tournamentRepository) GetBySport(ctx context.Context, sport string, limit, offset int) ([]*models.Tournament, error) {
 	query := `
-		SELECT id, sport, format, status, created_at, updated_at
+		SELECT id, event_id, sport, format, status, created_at, updated_at
 		FROM tournaments
 		WHERE sport = ?
 		ORDER BY created_at DESC
@@ -215,6 +351,7 @@ func (r *tournamentRepository) scanTournaments(rows *sql.Rows) ([]*models.Tourna
 		tournament := &models.Tournament{}
 		err := rows.Scan(
 			&tournament.ID,
+			&tournament.EventID,
 			&tournament.Sport,
 			&tournament.Format,
 			&tournament.Status,
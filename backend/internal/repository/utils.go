@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -103,7 +104,13 @@ func NewTransactionManager(baseRepo BaseRepository) *TransactionManager {
 
 // ExecuteInTransaction はトランザクション内で操作を実行する
 func (tm *TransactionManager) ExecuteInTransaction(operation func(*sql.Tx) error) error {
-	tx, err := tm.baseRepo.BeginTx()
+	return tm.ExecuteInTransactionContext(context.Background(), operation)
+}
+
+// ExecuteInTransactionContext はコンテキスト付きのトランザクション内で操作を実行する
+// コンテキストがキャンセルされた場合、トランザクションはロールバックされる
+func (tm *TransactionManager) ExecuteInTransactionContext(ctx context.Context, operation func(*sql.Tx) error) error {
+	tx, err := tm.baseRepo.BeginTxContext(ctx)
 	if err != nil {
 		return fmt.Errorf("トランザクション開始エラー: %w", err)
 	}
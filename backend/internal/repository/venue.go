@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"backend/internal/database"
+	"backend/internal/models"
+)
+
+// VenueRepository defines the interface for venue and court data operations
+type VenueRepository interface {
+	Create(ctx context.Context, venue *models.Venue) error
+	GetByID(ctx context.Context, id uint) (*models.Venue, error)
+	GetByEvent(ctx context.Context, eventID int) ([]*models.Venue, error)
+	Update(ctx context.Context, venue *models.Venue) error
+	Delete(ctx context.Context, id uint) error
+
+	// コートの操作
+	CreateCourt(ctx context.Context, court *models.Court) error
+	GetCourt(ctx context.Context, id uint) (*models.Court, error)
+	GetCourtsByEvent(ctx context.Context, eventID int) ([]*models.Court, error)
+	UpdateCourt(ctx context.Context, court *models.Court) error
+	DeleteCourt(ctx context.Context, id uint) error
+}
+
+// venueColumns は会場テーブルのSELECT対象カラム
+const venueColumns = `id, event_id, name, sort_order, created_at, updated_at`
+
+// courtColumns はコートテーブルのSELECT対象カラム
+const courtColumns = `c.id, c.venue_id, c.name, c.sports, c.sort_order, c.created_at, c.updated_at`
+
+// venueRepository implements VenueRepository
+type venueRepository struct {
+	base BaseRepository
+}
+
+// NewVenueRepository creates a new venue repository
+func NewVenueRepository(db *database.DB) VenueRepository {
+	if db == nil {
+		log.Fatal("データベース接続がnilです")
+	}
+
+	baseRepo := NewBaseRepository(db)
+	return &venueRepository{
+		base: baseRepo,
+	}
+}
+
+// Create creates a new venue
+func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	query := `
+		INSERT INTO venues (event_id, name, sort_order, created_at, updated_at)
+		VALUES (?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.base.ExecQuery(query, venue.EventID, venue.Name, venue.SortOrder)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	venue.ID = uint(id)
+	return nil
+}
+
+// GetByID retrieves a venue with its courts (nil when not found)
+func (r *venueRepository) GetByID(ctx context.Context, id uint) (*models.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE id = ?
+	`
+
+	venue, err := r.scanVenue(r.base.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := r.loadCourts(ctx, venue.EventID, []*models.Venue{venue}); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// GetByEvent retrieves the venues of an event with their courts in display order
+func (r *venueRepository) GetByEvent(ctx context.Context, eventID int) ([]*models.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE event_id = ?
+		ORDER BY sort_order ASC, id ASC
+	`
+
+	rows, err := r.base.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var venues []*models.Venue
+	for rows.Next() {
+		venue, err := r.scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		venues = append(venues, venue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadCourts(ctx, eventID, venues); err != nil {
+		return nil, err
+	}
+
+	return venues, nil
+}
+
+// Update updates an existing venue
+func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error {
+	query := `
+		UPDATE venues
+		SET name = ?, sort_order = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.base.ExecQuery(query, venue.Name, venue.SortOrder, venue.ID)
+	return err
+}
+
+// Delete deletes a venue together with its courts
+func (r *venueRepository) Delete(ctx context.Context, id uint) error {
+	query := `DELETE FROM venues WHERE id = ?`
+
+	_, err := r.base.ExecQuery(query, id)
+	return err
+}
+
+// CreateCourt creates a new court
+func (r *venueRepository) CreateCourt(ctx context.Context, court *models.Court) error {
+	sports, err := json.Marshal(court.Sports)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO courts (venue_id, name, sports, sort_order, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.base.ExecQuery(query, court.VenueID, court.Name, string(sports), court.SortOrder)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	court.ID = uint(id)
+	return nil
+}
+
+// GetCourt retrieves a court by ID (nil when not found)
+func (r *venueRepository) GetCourt(ctx context.Context, id uint) (*models.Court, error) {
+	query := `
+		SELECT ` + courtColumns + `
+		FROM courts c
+		WHERE c.id = ?
+	`
+
+	court, err := r.scanCourt(r.base.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return court, nil
+}
+
+// GetCourtsByEvent retrieves every court of an event ordered by venue and court display order
+func (r *venueRepository) GetCourtsByEvent(ctx context.Context, eventID int) ([]*models.Court, error) {
+	query := `
+		SELECT ` + courtColumns + `
+		FROM courts c
+		JOIN venues v ON v.id = c.venue_id
+		WHERE v.event_id = ?
+		ORDER BY v.sort_order ASC, v.id ASC, c.sort_order ASC, c.id ASC
+	`
+
+	rows, err := r.base.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courts []*models.Court
+	for rows.Next() {
+		court, err := r.scanCourt(rows)
+		if err != nil {
+			return nil, err
+		}
+		courts = append(courts, court)
+	}
+
+	return courts, rows.Err()
+}
+
+// UpdateCourt updates an existing court
+func (r *venueRepository) UpdateCourt(ctx context.Context, court *models.Court) error {
+	sports, err := json.Marshal(court.Sports)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE courts
+		SET name = ?, sports = ?, sort_order = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err = r.base.ExecQuery(query, court.Name, string(sports), court.SortOrder, court.ID)
+	return err
+}
+
+// DeleteCourt deletes a court; matches assigned to it become unassigned
+func (r *venueRepository) DeleteCourt(ctx context.Context, id uint) error {
+	query := `DELETE FROM courts WHERE id = ?`
+
+	_, err := r.base.ExecQuery(query, id)
+	return err
+}
+
+// loadCourts attaches the courts of an event to the given venues
+func (r *venueRepository) loadCourts(ctx context.Context, eventID int, venues []*models.Venue) error {
+	if len(venues) == 0 {
+		return nil
+	}
+
+	courts, err := r.GetCourtsByEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Venue, len(venues))
+	for _, venue := range venues {
+		venue.Courts = []*models.Court{}
+		byID[venue.ID] = venue
+	}
+	for _, court := range courts {
+		if venue := byID[court.VenueID]; venue != nil {
+			venue.Courts = append(venue.Courts, court)
+		}
+	}
+
+	return nil
+}
+
+// scanVenue scans a single venue row
+func (r *venueRepository) scanVenue(row rowScanner) (*models.Venue, error) {
+	venue := &models.Venue{}
+
+	err := row.Scan(
+		&venue.ID,
+		&venue.EventID,
+		&venue.Name,
+		&venue.SortOrder,
+		&venue.CreatedAt,
+		&venue.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// scanCourt scans a single court row
+func (r *venueRepository) scanCourt(row rowScanner) (*models.Court, error) {
+	court := &models.Court{}
+	var sports string
+
+	err := row.Scan(
+		&court.ID,
+		&court.VenueID,
+		&court.Name,
+		&sports,
+		&court.SortOrder,
+		&court.CreatedAt,
+		&court.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(sports), &court.Sports); err != nil {
+		return nil, err
+	}
+
+	return court, nil
+}
@@ -7,6 +7,7 @@ import (
 	"backend/internal/handler"
 	"backend/internal/logger"
 	"backend/internal/middleware"
+	"backend/internal/models"
 	"backend/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -22,12 +23,19 @@ type Router struct {
 
 // Handlers は全てのハンドラーをまとめる構造体
 type Handlers struct {
-	AuthHandler       *handler.AuthHandler
-	TournamentHandler *handler.TournamentHandler
-	MatchHandler      *handler.MatchHandler
-	WebSocketHandler  *handler.WebSocketHandler
-	PollingHandler    *handler.PollingHandler
-	AlertHandler      *handler.AlertHandler
+	AuthHandler         *handler.AuthHandler
+	TournamentHandler   *handler.TournamentHandler
+	MatchHandler        *handler.MatchHandler
+	WebSocketHandler    *handler.WebSocketHandler
+	PollingHandler      *handler.PollingHandler
+	AlertHandler        *handler.AlertHandler
+	SportHandler        *handler.SportHandler
+	EventHandler        *handler.EventHandler
+	TeamHandler         *handler.TeamHandler
+	PlayerHandler       *handler.PlayerHandler
+	ChampionshipHandler *handler.ChampionshipHandler
+	VenueHandler        *handler.VenueHandler
+	ScheduleHandler     *handler.ScheduleHandler
 }
 
 // NewRouter は新しいルーターを作成する
@@ -35,6 +43,13 @@ func NewRouter(
 	authService service.AuthService,
 	tournamentService service.TournamentService,
 	matchService service.MatchService,
+	sportService service.SportService,
+	eventService service.EventService,
+	teamService service.TeamService,
+	playerService service.PlayerService,
+	championshipService service.ChampionshipService,
+	venueService service.VenueService,
+	scheduleService service.ScheduleService,
 	wsHandler *handler.WebSocketHandler,
 	pollingHandler *handler.PollingHandler,
 	alertHandler *handler.AlertHandler,
@@ -44,12 +59,19 @@ func NewRouter(
 
 	// ハンドラーを初期化
 	handlers := &Handlers{
-		AuthHandler:       handler.NewAuthHandler(authService),
-		TournamentHandler: handler.NewTournamentHandler(tournamentService),
-		MatchHandler:      handler.NewMatchHandler(matchService),
-		WebSocketHandler:  wsHandler,
-		PollingHandler:    pollingHandler,
-		AlertHandler:      alertHandler,
+		AuthHandler:         handler.NewAuthHandler(authService),
+		TournamentHandler:   handler.NewTournamentHandler(tournamentService),
+		MatchHandler:        handler.NewMatchHandler(matchService),
+		WebSocketHandler:    wsHandler,
+		PollingHandler:      pollingHandler,
+		AlertHandler:        alertHandler,
+		SportHandler:        handler.NewSportHandler(sportService),
+		EventHandler:        handler.NewEventHandler(eventService),
+		TeamHandler:         handler.NewTeamHandler(teamService),
+		PlayerHandler:       handler.NewPlayerHandler(playerService),
+		ChampionshipHandler: handler.NewChampionshipHandler(championshipService),
+		VenueHandler:        handler.NewVenueHandler(venueService),
+		ScheduleHandler:     handler.NewScheduleHandler(scheduleService),
 	}
 
 	router := &Router{
@@ -86,6 +108,23 @@ func (r *Router) setupMiddleware() {
 
 	// レート制限ミドルウェア（認証エンドポイント用）
 	r.engine.Use(r.rateLimitMiddleware())
+
+	// 種目パラメータの検証ミドルウェア
+	r.engine.Use(r.sportParamMiddleware())
+}
+
+// sportParamMiddleware はパスの種目（:sport）が種目のレジストリに登録されているかを検証する
+// 登録されていない種目へのリクエストはハンドラーに渡さず404を返す
+func (r *Router) sportParamMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sport := c.Param("sport")
+		if sport != "" && !models.SportType(sport).IsValid() {
+			c.Error(errors.NewNotFoundError("種目 " + sport))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
 
 // rateLimitMiddleware は認証エンドポイント用のレート制限を実装する
@@ -155,6 +194,59 @@ func (r *Router) setupPublicRoutes(api *gin.RouterGroup) {
 		publicTournaments.GET("/sport/:sport", r.handlers.TournamentHandler.GetTournamentBySport) // GET /public/tournaments/sport/{sport}
 		publicTournaments.GET("/sport/:sport/bracket", r.handlers.TournamentHandler.GetTournamentBracket) // GET /public/tournaments/sport/{sport}/bracket
 		publicTournaments.GET("/sport/:sport/progress", r.handlers.TournamentHandler.GetTournamentProgress) // GET /public/tournaments/sport/{sport}/progress
+		publicTournaments.GET("/:id/standings", r.handlers.TournamentHandler.GetTournamentStandings)       // GET /public/tournaments/{id}/standings
+		publicTournaments.GET("/:id/teams", r.handlers.TeamHandler.GetTournamentTeams)                    // GET /public/tournaments/{id}/teams
+		publicTournaments.GET("/:id/leaderboard", r.handlers.PlayerHandler.GetTournamentLeaderboard)      // GET /public/tournaments/{id}/leaderboard
+		publicTournaments.GET("/:id/placements", r.handlers.ChampionshipHandler.GetTournamentPlacements)  // GET /public/tournaments/{id}/placements
+	}
+
+	// 公開チーム情報（認証不要）
+	publicTeams := api.Group("/public/teams")
+	{
+		publicTeams.GET("/:id", r.handlers.TeamHandler.GetTeam)                   // GET /public/teams/{id}
+		publicTeams.GET("/:id/players", r.handlers.PlayerHandler.GetTeamRoster) // GET /public/teams/{id}/players
+	}
+
+	// 公開大会情報（認証不要）
+	// 大会IDを含むパスは指定した大会（過去のアーカイブを含む）の種目別データを返す
+	publicEvents := api.Group("/public/events")
+	{
+		publicEvents.GET("", r.handlers.EventHandler.GetEvents)                                                      // GET /public/events
+		publicEvents.GET("/current", r.handlers.EventHandler.GetCurrentEvent)                                         // GET /public/events/current
+		publicEvents.GET("/:event_id", r.handlers.EventHandler.GetEvent)                                              // GET /public/events/{event_id}
+		publicEvents.GET("/:event_id/tournaments", r.handlers.EventHandler.GetEventTournaments)                       // GET /public/events/{event_id}/tournaments
+		publicEvents.GET("/:event_id/tournaments/sport/:sport", r.handlers.TournamentHandler.GetTournamentBySport)     // GET /public/events/{event_id}/tournaments/sport/{sport}
+		publicEvents.GET("/:event_id/tournaments/sport/:sport/bracket", r.handlers.TournamentHandler.GetTournamentBracket)   // GET /public/events/{event_id}/tournaments/sport/{sport}/bracket
+		publicEvents.GET("/:event_id/tournaments/sport/:sport/progress", r.handlers.TournamentHandler.GetTournamentProgress) // GET /public/events/{event_id}/tournaments/sport/{sport}/progress
+		publicEvents.GET("/:event_id/matches/sport/:sport", r.handlers.MatchHandler.GetMatchesBySport)                // GET /public/events/{event_id}/matches/sport/{sport}
+		publicEvents.GET("/:event_id/leaderboard", r.handlers.PlayerHandler.GetEventLeaderboard)                      // GET /public/events/{event_id}/leaderboard
+		publicEvents.GET("/:event_id/championship", r.handlers.ChampionshipHandler.GetChampionship)                   // GET /public/events/{event_id}/championship
+		publicEvents.GET("/:event_id/venues", r.handlers.VenueHandler.GetVenues)                                      // GET /public/events/{event_id}/venues
+		publicEvents.GET("/:event_id/courts", r.handlers.VenueHandler.GetCourtBoards)                                 // GET /public/events/{event_id}/courts
+		publicEvents.GET("/:event_id/calendar.ics", r.handlers.ScheduleHandler.GetCalendar)                           // GET /public/events/{event_id}/calendar.ics
+	}
+
+	// 公開総合順位（認証不要、今年度の大会）
+	api.GET("/public/championship", r.handlers.ChampionshipHandler.GetChampionship) // GET /public/championship
+
+	// 公開会場・コート（認証不要、今年度の大会）
+	api.GET("/public/venues", r.handlers.VenueHandler.GetVenues) // GET /public/venues
+
+	// 公開カレンダー（認証不要、今年度の大会）
+	api.GET("/public/calendar.ics", r.handlers.ScheduleHandler.GetCalendar) // GET /public/calendar.ics
+
+	// 公開コートの試合状況（認証不要、今年度の大会）
+	publicCourts := api.Group("/public/courts")
+	{
+		publicCourts.GET("", r.handlers.VenueHandler.GetCourtBoards)          // GET /public/courts
+		publicCourts.GET("/:id/board", r.handlers.VenueHandler.GetCourtBoard) // GET /public/courts/{id}/board
+	}
+
+	// 公開種目情報（認証不要）
+	publicSports := api.Group("/public/sports")
+	{
+		publicSports.GET("", r.handlers.SportHandler.GetSports)        // GET /public/sports
+		publicSports.GET("/:sport", r.handlers.SportHandler.GetSport) // GET /public/sports/{sport}
 	}
 
 	// 公開試合情報（認証不要）
@@ -163,6 +255,7 @@ func (r *Router) setupPublicRoutes(api *gin.RouterGroup) {
 		publicMatches.GET("/sport/:sport", r.handlers.MatchHandler.GetMatchesBySport)                // GET /public/matches/sport/{sport}
 		publicMatches.GET("/tournament/:tournament_id", r.handlers.MatchHandler.GetMatchesByTournament) // GET /public/matches/tournament/{tournament_id}
 		publicMatches.GET("/tournament/:tournament_id/next", r.handlers.MatchHandler.GetNextMatches) // GET /public/matches/tournament/{tournament_id}/next
+		publicMatches.GET("/:id/events", r.handlers.MatchHandler.GetMatchTimeline)                  // GET /public/matches/{id}/events
 	}
 }
 
@@ -194,6 +287,22 @@ func (r *Router) setupProtectedRoutes(api *gin.RouterGroup) {
 	admin.Use(authMiddleware.RequireAuth())
 	admin.Use(authMiddleware.RequireAdmin())
 
+	// 種目関連ルート（管理者専用）
+	r.setupSportRoutes(admin)
+
+	// 大会関連ルート（管理者専用）
+	r.setupEventRoutes(admin)
+
+	// チーム関連ルート（管理者専用）
+	r.setupTeamRoutes(admin)
+
+	// 選手・名簿・試合のMVP関連ルート（管理者専用）
+	r.setupPlayerRoutes(admin)
+
+	// 会場・コート・試合日程関連ルート（管理者専用）
+	r.setupVenueRoutes(admin)
+	r.setupScheduleRoutes(admin)
+
 	// トーナメント関連ルート
 	r.setupTournamentRoutes(protected, admin, authMiddleware)
 
@@ -247,6 +356,80 @@ func (r *Router) setupPollingRoutes(api *gin.RouterGroup) {
 	}
 }
 
+// setupSportRoutes は種目の登録・更新ルートを設定する（管理者専用）
+func (r *Router) setupSportRoutes(admin *gin.RouterGroup) {
+	adminSports := admin.Group("/sports")
+	{
+		adminSports.POST("", r.handlers.SportHandler.CreateSport)          // POST /admin/sports
+		adminSports.PUT("/:sport", r.handlers.SportHandler.UpdateSport)   // PUT /admin/sports/{sport}
+	}
+}
+
+// setupEventRoutes は大会の登録・更新ルートを設定する（管理者専用）
+func (r *Router) setupEventRoutes(admin *gin.RouterGroup) {
+	adminEvents := admin.Group("/events")
+	{
+		adminEvents.POST("", r.handlers.EventHandler.CreateEvent)           // POST /admin/events
+		adminEvents.PUT("/:event_id", r.handlers.EventHandler.UpdateEvent) // PUT /admin/events/{event_id}
+	}
+}
+
+// setupTeamRoutes はチームの登録・更新・削除ルートを設定する（管理者専用）
+func (r *Router) setupTeamRoutes(admin *gin.RouterGroup) {
+	admin.POST("/tournaments/:id/teams", r.handlers.TeamHandler.RegisterTeam) // POST /admin/tournaments/{id}/teams
+
+	adminTeams := admin.Group("/teams")
+	{
+		adminTeams.PUT("/:id", r.handlers.TeamHandler.UpdateTeam)    // PUT /admin/teams/{id}
+		adminTeams.DELETE("/:id", r.handlers.TeamHandler.DeleteTeam) // DELETE /admin/teams/{id}
+	}
+}
+
+// setupPlayerRoutes は選手・名簿・試合のMVPのルートを設定する（管理者専用）
+func (r *Router) setupPlayerRoutes(admin *gin.RouterGroup) {
+	adminPlayers := admin.Group("/players")
+	{
+		adminPlayers.GET("", r.handlers.PlayerHandler.ListPlayers)          // GET /admin/players
+		adminPlayers.POST("", r.handlers.PlayerHandler.CreatePlayer)        // POST /admin/players
+		adminPlayers.GET("/:id", r.handlers.PlayerHandler.GetPlayer)        // GET /admin/players/{id}
+		adminPlayers.PUT("/:id", r.handlers.PlayerHandler.UpdatePlayer)     // PUT /admin/players/{id}
+		adminPlayers.DELETE("/:id", r.handlers.PlayerHandler.DeletePlayer) // DELETE /admin/players/{id}
+	}
+
+	admin.POST("/teams/:id/players", r.handlers.PlayerHandler.AddToRoster)                  // POST /admin/teams/{id}/players
+	admin.DELETE("/teams/:id/players/:player_id", r.handlers.PlayerHandler.RemoveFromRoster) // DELETE /admin/teams/{id}/players/{player_id}
+	admin.PUT("/matches/:id/mvp", r.handlers.PlayerHandler.SetMatchMVP)                      // PUT /admin/matches/{id}/mvp
+	admin.DELETE("/matches/:id/mvp", r.handlers.PlayerHandler.ClearMatchMVP)                 // DELETE /admin/matches/{id}/mvp
+}
+
+// setupVenueRoutes は会場・コートの登録・更新・削除ルートを設定する（管理者専用）
+func (r *Router) setupVenueRoutes(admin *gin.RouterGroup) {
+	admin.POST("/events/:event_id/venues", r.handlers.VenueHandler.CreateVenue) // POST /admin/events/{event_id}/venues
+
+	adminVenues := admin.Group("/venues")
+	{
+		adminVenues.PUT("/:id", r.handlers.VenueHandler.UpdateVenue)         // PUT /admin/venues/{id}
+		adminVenues.DELETE("/:id", r.handlers.VenueHandler.DeleteVenue)      // DELETE /admin/venues/{id}
+		adminVenues.POST("/:id/courts", r.handlers.VenueHandler.CreateCourt) // POST /admin/venues/{id}/courts
+	}
+
+	adminCourts := admin.Group("/courts")
+	{
+		adminCourts.PUT("/:id", r.handlers.VenueHandler.UpdateCourt)    // PUT /admin/courts/{id}
+		adminCourts.DELETE("/:id", r.handlers.VenueHandler.DeleteCourt) // DELETE /admin/courts/{id}
+	}
+}
+
+// setupScheduleRoutes は試合日程の自動作成・後ろ倒し・試合の日程固定・コート割り当てルートを設定する（管理者専用）
+func (r *Router) setupScheduleRoutes(admin *gin.RouterGroup) {
+	admin.GET("/events/:event_id/schedule", r.handlers.ScheduleHandler.GetScheduleOptions)   // GET /admin/events/{event_id}/schedule
+	admin.POST("/events/:event_id/schedule", r.handlers.ScheduleHandler.GenerateSchedule)    // POST /admin/events/{event_id}/schedule
+	admin.POST("/events/:event_id/schedule/shift", r.handlers.ScheduleHandler.ShiftSchedule) // POST /admin/events/{event_id}/schedule/shift
+	admin.PUT("/matches/:id/schedule", r.handlers.ScheduleHandler.PinMatchSchedule)          // PUT /admin/matches/{id}/schedule
+	admin.DELETE("/matches/:id/schedule/pin", r.handlers.ScheduleHandler.UnpinMatchSchedule) // DELETE /admin/matches/{id}/schedule/pin
+	admin.PUT("/matches/:id/court", r.handlers.ScheduleHandler.AssignMatchCourt)             // PUT /admin/matches/{id}/court
+}
+
 // setupTournamentRoutes はトーナメント関連のルートを設定する
 func (r *Router) setupTournamentRoutes(protected *gin.RouterGroup, admin *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
 	// 認証が必要なトーナメント関連ルート（読み取り専用）
@@ -259,6 +442,7 @@ func (r *Router) setupTournamentRoutes(protected *gin.RouterGroup, admin *gin.Ro
 		tournaments.GET("/sport/:sport/bracket", r.handlers.TournamentHandler.GetTournamentBracket) // GET /tournaments/sport/{sport}/bracket
 		tournaments.GET("/sport/:sport/progress", r.handlers.TournamentHandler.GetTournamentProgress) // GET /tournaments/sport/{sport}/progress
 		tournaments.GET("/active", r.handlers.TournamentHandler.GetActiveTournaments)       // GET /tournaments/active
+		tournaments.GET("/:id/standings", r.handlers.TournamentHandler.GetTournamentStandings) // GET /tournaments/{id}/standings
 	}
 
 	// 管理者専用トーナメント関連ルート（作成・更新・削除）
@@ -268,6 +452,11 @@ func (r *Router) setupTournamentRoutes(protected *gin.RouterGroup, admin *gin.Ro
 		adminTournaments.PUT("/:id", r.handlers.TournamentHandler.UpdateTournament)                // PUT /admin/tournaments/{id}
 		adminTournaments.DELETE("/:id", r.handlers.TournamentHandler.DeleteTournament)             // DELETE /admin/tournaments/{id}
 		adminTournaments.PUT("/:id/format", r.handlers.TournamentHandler.SwitchTournamentFormat)   // PUT /admin/tournaments/{id}/format
+		adminTournaments.POST("/:id/draw", r.handlers.TournamentHandler.DrawTournamentBracket)     // POST /admin/tournaments/{id}/draw
+		adminTournaments.GET("/:id/draw", r.handlers.TournamentHandler.GetTournamentDraw)          // GET /admin/tournaments/{id}/draw
+		adminTournaments.PUT("/:id/league-rules", r.handlers.TournamentHandler.UpdateLeagueRules)  // PUT /admin/tournaments/{id}/league-rules
+		adminTournaments.POST("/:id/swiss/next-round", r.handlers.TournamentHandler.GenerateNextSwissRound) // POST /admin/tournaments/{id}/swiss/next-round
+		adminTournaments.PUT("/:id/status", r.handlers.TournamentHandler.ChangeTournamentStatus)   // PUT /admin/tournaments/{id}/status
 		adminTournaments.PUT("/sport/:sport/complete", r.handlers.TournamentHandler.CompleteTournament) // PUT /admin/tournaments/sport/{sport}/complete
 	}
 }
@@ -280,6 +469,7 @@ func (r *Router) setupMatchRoutes(protected *gin.RouterGroup, admin *gin.RouterG
 		// 認証済みユーザーがアクセス可能 - RESTful設計に従った統一パス構造
 		matches.GET("", r.handlers.MatchHandler.GetMatches)                                    // GET /matches
 		matches.GET("/:id", r.handlers.MatchHandler.GetMatch)                                  // GET /matches/{id}
+		matches.GET("/:id/events", r.handlers.MatchHandler.GetMatchTimeline)                   // GET /matches/{id}/events
 		matches.GET("/sport/:sport", r.handlers.MatchHandler.GetMatchesBySport)                // GET /matches/sport/{sport}
 		matches.GET("/tournament/:tournament_id", r.handlers.MatchHandler.GetMatchesByTournament) // GET /matches/tournament/{tournament_id}
 		matches.GET("/tournament/:tournament_id/statistics", r.handlers.MatchHandler.GetMatchStatistics) // GET /matches/tournament/{tournament_id}/statistics
@@ -293,6 +483,10 @@ func (r *Router) setupMatchRoutes(protected *gin.RouterGroup, admin *gin.RouterG
 		adminMatches.PUT("/:id", r.handlers.MatchHandler.UpdateMatch)                      // PUT /admin/matches/{id}
 		adminMatches.DELETE("/:id", r.handlers.MatchHandler.DeleteMatch)                   // DELETE /admin/matches/{id}
 		adminMatches.PUT("/:id/result", r.handlers.MatchHandler.SubmitMatchResult)         // PUT /admin/matches/{id}/result
+		adminMatches.PUT("/:id/correction", r.handlers.MatchHandler.CorrectMatchResult)    // PUT /admin/matches/{id}/correction
+		adminMatches.GET("/:id/corrections", r.handlers.MatchHandler.GetMatchCorrections)  // GET /admin/matches/{id}/corrections
+		adminMatches.POST("/:id/events", r.handlers.MatchHandler.AppendMatchEvent)         // POST /admin/matches/{id}/events
+		adminMatches.PUT("/:id/status", r.handlers.MatchHandler.ChangeMatchStatus)         // PUT /admin/matches/{id}/status
 	}
 }
 
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"testing"
 
-	"backend/internal/config"
 	"backend/internal/models"
 )
 
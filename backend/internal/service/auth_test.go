@@ -218,7 +218,7 @@ func TestAuthService_Login(t *testing.T) {
 			}
 			
 			// ログインを実行
-			token, err := service.Login(tt.username, tt.password)
+			token, _, err := service.Login(tt.username, tt.password)
 			
 			if tt.expectedError {
 				if err == nil {
@@ -576,7 +576,7 @@ func TestAuthService_LoginToValidateFlow(t *testing.T) {
 	}
 	
 	// ログインしてトークンを取得
-	token, err := service.Login(username, password)
+	token, _, err := service.Login(username, password)
 	if err != nil {
 		t.Fatalf("ログインに失敗: %v", err)
 	}
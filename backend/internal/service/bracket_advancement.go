@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// saveWithAdvancement persists a completed match together with the next-round
+// slots its winner (and, for semifinals, its loser) advance into. The bracket is
+// read and written in one transaction that locks the tournament's matches, so
+// two semifinals finishing at the same time both reach the final. from is the
+// status the match had when the result was accepted; a match changed by another
+// request in the meantime is refused with a conflict. The tournament's
+// automatic lifecycle transitions are applied in the same transaction. It
+// returns the tournament's matches reflecting the new state, whether any
+// downstream slot changed and the transitions to announce.
+//
+// When the match completes a group stage, the knockout slots named after group
+// places are filled from the group tables, ranked with the tournament's league
+// rules (or the defaults when tournamentRepo is nil).
+//
+// A double forfeit sends models.TeamWithdrawn into the next-round slots; matches
+// left facing a withdrawn slot are completed as walkovers and advanced in turn.
+func saveWithAdvancement(ctx context.Context, matchRepo repository.MatchRepository, tournamentRepo repository.TournamentRepository, match *models.Match, from models.MatchStatus) ([]*models.Match, bool, []tournamentTransition, error) {
+	var matches, advanced []*models.Match
+	var transitions []tournamentTransition
+	err := matchRepo.AdvanceBracket(ctx, uint(match.TournamentID), func(tournament *models.Tournament, locked []*models.Match) ([]*models.Match, error) {
+		matches, advanced = locked, nil
+
+		// Replace the stored copy so the returned bracket reflects the new result
+		for i, m := range matches {
+			if m.ID != match.ID {
+				continue
+			}
+			if m.GetStatus() != from {
+				return nil, NewConflictError("match was changed by another request; reload it and try again")
+			}
+			matches[i] = match
+		}
+
+		// A drawn league match has no winner and nothing to advance
+		if !match.IsDraw() {
+			changed, err := advanceMatchTeams(match, matches)
+			if err != nil {
+				return nil, err
+			}
+			advanced, err = resolveWithdrawnMatches(ctx, tournamentRepo, match.TournamentID, matches, changed)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if match.GetRound() == models.RoundGroupStageEnum && models.GroupStageComplete(matches) {
+			qualified, err := fillGroupQualifiers(ctx, tournamentRepo, match.TournamentID, matches)
+			if err != nil {
+				return nil, err
+			}
+			advanced = append(advanced, qualified...)
+		}
+
+		var err error
+		transitions, err = advanceTournamentStatus(tournament, matches)
+		if err != nil {
+			return nil, err
+		}
+		return append([]*models.Match{match}, advanced...), nil
+	})
+	if err != nil {
+		var serviceErr *ServiceError
+		if errors.As(err, &serviceErr) {
+			return nil, false, nil, serviceErr
+		}
+		logger.Error("Failed to save match advancement", "matchID", match.ID, "error", err)
+		return nil, false, nil, NewDatabaseError("failed to advance winner")
+	}
+
+	if len(advanced) > 0 {
+		logger.Info("Bracket advanced", "matchID", match.ID, "updatedMatches", len(advanced))
+	}
+
+	return matches, len(advanced) > 0, transitions, nil
+}
+
+// advanceMatchTeams places the winner and loser of a completed match into their
+// next-round slots and returns the matches that changed
+func advanceMatchTeams(match *models.Match, matches []*models.Match) ([]*models.Match, error) {
+	winner, loser := match.AdvancingTeams()
+	if winner.Name == "" {
+		return nil, NewValidationError("match has no winner")
+	}
+
+	byID := make(map[int]*models.Match, len(matches))
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+
+	// The reset match is only played when the loser-bracket side wins the grand final
+	if match.GetRound() == models.RoundGrandFinalEnum && !match.NeedsGrandFinalReset() {
+		return nil, nil
+	}
+
+	// Destinations are read from the stored bracket structure
+	progression := match.GetProgression()
+
+	var changed []*models.Match
+	// Teams move with their IDs, so a renamed team keeps matching its own results
+	place := func(slot *models.BracketSlot, team models.TeamRef) error {
+		if slot == nil || team.Name == "" {
+			return nil
+		}
+		target, ok := byID[slot.MatchID]
+		if !ok {
+			return nil
+		}
+		if target.TeamInSlot(slot.Slot).Is(team) {
+			return nil
+		}
+		if target.IsCompleted() {
+			return NewConflictError("next round match has already been completed")
+		}
+		if err := target.SetTeamInSlot(slot.Slot, team); err != nil {
+			return NewInternalError(err.Error())
+		}
+		changed = append(changed, target)
+		return nil
+	}
+
+	if err := place(progression.Winner, winner); err != nil {
+		return nil, err
+	}
+	if err := place(progression.Loser, loser); err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// resolveWithdrawnMatches completes the changed matches that now face a withdrawn
+// slot (a walkover, or a double forfeit when both slots are withdrawn) and
+// advances them. It returns the changed matches including the ones it completed.
+func resolveWithdrawnMatches(ctx context.Context, tournamentRepo repository.TournamentRepository, tournamentID int, matches []*models.Match, changed []*models.Match) ([]*models.Match, error) {
+	var sport models.SportType
+	sportLoaded := false
+	saved := make(map[int]bool, len(changed))
+	var result []*models.Match
+
+	for queue := changed; len(queue) > 0; queue = queue[1:] {
+		target := queue[0]
+		if !saved[target.ID] {
+			saved[target.ID] = true
+			result = append(result, target)
+		}
+
+		if target.Team1 != models.TeamWithdrawn && target.Team2 != models.TeamWithdrawn {
+			continue
+		}
+		if !sportLoaded {
+			var err error
+			if sport, err = tournamentSport(ctx, tournamentRepo, tournamentID); err != nil {
+				return nil, err
+			}
+			sportLoaded = true
+		}
+
+		// Already completed, or the opponent is not decided yet (resolved when it advances)
+		walkover, ok := target.WithdrawnResult(sport)
+		if !ok {
+			continue
+		}
+		if err := target.CheckTransition(models.MatchStatusCompletedEnum); err != nil {
+			return nil, NewTransitionError(err)
+		}
+		target.ApplyResult(walkover)
+		if err := target.TransitionTo(models.MatchStatusCompletedEnum); err != nil {
+			return nil, NewTransitionError(err)
+		}
+
+		next, err := advanceMatchTeams(target, matches)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, next...)
+	}
+
+	return result, nil
+}
+
+// tournamentSport returns the sport of the tournament, or an empty sport when
+// tournamentRepo is nil (results then use the default non-set scores)
+func tournamentSport(ctx context.Context, tournamentRepo repository.TournamentRepository, tournamentID int) (models.SportType, error) {
+	if tournamentRepo == nil {
+		return "", nil
+	}
+
+	tournament, err := tournamentRepo.GetByID(ctx, uint(tournamentID))
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", tournamentID, "error", err)
+		return "", NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return "", NewNotFoundError("tournament not found")
+	}
+	return tournament.GetSportType(), nil
+}
+
+// applyForfeitDefaults replaces the scores and winner of a walkover, forfeit or
+// disqualification with the sport's default result. Played results are returned unchanged.
+func applyForfeitDefaults(ctx context.Context, tournamentRepo repository.TournamentRepository, match *models.Match, result models.MatchResult) (models.MatchResult, error) {
+	if !result.IsForfeit() {
+		return result, nil
+	}
+
+	sport, err := tournamentSport(ctx, tournamentRepo, match.TournamentID)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+
+	forfeit, err := models.NewForfeitResult(sport, result.ResultType, match.Team1, match.Team2, result.ForfeitingTeam)
+	if err != nil {
+		return models.MatchResult{}, NewValidationError(err.Error())
+	}
+	return forfeit, nil
+}
+
+// fillGroupQualifiers places the group-stage qualifiers into the knockout bracket
+// and returns the matches that changed
+func fillGroupQualifiers(ctx context.Context, tournamentRepo repository.TournamentRepository, tournamentID int, matches []*models.Match) ([]*models.Match, error) {
+	rules, err := loadLeagueRules(ctx, tournamentRepo, uint(tournamentID), models.DefaultLeagueRules())
+	if err != nil {
+		return nil, err
+	}
+
+	qualified, err := models.FillGroupQualifiers(matches, rules)
+	if err != nil {
+		// Nothing is saved, so the result can be submitted again once the
+		// league rules include a tiebreaker that settles the places (e.g. lottery)
+		logger.Error("Failed to fill knockout from group standings", "tournamentID", tournamentID, "error", err)
+		return nil, NewConflictError(err.Error())
+	}
+
+	if len(qualified) > 0 {
+		logger.Info("Group stage completed", "tournamentID", tournamentID, "filledMatches", len(qualified))
+	}
+	return qualified, nil
+}
+
+// validateSetsForTournament checks per-set scores against the set rules of the
+// tournament's sport. Results without sets are not checked.
+func validateSetsForTournament(ctx context.Context, tournamentRepo repository.TournamentRepository, tournamentID int, result models.MatchResult) error {
+	if len(result.Sets) == 0 || tournamentRepo == nil {
+		return nil
+	}
+
+	sport, err := tournamentSport(ctx, tournamentRepo, tournamentID)
+	if err != nil {
+		return err
+	}
+
+	if err := result.ValidateSetsForSport(sport); err != nil {
+		return NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// loadLeagueRules returns the tournament's points and tiebreakers, or the
+// given defaults when none are configured
+func loadLeagueRules(ctx context.Context, tournamentRepo repository.TournamentRepository, tournamentID uint, defaults models.LeagueRules) (models.LeagueRules, error) {
+	if tournamentRepo == nil {
+		return defaults, nil
+	}
+
+	rules, err := tournamentRepo.GetLeagueRules(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get league rules", "tournamentID", tournamentID, "error", err)
+		return models.LeagueRules{}, NewDatabaseError("failed to get league rules")
+	}
+	if rules == nil {
+		return defaults, nil
+	}
+	return *rules, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"backend/internal/models"
+)
+
+func TestSaveWithAdvancement(t *testing.T) {
+	// 準決勝の勝者は決勝、敗者は3位決定戦に進む
+	newBracket := func() []*models.Match {
+		semifinal1 := newTestMatch(1, models.RoundSemifinalEnum, "IE4", "IS4")
+		semifinal2 := newTestMatch(2, models.RoundSemifinalEnum, "IT4", "IC4")
+		thirdPlace := newTestMatch(3, models.RoundThirdPlaceEnum, models.TeamTBD, models.TeamTBD)
+		final := newTestMatch(4, models.RoundFinalEnum, models.TeamTBD, models.TeamTBD)
+		semifinal1.SetProgression(models.BracketProgression{
+			Winner: &models.BracketSlot{MatchID: 4, Slot: models.SlotTeam1},
+			Loser:  &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam1},
+		})
+		semifinal2.SetProgression(models.BracketProgression{
+			Winner: &models.BracketSlot{MatchID: 4, Slot: models.SlotTeam2},
+			Loser:  &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam2},
+		})
+		return []*models.Match{semifinal1, semifinal2, thirdPlace, final}
+	}
+	complete := func(match *models.Match, result models.MatchResult) {
+		match.ApplyResult(result)
+		match.SetStatus(models.MatchStatusCompletedEnum)
+	}
+	doubleForfeit := models.MatchResult{Score1: 0, Score2: 0, ResultType: models.ResultTypeDoubleForfeitEnum}
+
+	tests := []struct {
+		name              string
+		result            models.MatchResult
+		prepare           func(locked []*models.Match)
+		advanceErr        error
+		expectedErrorType string
+		wantSaved         []int
+		wantTeams         map[int][2]string
+		wantWinners       map[int]string
+	}{
+		{
+			name:      "勝者は決勝、敗者は3位決定戦へ",
+			result:    models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			wantSaved: []int{1, 4, 3},
+			wantTeams: map[int][2]string{3: {"IS4", models.TeamTBD}, 4: {"IE4", models.TeamTBD}},
+		},
+		{
+			name:   "両チーム棄権で相手が決まっている試合は不戦勝",
+			result: doubleForfeit,
+			prepare: func(locked []*models.Match) {
+				complete(locked[1], models.MatchResult{Score1: 2, Score2: 0, Winner: "IT4"})
+				locked[2].Team2, locked[3].Team2 = "IC4", "IT4"
+			},
+			wantSaved:   []int{1, 4, 3},
+			wantTeams:   map[int][2]string{3: {models.TeamWithdrawn, "IC4"}, 4: {models.TeamWithdrawn, "IT4"}},
+			wantWinners: map[int]string{3: "IC4", 4: "IT4"},
+		},
+		{
+			name:   "棄権したチームの3位決定戦は相手の不戦勝",
+			result: models.MatchResult{Score1: 3, Score2: 0, Winner: "IE4", ResultType: models.ResultTypeForfeitEnum, ForfeitingTeam: "IS4"},
+			prepare: func(locked []*models.Match) {
+				complete(locked[1], models.MatchResult{Score1: 2, Score2: 0, Winner: "IT4"})
+				locked[2].Team2, locked[3].Team2 = "IC4", "IT4"
+			},
+			wantSaved:   []int{1, 4, 3},
+			wantTeams:   map[int][2]string{3: {models.TeamWithdrawn, "IC4"}, 4: {"IE4", "IT4"}},
+			wantWinners: map[int]string{3: "IC4"},
+		},
+		{
+			name:   "読み込み後に別のリクエストで結果が登録された",
+			result: models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			prepare: func(locked []*models.Match) {
+				complete(locked[0], models.MatchResult{Score1: 0, Score2: 2, Winner: "IS4"})
+			},
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:   "進出先の試合が終了済み",
+			result: models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			prepare: func(locked []*models.Match) {
+				locked[3].Team1, locked[3].Team2 = "IS4", "IT4"
+				complete(locked[3], models.MatchResult{Score1: 1, Score2: 0, Winner: "IS4"})
+			},
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:              "データベースエラー",
+			result:            models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			advanceErr:        errors.New("lock wait timeout exceeded"),
+			expectedErrorType: ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, mocks := newTestMatchService(models.SportSoccer)
+			locked := newBracket()
+			// 結果を受け付けた時点の試合は、ロックして読み直したブラケットとは別のコピー
+			match := *locked[0]
+			if tt.prepare != nil {
+				tt.prepare(locked)
+			}
+			var lockedArg interface{} = locked
+			if tt.advanceErr != nil {
+				lockedArg = nil
+			}
+			mocks.matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return(lockedArg, tt.advanceErr)
+
+			from := match.GetStatus()
+			complete(&match, tt.result)
+			matches, changed, _, err := saveWithAdvancement(context.Background(), mocks.matchRepo, mocks.tournamentRepo, &match, from)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				if mocks.matchRepo.Saved != nil {
+					t.Errorf("エラー時に試合が保存されました: %v", mocks.matchRepo.Saved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if !changed || matches[0] != &match {
+				t.Errorf("返されたブラケットに結果が反映されていません: changed=%v", changed)
+			}
+
+			saved := make([]int, 0, len(mocks.matchRepo.Saved))
+			for _, m := range mocks.matchRepo.Saved {
+				saved = append(saved, m.ID)
+			}
+			if len(saved) != len(tt.wantSaved) {
+				t.Fatalf("期待された保存対象: %v, 実際: %v", tt.wantSaved, saved)
+			}
+			for i := range saved {
+				if saved[i] != tt.wantSaved[i] {
+					t.Fatalf("期待された保存対象: %v, 実際: %v", tt.wantSaved, saved)
+				}
+			}
+			for _, m := range matches {
+				want, ok := tt.wantTeams[m.ID]
+				if ok && (m.Team1 != want[0] || m.Team2 != want[1]) {
+					t.Errorf("試合%dの期待されたチーム: %v, 実際: %s vs %s", m.ID, want, m.Team1, m.Team2)
+				}
+				winner, ok := tt.wantWinners[m.ID]
+				if ok && (!m.IsCompleted() || m.Winner == nil || *m.Winner != winner) {
+					t.Errorf("試合%dの期待された勝者: %s, 実際: %v (%s)", m.ID, winner, m.Winner, m.GetStatus())
+				}
+			}
+		})
+	}
+}
+
+func TestAdvanceMatchTeams_TeamIDs(t *testing.T) {
+	teams := []*models.Team{{ID: 11, TournamentID: 1, Name: "IE4"}, {ID: 12, TournamentID: 1, Name: "IS4"}}
+	semifinal := newTestMatch(1, models.RoundSemifinalEnum, "IE4", "IS4")
+	final := newTestMatch(2, models.RoundFinalEnum, models.TeamTBD, "IT4")
+	semifinal.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 2, Slot: models.SlotTeam1}})
+	models.AssignTeamIDs([]*models.Match{semifinal}, teams)
+	semifinal.ApplyResult(models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"})
+	semifinal.SetStatus(models.MatchStatusCompletedEnum)
+
+	// 結果の登録後にIE4をIE4Aに変更しても、勝者はチームIDで決まる
+	semifinal.Team1 = "IE4A"
+	changed, err := advanceMatchTeams(semifinal, []*models.Match{semifinal, final})
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(changed) != 1 || final.Team1 != "IE4A" || final.Team1ID == nil || *final.Team1ID != 11 {
+		t.Errorf("決勝の1枠目が期待されたチーム: IE4A (ID 11), 実際: %s (ID %v)", final.Team1, final.Team1ID)
+	}
+
+	// 同じチームが入っている枠は変更しない
+	if changed, err := advanceMatchTeams(semifinal, []*models.Match{semifinal, final}); err != nil || len(changed) != 0 {
+		t.Errorf("再度の進出で変更された試合: %v, error = %v", changed, err)
+	}
+}
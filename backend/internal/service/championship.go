@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// ChampionshipService defines the interface for the overall championship
+// table, which ranks classes by the placement points earned in every sport
+type ChampionshipService interface {
+	GetChampionship(ctx context.Context, eventID uint) (*models.Championship, error)
+	GetTournamentPlacements(ctx context.Context, tournamentID uint) (*models.TournamentPlacements, error)
+	RefreshPlacements(ctx context.Context, tournamentID int)
+	SetNotificationService(notificationService *NotificationService)
+}
+
+// championshipService implements ChampionshipService
+type championshipService struct {
+	tournamentRepo      repository.TournamentRepository
+	matchRepo           repository.MatchRepository
+	teamRepo            repository.TeamRepository
+	eventRepo           repository.EventRepository
+	notificationService *NotificationService
+
+	// placements holds the last final placements seen for each tournament so
+	// that an update is only pushed when a placement actually changes
+	mu         sync.Mutex
+	placements map[int][]models.Placement
+}
+
+// NewChampionshipService creates a new championship service
+func NewChampionshipService(tournamentRepo repository.TournamentRepository, matchRepo repository.MatchRepository, teamRepo repository.TeamRepository, eventRepo repository.EventRepository) ChampionshipService {
+	return &championshipService{
+		tournamentRepo: tournamentRepo,
+		matchRepo:      matchRepo,
+		teamRepo:       teamRepo,
+		eventRepo:      eventRepo,
+		placements:     make(map[int][]models.Placement),
+	}
+}
+
+// SetNotificationService sets the notification service for real-time updates
+func (s *championshipService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// GetChampionship returns the overall championship table of an event (the
+// current event when eventID is 0). Every class registered in one of the
+// event's tournaments is listed, including those without points yet.
+func (s *championshipService) GetChampionship(ctx context.Context, eventID uint) (*models.Championship, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tournaments, err := s.tournamentRepo.GetByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get tournaments for championship", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get tournaments")
+	}
+
+	var classNames []string
+	seen := make(map[string]bool)
+	results := make([]models.TournamentPlacements, 0, len(tournaments))
+	for _, tournament := range tournaments {
+		if tournament.IsCancelled() {
+			continue
+		}
+
+		teams, err := s.teamRepo.GetByTournamentID(ctx, uint(tournament.ID))
+		if err != nil {
+			logger.Error("Failed to get teams for championship", "tournamentID", tournament.ID, "error", err)
+			return nil, NewDatabaseError("failed to get teams")
+		}
+		for _, team := range teams {
+			if !seen[team.Name] {
+				seen[team.Name] = true
+				classNames = append(classNames, team.Name)
+			}
+		}
+
+		result, err := s.computePlacements(ctx, tournament)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return models.BuildChampionship(event.ID, classNames, results), nil
+}
+
+// GetTournamentPlacements returns the final placements decided so far in a
+// tournament together with the placement points they earn
+func (s *championshipService) GetTournamentPlacements(ctx context.Context, tournamentID uint) (*models.TournamentPlacements, error) {
+	tournament, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.computePlacements(ctx, tournament)
+}
+
+// RefreshPlacements recomputes the final placements of a tournament after a
+// result was saved or corrected and, when they changed, pushes the updated
+// championship table to every connected client. Failures are only logged so
+// that they never undo the result that triggered the refresh.
+func (s *championshipService) RefreshPlacements(ctx context.Context, tournamentID int) {
+	tournament, err := s.getTournament(ctx, uint(tournamentID))
+	if err != nil {
+		logger.Error("Failed to refresh placements", "tournamentID", tournamentID, "error", err)
+		return
+	}
+
+	result, err := s.computePlacements(ctx, tournament)
+	if err != nil {
+		logger.Error("Failed to refresh placements", "tournamentID", tournamentID, "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	previous, known := s.placements[tournamentID]
+	changed := (known && !reflect.DeepEqual(previous, result.Placements)) || (!known && len(result.Placements) > 0)
+	s.placements[tournamentID] = result.Placements
+	s.mu.Unlock()
+
+	if !changed || s.notificationService == nil {
+		return
+	}
+
+	championship, err := s.GetChampionship(ctx, uint(tournament.EventID))
+	if err != nil {
+		logger.Error("Failed to build championship", "eventID", tournament.EventID, "error", err)
+		return
+	}
+	s.notificationService.NotifyChampionshipUpdate(tournament.GetSportType(), tournament.ID, result.Placements, championship)
+}
+
+// computePlacements derives the final placements of a tournament from its
+// matches and awards the placement points of its sport. League formats only
+// have placements once the table is final: when every round robin match is
+// completed, or when a Swiss tournament has been completed.
+func (s *championshipService) computePlacements(ctx context.Context, tournament *models.Tournament) (*models.TournamentPlacements, error) {
+	matches, err := s.matchRepo.GetByTournamentID(ctx, uint(tournament.ID))
+	if err != nil {
+		logger.Error("Failed to get matches for placements", "tournamentID", tournament.ID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+
+	var placements []models.Placement
+	switch tournament.GetFormat().BracketStructure() {
+	case "double_elimination":
+		placements = models.DoubleEliminationPlacements(matches)
+	case "round_robin":
+		if allMatchesCompleted(matches) {
+			rules, err := loadLeagueRules(ctx, s.tournamentRepo, uint(tournament.ID), models.DefaultLeagueRules())
+			if err != nil {
+				return nil, err
+			}
+			placements = models.StandingsPlacements(models.ComputeStandings(matches, rules))
+		}
+	case "swiss":
+		if tournament.IsCompleted() {
+			table, err := s.swissTable(ctx, tournament, matches)
+			if err != nil {
+				return nil, err
+			}
+			placements = models.StandingsPlacements(table)
+		}
+	default:
+		placements = models.KnockoutPlacements(matches)
+	}
+	if placements == nil {
+		placements = []models.Placement{}
+	}
+
+	sport, ok := models.LookupSport(tournament.GetSportType())
+	if !ok {
+		// A sport removed from the registry falls back to the default points
+		sport = &models.Sport{}
+	}
+	models.ApplyPlacementPoints(placements, sport)
+
+	return &models.TournamentPlacements{
+		TournamentID: tournament.ID,
+		Sport:        tournament.GetSportType(),
+		Placements:   placements,
+	}, nil
+}
+
+// swissTable computes the final Swiss table with every registered team
+func (s *championshipService) swissTable(ctx context.Context, tournament *models.Tournament, matches []*models.Match) ([]models.Standing, error) {
+	rules, err := loadLeagueRules(ctx, s.tournamentRepo, uint(tournament.ID), models.DefaultSwissRules())
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetByTournamentID(ctx, uint(tournament.ID))
+	if err != nil {
+		logger.Error("Failed to get teams for placements", "tournamentID", tournament.ID, "error", err)
+		return nil, NewDatabaseError("failed to get teams")
+	}
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+
+	return models.ComputeSwissStandings(names, matches, rules), nil
+}
+
+// getTournament returns a tournament by ID
+func (s *championshipService) getTournament(ctx context.Context, tournamentID uint) (*models.Tournament, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return nil, NewNotFoundError("tournament not found")
+	}
+	return tournament, nil
+}
+
+// allMatchesCompleted reports whether a tournament has matches and all of them are completed
+func allMatchesCompleted(matches []*models.Match) bool {
+	if len(matches) == 0 {
+		return false
+	}
+	for _, match := range matches {
+		if !match.IsCompleted() {
+			return false
+		}
+	}
+	return true
+}
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+
+	"backend/internal/models"
 )
 
 // Logger instance
@@ -13,9 +16,11 @@ var logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 
 // ServiceError represents a service layer error
 type ServiceError struct {
-	Type    string `json:"type"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Type      string                 `json:"type"`
+	Message   string                 `json:"message"`
+	Code      int                    `json:"code"`
+	ErrorCode string                 `json:"error_code,omitempty"` // API error code when the type alone does not identify it
+	Details   map[string]interface{} `json:"details,omitempty"`
 }
 
 func (e *ServiceError) Error() string {
@@ -24,11 +29,12 @@ func (e *ServiceError) Error() string {
 
 // Error types
 const (
-	ErrorTypeValidation = "validation_error"
-	ErrorTypeNotFound   = "not_found_error"
-	ErrorTypeConflict   = "conflict_error"
-	ErrorTypeDatabase   = "database_error"
-	ErrorTypeInternal   = "internal_error"
+	ErrorTypeValidation        = "validation_error"
+	ErrorTypeNotFound          = "not_found_error"
+	ErrorTypeConflict          = "conflict_error"
+	ErrorTypeInvalidTransition = "invalid_transition_error"
+	ErrorTypeDatabase          = "database_error"
+	ErrorTypeInternal          = "internal_error"
 )
 
 // NewValidationError creates a new validation error
@@ -58,6 +64,38 @@ func NewConflictError(message string) *ServiceError {
 	}
 }
 
+// NewTransitionError creates an error for a status change the match or
+// tournament state machine refuses. The match or tournament and both statuses
+// are kept as details so that clients can tell which change was rejected.
+func NewTransitionError(err error) *ServiceError {
+	serviceErr := &ServiceError{
+		Type:    ErrorTypeInvalidTransition,
+		Message: err.Error(),
+		Code:    409,
+	}
+	var matchErr *models.MatchTransitionError
+	var tournamentErr *models.TournamentTransitionError
+	switch {
+	case errors.As(err, &matchErr):
+		serviceErr.ErrorCode = models.ErrorBusinessInvalidMatchTransition
+		serviceErr.Details = map[string]interface{}{
+			"match_id": matchErr.MatchID,
+			"from":     matchErr.From,
+			"to":       matchErr.To,
+			"reason":   matchErr.Reason,
+		}
+	case errors.As(err, &tournamentErr):
+		serviceErr.ErrorCode = models.ErrorBusinessInvalidTournamentTransition
+		serviceErr.Details = map[string]interface{}{
+			"tournament_id": tournamentErr.TournamentID,
+			"from":          tournamentErr.From,
+			"to":            tournamentErr.To,
+			"reason":        tournamentErr.Reason,
+		}
+	}
+	return serviceErr
+}
+
 // NewDatabaseError creates a new database error
 func NewDatabaseError(message string) *ServiceError {
 	return &ServiceError{
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// EventService defines the interface for event operations
+type EventService interface {
+	ListEvents(ctx context.Context) ([]*models.Event, error)
+	GetEvent(ctx context.Context, id uint) (*models.Event, error)
+	GetCurrentEvent(ctx context.Context) (*models.Event, error)
+	GetEventTournaments(ctx context.Context, id uint) ([]*models.Tournament, error)
+	CreateEvent(ctx context.Context, event *models.Event) error
+	UpdateEvent(ctx context.Context, id uint, event *models.Event) error
+}
+
+// eventService implements EventService
+type eventService struct {
+	eventRepo      repository.EventRepository
+	tournamentRepo repository.TournamentRepository
+}
+
+// NewEventService creates a new event service
+func NewEventService(eventRepo repository.EventRepository, tournamentRepo repository.TournamentRepository) EventService {
+	return &eventService{
+		eventRepo:      eventRepo,
+		tournamentRepo: tournamentRepo,
+	}
+}
+
+// ListEvents returns every event, newest first
+func (s *eventService) ListEvents(ctx context.Context) ([]*models.Event, error) {
+	events, err := s.eventRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get events", "error", err)
+		return nil, NewDatabaseError("failed to get events")
+	}
+	return events, nil
+}
+
+// GetEvent returns an event by ID
+func (s *eventService) GetEvent(ctx context.Context, id uint) (*models.Event, error) {
+	return resolveEvent(ctx, s.eventRepo, id)
+}
+
+// GetCurrentEvent returns the event used when a request names no event
+func (s *eventService) GetCurrentEvent(ctx context.Context) (*models.Event, error) {
+	return resolveEvent(ctx, s.eventRepo, 0)
+}
+
+// GetEventTournaments returns the tournaments of an event (the current event when id is 0)
+func (s *eventService) GetEventTournaments(ctx context.Context, id uint) ([]*models.Tournament, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tournaments, err := s.tournamentRepo.GetByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get tournaments of event", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get tournaments")
+	}
+	return tournaments, nil
+}
+
+// CreateEvent creates a new event
+func (s *eventService) CreateEvent(ctx context.Context, event *models.Event) error {
+	if event.Timezone == "" {
+		event.Timezone = models.DefaultEventTimezone
+	}
+	if event.Status == "" {
+		event.SetStatus(models.EventStatusPlannedEnum)
+	}
+	if err := event.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if event.HasEnded(time.Now()) {
+		return NewValidationError("cannot create an event that has already ended")
+	}
+
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		logger.Error("Failed to create event", "error", err)
+		return NewDatabaseError("failed to create event")
+	}
+
+	logger.Info("Event created", "eventID", event.ID, "name", event.Name)
+	return nil
+}
+
+// UpdateEvent updates an event. Archived events are read-only.
+func (s *eventService) UpdateEvent(ctx context.Context, id uint, event *models.Event) error {
+	existing, err := resolveEvent(ctx, s.eventRepo, id)
+	if err != nil {
+		return err
+	}
+	if existing.IsArchived() {
+		return NewConflictError("event is archived and read-only")
+	}
+
+	event.ID = existing.ID
+	event.CreatedAt = existing.CreatedAt
+	if event.Timezone == "" {
+		event.Timezone = existing.Timezone
+	}
+	if event.Status == "" {
+		event.Status = existing.Status
+	}
+	if err := event.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		logger.Error("Failed to update event", "eventID", id, "error", err)
+		return NewDatabaseError("failed to update event")
+	}
+
+	logger.Info("Event updated", "eventID", id, "status", event.Status)
+	return nil
+}
+
+// resolveEvent returns the event with the given ID, or the current event when
+// eventID is 0 (the ongoing event, otherwise the next planned or latest one)
+func resolveEvent(ctx context.Context, eventRepo repository.EventRepository, eventID uint) (*models.Event, error) {
+	var event *models.Event
+	var err error
+	if eventID == 0 {
+		event, err = eventRepo.GetCurrent(ctx)
+	} else {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil {
+		logger.Error("Failed to get event", "eventID", eventID, "error", err)
+		return nil, NewDatabaseError("failed to get event")
+	}
+	if event == nil {
+		return nil, NewNotFoundError("event not found")
+	}
+	return event, nil
+}
+
+// ensureEventWritable rejects changes to the tournaments and matches of an
+// archived event, which are kept as a read-only history. Only an explicit
+// archive counts: an event past its end date still accepts result corrections.
+func ensureEventWritable(ctx context.Context, eventRepo repository.EventRepository, eventID int) error {
+	event, err := resolveEvent(ctx, eventRepo, uint(eventID))
+	if err != nil {
+		return err
+	}
+	if event.IsArchived() {
+		return NewConflictError("event is archived and read-only")
+	}
+	return nil
+}
+
+// ensureTournamentWritable rejects changes to an archived tournament or to a
+// tournament whose event is archived
+func ensureTournamentWritable(ctx context.Context, tournamentRepo repository.TournamentRepository, eventRepo repository.EventRepository, tournamentID int) error {
+	tournament, err := tournamentRepo.GetByID(ctx, uint(tournamentID))
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", tournamentID, "error", err)
+		return NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return NewNotFoundError("tournament not found")
+	}
+	if tournament.IsArchived() {
+		return NewConflictError("tournament is archived and read-only")
+	}
+	return ensureEventWritable(ctx, eventRepo, tournament.EventID)
+}
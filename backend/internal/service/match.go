@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 	"backend/internal/models"
 	"backend/internal/repository"
 )
@@ -14,16 +17,23 @@ type MatchService interface {
 	GetMatches(ctx context.Context, limit, offset int) ([]*models.Match, error)
 	UpdateMatch(match *models.Match) error
 	DeleteMatch(id int) error
+	ChangeMatchStatus(matchID int, status models.MatchStatus, scheduledAt *time.Time) (*models.Match, error)
 	
 	// Query operations
 	GetMatchesByTournament(tournamentID int) ([]*models.Match, error)
-	GetMatchesBySport(sport string) ([]*models.Match, error)
+	GetMatchesBySport(eventID uint, sport string) ([]*models.Match, error)
 	GetPendingMatches() ([]*models.Match, error)
 	GetCompletedMatches() ([]*models.Match, error)
 	GetNextMatches(tournamentID int) ([]*models.Match, error)
 	
 	// Match result operations
 	UpdateMatchResult(matchID int, result models.MatchResult) error
+	CorrectMatchResult(matchID int, result models.MatchResult, reason string, correctedBy *int, confirmReset bool) (*models.ResultCorrection, error)
+	GetMatchCorrections(matchID int) ([]*models.ResultCorrection, error)
+	
+	// Live scoring operations
+	AppendMatchEvent(matchID int, event *models.MatchEvent) (*models.LiveScore, error)
+	GetMatchTimeline(matchID int) ([]*models.MatchEvent, *models.LiveScore, error)
 	
 	// Statistics
 	GetMatchStatistics(tournamentID int) (*MatchStatistics, error)
@@ -31,14 +41,16 @@ type MatchService interface {
 
 // MatchStatistics represents match statistics
 type MatchStatistics struct {
-	TournamentID     int                    `json:"tournament_id"`
-	TotalMatches     int                    `json:"total_matches"`
-	CompletedMatches int                    `json:"completed_matches"`
-	PendingMatches   int                    `json:"pending_matches"`
-	MatchesByRound   map[string]int         `json:"matches_by_round"`
-	CompletionRate   float64                `json:"completion_rate"`
-	AverageScore     map[string]float64     `json:"average_score"`
-	TeamStats        map[string]*TeamStats  `json:"team_stats"`
+	TournamentID      int                    `json:"tournament_id"`
+	TotalMatches      int                    `json:"total_matches"`
+	CompletedMatches  int                    `json:"completed_matches"`
+	PendingMatches    int                    `json:"pending_matches"`
+	MatchesByRound    map[string]int         `json:"matches_by_round"`
+	MatchesByDecision map[string]int         `json:"matches_by_decision"` // completed matches per decision method
+	MatchesByResult   map[string]int         `json:"matches_by_result"`   // completed matches per result type (played, walkover, ...)
+	CompletionRate    float64                `json:"completion_rate"`
+	AverageScore      map[string]float64     `json:"average_score"`
+	TeamStats         map[string]*TeamStats  `json:"team_stats"`
 }
 
 // TeamStats represents team statistics
@@ -47,6 +59,7 @@ type TeamStats struct {
 	MatchesPlayed int     `json:"matches_played"`
 	Wins          int     `json:"wins"`
 	Losses        int     `json:"losses"`
+	Forfeits      int     `json:"forfeits"` // walkovers, forfeits and disqualifications against the team
 	TotalScore    int     `json:"total_score"`
 	AverageScore  float64 `json:"average_score"`
 }
@@ -54,18 +67,90 @@ type TeamStats struct {
 // matchService implements MatchService
 type matchService struct {
 	matchRepo           repository.MatchRepository
+	tournamentRepo      repository.TournamentRepository
+	eventRepo           repository.EventRepository
+	teamRepo            repository.TeamRepository
+	playerRepo          repository.PlayerRepository
 	notificationService *NotificationService
+	championshipService ChampionshipService
+	venueService        VenueService
 }
 
 // NewMatchService creates a new match service
-func NewMatchService(matchRepo repository.MatchRepository) MatchService {
+func NewMatchService(matchRepo repository.MatchRepository, tournamentRepo repository.TournamentRepository, eventRepo repository.EventRepository, teamRepo repository.TeamRepository, playerRepo repository.PlayerRepository) MatchService {
 	return &matchService{
-		matchRepo: matchRepo,
+		matchRepo:      matchRepo,
+		tournamentRepo: tournamentRepo,
+		eventRepo:      eventRepo,
+		teamRepo:       teamRepo,
+		playerRepo:     playerRepo,
+	}
+}
+
+// ensureWritable rejects changes to matches of an archived event
+func (s *matchService) ensureWritable(ctx context.Context, tournamentID int) error {
+	if s.tournamentRepo == nil || s.eventRepo == nil {
+		return nil
 	}
+	return ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, tournamentID)
+}
+
+// resolveTeams identifies the teams of a match. The team ID is the source of
+// truth: a team given by ID takes its registered name, and a team given only
+// by name is linked to the team registered under that name in the tournament.
+// Placeholders and unregistered names are kept without an ID.
+func (s *matchService) resolveTeams(ctx context.Context, match *models.Match) error {
+	for _, slot := range []int{models.SlotTeam1, models.SlotTeam2} {
+		team := match.TeamInSlot(slot)
+		if team.ID == nil && (s.teamRepo == nil || models.IsUndecidedTeam(team.Name) || team.Name == models.TeamWithdrawn) {
+			continue
+		}
+		if s.teamRepo == nil {
+			return NewInternalError("team repository is not configured")
+		}
+
+		var registered *models.Team
+		var err error
+		if team.ID != nil {
+			registered, err = s.teamRepo.GetByID(ctx, uint(*team.ID))
+		} else {
+			registered, err = s.teamRepo.GetByTournamentAndName(ctx, uint(match.TournamentID), team.Name)
+		}
+		if err != nil {
+			logger.Error("Failed to get team", "teamID", team.ID, "name", team.Name, "error", err)
+			return NewDatabaseError("failed to get team")
+		}
+		if registered == nil {
+			if team.ID != nil {
+				return NewNotFoundError("team not found")
+			}
+			continue
+		}
+		if int(registered.TournamentID) != match.TournamentID {
+			return NewValidationError("team is not registered in this tournament")
+		}
+
+		id := int(registered.ID)
+		if err := match.SetTeamInSlot(slot, models.TeamRef{ID: &id, Name: registered.Name}); err != nil {
+			return NewInternalError(err.Error())
+		}
+	}
+
+	if match.TeamInSlot(models.SlotTeam1).Is(match.TeamInSlot(models.SlotTeam2)) {
+		return NewValidationError("a team cannot play against itself")
+	}
+	return nil
 }
 
 // CreateMatch creates a new match
 func (s *matchService) CreateMatch(match *models.Match) error {
+	if err := s.ensureWritable(context.Background(), match.TournamentID); err != nil {
+		return err
+	}
+	if err := s.resolveTeams(context.Background(), match); err != nil {
+		return err
+	}
+
 	if err := s.matchRepo.Create(context.Background(), match); err != nil {
 		logger.Error("Failed to create match", "error", err)
 		return NewDatabaseError("failed to create match")
@@ -102,23 +187,93 @@ func (s *matchService) GetMatches(ctx context.Context, limit, offset int) ([]*mo
 	return matches, nil
 }
 
-// UpdateMatch updates an existing match
+// UpdateMatch updates the round, teams and start time of an existing match.
+// The status and result are kept as stored: a status change is applied with
+// ChangeMatchStatus so it follows the match state machine, and results are only
+// submitted with UpdateMatchResult. match is set to the saved match.
 func (s *matchService) UpdateMatch(match *models.Match) error {
-	if err := s.matchRepo.Update(context.Background(), match); err != nil {
+	ctx := context.Background()
+
+	// The stored match decides which tournament is written to
+	existing, err := s.GetMatch(match.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureWritable(ctx, existing.TournamentID); err != nil {
+		return err
+	}
+	if match.TournamentID != 0 && match.TournamentID != existing.TournamentID {
+		return NewValidationError("a match cannot be moved to another tournament")
+	}
+
+	from, next := existing.GetStatus(), match.GetStatus()
+	if match.Status == "" {
+		next = from
+	}
+	if next != from && next == models.MatchStatusCompletedEnum {
+		return NewValidationError("match results are submitted with the result endpoint")
+	}
+
+	updated := *existing
+	updated.Round = match.Round
+	updated.Team1, updated.Team2 = match.Team1, match.Team2
+	updated.Team1ID, updated.Team2ID = match.Team1ID, match.Team2ID
+	// A new start time given with a reschedule is applied by the reschedule
+	scheduledAt := match.ScheduledAt
+	if next == from || next != models.MatchStatusRescheduledEnum {
+		updated.ScheduledAt = scheduledAt
+	}
+	if err := s.resolveTeams(ctx, &updated); err != nil {
+		return err
+	}
+
+	// The status change is checked on a copy before anything is saved, so a
+	// refused change leaves the match as it was
+	if next != from {
+		probe := updated
+		if next == models.MatchStatusRescheduledEnum {
+			err = probe.Reschedule(scheduledAt)
+		} else {
+			err = probe.TransitionTo(next)
+		}
+		if err != nil {
+			return NewTransitionError(err)
+		}
+	}
+
+	if err := s.matchRepo.Update(ctx, &updated); err != nil {
 		logger.Error("Failed to update match", "id", match.ID, "error", err)
 		return NewDatabaseError("failed to update match")
 	}
+	*match = updated
 
 	// Send real-time notification
 	if s.notificationService != nil {
 		s.notificationService.NotifyMatchUpdate(match, "updated")
 	}
+	s.refreshCourtBoard(match)
 
+	if next == from {
+		return nil
+	}
+	changed, err := s.ChangeMatchStatus(match.ID, next, &scheduledAt)
+	if err != nil {
+		return err
+	}
+	*match = *changed
 	return nil
 }
 
 // DeleteMatch deletes a match
 func (s *matchService) DeleteMatch(id int) error {
+	match, err := s.GetMatch(id)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureWritable(context.Background(), match.TournamentID); err != nil {
+		return err
+	}
+
 	if err := s.matchRepo.Delete(context.Background(), uint(id)); err != nil {
 		logger.Error("Failed to delete match", "id", id, "error", err)
 		return NewDatabaseError("failed to delete match")
@@ -126,6 +281,77 @@ func (s *matchService) DeleteMatch(id int) error {
 	return nil
 }
 
+// ChangeMatchStatus moves a match to another status of the state machine:
+// starting, suspending, postponing, rescheduling or cancelling it. Results are
+// submitted with UpdateMatchResult, so completed is refused here. Rescheduling
+// needs the new start time; the originally planned time is kept. The match is
+// saved in the transaction that locks its tournament's bracket, together with
+// the tournament status it leads to.
+func (s *matchService) ChangeMatchStatus(matchID int, status models.MatchStatus, scheduledAt *time.Time) (*models.Match, error) {
+	ctx := context.Background()
+
+	match, err := s.GetMatch(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureWritable(ctx, match.TournamentID); err != nil {
+		return nil, err
+	}
+	if status == models.MatchStatusCompletedEnum {
+		return nil, NewValidationError("match results are submitted with the result endpoint")
+	}
+
+	from := match.GetStatus()
+	switch {
+	case status == models.MatchStatusRescheduledEnum && scheduledAt == nil:
+		return nil, NewValidationError("scheduled_at is required to reschedule a match")
+	case status == models.MatchStatusRescheduledEnum:
+		err = match.Reschedule(*scheduledAt)
+	default:
+		err = match.TransitionTo(status)
+	}
+	if err != nil {
+		return nil, NewTransitionError(err)
+	}
+
+	var transitions []tournamentTransition
+	err = s.matchRepo.AdvanceBracket(ctx, uint(match.TournamentID), func(tournament *models.Tournament, matches []*models.Match) ([]*models.Match, error) {
+		for i, m := range matches {
+			if m.ID != match.ID {
+				continue
+			}
+			if m.GetStatus() != from {
+				return nil, NewConflictError("match was changed by another request; reload it and try again")
+			}
+			matches[i] = match
+		}
+
+		var err error
+		transitions, err = advanceTournamentStatus(tournament, matches)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.Match{match}, nil
+	})
+	if err != nil {
+		var serviceErr *ServiceError
+		if errors.As(err, &serviceErr) {
+			return nil, serviceErr
+		}
+		logger.Error("Failed to change match status", "id", match.ID, "status", status, "error", err)
+		return nil, NewDatabaseError("failed to change match status")
+	}
+
+	logger.Info("Match status changed", "id", match.ID, "status", status)
+	if s.notificationService != nil {
+		s.notificationService.NotifyMatchUpdate(match, "status_changed")
+	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshCourtBoard(match)
+
+	return match, nil
+}
+
 // GetMatchesByTournament retrieves matches for a specific tournament
 func (s *matchService) GetMatchesByTournament(tournamentID int) ([]*models.Match, error) {
 	matches, err := s.matchRepo.GetByTournamentID(context.Background(), uint(tournamentID))
@@ -136,13 +362,19 @@ func (s *matchService) GetMatchesByTournament(tournamentID int) ([]*models.Match
 	return matches, nil
 }
 
-// GetMatchesBySport retrieves matches by sport (simplified implementation)
-func (s *matchService) GetMatchesBySport(sport string) ([]*models.Match, error) {
-	// This is a simplified implementation
-	// In a real system, you would need to join with tournaments table
-	matches, err := s.matchRepo.GetAll(context.Background(), 100, 0)
+// GetMatchesBySport retrieves the matches of a sport's tournament in an event
+// (the current event when eventID is 0)
+func (s *matchService) GetMatchesBySport(eventID uint, sport string) ([]*models.Match, error) {
+	ctx := context.Background()
+
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
 	if err != nil {
-		logger.Error("Failed to get matches by sport", "sport", sport, "error", err)
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.GetByEventAndSport(ctx, uint(event.ID), sport)
+	if err != nil {
+		logger.Error("Failed to get matches by sport", "eventID", event.ID, "sport", sport, "error", err)
 		return nil, NewDatabaseError("failed to get matches by sport")
 	}
 	return matches, nil
@@ -158,7 +390,7 @@ func (s *matchService) GetPendingMatches() ([]*models.Match, error) {
 	
 	var pendingMatches []*models.Match
 	for _, match := range matches {
-		if match.Status == "pending" {
+		if match.IsPending() {
 			pendingMatches = append(pendingMatches, match)
 		}
 	}
@@ -175,7 +407,7 @@ func (s *matchService) GetCompletedMatches() ([]*models.Match, error) {
 	
 	var completedMatches []*models.Match
 	for _, match := range matches {
-		if match.Status == "completed" {
+		if match.IsCompleted() {
 			completedMatches = append(completedMatches, match)
 		}
 	}
@@ -191,7 +423,7 @@ func (s *matchService) GetNextMatches(tournamentID int) ([]*models.Match, error)
 	
 	var nextMatches []*models.Match
 	for _, match := range matches {
-		if match.Status == "pending" {
+		if match.GetStatus().IsPlayable() {
 			nextMatches = append(nextMatches, match)
 		}
 	}
@@ -205,24 +437,310 @@ func (s *matchService) UpdateMatchResult(matchID int, result models.MatchResult)
 		return err
 	}
 	
-	match.Score1 = &result.Score1
-	match.Score2 = &result.Score2
-	match.Winner = &result.Winner
-	match.Status = "completed"
+	if err := s.ensureWritable(context.Background(), match.TournamentID); err != nil {
+		return err
+	}
 	
-	if err := s.matchRepo.Update(context.Background(), match); err != nil {
-		logger.Error("Failed to update match result", "id", match.ID, "error", err)
-		return NewDatabaseError("failed to update match result")
+	// Completed results go through CorrectMatchResult so downstream slots stay consistent
+	if match.IsCompleted() {
+		return NewConflictError("match is already completed; use a result correction instead")
+	}
+	if err := match.CheckTransition(models.MatchStatusCompletedEnum); err != nil {
+		return NewTransitionError(err)
+	}
+	
+	// Knockout slots stay undecided until the previous round or group stage is over
+	if match.HasUndecidedTeams() {
+		return NewValidationError("match teams are not decided yet")
+	}
+	
+	// Walkovers, forfeits and disqualifications get the sport's default score
+	result, err = applyForfeitDefaults(context.Background(), s.tournamentRepo, match, result)
+	if err != nil {
+		return err
+	}
+	
+	// Draws are only accepted in rounds that allow them (round-robin, group stage)
+	if err := result.ValidateForRound(match.GetRound(), match.Team1, match.Team2); err != nil {
+		return NewValidationError(err.Error())
+	}
+	
+	// Set scores must follow the sport's set rules (points per set, best of)
+	if err := validateSetsForTournament(context.Background(), s.tournamentRepo, match.TournamentID, result); err != nil {
+		return err
+	}
+	
+	// Extra time and shootout scores are kept alongside the regulation score
+	from := match.GetStatus()
+	match.ApplyResult(result)
+	if err := match.TransitionTo(models.MatchStatusCompletedEnum); err != nil {
+		return NewTransitionError(err)
+	}
+	
+	// Save the result, fill the next-round slots and move the tournament along atomically
+	matches, advanced, transitions, err := saveWithAdvancement(context.Background(), s.matchRepo, s.tournamentRepo, match, from)
+	if err != nil {
+		return err
 	}
 
 	// Send specific match result notification
 	if s.notificationService != nil {
 		s.notificationService.NotifyMatchResult(match)
+		if advanced {
+			s.notificationService.NotifyMatchAdvancement(match, matches)
+		}
+	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshPlacements(match.TournamentID)
+	s.refreshCourtBoard(match)
+
+	return nil
+}
+
+// CorrectMatchResult changes the result of a completed match and re-derives the
+// downstream bracket. Slots the old winner or loser advanced into are reassigned;
+// matches already played with those teams are reset to pending, which must be
+// confirmed with confirmReset. The bracket is read, corrected and saved with the
+// correction record in one transaction that locks the tournament's matches, so
+// a result recorded at the same time downstream is corrected from, not over.
+func (s *matchService) CorrectMatchResult(matchID int, result models.MatchResult, reason string, correctedBy *int, confirmReset bool) (*models.ResultCorrection, error) {
+	ctx := context.Background()
+	
+	if strings.TrimSpace(reason) == "" {
+		return nil, NewValidationError("correction reason is required")
+	}
+	
+	match, err := s.GetMatch(matchID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Results of archived events are kept as they were
+	if err := s.ensureWritable(ctx, match.TournamentID); err != nil {
+		return nil, err
+	}
+	
+	var correction *models.ResultCorrection
+	var matches, changed []*models.Match
+	var transitions []tournamentTransition
+	err = s.matchRepo.CorrectBracket(ctx, uint(match.TournamentID), func(tournament *models.Tournament, locked []*models.Match) (*models.ResultCorrection, []*models.Match, error) {
+		// The cascade starts from the locked copy of the match, not the one read above
+		matches, match = locked, nil
+		for _, m := range matches {
+			if m.ID == matchID {
+				match = m
+			}
+		}
+		if match == nil {
+			return nil, nil, NewNotFoundError("match not found")
+		}
+		
+		corrected, err := applyForfeitDefaults(ctx, s.tournamentRepo, match, result)
+		if err != nil {
+			return nil, nil, err
+		}
+		
+		if err := validateSetsForTournament(ctx, s.tournamentRepo, match.TournamentID, corrected); err != nil {
+			return nil, nil, err
+		}
+		
+		correction, changed, err = models.CorrectResult(match, matches, corrected)
+		if err != nil {
+			var transitionErr *models.MatchTransitionError
+			if errors.As(err, &transitionErr) {
+				return nil, nil, NewTransitionError(err)
+			}
+			return nil, nil, NewValidationError(err.Error())
+		}
+		
+		if correction.HasPlayedImpacts() && !confirmReset {
+			return nil, nil, NewConflictError("correction resets matches that were already played: " + strings.Join(correction.Warnings, "; "))
+		}
+		
+		// A corrected double forfeit turns the next-round opponents into walkovers
+		changed, err = resolveWithdrawnMatches(ctx, s.tournamentRepo, match.TournamentID, matches, changed)
+		if err != nil {
+			return nil, nil, err
+		}
+		
+		correction.Reason = strings.TrimSpace(reason)
+		correction.CorrectedBy = correctedBy
+
+		// A reset final reopens a completed tournament
+		transitions, err = advanceTournamentStatus(tournament, matches)
+		if err != nil {
+			return nil, nil, err
+		}
+		return correction, append([]*models.Match{match}, changed...), nil
+	})
+	if err != nil {
+		var serviceErr *ServiceError
+		if errors.As(err, &serviceErr) {
+			return nil, serviceErr
+		}
+		logger.Error("Failed to save result correction", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to save result correction")
+	}
+	
+	logger.Info("Match result corrected", "matchID", match.ID, "affectedMatches", len(changed), "correctedBy", correctedBy)
+	
+	if s.notificationService != nil {
+		s.notificationService.NotifyResultCorrection(match, correction, matches)
+	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshPlacements(match.TournamentID)
+	s.refreshCourtBoard(match)
+	
+	return correction, nil
+}
+
+// GetMatchCorrections retrieves the result corrections recorded for a match
+func (s *matchService) GetMatchCorrections(matchID int) ([]*models.ResultCorrection, error) {
+	if _, err := s.GetMatch(matchID); err != nil {
+		return nil, err
+	}
+	
+	corrections, err := s.matchRepo.GetCorrections(context.Background(), uint(matchID))
+	if err != nil {
+		logger.Error("Failed to get match corrections", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to get match corrections")
+	}
+	if corrections == nil {
+		corrections = []*models.ResultCorrection{}
 	}
+	return corrections, nil
+}
 
+// AppendMatchEvent records an event on the match timeline and returns the live
+// score derived from the whole timeline. Events are checked against the sport's
+// scoring (goals or sets) and cannot be recorded before kickoff or after the end.
+// A kickoff starts a match that is not in progress yet through ChangeMatchStatus.
+// The official result is still submitted with UpdateMatchResult.
+func (s *matchService) AppendMatchEvent(matchID int, event *models.MatchEvent) (*models.LiveScore, error) {
+	ctx := context.Background()
+	
+	match, err := s.GetMatch(matchID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if match.IsCompleted() {
+		return nil, NewConflictError("match is already completed")
+	}
+	if !match.CanUpdateResult() {
+		return nil, NewTransitionError(match.CheckTransition(models.MatchStatusCompletedEnum))
+	}
+	
+	if err := s.ensureWritable(ctx, match.TournamentID); err != nil {
+		return nil, err
+	}
+	
+	if match.HasUndecidedTeams() {
+		return nil, NewValidationError("match teams are not decided yet")
+	}
+	
+	if err := event.Validate(); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	
+	if s.tournamentRepo != nil {
+		sport, err := tournamentSport(ctx, s.tournamentRepo, match.TournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := event.ValidateForSport(sport); err != nil {
+			return nil, NewValidationError(err.Error())
+		}
+	}
+	
+	if err := s.ensurePlayerOnRoster(ctx, match, event); err != nil {
+		return nil, err
+	}
+	
+	events, err := s.matchRepo.GetEvents(ctx, uint(matchID))
+	if err != nil {
+		logger.Error("Failed to get match events", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to get match events")
+	}
+	
+	if err := event.ValidateNext(events); err != nil {
+		return nil, NewConflictError(err.Error())
+	}
+	
+	// The match is started before its kickoff is recorded, so a refused start records nothing
+	if event.Type == models.MatchEventKickoff && !match.IsInProgress() {
+		if match, err = s.ChangeMatchStatus(matchID, models.MatchStatusInProgressEnum, nil); err != nil {
+			return nil, err
+		}
+	}
+	
+	event.MatchID = matchID
+	if err := s.matchRepo.CreateEvent(ctx, event); err != nil {
+		logger.Error("Failed to create match event", "matchID", matchID, "type", event.Type, "error", err)
+		return nil, NewDatabaseError("failed to create match event")
+	}
+	
+	live := models.DeriveLiveScore(matchID, append(events, event))
+	
+	if s.notificationService != nil {
+		s.notificationService.NotifyMatchEvent(match, event, live)
+	}
+	
+	return live, nil
+}
+
+// ensurePlayerOnRoster checks that the player an event is attributed to is on
+// the roster of the team in the event's slot
+func (s *matchService) ensurePlayerOnRoster(ctx context.Context, match *models.Match, event *models.MatchEvent) error {
+	if event.PlayerID == nil {
+		return nil
+	}
+	if s.playerRepo == nil {
+		return NewInternalError("player repository is not configured")
+	}
+	
+	teamID := match.Team1ID
+	if *event.Slot == models.SlotTeam2 {
+		teamID = match.Team2ID
+	}
+	if teamID == nil {
+		return NewValidationError("team is not registered, so players cannot be recorded")
+	}
+	
+	entry, err := s.playerRepo.GetRosterEntry(ctx, uint(match.TournamentID), uint(*event.PlayerID))
+	if err != nil {
+		logger.Error("Failed to get roster entry", "matchID", match.ID, "playerID", *event.PlayerID, "error", err)
+		return NewDatabaseError("failed to check roster")
+	}
+	if entry == nil || entry.TeamID != uint(*teamID) {
+		return NewValidationError("player is not on the roster of the team")
+	}
+	
+	if player, err := s.playerRepo.GetByID(ctx, entry.PlayerID); err == nil && player != nil {
+		event.PlayerName = player.Name
+	}
 	return nil
 }
 
+// GetMatchTimeline retrieves the events recorded for a match and the live score
+// derived from them
+func (s *matchService) GetMatchTimeline(matchID int) ([]*models.MatchEvent, *models.LiveScore, error) {
+	if _, err := s.GetMatch(matchID); err != nil {
+		return nil, nil, err
+	}
+	
+	events, err := s.matchRepo.GetEvents(context.Background(), uint(matchID))
+	if err != nil {
+		logger.Error("Failed to get match events", "matchID", matchID, "error", err)
+		return nil, nil, NewDatabaseError("failed to get match events")
+	}
+	if events == nil {
+		events = []*models.MatchEvent{}
+	}
+	
+	return events, models.DeriveLiveScore(matchID, events), nil
+}
+
 // GetMatchStatistics retrieves match statistics for a tournament
 func (s *matchService) GetMatchStatistics(tournamentID int) (*MatchStatistics, error) {
 	matches, err := s.GetMatchesByTournament(tournamentID)
@@ -231,19 +749,68 @@ func (s *matchService) GetMatchStatistics(tournamentID int) (*MatchStatistics, e
 	}
 	
 	stats := &MatchStatistics{
-		TournamentID:   tournamentID,
-		TotalMatches:   len(matches),
-		MatchesByRound: make(map[string]int),
-		AverageScore:   make(map[string]float64),
-		TeamStats:      make(map[string]*TeamStats),
+		TournamentID:      tournamentID,
+		TotalMatches:      len(matches),
+		MatchesByRound:    make(map[string]int),
+		MatchesByDecision: make(map[string]int),
+		MatchesByResult:   make(map[string]int),
+		AverageScore:      make(map[string]float64),
+		TeamStats:         make(map[string]*TeamStats),
 	}
 	
+	teamStats := func(team string) *TeamStats {
+		if stats.TeamStats[team] == nil {
+			stats.TeamStats[team] = &TeamStats{TeamName: team}
+		}
+		return stats.TeamStats[team]
+	}
+	
+	// Scored matches only: default forfeit scores would distort the averages
+	scoredMatches := make(map[string]int)
 	completedCount := 0
 	for _, match := range matches {
 		stats.MatchesByRound[match.Round]++
 		
-		if match.Status == "completed" {
-			completedCount++
+		if !match.IsCompleted() || !match.HasResult() {
+			continue
+		}
+		completedCount++
+		stats.MatchesByResult[match.GetResultType().String()]++
+		if decision := match.GetDecisionMethod(); decision != "" {
+			stats.MatchesByDecision[decision.String()]++
+		}
+		
+		for _, slot := range []int{models.SlotTeam1, models.SlotTeam2} {
+			team := match.GetTeamInSlot(slot)
+			if models.IsUndecidedTeam(team) || team == models.TeamWithdrawn {
+				continue
+			}
+			ts := teamStats(team)
+			ts.MatchesPlayed++
+			switch {
+			case match.WinnerSlot() == slot:
+				ts.Wins++
+			case match.Winner != nil || match.IsDoubleForfeit():
+				ts.Losses++
+			}
+			if match.HasForfeited(slot) {
+				ts.Forfeits++
+			}
+			if !match.IsForfeit() {
+				score := *match.Score1
+				if slot == models.SlotTeam2 {
+					score = *match.Score2
+				}
+				ts.TotalScore += score
+				scoredMatches[team]++
+			}
+		}
+	}
+	
+	for team, ts := range stats.TeamStats {
+		if scoredMatches[team] > 0 {
+			ts.AverageScore = float64(ts.TotalScore) / float64(scoredMatches[team])
+			stats.AverageScore[team] = ts.AverageScore
 		}
 	}
 	
@@ -260,4 +827,31 @@ func (s *matchService) GetMatchStatistics(tournamentID int) (*MatchStatistics, e
 // SetNotificationService sets the notification service for real-time updates
 func (s *matchService) SetNotificationService(notificationService *NotificationService) {
 	s.notificationService = notificationService
+}
+
+// SetChampionshipService sets the championship service that recomputes final
+// placements after a result is saved or corrected
+func (s *matchService) SetChampionshipService(championshipService ChampionshipService) {
+	s.championshipService = championshipService
+}
+
+// refreshPlacements updates the championship table when a result may have
+// decided or changed a final placement of the tournament
+func (s *matchService) refreshPlacements(tournamentID int) {
+	if s.championshipService != nil {
+		s.championshipService.RefreshPlacements(context.Background(), tournamentID)
+	}
+}
+
+// SetVenueService sets the venue service that pushes the queue of a court
+// after one of its matches is updated or finished
+func (s *matchService) SetVenueService(venueService VenueService) {
+	s.venueService = venueService
+}
+
+// refreshCourtBoard updates the board of the court the match is played on
+func (s *matchService) refreshCourtBoard(match *models.Match) {
+	if s.venueService != nil {
+		s.venueService.RefreshCourtBoards(context.Background(), match.CourtID)
+	}
 }
\ No newline at end of file
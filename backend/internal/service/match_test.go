@@ -1,573 +1,703 @@
 package service
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
+
 	"backend/internal/models"
 )
 
+// matchServiceMocks はMatchServiceのテストで使うモックのリポジトリ
+type matchServiceMocks struct {
+	matchRepo      *MockMatchRepository
+	tournamentRepo *MockTournamentRepository
+	eventRepo      *MockEventRepository
+	teamRepo       *MockTeamRepository
+}
+
+// newTestMatchService はモックのリポジトリを使うMatchServiceを作成する
+// トーナメント1は開催中の大会1に属し、試合のチームは登録されていない名前として扱う
+func newTestMatchService(sport string) (MatchService, *matchServiceMocks) {
+	mocks := &matchServiceMocks{
+		matchRepo:      new(MockMatchRepository),
+		tournamentRepo: new(MockTournamentRepository),
+		eventRepo:      new(MockEventRepository),
+		teamRepo:       new(MockTeamRepository),
+	}
+	tournament := &models.Tournament{ID: 1, EventID: 1, Sport: sport, Format: models.FormatStandard, Status: models.TournamentStatusActive}
+	mocks.tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tournament, nil).Maybe()
+	mocks.eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil).Maybe()
+	mocks.teamRepo.On("GetByTournamentAndName", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	service := NewMatchService(mocks.matchRepo, mocks.tournamentRepo, mocks.eventRepo, mocks.teamRepo, new(MockPlayerRepository))
+	return service, mocks
+}
+
+// newTestMatch はトーナメント1の未実施の試合を作成する
+func newTestMatch(id int, round models.RoundType, team1, team2 string) *models.Match {
+	return &models.Match{
+		ID:           id,
+		TournamentID: 1,
+		Round:        string(round),
+		Team1:        team1,
+		Team2:        team2,
+		Status:       models.MatchStatusPending,
+	}
+}
+
 func TestNewMatchService(t *testing.T) {
-	mockMatchRepo := NewMockMatchRepository()
-	mockTournamentRepo := NewMockTournamentRepository()
-	
-	service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-	
+	service, _ := newTestMatchService(models.SportVolleyball)
+
 	if service == nil {
 		t.Error("MatchServiceの作成に失敗しました")
 	}
 }
 
 func TestMatchService_CreateMatch(t *testing.T) {
+	teamID := func(id int) *int { return &id }
+
 	tests := []struct {
-		name          string
-		match         *models.Match
-		setupTournament bool
-		expectedError bool
-		errorMessage  string
+		name              string
+		match             *models.Match
+		archived          bool
+		repoErr           error
+		expectedErrorType string
+		wantTeams         [2]string
+		wantTeamIDs       [2]int // 0は登録チームでない枠
 	}{
 		{
-			name: "正常な試合作成",
-			match: &models.Match{
-				TournamentID: 1,
-				Round:        models.Round1stRound,
-				Team1:        "チーム1",
-				Team2:        "チーム2",
-				Status:       models.MatchStatusPending,
-				ScheduledAt:  time.Now().Add(time.Hour),
-			},
-			setupTournament: true,
-			expectedError:   false,
+			name:        "正常な試合作成",
+			match:       newTestMatch(0, models.Round1stRoundEnum, "IE4", "IS4"),
+			wantTeams:   [2]string{"IE4", "IS4"},
+			wantTeamIDs: [2]int{11, 12},
 		},
 		{
-			name:          "nil試合",
-			match:         nil,
-			expectedError: true,
-			errorMessage:  "試合は必須です",
+			name:        "チームIDで指定",
+			match:       &models.Match{TournamentID: 1, Round: models.Round1stRound, Team1ID: teamID(11), Team2ID: teamID(12), Status: models.MatchStatusPending},
+			wantTeams:   [2]string{"IE4", "IS4"},
+			wantTeamIDs: [2]int{11, 12},
 		},
 		{
-			name: "存在しないトーナメント",
-			match: &models.Match{
-				TournamentID: 999,
-				Round:        models.Round1stRound,
-				Team1:        "チーム1",
-				Team2:        "チーム2",
-				Status:       models.MatchStatusPending,
-				ScheduledAt:  time.Now().Add(time.Hour),
-			},
-			expectedError: true,
-			errorMessage:  "指定されたトーナメントが見つかりません",
-		},
-		{
-			name: "無効な試合データ",
-			match: &models.Match{
-				TournamentID: 1,
-				Round:        "invalid_round",
-				Team1:        "チーム1",
-				Team2:        "チーム2",
-				Status:       models.MatchStatusPending,
-				ScheduledAt:  time.Now().Add(time.Hour),
-			},
-			setupTournament: true,
-			expectedError:   true,
-			errorMessage:    "試合の検証に失敗しました: 無効なラウンドです",
+			name:        "登録されていないチーム名",
+			match:       newTestMatch(0, models.Round1stRoundEnum, "IE4", "教員"),
+			wantTeams:   [2]string{"IE4", "教員"},
+			wantTeamIDs: [2]int{11, 0},
+		},
+		{
+			name:              "別のトーナメントのチーム",
+			match:             &models.Match{TournamentID: 1, Round: models.Round1stRound, Team1ID: teamID(11), Team2ID: teamID(21), Status: models.MatchStatusPending},
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "存在しないトーナメント",
+			match:             &models.Match{TournamentID: 999, Round: models.Round1stRound, Team1: "IE4", Team2: "IS4", Status: models.MatchStatusPending},
+			expectedErrorType: ErrorTypeNotFound,
+		},
+		{
+			name:              "同じチーム同士",
+			match:             newTestMatch(0, models.Round1stRoundEnum, "IE4", "IE4"),
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "アーカイブ済みの大会",
+			match:             newTestMatch(0, models.Round1stRoundEnum, "IE4", "IS4"),
+			archived:          true,
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:              "データベースエラー",
+			match:             newTestMatch(0, models.Round1stRoundEnum, "IE4", "IS4"),
+			repoErr:           errors.New("connection refused"),
+			expectedErrorType: ErrorTypeDatabase,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockMatchRepo := NewMockMatchRepository()
-			mockTournamentRepo := NewMockTournamentRepository()
-			service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-			
-			// テストトーナメントを設定
-			if tt.setupTournament {
-				mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-			}
-			
+			mocks := &matchServiceMocks{
+				matchRepo:      new(MockMatchRepository),
+				tournamentRepo: new(MockTournamentRepository),
+				eventRepo:      new(MockEventRepository),
+				teamRepo:       new(MockTeamRepository),
+			}
+			event := newTestEvent(1)
+			if tt.archived {
+				event.SetStatus(models.EventStatusArchivedEnum)
+			}
+			mocks.tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(&models.Tournament{ID: 1, EventID: 1, Sport: models.SportVolleyball}, nil)
+			mocks.tournamentRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, nil).Maybe()
+			mocks.eventRepo.On("GetByID", mock.Anything, uint(1)).Return(event, nil)
+			mocks.teamRepo.On("GetByID", mock.Anything, uint(11)).Return(&models.Team{ID: 11, TournamentID: 1, Name: "IE4"}, nil).Maybe()
+			mocks.teamRepo.On("GetByID", mock.Anything, uint(12)).Return(&models.Team{ID: 12, TournamentID: 1, Name: "IS4"}, nil).Maybe()
+			mocks.teamRepo.On("GetByID", mock.Anything, uint(21)).Return(&models.Team{ID: 21, TournamentID: 2, Name: "IT4"}, nil).Maybe()
+			mocks.teamRepo.On("GetByTournamentAndName", mock.Anything, uint(1), "IE4").Return(&models.Team{ID: 11, TournamentID: 1, Name: "IE4"}, nil).Maybe()
+			mocks.teamRepo.On("GetByTournamentAndName", mock.Anything, uint(1), "IS4").Return(&models.Team{ID: 12, TournamentID: 1, Name: "IS4"}, nil).Maybe()
+			mocks.teamRepo.On("GetByTournamentAndName", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			mocks.matchRepo.On("Create", mock.Anything, tt.match).Return(tt.repoErr).Maybe()
+			service := NewMatchService(mocks.matchRepo, mocks.tournamentRepo, mocks.eventRepo, mocks.teamRepo, new(MockPlayerRepository))
+
 			err := service.CreateMatch(tt.match)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				if tt.repoErr == nil {
+					mocks.matchRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			mocks.matchRepo.AssertCalled(t, "Create", mock.Anything, tt.match)
+			if tt.match.Team1 != tt.wantTeams[0] || tt.match.Team2 != tt.wantTeams[1] {
+				t.Errorf("期待されたチーム: %v, 実際: %s vs %s", tt.wantTeams, tt.match.Team1, tt.match.Team2)
+			}
+			for i, id := range []*int{tt.match.Team1ID, tt.match.Team2ID} {
+				got := 0
+				if id != nil {
+					got = *id
 				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
+				if got != tt.wantTeamIDs[i] {
+					t.Errorf("期待されたチーム%dのID: %d, 実際: %d", i+1, tt.wantTeamIDs[i], got)
 				}
 			}
 		})
 	}
 }
 
-func TestMatchService_UpdateMatchResult(t *testing.T) {
+func TestMatchService_UpdateMatch(t *testing.T) {
+	scheduledAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	rescheduledAt := scheduledAt.Add(2 * time.Hour)
+	intPtr := func(v int) *int { return &v }
+	stringPtr := func(v string) *string { return &v }
+
 	tests := []struct {
-		name          string
-		matchID       int
-		result        models.MatchResult
-		setupMatch    bool
-		matchCompleted bool
-		expectedError bool
-		errorMessage  string
+		name              string
+		request           func(match *models.Match)
+		expectedErrorType string
+		wantStatus        models.MatchStatus
+		wantScheduledAt   time.Time
 	}{
 		{
-			name:    "正常な試合結果更新",
-			matchID: 1,
-			result: models.MatchResult{
-				Score1: 3,
-				Score2: 1,
-				Winner: "チーム1",
-			},
-			setupMatch:    true,
-			expectedError: false,
+			name:            "日程の変更",
+			request:         func(match *models.Match) { match.ScheduledAt = rescheduledAt },
+			wantStatus:      models.MatchStatusPendingEnum,
+			wantScheduledAt: rescheduledAt,
 		},
 		{
-			name:          "無効な試合ID",
-			matchID:       0,
-			result:        models.MatchResult{},
-			expectedError: true,
-			errorMessage:  "無効な試合IDです",
+			name:            "試合の開始は状態遷移で行う",
+			request:         func(match *models.Match) { match.Status = string(models.MatchStatusInProgressEnum) },
+			wantStatus:      models.MatchStatusInProgressEnum,
+			wantScheduledAt: scheduledAt,
 		},
 		{
-			name:    "引き分け結果",
-			matchID: 1,
-			result: models.MatchResult{
-				Score1: 2,
-				Score2: 2,
-				Winner: "チーム1",
+			name: "延期は元の開始時刻を残す",
+			request: func(match *models.Match) {
+				match.Status = string(models.MatchStatusRescheduledEnum)
+				match.ScheduledAt = rescheduledAt
 			},
-			setupMatch:    true,
-			expectedError: true,
-			errorMessage:  "試合結果の検証に失敗しました: 引き分けは許可されていません",
+			wantStatus:      models.MatchStatusRescheduledEnum,
+			wantScheduledAt: rescheduledAt,
 		},
 		{
-			name:    "完了済み試合の更新",
-			matchID: 1,
-			result: models.MatchResult{
-				Score1: 3,
-				Score2: 1,
-				Winner: "チーム1",
+			name: "結果は結果の登録で行う",
+			request: func(match *models.Match) {
+				match.Status = models.MatchStatusCompleted
+				match.Score1, match.Score2, match.Winner = intPtr(2), intPtr(0), stringPtr("IE4")
 			},
-			setupMatch:     true,
-			matchCompleted: true,
-			expectedError:  true,
-			errorMessage:   "既に完了している試合の結果は更新できません",
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "許可されていない変更",
+			request:           func(match *models.Match) { match.Status = string(models.MatchStatusSuspendedEnum) },
+			expectedErrorType: ErrorTypeInvalidTransition,
+		},
+		{
+			name:              "別のトーナメントに移す",
+			request:           func(match *models.Match) { match.TournamentID = 2 },
+			expectedErrorType: ErrorTypeValidation,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockMatchRepo := NewMockMatchRepository()
-			mockTournamentRepo := NewMockTournamentRepository()
-			service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-			
-			// テストトーナメントを設定
-			tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-			
-			// テスト試合を設定
-			if tt.setupMatch {
-				match := &models.Match{
-					TournamentID: tournament.ID,
-					Round:        models.Round1stRound,
-					Team1:        "チーム1",
-					Team2:        "チーム2",
-					Status:       models.MatchStatusPending,
-					ScheduledAt:  time.Now().Add(time.Hour),
-				}
-				
-				if tt.matchCompleted {
-					match.Status = models.MatchStatusCompleted
-					score1 := 2
-					score2 := 1
-					winner := "チーム1"
-					match.Score1 = &score1
-					match.Score2 = &score2
-					match.Winner = &winner
-					completedAt := time.Now()
-					match.CompletedAt = &completedAt
-				}
-				
-				mockMatchRepo.Create(match)
+			service, mocks := newTestMatchService(models.SportSoccer)
+			stored := newTestMatch(1, models.Round1stRoundEnum, "IE4", "IS4")
+			stored.ScheduledAt = scheduledAt
+			locked := *stored
+			mocks.matchRepo.On("GetByID", mock.Anything, uint(1)).Return(stored, nil)
+			mocks.matchRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mocks.matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return([]*models.Match{&locked}, nil).Maybe()
+
+			request := *stored
+			tt.request(&request)
+			err := service.UpdateMatch(&request)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				mocks.matchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
 			}
-			
-			err := service.UpdateMatchResult(tt.matchID, tt.result)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			// ラウンド・チーム・開始時刻を保存し、ステータスの変更はブラケットをロックして保存する
+			mocks.matchRepo.AssertNumberOfCalls(t, "Update", 1)
+			if tt.wantStatus != models.MatchStatusPendingEnum && len(mocks.matchRepo.Saved) != 1 {
+				t.Errorf("ステータスの変更が保存されていません: %v", mocks.matchRepo.Saved)
+			}
+			if request.GetStatus() != tt.wantStatus {
+				t.Errorf("期待されたステータス: %s, 実際: %s", tt.wantStatus, request.GetStatus())
+			}
+			if !request.ScheduledAt.Equal(tt.wantScheduledAt) {
+				t.Errorf("期待された開始時刻: %v, 実際: %v", tt.wantScheduledAt, request.ScheduledAt)
+			}
+			if tt.wantStatus == models.MatchStatusRescheduledEnum && (request.PlannedAt == nil || !request.PlannedAt.Equal(scheduledAt)) {
+				t.Errorf("元の開始時刻が残っていません: %v", request.PlannedAt)
 			}
 		})
 	}
 }
 
-func TestMatchService_ValidateMatchResult(t *testing.T) {
+func TestMatchService_UpdateMatchResult(t *testing.T) {
+	// 準決勝1の勝者は決勝の1枠目に進む
+	newBracket := func() []*models.Match {
+		semifinal1 := newTestMatch(1, models.RoundSemifinalEnum, "IE4", "IS4")
+		semifinal2 := newTestMatch(2, models.RoundSemifinalEnum, "IT4", "IC4")
+		final := newTestMatch(3, models.RoundFinalEnum, models.TeamTBD, models.TeamTBD)
+		semifinal1.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam1}})
+		semifinal2.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam2}})
+		return []*models.Match{semifinal1, semifinal2, final}
+	}
+
 	tests := []struct {
-		name          string
-		matchID       int
-		result        models.MatchResult
-		setupMatch    bool
-		expectedError bool
-		errorMessage  string
+		name              string
+		matchID           int
+		result            models.MatchResult
+		prepare           func(matches []*models.Match)
+		concurrent        func(locked []*models.Match)
+		expectedErrorType string
 	}{
 		{
-			name:    "正常な結果検証",
+			name:    "正常な試合結果更新",
 			matchID: 1,
-			result: models.MatchResult{
-				Score1: 3,
-				Score2: 1,
-				Winner: "チーム1",
-			},
-			setupMatch:    true,
-			expectedError: false,
+			result:  models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
 		},
 		{
-			name:    "無効な勝者",
-			matchID: 1,
-			result: models.MatchResult{
-				Score1: 3,
-				Score2: 1,
-				Winner: "チーム3",
-			},
-			setupMatch:    true,
-			expectedError: true,
-			errorMessage:  "勝者は参加チームのいずれかである必要があります",
+			name:              "無効な試合ID",
+			matchID:           99,
+			result:            models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			expectedErrorType: ErrorTypeNotFound,
+		},
+		{
+			name:              "完了済み試合の更新",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			prepare:           func(matches []*models.Match) { matches[0].SetStatus(models.MatchStatusCompletedEnum) },
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:              "対戦チームが未確定",
+			matchID:           3,
+			result:            models.MatchResult{Score1: 2, Score2: 0, Winner: "IE4"},
+			expectedErrorType: ErrorTypeValidation,
 		},
 		{
-			name:    "スコアと勝者の不一致",
+			name:              "引き分け結果",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 1},
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "無効な勝者",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 2, Score2: 1, Winner: "IT4"},
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "スコアと勝者の不一致",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 2, Winner: "IE4"},
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "中止した試合",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			prepare:           func(matches []*models.Match) { matches[0].SetStatus(models.MatchStatusCancelledEnum) },
+			expectedErrorType: ErrorTypeInvalidTransition,
+		},
+		{
+			name:    "読み込み後に別のリクエストで結果が登録された",
 			matchID: 1,
-			result: models.MatchResult{
-				Score1: 1,
-				Score2: 3,
-				Winner: "チーム1",
+			result:  models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"},
+			concurrent: func(locked []*models.Match) {
+				locked[0].ApplyResult(models.MatchResult{Score1: 0, Score2: 2, Winner: "IS4"})
+				locked[0].SetStatus(models.MatchStatusCompletedEnum)
 			},
-			setupMatch:    true,
-			expectedError: true,
-			errorMessage:  "スコアと勝者が一致しません",
+			expectedErrorType: ErrorTypeConflict,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockMatchRepo := NewMockMatchRepository()
-			mockTournamentRepo := NewMockTournamentRepository()
-			service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-			
-			// テストトーナメントを設定
-			tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-			
-			// テスト試合を設定
-			if tt.setupMatch {
-				match := &models.Match{
-					TournamentID: tournament.ID,
-					Round:        models.Round1stRound,
-					Team1:        "チーム1",
-					Team2:        "チーム2",
-					Status:       models.MatchStatusPending,
-					ScheduledAt:  time.Now().Add(time.Hour),
-				}
-				mockMatchRepo.Create(match)
-			}
-			
-			err := service.ValidateMatchResult(tt.matchID, tt.result)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
+			service, mocks := newTestMatchService(models.SportSoccer)
+			matches := newBracket()
+			if tt.prepare != nil {
+				tt.prepare(matches)
+			}
+			// 結果を受け付けた時点の試合と、ロックして読み直したブラケットは別のコピー
+			var read *models.Match
+			if tt.matchID <= len(matches) {
+				stored := *matches[tt.matchID-1]
+				read = &stored
+			}
+			if tt.concurrent != nil {
+				tt.concurrent(matches)
+			}
+			mocks.matchRepo.On("GetByID", mock.Anything, uint(tt.matchID)).Return(read, nil)
+			mocks.matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return(matches, nil).Maybe()
+
+			err := service.UpdateMatchResult(tt.matchID, tt.result)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				if mocks.matchRepo.Saved != nil {
+					t.Errorf("エラー時に試合が保存されました: %v", mocks.matchRepo.Saved)
 				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if !read.IsCompleted() || read.Winner == nil || *read.Winner != "IE4" {
+				t.Errorf("試合の結果が保存されていません: %+v", read)
+			}
+			if matches[2].Team1 != "IE4" {
+				t.Errorf("決勝の1枠目が期待されたチーム: IE4, 実際: %s", matches[2].Team1)
+			}
+			want := []*models.Match{read, matches[2]}
+			if len(mocks.matchRepo.Saved) != len(want) || mocks.matchRepo.Saved[0] != want[0] || mocks.matchRepo.Saved[1] != want[1] {
+				t.Errorf("保存された試合が異なります: %v", mocks.matchRepo.Saved)
 			}
 		})
 	}
 }
 
-func TestMatchService_AdvanceWinner(t *testing.T) {
-	mockMatchRepo := NewMockMatchRepository()
-	mockTournamentRepo := NewMockTournamentRepository()
-	service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-	
-	// テストトーナメントを設定
-	tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-	
-	// 1回戦の試合を作成（完了済み）
-	match1 := &models.Match{
-		TournamentID: tournament.ID,
-		Round:        models.Round1stRound,
-		Team1:        "チーム1",
-		Team2:        "チーム2",
-		Status:       models.MatchStatusCompleted,
-		ScheduledAt:  time.Now(),
-	}
-	score1 := 3
-	score2 := 1
-	winner := "チーム1"
-	match1.Score1 = &score1
-	match1.Score2 = &score2
-	match1.Winner = &winner
-	completedAt := time.Now()
-	match1.CompletedAt = &completedAt
-	mockMatchRepo.Create(match1)
-	
-	// 準々決勝の試合を作成（TBD）
-	match2 := &models.Match{
-		TournamentID: tournament.ID,
-		Round:        models.RoundQuarterfinal,
-		Team1:        "TBD",
-		Team2:        "チーム3",
-		Status:       models.MatchStatusPending,
-		ScheduledAt:  time.Now().Add(time.Hour),
+func TestMatchService_CorrectMatchResult(t *testing.T) {
+	// 準決勝は IE4・IT4 の勝ちで終了し、決勝は IE4 vs IT4
+	newBracket := func() []*models.Match {
+		semifinal1 := newTestMatch(1, models.RoundSemifinalEnum, "IE4", "IS4")
+		semifinal2 := newTestMatch(2, models.RoundSemifinalEnum, "IT4", "IC4")
+		final := newTestMatch(3, models.RoundFinalEnum, "IE4", "IT4")
+		semifinal1.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam1}})
+		semifinal2.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 3, Slot: models.SlotTeam2}})
+		semifinal1.ApplyResult(models.MatchResult{Score1: 2, Score2: 1, Winner: "IE4"})
+		semifinal1.SetStatus(models.MatchStatusCompletedEnum)
+		semifinal2.ApplyResult(models.MatchResult{Score1: 3, Score2: 0, Winner: "IT4"})
+		semifinal2.SetStatus(models.MatchStatusCompletedEnum)
+		return []*models.Match{semifinal1, semifinal2, final}
 	}
-	mockMatchRepo.Create(match2)
-	
-	// 勝者進出処理を実行
-	err := service.AdvanceWinner(1)
-	
-	if err != nil {
-		t.Errorf("予期しないエラー: %v", err)
-	}
-	
-	// 次のラウンドの試合が更新されたかチェック
-	updatedMatch, err := mockMatchRepo.GetByID(2)
-	if err != nil {
-		t.Errorf("更新された試合の取得に失敗: %v", err)
+	finalPlayed := func(matches []*models.Match) {
+		matches[2].ApplyResult(models.MatchResult{Score1: 1, Score2: 0, Winner: "IE4"})
+		matches[2].SetStatus(models.MatchStatusCompletedEnum)
 	}
-	
-	if updatedMatch.Team1 != "チーム1" {
-		t.Errorf("期待されたチーム1: チーム1, 実際: %s", updatedMatch.Team1)
-	}
-}
 
-func TestMatchService_GetMatchStatistics(t *testing.T) {
-	mockMatchRepo := NewMockMatchRepository()
-	mockTournamentRepo := NewMockTournamentRepository()
-	service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-	
-	// テストトーナメントを設定
-	tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-	
-	// テスト試合を作成
-	for i := 0; i < 4; i++ {
-		match := &models.Match{
-			TournamentID: tournament.ID,
-			Round:        models.Round1stRound,
-			Team1:        "チーム1",
-			Team2:        "チーム2",
-			Status:       models.MatchStatusPending,
-			ScheduledAt:  time.Now().Add(time.Duration(i) * time.Hour),
-		}
-		
-		// 半分の試合を完了状態にする
-		if i < 2 {
-			match.Status = models.MatchStatusCompleted
-			score1 := 3
-			score2 := 1
-			winner := "チーム1"
-			match.Score1 = &score1
-			match.Score2 = &score2
-			match.Winner = &winner
-			completedAt := time.Now()
-			match.CompletedAt = &completedAt
-		}
-		
-		mockMatchRepo.Create(match)
-	}
-	
-	stats, err := service.GetMatchStatistics(tournament.ID)
-	
-	if err != nil {
-		t.Errorf("予期しないエラー: %v", err)
+	tests := []struct {
+		name              string
+		matchID           int
+		result            models.MatchResult
+		reason            string
+		confirmReset      bool
+		prepare           func(matches []*models.Match)
+		concurrent        func(matches []*models.Match)
+		tournamentStatus  models.TournamentStatus
+		saveErr           error
+		expectedErrorType string
+		wantFinal         [2]string
+		wantTournament    models.TournamentStatus
+	}{
+		{
+			name:      "勝者の訂正で決勝の枠が入れ替わる",
+			matchID:   1,
+			result:    models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:    "スコアの記入ミス",
+			wantFinal: [2]string{"IS4", "IT4"},
+		},
+		{
+			name:      "スコアのみの訂正",
+			matchID:   1,
+			result:    models.MatchResult{Score1: 3, Score2: 1, Winner: "IE4"},
+			reason:    "スコアの記入ミス",
+			wantFinal: [2]string{"IE4", "IT4"},
+		},
+		{
+			name:              "理由なし",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:            " ",
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "実施済みの決勝のリセットを確認していない",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:            "スコアの記入ミス",
+			prepare:           finalPlayed,
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:         "実施済みの決勝のリセットを確認済み",
+			matchID:      1,
+			result:       models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:       "スコアの記入ミス",
+			confirmReset: true,
+			prepare:      finalPlayed,
+			wantFinal:    [2]string{"IS4", "IT4"},
+		},
+		{
+			name:             "決勝のやり直しで終了したトーナメントを再開する",
+			matchID:          1,
+			result:           models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:           "スコアの記入ミス",
+			confirmReset:     true,
+			prepare:          finalPlayed,
+			tournamentStatus: models.TournamentStatusCompletedEnum,
+			wantFinal:        [2]string{"IS4", "IT4"},
+			wantTournament:   models.TournamentStatusActiveEnum,
+		},
+		{
+			// 試合の取得後、ロックするまでに決勝の結果が記録された
+			name:              "訂正中に決勝の結果が記録された",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:            "スコアの記入ミス",
+			concurrent:        finalPlayed,
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:              "未実施の試合",
+			matchID:           3,
+			result:            models.MatchResult{Score1: 1, Score2: 0, Winner: "IE4"},
+			reason:            "スコアの記入ミス",
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "データベースエラー",
+			matchID:           1,
+			result:            models.MatchResult{Score1: 1, Score2: 2, Winner: "IS4"},
+			reason:            "スコアの記入ミス",
+			saveErr:           errors.New("connection refused"),
+			expectedErrorType: ErrorTypeDatabase,
+		},
 	}
-	
-	if stats == nil {
-		t.Error("統計情報が返されませんでした")
-	} else {
-		if stats.TotalMatches != 4 {
-			t.Errorf("期待された総試合数: 4, 実際: %d", stats.TotalMatches)
-		}
-		if stats.CompletedMatches != 2 {
-			t.Errorf("期待された完了試合数: 2, 実際: %d", stats.CompletedMatches)
-		}
-		if stats.PendingMatches != 2 {
-			t.Errorf("期待された未実施試合数: 2, 実際: %d", stats.PendingMatches)
-		}
-		if stats.CompletionRate != 50.0 {
-			t.Errorf("期待された完了率: 50.0, 実際: %f", stats.CompletionRate)
-		}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newTestMatchService(models.SportSoccer)
+			matches := newBracket()
+			if tt.prepare != nil {
+				tt.prepare(matches)
+			}
+			read := *matches[tt.matchID-1]
+			if tt.concurrent != nil {
+				tt.concurrent(matches)
+			}
+			mocks.matchRepo.On("GetByID", mock.Anything, uint(tt.matchID)).Return(&read, nil)
+			mocks.matchRepo.On("CorrectBracket", mock.Anything, uint(1)).Return(matches, tt.saveErr).Maybe()
+			if tt.tournamentStatus != "" {
+				mocks.matchRepo.Tournament = &models.Tournament{ID: 1, EventID: 1, Sport: models.SportSoccer, Format: models.FormatStandard, Status: string(tt.tournamentStatus)}
+			}
+
+			correction, err := service.CorrectMatchResult(tt.matchID, tt.result, tt.reason, nil, tt.confirmReset)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				if mocks.matchRepo.Saved != nil {
+					t.Errorf("エラー時に試合が保存されました: %v", mocks.matchRepo.Saved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if correction.Reason != tt.reason {
+				t.Errorf("期待された訂正理由: %s, 実際: %s", tt.reason, correction.Reason)
+			}
+			// 訂正はロックして読み直した試合に対して行う
+			corrected := matches[tt.matchID-1]
+			if *corrected.Winner != tt.result.Winner || *corrected.Score1 != tt.result.Score1 {
+				t.Errorf("訂正した結果が試合に反映されていません: %+v", corrected)
+			}
+
+			final := matches[2]
+			if final.Team1 != tt.wantFinal[0] || final.Team2 != tt.wantFinal[1] {
+				t.Errorf("期待された決勝: %v, 実際: %s vs %s", tt.wantFinal, final.Team1, final.Team2)
+			}
+			// 勝者が変わった場合、実施済みの決勝は未実施に戻す
+			if tt.wantFinal[0] != "IE4" && !final.IsPending() {
+				t.Errorf("決勝の状態が期待された状態: pending, 実際: %s", final.GetStatus())
+			}
+
+			// 訂正した試合と影響を受けた試合を1回で保存する
+			mocks.matchRepo.AssertNumberOfCalls(t, "CorrectBracket", 1)
+			wantUpdates := 1
+			if tt.wantFinal[0] != "IE4" {
+				wantUpdates = 2
+			}
+			updates := mocks.matchRepo.Saved
+			if len(updates) != wantUpdates || updates[0] != corrected {
+				t.Errorf("保存された試合が異なります: %v", updates)
+			}
+			// トーナメントのステータスは訂正と同じトランザクションで変更する
+			if tt.wantTournament != "" && mocks.matchRepo.Tournament.GetStatus() != tt.wantTournament {
+				t.Errorf("期待されたトーナメントのステータス: %s, 実際: %s", tt.wantTournament, mocks.matchRepo.Tournament.GetStatus())
+			}
+		})
 	}
 }
 
-func TestMatchService_EnforceTournamentRules(t *testing.T) {
+func TestMatchService_AppendMatchEvent(t *testing.T) {
+	kickoff := func() *models.MatchEvent { return &models.MatchEvent{Type: models.MatchEventKickoff} }
+	slot := func(v int) *int { return &v }
+
 	tests := []struct {
-		name          string
-		sport         string
-		format        string
-		matches       []*models.Match
-		expectedError bool
-		errorMessage  string
+		name              string
+		status            models.MatchStatus
+		events            []*models.MatchEvent
+		event             *models.MatchEvent
+		expectedErrorType string
+		wantStarted       bool
 	}{
 		{
-			name:   "バレーボールルール正常",
-			sport:  models.SportVolleyball,
-			format: models.FormatStandard,
-			matches: []*models.Match{
-				{
-					Round:  models.Round1stRound,
-					Status: models.MatchStatusCompleted,
-					Score1: func() *int { s := 25; return &s }(),
-					Score2: func() *int { s := 20; return &s }(),
-				},
-			},
-			expectedError: false,
-		},
-		{
-			name:   "バレーボールスコア範囲外",
-			sport:  models.SportVolleyball,
-			format: models.FormatStandard,
-			matches: []*models.Match{
-				{
-					Round:  models.Round1stRound,
-					Status: models.MatchStatusCompleted,
-					Score1: func() *int { s := 30; return &s }(),
-					Score2: func() *int { s := 20; return &s }(),
-				},
-			},
-			expectedError: true,
-			errorMessage:  "試合 1 のスコアがバレーボールの有効範囲を超えています",
-		},
-		{
-			name:   "卓球ルール正常",
-			sport:  models.SportTableTennis,
-			format: models.FormatStandard,
-			matches: []*models.Match{
-				{
-					Round:  models.Round1stRound,
-					Status: models.MatchStatusCompleted,
-					Score1: func() *int { s := 11; return &s }(),
-					Score2: func() *int { s := 9; return &s }(),
-				},
-			},
-			expectedError: false,
-		},
-		{
-			name:   "サッカールール正常",
-			sport:  models.SportSoccer,
-			format: models.FormatStandard,
-			matches: []*models.Match{
-				{
-					Round:  models.Round1stRound,
-					Status: models.MatchStatusCompleted,
-					Score1: func() *int { s := 3; return &s }(),
-					Score2: func() *int { s := 1; return &s }(),
-				},
-			},
-			expectedError: false,
+			name:        "試合開始で試合中にする",
+			status:      models.MatchStatusPendingEnum,
+			event:       kickoff(),
+			wantStarted: true,
+		},
+		{
+			name:   "試合中の試合の試合開始",
+			status: models.MatchStatusInProgressEnum,
+			event:  kickoff(),
+		},
+		{
+			name:   "得点",
+			status: models.MatchStatusInProgressEnum,
+			events: []*models.MatchEvent{kickoff()},
+			event:  &models.MatchEvent{Type: models.MatchEventGoal, Slot: slot(models.SlotTeam1)},
+		},
+		{
+			name:              "試合開始前の得点",
+			status:            models.MatchStatusPendingEnum,
+			event:             &models.MatchEvent{Type: models.MatchEventGoal, Slot: slot(models.SlotTeam1)},
+			expectedErrorType: ErrorTypeConflict,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockMatchRepo := NewMockMatchRepository()
-			mockTournamentRepo := NewMockTournamentRepository()
-			service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-			
-			// テストトーナメントを設定
-			tournament := mockTournamentRepo.AddTournament(tt.sport, tt.format, models.TournamentStatusActive)
-			
-			// テスト試合を設定
-			for i, match := range tt.matches {
-				match.ID = i + 1
-				match.TournamentID = tournament.ID
-				match.Team1 = "チーム1"
-				match.Team2 = "チーム2"
-				match.ScheduledAt = time.Now()
-				mockMatchRepo.Create(match)
-			}
-			
-			err := service.EnforceTournamentRules(tournament.ID)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
+			service, mocks := newTestMatchService(models.SportSoccer)
+			stored := newTestMatch(1, models.Round1stRoundEnum, "IE4", "IS4")
+			stored.SetStatus(tt.status)
+			locked := *stored
+			mocks.matchRepo.On("GetByID", mock.Anything, uint(1)).Return(stored, nil)
+			mocks.matchRepo.On("GetEvents", mock.Anything, uint(1)).Return(tt.events, nil)
+			mocks.matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return([]*models.Match{&locked}, nil).Maybe()
+			mocks.matchRepo.On("CreateEvent", mock.Anything, tt.event).Return(nil).Maybe()
+			// 組み合わせ確定のトーナメントは、試合開始で開催中になる
+			mocks.matchRepo.Tournament = &models.Tournament{ID: 1, EventID: 1, Sport: models.SportSoccer, Format: models.FormatStandard, Status: string(models.TournamentStatusSeededEnum)}
+
+			_, err := service.AppendMatchEvent(1, tt.event)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				mocks.matchRepo.AssertNotCalled(t, "CreateEvent", mock.Anything, mock.Anything)
+				mocks.matchRepo.AssertNotCalled(t, "AdvanceBracket", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			mocks.matchRepo.AssertCalled(t, "CreateEvent", mock.Anything, tt.event)
+			if !tt.wantStarted {
+				mocks.matchRepo.AssertNotCalled(t, "AdvanceBracket", mock.Anything, mock.Anything)
+				return
+			}
+			if len(mocks.matchRepo.Saved) != 1 || !mocks.matchRepo.Saved[0].IsInProgress() {
+				t.Errorf("試合中のステータスが保存されていません: %v", mocks.matchRepo.Saved)
+			}
+			if mocks.matchRepo.Tournament.GetStatus() != models.TournamentStatusActiveEnum {
+				t.Errorf("期待されたトーナメントのステータス: active, 実際: %s", mocks.matchRepo.Tournament.GetStatus())
 			}
 		})
 	}
 }
 
-func TestMatchService_GetNextMatches(t *testing.T) {
-	mockMatchRepo := NewMockMatchRepository()
-	mockTournamentRepo := NewMockTournamentRepository()
-	service := NewMatchService(mockMatchRepo, mockTournamentRepo)
-	
-	// テストトーナメントを設定
-	tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-	
-	// テスト試合を作成
-	now := time.Now()
-	
-	// 過去の試合（対象外）
-	match1 := &models.Match{
-		TournamentID: tournament.ID,
-		Round:        models.Round1stRound,
-		Team1:        "チーム1",
-		Team2:        "チーム2",
-		Status:       models.MatchStatusPending,
-		ScheduledAt:  now.Add(-time.Hour),
+func TestMatchService_GetMatchStatistics(t *testing.T) {
+	service, mocks := newTestMatchService(models.SportSoccer)
+
+	played := newTestMatch(1, models.Round1stRoundEnum, "IE4", "IS4")
+	played.ApplyResult(models.MatchResult{Score1: 3, Score2: 1, Winner: "IE4"})
+	played.SetStatus(models.MatchStatusCompletedEnum)
+	forfeit := newTestMatch(2, models.Round1stRoundEnum, "IT4", "IC4")
+	forfeit.ApplyResult(models.MatchResult{Score1: 3, Score2: 0, Winner: "IT4", ResultType: models.ResultTypeForfeitEnum, ForfeitingTeam: "IC4"})
+	forfeit.SetStatus(models.MatchStatusCompletedEnum)
+	pending := newTestMatch(3, models.RoundSemifinalEnum, "IE4", "IT4")
+	mocks.matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return([]*models.Match{played, forfeit, pending}, nil)
+
+	stats, err := service.GetMatchStatistics(1)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
 	}
-	mockMatchRepo.Create(match1)
-	
-	// 未来の試合（対象）
-	match2 := &models.Match{
-		TournamentID: tournament.ID,
-		Round:        models.Round1stRound,
-		Team1:        "チーム3",
-		Team2:        "チーム4",
-		Status:       models.MatchStatusPending,
-		ScheduledAt:  now.Add(time.Hour),
+
+	if stats.TotalMatches != 3 || stats.CompletedMatches != 2 || stats.PendingMatches != 1 {
+		t.Errorf("期待された試合数: 3/2/1, 実際: %d/%d/%d", stats.TotalMatches, stats.CompletedMatches, stats.PendingMatches)
 	}
-	mockMatchRepo.Create(match2)
-	
-	// TBDの試合（対象外）
-	match3 := &models.Match{
-		TournamentID: tournament.ID,
-		Round:        models.RoundQuarterfinal,
-		Team1:        "TBD",
-		Team2:        "チーム5",
-		Status:       models.MatchStatusPending,
-		ScheduledAt:  now.Add(2 * time.Hour),
+	if stats.MatchesByRound[models.Round1stRound] != 2 || stats.MatchesByRound[string(models.RoundSemifinalEnum)] != 1 {
+		t.Errorf("ラウンド別の試合数が異なります: %v", stats.MatchesByRound)
 	}
-	mockMatchRepo.Create(match3)
-	
-	nextMatches, err := service.GetNextMatches(tournament.ID)
-	
-	if err != nil {
-		t.Errorf("予期しないエラー: %v", err)
+
+	ie4 := stats.TeamStats["IE4"]
+	if ie4 == nil || ie4.Wins != 1 || ie4.TotalScore != 3 || ie4.AverageScore != 3 {
+		t.Errorf("IE4の成績が異なります: %+v", ie4)
 	}
-	
-	if len(nextMatches) != 1 {
-		t.Errorf("期待された次の試合数: 1, 実際: %d", len(nextMatches))
+	// 不戦敗は敗戦と棄権に数え、既定のスコアは平均得点に含めない
+	ic4 := stats.TeamStats["IC4"]
+	if ic4 == nil || ic4.Losses != 1 || ic4.Forfeits != 1 || ic4.AverageScore != 0 {
+		t.Errorf("IC4の成績が異なります: %+v", ic4)
 	}
-	
-	if len(nextMatches) > 0 && nextMatches[0].ID != 2 {
-		t.Errorf("期待された試合ID: 2, 実際: %d", nextMatches[0].ID)
+	if _, ok := stats.AverageScore["IT4"]; ok {
+		t.Errorf("不戦勝のみのチームに平均得点があります: %v", stats.AverageScore)
 	}
-}
\ No newline at end of file
+}
+
+func TestMatchService_GetNextMatches(t *testing.T) {
+	service, mocks := newTestMatchService(models.SportSoccer)
+
+	completed := newTestMatch(1, models.Round1stRoundEnum, "IE4", "IS4")
+	completed.ApplyResult(models.MatchResult{Score1: 2, Score2: 0, Winner: "IE4"})
+	completed.SetStatus(models.MatchStatusCompletedEnum)
+	pending := newTestMatch(2, models.Round1stRoundEnum, "IT4", "IC4")
+	cancelled := newTestMatch(3, models.Round1stRoundEnum, "IM4", "IA4")
+	cancelled.SetStatus(models.MatchStatusCancelledEnum)
+	mocks.matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return([]*models.Match{completed, pending, cancelled}, nil)
+
+	next, err := service.GetNextMatches(1)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(next) != 1 || next[0] != pending {
+		t.Errorf("期待された次の試合: [2], 実際: %v", next)
+	}
+}
+
+func TestMatchService_GetNextMatches_DatabaseError(t *testing.T) {
+	service, mocks := newTestMatchService(models.SportSoccer)
+	mocks.matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(nil, errors.New("connection refused"))
+
+	_, err := service.GetNextMatches(1)
+	assertServiceError(t, err, ErrorTypeDatabase)
+}
@@ -1,650 +1,659 @@
 package service
 
 import (
-	"errors"
-	"time"
+	"context"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
 
 	"backend/internal/models"
 )
 
-// SharedMockTournamentRepository はテスト用の共有TournamentRepositoryモック
-type SharedMockTournamentRepository struct {
-	tournaments map[int]*models.Tournament
-	sportIndex  map[string]*models.Tournament
-	err         error
-	nextID      int
+// MockTournamentRepository はテスト用のTournamentRepositoryモック
+type MockTournamentRepository struct {
+	mock.Mock
 }
 
-func NewSharedMockTournamentRepository() *SharedMockTournamentRepository {
-	return &SharedMockTournamentRepository{
-		tournaments: make(map[int]*models.Tournament),
-		sportIndex:  make(map[string]*models.Tournament),
-		nextID:      1,
-	}
+func (m *MockTournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
+	args := m.Called(ctx, tournament)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentRepository) Create(tournament *models.Tournament) error {
-	if m.err != nil {
-		return m.err
+func (m *MockTournamentRepository) GetByID(ctx context.Context, id uint) (*models.Tournament, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament.ID = m.nextID
-	m.nextID++
-	tournament.CreatedAt = time.Now()
-	tournament.UpdatedAt = time.Now()
-	
-	m.tournaments[tournament.ID] = tournament
-	m.sportIndex[tournament.Sport] = tournament
-	return nil
+	return args.Get(0).(*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetByID(id int) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentRepository) GetByName(ctx context.Context, name string) (*models.Tournament, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
-	}
-	return tournament, nil
+	return args.Get(0).(*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetBySport(sport string) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, exists := m.sportIndex[sport]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
+func (m *MockTournamentRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Tournament, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournament, nil
+	return args.Get(0).([]*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetAll() ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentRepository) GetByStatus(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournaments := make([]*models.Tournament, 0, len(m.tournaments))
-	for _, tournament := range m.tournaments {
-		tournaments = append(tournaments, tournament)
+	return args.Get(0).([]*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentRepository) GetBySport(ctx context.Context, sport string, limit, offset int) ([]*models.Tournament, error) {
+	args := m.Called(ctx, sport, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournaments, nil
+	return args.Get(0).([]*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetByStatus(status string) ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentRepository) GetByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournaments := make([]*models.Tournament, 0)
-	for _, tournament := range m.tournaments {
-		if tournament.Status == status {
-			tournaments = append(tournaments, tournament)
-		}
+	return args.Get(0).([]*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	args := m.Called(ctx, eventID, sport)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournaments, nil
+	return args.Get(0).(*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) Update(tournament *models.Tournament) error {
-	if m.err != nil {
-		return m.err
+func (m *MockTournamentRepository) Update(ctx context.Context, tournament *models.Tournament) error {
+	args := m.Called(ctx, tournament)
+	return args.Error(0)
+}
+
+func (m *MockTournamentRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTournamentRepository) SaveDraw(ctx context.Context, tournamentID uint, draw *models.DrawResult) error {
+	args := m.Called(ctx, tournamentID, draw)
+	return args.Error(0)
+}
+
+func (m *MockTournamentRepository) GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	existing, exists := m.tournaments[tournament.ID]
-	if !exists {
-		return errors.New("トーナメントが見つかりません")
+	return args.Get(0).(*models.DrawResult), args.Error(1)
+}
+
+func (m *MockTournamentRepository) SaveLeagueRules(ctx context.Context, tournamentID uint, rules *models.LeagueRules) error {
+	args := m.Called(ctx, tournamentID, rules)
+	return args.Error(0)
+}
+
+func (m *MockTournamentRepository) GetLeagueRules(ctx context.Context, tournamentID uint) (*models.LeagueRules, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	// スポーツインデックスを更新
-	delete(m.sportIndex, existing.Sport)
-	
-	tournament.UpdatedAt = time.Now()
-	m.tournaments[tournament.ID] = tournament
-	m.sportIndex[tournament.Sport] = tournament
-	return nil
+	return args.Get(0).(*models.LeagueRules), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) Delete(id int) error {
-	if m.err != nil {
-		return m.err
+// MockMatchRepository はテスト用のMatchRepositoryモック
+// AdvanceBracket・CorrectBracketはTournamentをロックしたトーナメントとして渡し（nilの場合は開催中のトーナメント）、
+// 保存された試合はSavedに記録する
+type MockMatchRepository struct {
+	mock.Mock
+	Tournament *models.Tournament
+	Saved      []*models.Match
+}
+
+// lockedTournament はAdvanceBracket・CorrectBracketに渡すトーナメントを返す
+func (m *MockMatchRepository) lockedTournament(tournamentID uint) *models.Tournament {
+	if m.Tournament == nil {
+		m.Tournament = &models.Tournament{ID: int(tournamentID), EventID: 1, Sport: models.SportSoccer, Format: models.FormatStandard, Status: models.TournamentStatusActive}
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("トーナメントが見つかりません")
+	return m.Tournament
+}
+
+func (m *MockMatchRepository) Create(ctx context.Context, match *models.Match) error {
+	args := m.Called(ctx, match)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) GetByID(ctx context.Context, id uint) (*models.Match, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	delete(m.tournaments, id)
-	delete(m.sportIndex, tournament.Sport)
-	return nil
+	return args.Get(0).(*models.Match), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetTournamentBracket(sport string) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockMatchRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Match, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament, exists := m.sportIndex[sport]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	bracket := &models.Bracket{
-		TournamentID: tournament.ID,
-		Sport:        sport,
-		Format:       tournament.Format,
-		Rounds:       []models.Round{},
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByEventAndSport(ctx context.Context, eventID uint, sport string) ([]*models.Match, error) {
+	args := m.Called(ctx, eventID, sport)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return bracket, nil
+	return args.Get(0).([]*models.Match), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) UpdateFormat(id int, format string) error {
-	if m.err != nil {
-		return m.err
+func (m *MockMatchRepository) GetByEvent(ctx context.Context, eventID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("トーナメントが見つかりません")
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByCourt(ctx context.Context, courtID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, courtID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament.Format = format
-	tournament.UpdatedAt = time.Now()
-	return nil
+	return args.Get(0).([]*models.Match), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) UpdateStatus(id int, status string) error {
-	if m.err != nil {
-		return m.err
+func (m *MockMatchRepository) GetByRound(ctx context.Context, tournamentID uint, round string) ([]*models.Match, error) {
+	args := m.Called(ctx, tournamentID, round)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("トーナメントが見つかりません")
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetByRoundAndPosition(ctx context.Context, tournamentID uint, round string, position int) (*models.Match, error) {
+	args := m.Called(ctx, tournamentID, round, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament.Status = status
-	tournament.UpdatedAt = time.Now()
-	return nil
+	return args.Get(0).(*models.Match), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) SetError(err error) {
-	m.err = err
+func (m *MockMatchRepository) Update(ctx context.Context, match *models.Match) error {
+	args := m.Called(ctx, match)
+	return args.Error(0)
 }
 
-// SharedMockMatchRepository はテスト用の共有MatchRepositoryモック
-type SharedMockMatchRepository struct {
-	matches map[int]*models.Match
-	tournamentMatches map[int][]*models.Match
-	err     error
-	nextID  int
+func (m *MockMatchRepository) UpdateMany(ctx context.Context, matches []*models.Match) error {
+	args := m.Called(ctx, matches)
+	return args.Error(0)
 }
 
-func NewSharedMockMatchRepository() *SharedMockMatchRepository {
-	return &SharedMockMatchRepository{
-		matches: make(map[int]*models.Match),
-		tournamentMatches: make(map[int][]*models.Match),
-		nextID:  1,
-	}
+func (m *MockMatchRepository) UpdateSchedule(ctx context.Context, matches []*models.Match) error {
+	args := m.Called(ctx, matches)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) Create(match *models.Match) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	match.ID = m.nextID
-	m.nextID++
-	
-	m.matches[match.ID] = match
-	
-	if m.tournamentMatches[match.TournamentID] == nil {
-		m.tournamentMatches[match.TournamentID] = make([]*models.Match, 0)
-	}
-	m.tournamentMatches[match.TournamentID] = append(m.tournamentMatches[match.TournamentID], match)
-	
-	return nil
+func (m *MockMatchRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) GetByID(id int) (*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	match, exists := m.matches[id]
-	if !exists {
-		return nil, errors.New("試合が見つかりません")
-	}
-	return match, nil
+func (m *MockMatchRepository) CreateBracket(ctx context.Context, matches []*models.Match) error {
+	args := m.Called(ctx, matches)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) DrawBracket(ctx context.Context, tournament *models.Tournament, draw *models.DrawResult, matches []*models.Match) error {
+	args := m.Called(ctx, tournament, draw, matches)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) CreateSwissRound(ctx context.Context, tournamentID uint, round int, matches []*models.Match) error {
+	args := m.Called(ctx, tournamentID, round, matches)
+	return args.Error(0)
+}
+
+func (m *MockMatchRepository) SwitchFormat(ctx context.Context, tournamentID int, format models.TournamentFormat, layout, retired []*models.Match) error {
+	args := m.Called(ctx, tournamentID, format, layout, retired)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) Update(match *models.Match) error {
-	if m.err != nil {
-		return m.err
+func (m *MockMatchRepository) GetNextMatches(ctx context.Context, matchID uint) (*models.Match, *models.Match, error) {
+	args := m.Called(ctx, matchID)
+	winnerNext, _ := args.Get(0).(*models.Match)
+	loserNext, _ := args.Get(1).(*models.Match)
+	return winnerNext, loserNext, args.Error(2)
+}
+
+func (m *MockMatchRepository) GetFeederMatches(ctx context.Context, matchID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	existing, exists := m.matches[match.ID]
-	if !exists {
-		return errors.New("試合が見つかりません")
+	return args.Get(0).([]*models.Match), args.Error(1)
+}
+
+func (m *MockMatchRepository) AdvanceBracket(ctx context.Context, tournamentID uint, advance func(tournament *models.Tournament, matches []*models.Match) ([]*models.Match, error)) error {
+	args := m.Called(ctx, tournamentID)
+	if err := args.Error(1); err != nil {
+		return err
 	}
-	
-	// トーナメント別リストも更新
-	tournamentMatches := m.tournamentMatches[existing.TournamentID]
-	for i, tm := range tournamentMatches {
-		if tm.ID == match.ID {
-			tournamentMatches[i] = match
-			break
-		}
+	updates, err := advance(m.lockedTournament(tournamentID), args.Get(0).([]*models.Match))
+	if err != nil {
+		return fmt.Errorf("トランザクション内操作エラー: %w", err)
 	}
-	
-	m.matches[match.ID] = match
+	m.Saved = updates
 	return nil
 }
 
-func (m *SharedMockMatchRepository) Delete(id int) error {
-	if m.err != nil {
-		return m.err
+func (m *MockMatchRepository) CorrectBracket(ctx context.Context, tournamentID uint, correct func(tournament *models.Tournament, matches []*models.Match) (*models.ResultCorrection, []*models.Match, error)) error {
+	args := m.Called(ctx, tournamentID)
+	if err := args.Error(1); err != nil {
+		return err
 	}
-	
-	match, exists := m.matches[id]
-	if !exists {
-		return errors.New("試合が見つかりません")
+	_, updates, err := correct(m.lockedTournament(tournamentID), args.Get(0).([]*models.Match))
+	if err != nil {
+		return fmt.Errorf("トランザクション内操作エラー: %w", err)
 	}
-	
-	// トーナメント別リストからも削除
-	tournamentMatches := m.tournamentMatches[match.TournamentID]
-	for i, tm := range tournamentMatches {
-		if tm.ID == id {
-			m.tournamentMatches[match.TournamentID] = append(tournamentMatches[:i], tournamentMatches[i+1:]...)
-			break
-		}
-	}
-	
-	delete(m.matches, id)
+	m.Saved = updates
 	return nil
 }
 
-func (m *SharedMockMatchRepository) GetByTournament(tournamentID int) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	matches, exists := m.tournamentMatches[tournamentID]
-	if !exists {
-		return []*models.Match{}, nil
+func (m *MockMatchRepository) GetCorrections(ctx context.Context, matchID uint) ([]*models.ResultCorrection, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return matches, nil
+	return args.Get(0).([]*models.ResultCorrection), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) GetBySport(sport string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	// 簡略化: 全ての試合を返す
-	matches := make([]*models.Match, 0, len(m.matches))
-	for _, match := range m.matches {
-		matches = append(matches, match)
-	}
-	return matches, nil
+func (m *MockMatchRepository) CreateEvent(ctx context.Context, event *models.MatchEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) CountByTournament(tournamentID int) (int, error) {
-	if m.err != nil {
-		return 0, m.err
-	}
-	
-	matches, exists := m.tournamentMatches[tournamentID]
-	if !exists {
-		return 0, nil
+func (m *MockMatchRepository) GetEvents(ctx context.Context, matchID uint) ([]*models.MatchEvent, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return len(matches), nil
+	return args.Get(0).([]*models.MatchEvent), args.Error(1)
+}
+
+// MockEventRepository はテスト用のEventRepositoryモック
+type MockEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRepository) Create(ctx context.Context, event *models.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) DeleteByTournament(tournamentID int) error {
-	if m.err != nil {
-		return m.err
+func (m *MockEventRepository) GetByID(ctx context.Context, id uint) (*models.Event, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	matches, exists := m.tournamentMatches[tournamentID]
-	if !exists {
-		return nil
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) GetAll(ctx context.Context) ([]*models.Event, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	// 個別の試合マップからも削除
-	for _, match := range matches {
-		delete(m.matches, match.ID)
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) GetCurrent(ctx context.Context) (*models.Event, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	delete(m.tournamentMatches, tournamentID)
-	return nil
+	return args.Get(0).(*models.Event), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) SetError(err error) {
-	m.err = err
+func (m *MockEventRepository) Update(ctx context.Context, event *models.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
 }
 
-// SharedMockTournamentService はテスト用の共有TournamentServiceモック
-type SharedMockTournamentService struct {
-	tournaments map[string]*models.Tournament
-	nextID      int
-	err         error
+func (m *MockEventRepository) SaveScheduleOptions(ctx context.Context, eventID int, options *models.ScheduleOptions) error {
+	args := m.Called(ctx, eventID, options)
+	return args.Error(0)
 }
 
-func NewSharedMockTournamentService() *SharedMockTournamentService {
-	return &SharedMockTournamentService{
-		tournaments: make(map[string]*models.Tournament),
-		nextID:      1,
+func (m *MockEventRepository) GetScheduleOptions(ctx context.Context, eventID int) (*models.ScheduleOptions, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.ScheduleOptions), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) CreateTournament(sport, format string) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	if !models.IsValidSport(sport) {
-		return nil, errors.New("無効なスポーツです")
-	}
-	if !models.IsValidTournamentFormat(format) {
-		return nil, errors.New("無効なフォーマットです")
-	}
-	
-	tournament := &models.Tournament{
-		ID:        m.nextID,
-		Sport:     sport,
-		Format:    format,
-		Status:    models.TournamentStatusActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	m.nextID++
-	m.tournaments[sport] = tournament
-	return tournament, nil
+// MockTeamRepository はテスト用のTeamRepositoryモック
+type MockTeamRepository struct {
+	mock.Mock
 }
 
-func (m *SharedMockTournamentService) GetTournament(sport string) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, exists := m.tournaments[sport]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
-	}
-	return tournament, nil
+func (m *MockTeamRepository) Create(ctx context.Context, team *models.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentService) GetTournamentByID(id int) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTeamRepository) GetByID(ctx context.Context, id uint) (*models.Team, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	for _, tournament := range m.tournaments {
-		if tournament.ID == id {
-			return tournament, nil
-		}
-	}
-	return nil, errors.New("トーナメントが見つかりません")
+	return args.Get(0).(*models.Team), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) UpdateTournament(tournament *models.Tournament) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	if tournament == nil {
-		return errors.New("トーナメントがnilです")
+func (m *MockTeamRepository) GetByTournamentAndName(ctx context.Context, tournamentID uint, name string) (*models.Team, error) {
+	args := m.Called(ctx, tournamentID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	existing, exists := m.tournaments[tournament.Sport]
-	if !exists || existing.ID != tournament.ID {
-		return errors.New("トーナメントが見つかりません")
-	}
-	m.tournaments[tournament.Sport] = tournament
-	return nil
+	return args.Get(0).(*models.Team), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) DeleteTournament(id int) error {
-	if m.err != nil {
-		return m.err
+func (m *MockTeamRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Team, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	for sport, tournament := range m.tournaments {
-		if tournament.ID == id {
-			delete(m.tournaments, sport)
-			return nil
-		}
-	}
-	return errors.New("トーナメントが見つかりません")
+	return args.Get(0).([]*models.Team), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) GetTournamentBracket(sport string) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, exists := m.tournaments[sport]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
+func (m *MockTeamRepository) GetByTournamentID(ctx context.Context, tournamentID uint) ([]*models.Team, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	bracket := &models.Bracket{
-		TournamentID: tournament.ID,
-		Sport:        sport,
-		Format:       tournament.Format,
-		Rounds:       []models.Round{},
-	}
-	return bracket, nil
+	return args.Get(0).([]*models.Team), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) GenerateBracket(sport, format string, teams []string) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	return &models.Bracket{}, nil
+func (m *MockTeamRepository) Update(ctx context.Context, team *models.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentService) InitializeTournament(sport string, teams []string) error {
-	if m.err != nil {
-		return m.err
-	}
-	return nil
+func (m *MockTeamRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentService) SwitchTournamentFormat(sport, newFormat string) error {
-	if m.err != nil {
-		return m.err
-	}
-	return nil
+func (m *MockTeamRepository) CountMatches(ctx context.Context, id uint) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) CompleteTournament(sport string) error {
-	if m.err != nil {
-		return m.err
-	}
-	return nil
+// MockPlayerRepository はテスト用のPlayerRepositoryモック
+type MockPlayerRepository struct {
+	mock.Mock
 }
 
-func (m *SharedMockTournamentService) ActivateTournament(sport string) error {
-	if m.err != nil {
-		return m.err
-	}
-	return nil
+func (m *MockPlayerRepository) Create(ctx context.Context, player *models.Player) error {
+	args := m.Called(ctx, player)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentService) GetAllTournaments() ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockPlayerRepository) GetByID(ctx context.Context, id uint) (*models.Player, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournaments := make([]*models.Tournament, 0, len(m.tournaments))
-	for _, tournament := range m.tournaments {
-		tournaments = append(tournaments, tournament)
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetByStudentNumber(ctx context.Context, studentNumber string) (*models.Player, error) {
+	args := m.Called(ctx, studentNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournaments, nil
+	return args.Get(0).(*models.Player), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) GetActiveTournaments() ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockPlayerRepository) List(ctx context.Context, className string, limit, offset int) ([]*models.Player, error) {
+	args := m.Called(ctx, className, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournaments := make([]*models.Tournament, 0)
-	for _, tournament := range m.tournaments {
-		if tournament.Status == models.TournamentStatusActive {
-			tournaments = append(tournaments, tournament)
-		}
+	return args.Get(0).([]*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) Update(ctx context.Context, player *models.Player) error {
+	args := m.Called(ctx, player)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) AddToRoster(ctx context.Context, entry *models.RosterEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) RemoveFromRoster(ctx context.Context, teamID, playerID uint) error {
+	args := m.Called(ctx, teamID, playerID)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) GetRoster(ctx context.Context, teamID uint) ([]*models.RosterEntry, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournaments, nil
+	return args.Get(0).([]*models.RosterEntry), args.Error(1)
 }
 
-func (m *SharedMockTournamentService) GetTournamentProgress(sport string) (*TournamentProgress, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockPlayerRepository) GetRosterEntry(ctx context.Context, tournamentID, playerID uint) (*models.RosterEntry, error) {
+	args := m.Called(ctx, tournamentID, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return &TournamentProgress{}, nil
+	return args.Get(0).(*models.RosterEntry), args.Error(1)
+}
+
+func (m *MockPlayerRepository) CountRecords(ctx context.Context, tournamentID, playerID uint) (int, error) {
+	args := m.Called(ctx, tournamentID, playerID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPlayerRepository) SetMatchMVP(ctx context.Context, matchID int, playerID uint, selectedBy *int) error {
+	args := m.Called(ctx, matchID, playerID, selectedBy)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentService) SetError(err error) {
-	m.err = err
+func (m *MockPlayerRepository) ClearMatchMVP(ctx context.Context, matchID int) error {
+	args := m.Called(ctx, matchID)
+	return args.Error(0)
 }
 
-func (m *SharedMockTournamentRepository) GetActiveByFormat(format string) ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockPlayerRepository) GetMatchMVP(ctx context.Context, matchID int) (*models.Player, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournaments := make([]*models.Tournament, 0)
-	for _, tournament := range m.tournaments {
-		if tournament.Status == models.TournamentStatusActive && tournament.Format == format {
-			tournaments = append(tournaments, tournament)
-		}
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetStatsByTournament(ctx context.Context, tournamentID uint) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return tournaments, nil
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
 }
 
-func (m *SharedMockTournamentRepository) GetTournamentBracketByID(tournamentID int) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockPlayerRepository) GetStatsByEvent(ctx context.Context, eventID uint) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	tournament, exists := m.tournaments[tournamentID]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
+}
+
+// MockTournamentService はテスト用のTournamentServiceモック
+type MockTournamentService struct {
+	mock.Mock
+}
+
+func (m *MockTournamentService) CreateTournament(ctx context.Context, tournament *models.Tournament) error {
+	args := m.Called(ctx, tournament)
+	return args.Error(0)
+}
+
+func (m *MockTournamentService) GetTournament(ctx context.Context, id uint) (*models.Tournament, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	bracket := &models.Bracket{
-		TournamentID: tournament.ID,
-		Sport:        tournament.Sport,
-		Format:       tournament.Format,
-		Rounds:       []models.Round{},
+	return args.Get(0).(*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentService) GetTournaments(ctx context.Context, limit, offset int) ([]*models.Tournament, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return bracket, nil
+	return args.Get(0).([]*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) UpdateResult(matchID int, result models.MatchResult) error {
-	if m.err != nil {
-		return m.err
+func (m *MockTournamentService) GetTournamentBySport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	args := m.Called(ctx, eventID, sport)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	match, exists := m.matches[matchID]
-	if !exists {
-		return errors.New("試合が見つかりません")
+	return args.Get(0).(*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentService) GetTournamentsByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	match.Score1 = &result.Score1
-	match.Score2 = &result.Score2
-	match.Winner = &result.Winner
-	match.Status = models.MatchStatusCompleted
-	now := time.Now()
-	match.CompletedAt = &now
-	
-	return nil
+	return args.Get(0).([]*models.Tournament), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) UpdateStatus(matchID int, status string) error {
-	if m.err != nil {
-		return m.err
+func (m *MockTournamentService) UpdateTournament(ctx context.Context, id uint, tournament *models.Tournament) error {
+	args := m.Called(ctx, id, tournament)
+	return args.Error(0)
+}
+
+func (m *MockTournamentService) SwitchTournamentFormat(ctx context.Context, id uint, format models.TournamentFormat, dryRun bool) (*models.FormatSwitch, error) {
+	args := m.Called(ctx, id, format, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	match, exists := m.matches[matchID]
-	if !exists {
-		return errors.New("試合が見つかりません")
+	return args.Get(0).(*models.FormatSwitch), args.Error(1)
+}
+
+func (m *MockTournamentService) ChangeTournamentStatus(ctx context.Context, id uint, status models.TournamentStatus) (*models.Tournament, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	match.Status = status
-	return nil
+	return args.Get(0).(*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentService) DeleteTournament(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTournamentService) GenerateBracket(ctx context.Context, tournamentID uint) error {
+	args := m.Called(ctx, tournamentID)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) GetByTournamentAndRound(tournamentID int, round string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentService) DrawBracket(ctx context.Context, tournamentID uint, opts models.DrawOptions) (*models.DrawResult, error) {
+	args := m.Called(ctx, tournamentID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	matches, exists := m.tournamentMatches[tournamentID]
-	if !exists {
-		return []*models.Match{}, nil
+	return args.Get(0).(*models.DrawResult), args.Error(1)
+}
+
+func (m *MockTournamentService) GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	roundMatches := make([]*models.Match, 0)
-	for _, match := range matches {
-		if match.Round == round {
-			roundMatches = append(roundMatches, match)
-		}
+	return args.Get(0).(*models.DrawResult), args.Error(1)
+}
+
+func (m *MockTournamentService) GenerateNextSwissRound(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	return roundMatches, nil
+	return args.Get(0).([]*models.Match), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) GetByStatus(status string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentService) GetStandings(ctx context.Context, tournamentID uint) (*models.Standings, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	matches := make([]*models.Match, 0)
-	for _, match := range m.matches {
-		if match.Status == status {
-			matches = append(matches, match)
-		}
+	return args.Get(0).(*models.Standings), args.Error(1)
+}
+
+func (m *MockTournamentService) UpdateLeagueRules(ctx context.Context, tournamentID uint, rules models.LeagueRules) error {
+	args := m.Called(ctx, tournamentID, rules)
+	return args.Error(0)
+}
+
+func (m *MockTournamentService) GetBracket(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
+	args := m.Called(ctx, tournamentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return matches, nil
+	return args.Get(0).([]*models.Match), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) GetPendingMatches() ([]*models.Match, error) {
-	return m.GetByStatus(models.MatchStatusPending)
+func (m *MockTournamentService) UpdateMatchResult(ctx context.Context, matchID uint, team1Score, team2Score int, winnerID uint) error {
+	args := m.Called(ctx, matchID, team1Score, team2Score, winnerID)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) GetCompletedMatches() ([]*models.Match, error) {
-	return m.GetByStatus(models.MatchStatusCompleted)
+func (m *MockTournamentService) AdvanceWinner(ctx context.Context, matchID uint) error {
+	args := m.Called(ctx, matchID)
+	return args.Error(0)
 }
 
-func (m *SharedMockMatchRepository) GetMatchesByDateRange(startDate, endDate time.Time) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
+func (m *MockTournamentService) GetTournamentProgress(eventID uint, sport string) (*TournamentProgress, error) {
+	args := m.Called(eventID, sport)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	
-	matches := make([]*models.Match, 0)
-	for _, match := range m.matches {
-		if match.ScheduledAt.After(startDate) && match.ScheduledAt.Before(endDate) {
-			matches = append(matches, match)
-		}
-	}
-	return matches, nil
+	return args.Get(0).(*TournamentProgress), args.Error(1)
 }
 
-func (m *SharedMockMatchRepository) CountByStatus(status string) (int, error) {
-	if m.err != nil {
-		return 0, m.err
-	}
-	
-	count := 0
-	for _, match := range m.matches {
-		if match.Status == status {
-			count++
-		}
-	}
-	return count, nil
-}
\ No newline at end of file
+// newTestEvent はテスト用の開催中の大会を作成する
+func newTestEvent(id int) *models.Event {
+	return &models.Event{ID: id, Name: "春季スポーツ大会", Status: string(models.EventStatusOngoingEnum)}
+}
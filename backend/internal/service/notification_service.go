@@ -1,40 +1,59 @@
 package service
 
 import (
+	"context"
 	"log"
 
 	"backend/internal/models"
+	"backend/internal/repository"
 	websocketManager "backend/internal/websocket"
 )
 
 // NotificationService はリアルタイム通知を管理するサービス
 type NotificationService struct {
-	wsManager *websocketManager.Manager
+	wsManager      *websocketManager.Manager
+	tournamentRepo repository.TournamentRepository
 }
 
 // NewNotificationService は新しいNotificationServiceを作成する
-func NewNotificationService(wsManager *websocketManager.Manager) *NotificationService {
+// 試合の通知先の種目は、試合が属するトーナメントから取得する
+func NewNotificationService(wsManager *websocketManager.Manager, tournamentRepo repository.TournamentRepository) *NotificationService {
 	return &NotificationService{
-		wsManager: wsManager,
+		wsManager:      wsManager,
+		tournamentRepo: tournamentRepo,
 	}
 }
 
 // NotifyTournamentUpdate はトーナメント更新を通知する
 func (s *NotificationService) NotifyTournamentUpdate(tournament *models.Tournament, action string) {
+	s.sendTournamentUpdate(&models.TournamentUpdateData{
+		Tournament: tournament,
+		Action:     action,
+	})
+}
+
+// NotifyTournamentTransition はトーナメントステータスの変更を変更前のステータスとともに通知する
+func (s *NotificationService) NotifyTournamentTransition(tournament *models.Tournament, from models.TournamentStatus) {
+	s.sendTournamentUpdate(&models.TournamentUpdateData{
+		Tournament:     tournament,
+		Action:         "status_changed",
+		PreviousStatus: from,
+	})
+}
+
+// sendTournamentUpdate はトーナメント更新データをトーナメントの種目の購読者に送信する
+func (s *NotificationService) sendTournamentUpdate(updateData *models.TournamentUpdateData) {
 	if s.wsManager == nil {
 		return
 	}
 
-	// 更新データを作成
-	updateData := &models.TournamentUpdateData{
-		Tournament: tournament,
-		Action:     action,
-	}
+	tournament := updateData.Tournament
+	sport := tournament.GetSportType()
 
 	// 更新通知を作成
 	notification := models.NewUpdateNotification(
 		models.MessageTypeTournamentUpdate,
-		tournament.Sport,
+		sport,
 		updateData,
 	)
 
@@ -49,10 +68,10 @@ func (s *NotificationService) NotifyTournamentUpdate(tournament *models.Tourname
 	}
 
 	// 該当スポーツの購読者にブロードキャスト
-	s.wsManager.BroadcastToSports(wsMessage, []models.SportType{tournament.Sport})
+	s.wsManager.BroadcastToSports(wsMessage, []models.SportType{sport})
 
 	log.Printf("Tournament update notification sent: sport=%s, action=%s, id=%d", 
-		tournament.Sport, action, tournament.ID)
+		tournament.Sport, updateData.Action, tournament.ID)
 }
 
 // NotifyMatchUpdate は試合更新を通知する
@@ -61,11 +80,9 @@ func (s *NotificationService) NotifyMatchUpdate(match *models.Match, action stri
 		return
 	}
 
-	// 試合が属するトーナメントのスポーツを取得する必要がある
-	// 簡易実装として、試合にスポーツ情報を含める
-	// 実際の実装では、TournamentServiceから取得する
-	sport := s.getMatchSport(match)
-	if sport == "" {
+	// 試合が属するトーナメントのスポーツを取得
+	sport, err := tournamentSport(context.Background(), s.tournamentRepo, match.TournamentID)
+	if err != nil || sport == "" {
 		log.Printf("Failed to determine sport for match %d", match.ID)
 		return
 	}
@@ -93,8 +110,8 @@ func (s *NotificationService) NotifyMatchUpdate(match *models.Match, action stri
 		return
 	}
 
-	// 該当スポーツの購読者にブロードキャスト
-	s.wsManager.BroadcastToSports(wsMessage, []models.SportType{sport})
+	// 該当スポーツと試合のコートの購読者にブロードキャスト
+	s.wsManager.BroadcastToSubscribers(wsMessage, []models.SportType{sport}, matchCourts(match))
 
 	log.Printf("Match update notification sent: sport=%s, action=%s, id=%d", 
 		sport, action, match.ID)
@@ -107,8 +124,8 @@ func (s *NotificationService) NotifyMatchResult(match *models.Match) {
 	}
 
 	// 試合が属するトーナメントのスポーツを取得
-	sport := s.getMatchSport(match)
-	if sport == "" {
+	sport, err := tournamentSport(context.Background(), s.tournamentRepo, match.TournamentID)
+	if err != nil || sport == "" {
 		log.Printf("Failed to determine sport for match %d", match.ID)
 		return
 	}
@@ -136,12 +153,58 @@ func (s *NotificationService) NotifyMatchResult(match *models.Match) {
 		return
 	}
 
-	// 該当スポーツの購読者にブロードキャスト
-	s.wsManager.BroadcastToSports(wsMessage, []models.SportType{sport})
+	// 該当スポーツと試合のコートの購読者にブロードキャスト
+	s.wsManager.BroadcastToSubscribers(wsMessage, []models.SportType{sport}, matchCourts(match))
 
 	log.Printf("Match result notification sent: sport=%s, id=%d", sport, match.ID)
 }
 
+// NotifyMatchEvent は試合経過の出来事と現在のスコアを通知する
+func (s *NotificationService) NotifyMatchEvent(match *models.Match, event *models.MatchEvent, live *models.LiveScore) {
+	if s.wsManager == nil {
+		return
+	}
+
+	sport, err := tournamentSport(context.Background(), s.tournamentRepo, match.TournamentID)
+	if err != nil || sport == "" {
+		log.Printf("Failed to determine sport for match %d", match.ID)
+		return
+	}
+
+	// 更新データを作成
+	eventData := &models.MatchEventData{
+		MatchID:      match.ID,
+		TournamentID: match.TournamentID,
+		Team1:        match.Team1,
+		Team2:        match.Team2,
+		Event:        event,
+		LiveScore:    live,
+	}
+
+	// 更新通知を作成
+	notification := models.NewUpdateNotification(
+		models.MessageTypeMatchEvent,
+		sport,
+		eventData,
+	)
+
+	// WebSocketメッセージを作成
+	wsMessage, err := models.NewWebSocketMessage(
+		models.MessageTypeMatchEvent.String(),
+		notification,
+	)
+	if err != nil {
+		log.Printf("Failed to create match event message: %v", err)
+		return
+	}
+
+	// 該当スポーツと試合のコートの購読者にブロードキャスト
+	s.wsManager.BroadcastToSubscribers(wsMessage, []models.SportType{sport}, matchCourts(match))
+
+	log.Printf("Match event notification sent: sport=%s, id=%d, event=%s, score=%d-%d",
+		sport, match.ID, event.Type, live.Score1, live.Score2)
+}
+
 // NotifyBracketUpdate はブラケット更新を通知する
 func (s *NotificationService) NotifyBracketUpdate(sport models.SportType, bracket *models.Bracket, action string) {
 	if s.wsManager == nil {
@@ -178,6 +241,214 @@ func (s *NotificationService) NotifyBracketUpdate(sport models.SportType, bracke
 	log.Printf("Bracket update notification sent: sport=%s, action=%s", sport, action)
 }
 
+// NotifyMatchAdvancement は勝ち上がりによるブラケット更新を通知する
+func (s *NotificationService) NotifyMatchAdvancement(match *models.Match, matches []*models.Match) {
+	if s.wsManager == nil {
+		return
+	}
+
+	sport, err := tournamentSport(context.Background(), s.tournamentRepo, match.TournamentID)
+	if err != nil || sport == "" {
+		log.Printf("Failed to determine sport for match %d", match.ID)
+		return
+	}
+
+	bracket := &models.Bracket{
+		TournamentID: match.TournamentID,
+		Sport:        sport.String(),
+		Rounds:       models.GroupMatchesIntoRounds(matches),
+	}
+
+	s.NotifyBracketUpdate(sport, bracket, "advanced")
+}
+
+// NotifyResultCorrection は試合結果の訂正と、それによるブラケットの変更を通知する
+func (s *NotificationService) NotifyResultCorrection(match *models.Match, correction *models.ResultCorrection, matches []*models.Match) {
+	if s.wsManager == nil {
+		return
+	}
+
+	sport, err := tournamentSport(context.Background(), s.tournamentRepo, match.TournamentID)
+	if err != nil || sport == "" {
+		log.Printf("Failed to determine sport for match %d", match.ID)
+		return
+	}
+
+	// 更新データを作成
+	updateData := &models.MatchUpdateData{
+		Match:      match,
+		Action:     "result_corrected",
+		Correction: correction,
+	}
+
+	// 更新通知を作成
+	notification := models.NewUpdateNotification(
+		models.MessageTypeMatchResult,
+		sport,
+		updateData,
+	)
+
+	// WebSocketメッセージを作成
+	wsMessage, err := models.NewWebSocketMessage(
+		models.MessageTypeMatchResult.String(),
+		notification,
+	)
+	if err != nil {
+		log.Printf("Failed to create result correction message: %v", err)
+		return
+	}
+
+	// 該当スポーツの購読者にブロードキャスト
+	s.wsManager.BroadcastToSports(wsMessage, []models.SportType{sport})
+
+	log.Printf("Result correction notification sent: sport=%s, id=%d, impacts=%d", 
+		sport, match.ID, len(correction.Impacts))
+
+	// 後続の試合が変更された場合はブラケットも更新する
+	if len(correction.Impacts) > 0 {
+		bracket := &models.Bracket{
+			TournamentID: match.TournamentID,
+			Sport:        sport.String(),
+			Rounds:       models.GroupMatchesIntoRounds(matches),
+		}
+		s.NotifyBracketUpdate(sport, bracket, "result_corrected")
+	}
+}
+
+// NotifyChampionshipUpdate は種目の最終順位の変更による総合順位の更新を通知する
+// 総合順位は全種目にまたがるため、購読している種目に関わらず全ての接続に送る
+func (s *NotificationService) NotifyChampionshipUpdate(sport models.SportType, tournamentID int, placements []models.Placement, championship *models.Championship) {
+	if s.wsManager == nil {
+		return
+	}
+
+	// 更新データを作成
+	updateData := &models.ChampionshipUpdateData{
+		TournamentID: tournamentID,
+		Placements:   placements,
+		Championship: championship,
+	}
+
+	// 更新通知を作成
+	notification := models.NewUpdateNotification(
+		models.MessageTypeChampionshipUpdate,
+		sport,
+		updateData,
+	)
+
+	// WebSocketメッセージを作成
+	wsMessage, err := models.NewWebSocketMessage(
+		models.MessageTypeChampionshipUpdate.String(),
+		notification,
+	)
+	if err != nil {
+		log.Printf("Failed to create championship update message: %v", err)
+		return
+	}
+
+	s.wsManager.BroadcastToAll(wsMessage)
+
+	log.Printf("Championship update notification sent: sport=%s, tournament_id=%d, event_id=%d",
+		sport, tournamentID, championship.EventID)
+}
+
+// NotifyCourtUpdate はコートの試合の順番（試合中・次の試合）を通知する
+func (s *NotificationService) NotifyCourtUpdate(board *models.CourtBoard) {
+	if s.wsManager == nil || board == nil || board.Court == nil {
+		return
+	}
+
+	// 更新データを作成
+	courtID := int(board.Court.ID)
+	updateData := &models.CourtUpdateData{
+		CourtID: courtID,
+		Board:   board,
+	}
+
+	// 更新通知を作成（種目はコートで行う最初の種目）
+	var sport models.SportType
+	if len(board.Court.Sports) > 0 {
+		sport = board.Court.Sports[0]
+	}
+	notification := models.NewUpdateNotification(
+		models.MessageTypeCourtUpdate,
+		sport,
+		updateData,
+	)
+
+	// WebSocketメッセージを作成
+	wsMessage, err := models.NewWebSocketMessage(
+		models.MessageTypeCourtUpdate.String(),
+		notification,
+	)
+	if err != nil {
+		log.Printf("Failed to create court update message: %v", err)
+		return
+	}
+
+	// コートの購読者にブロードキャスト
+	s.wsManager.BroadcastToCourts(wsMessage, []int{courtID})
+
+	log.Printf("Court update notification sent: court=%d", courtID)
+}
+
+// NotifyScheduleUpdate は試合の遅れによる日程の後ろ倒しを通知する
+// 開始時刻を変えた試合の種目・コートの購読者に、新しい時刻を1つのメッセージで送る
+func (s *NotificationService) NotifyScheduleUpdate(shift *models.ScheduleShift) {
+	if s.wsManager == nil || shift == nil || len(shift.Matches) == 0 {
+		return
+	}
+
+	// 更新データを作成
+	updateData := &models.ScheduleUpdateData{
+		EventID:      shift.EventID,
+		CourtID:      shift.Request.CourtID,
+		TournamentID: shift.Request.TournamentID,
+		DelayMinutes: shift.Request.DelayMinutes,
+		Reason:       shift.Request.Reason,
+		Matches:      shift.Matches,
+	}
+
+	// 対象の種目・コートを集める
+	var sports []models.SportType
+	var courts []int
+	seenSports := make(map[models.SportType]bool)
+	seenCourts := make(map[int]bool)
+	for _, match := range shift.Matches {
+		if !seenSports[match.Sport] {
+			seenSports[match.Sport] = true
+			sports = append(sports, match.Sport)
+		}
+		if match.CourtID != nil && !seenCourts[*match.CourtID] {
+			seenCourts[*match.CourtID] = true
+			courts = append(courts, *match.CourtID)
+		}
+	}
+
+	// 更新通知を作成（種目は最初に開始時刻を変えた試合の種目）
+	notification := models.NewUpdateNotification(
+		models.MessageTypeScheduleUpdate,
+		shift.Matches[0].Sport,
+		updateData,
+	)
+
+	// WebSocketメッセージを作成
+	wsMessage, err := models.NewWebSocketMessage(
+		models.MessageTypeScheduleUpdate.String(),
+		notification,
+	)
+	if err != nil {
+		log.Printf("Failed to create schedule update message: %v", err)
+		return
+	}
+
+	// 種目・コートの購読者にブロードキャスト
+	s.wsManager.BroadcastToSubscribers(wsMessage, sports, courts)
+
+	log.Printf("Schedule update notification sent: event_id=%d, matches=%d, delay=%dmin",
+		shift.EventID, len(shift.Matches), shift.Request.DelayMinutes)
+}
+
 // NotifySystemMessage はシステムメッセージを通知する
 func (s *NotificationService) NotifySystemMessage(message string, sports []models.SportType, userIDs []int) {
 	if s.wsManager == nil {
@@ -210,24 +481,12 @@ func (s *NotificationService) NotifySystemMessage(message string, sports []model
 	log.Printf("System message notification sent: %s", message)
 }
 
-// getMatchSport は試合のスポーツを取得する（簡易実装）
-// 実際の実装では、TournamentServiceやRepositoryから取得する
-func (s *NotificationService) getMatchSport(match *models.Match) models.SportType {
-	// TODO: 実際の実装では、match.TournamentIDからトーナメント情報を取得してスポーツを判定する
-	// 現在は簡易的な実装として、試合IDやトーナメントIDから推測する
-	
-	// 仮の実装：トーナメントIDの範囲でスポーツを判定
-	switch {
-	case match.TournamentID >= 1 && match.TournamentID <= 10:
-		return models.SportTypeVolleyball
-	case match.TournamentID >= 11 && match.TournamentID <= 20:
-		return models.SportTypeTableTennis
-	case match.TournamentID >= 21 && match.TournamentID <= 30:
-		return models.SportTypeSoccer
-	default:
-		// デフォルトはバレーボール
-		return models.SportTypeVolleyball
+// matchCourts は試合の通知を受け取るコートを返す（コート未割り当ての場合は空）
+func matchCourts(match *models.Match) []int {
+	if match.CourtID == nil {
+		return nil
 	}
+	return []int{*match.CourtID}
 }
 
 // SetWebSocketManager はWebSocketマネージャーを設定する
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// PlayerService defines the interface for players, team rosters, match MVPs and leaderboards
+type PlayerService interface {
+	ListPlayers(ctx context.Context, className string, limit, offset int) ([]*models.Player, error)
+	GetPlayer(ctx context.Context, id uint) (*models.Player, error)
+	CreatePlayer(ctx context.Context, player *models.Player) error
+	UpdatePlayer(ctx context.Context, id uint, player *models.Player) error
+	DeletePlayer(ctx context.Context, id uint) error
+
+	GetRoster(ctx context.Context, teamID uint) ([]*models.RosterEntry, error)
+	AddToRoster(ctx context.Context, entry *models.RosterEntry) error
+	RemoveFromRoster(ctx context.Context, teamID, playerID uint) error
+
+	SetMatchMVP(ctx context.Context, matchID int, playerID uint, selectedBy *int) (*models.Player, error)
+	ClearMatchMVP(ctx context.Context, matchID int) error
+
+	GetTournamentLeaderboard(ctx context.Context, tournamentID uint, size int) (*models.Leaderboard, error)
+	GetEventLeaderboard(ctx context.Context, eventID uint, size int) (*models.Leaderboard, error)
+}
+
+// playerService implements PlayerService
+type playerService struct {
+	playerRepo     repository.PlayerRepository
+	teamRepo       repository.TeamRepository
+	matchRepo      repository.MatchRepository
+	tournamentRepo repository.TournamentRepository
+	eventRepo      repository.EventRepository
+}
+
+// NewPlayerService creates a new player service
+func NewPlayerService(playerRepo repository.PlayerRepository, teamRepo repository.TeamRepository, matchRepo repository.MatchRepository, tournamentRepo repository.TournamentRepository, eventRepo repository.EventRepository) PlayerService {
+	return &playerService{
+		playerRepo:     playerRepo,
+		teamRepo:       teamRepo,
+		matchRepo:      matchRepo,
+		tournamentRepo: tournamentRepo,
+		eventRepo:      eventRepo,
+	}
+}
+
+// ListPlayers returns players ordered by student number, optionally filtered by class
+func (s *playerService) ListPlayers(ctx context.Context, className string, limit, offset int) ([]*models.Player, error) {
+	players, err := s.playerRepo.List(ctx, strings.TrimSpace(className), limit, offset)
+	if err != nil {
+		logger.Error("Failed to list players", "className", className, "error", err)
+		return nil, NewDatabaseError("failed to get players")
+	}
+	if players == nil {
+		players = []*models.Player{}
+	}
+	return players, nil
+}
+
+// GetPlayer returns a player by ID
+func (s *playerService) GetPlayer(ctx context.Context, id uint) (*models.Player, error) {
+	player, err := s.playerRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get player", "playerID", id, "error", err)
+		return nil, NewDatabaseError("failed to get player")
+	}
+	if player == nil {
+		return nil, NewNotFoundError("player not found")
+	}
+	return player, nil
+}
+
+// CreatePlayer registers a student as a player
+func (s *playerService) CreatePlayer(ctx context.Context, player *models.Player) error {
+	normalizePlayer(player)
+	if err := player.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureStudentNumberAvailable(ctx, player); err != nil {
+		return err
+	}
+
+	if err := s.playerRepo.Create(ctx, player); err != nil {
+		logger.Error("Failed to create player", "studentNumber", player.StudentNumber, "error", err)
+		return NewDatabaseError("failed to create player")
+	}
+
+	logger.Info("Player created", "playerID", player.ID, "studentNumber", player.StudentNumber)
+	return nil
+}
+
+// UpdatePlayer updates a player's student number, name and class
+func (s *playerService) UpdatePlayer(ctx context.Context, id uint, player *models.Player) error {
+	existing, err := s.GetPlayer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	player.ID = existing.ID
+	player.CreatedAt = existing.CreatedAt
+	normalizePlayer(player)
+	if err := player.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureStudentNumberAvailable(ctx, player); err != nil {
+		return err
+	}
+
+	if err := s.playerRepo.Update(ctx, player); err != nil {
+		logger.Error("Failed to update player", "playerID", id, "error", err)
+		return NewDatabaseError("failed to update player")
+	}
+	return nil
+}
+
+// DeletePlayer deletes a player. The player's roster entries and MVP awards are
+// removed and the match events recorded for the player are kept without a player.
+func (s *playerService) DeletePlayer(ctx context.Context, id uint) error {
+	if _, err := s.GetPlayer(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.playerRepo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete player", "playerID", id, "error", err)
+		return NewDatabaseError("failed to delete player")
+	}
+	return nil
+}
+
+// GetRoster returns the players registered to a team
+func (s *playerService) GetRoster(ctx context.Context, teamID uint) ([]*models.RosterEntry, error) {
+	if _, err := s.getTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	roster, err := s.playerRepo.GetRoster(ctx, teamID)
+	if err != nil {
+		logger.Error("Failed to get roster", "teamID", teamID, "error", err)
+		return nil, NewDatabaseError("failed to get roster")
+	}
+	if roster == nil {
+		roster = []*models.RosterEntry{}
+	}
+	return roster, nil
+}
+
+// AddToRoster adds a player to a team roster. A student can only play for one
+// team per sport, so a player already on a team of the same tournament is rejected.
+func (s *playerService) AddToRoster(ctx context.Context, entry *models.RosterEntry) error {
+	if err := entry.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	team, err := s.getTeam(ctx, entry.TeamID)
+	if err != nil {
+		return err
+	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, int(team.TournamentID)); err != nil {
+		return err
+	}
+	player, err := s.GetPlayer(ctx, entry.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.playerRepo.GetRosterEntry(ctx, team.TournamentID, player.ID)
+	if err != nil {
+		logger.Error("Failed to get roster entry", "tournamentID", team.TournamentID, "playerID", player.ID, "error", err)
+		return NewDatabaseError("failed to check roster")
+	}
+	if existing != nil {
+		if existing.TeamID == team.ID {
+			return NewConflictError("player is already on the roster of this team")
+		}
+		return NewConflictError("player is already on another team in this sport")
+	}
+
+	if entry.ShirtNumber != nil {
+		roster, err := s.playerRepo.GetRoster(ctx, team.ID)
+		if err != nil {
+			logger.Error("Failed to get roster", "teamID", team.ID, "error", err)
+			return NewDatabaseError("failed to check roster")
+		}
+		for _, other := range roster {
+			if other.ShirtNumber != nil && *other.ShirtNumber == *entry.ShirtNumber {
+				return NewConflictError("shirt number is already taken in this team")
+			}
+		}
+	}
+
+	entry.TournamentID = team.TournamentID
+	if err := s.playerRepo.AddToRoster(ctx, entry); err != nil {
+		logger.Error("Failed to add player to roster", "teamID", team.ID, "playerID", player.ID, "error", err)
+		return NewDatabaseError("failed to add player to roster")
+	}
+	entry.Player = player
+
+	logger.Info("Player added to roster", "teamID", team.ID, "playerID", player.ID)
+	return nil
+}
+
+// RemoveFromRoster removes a player from a team roster. Players with goals,
+// cards or MVP awards in the tournament are kept so that the leaderboards stay intact.
+func (s *playerService) RemoveFromRoster(ctx context.Context, teamID, playerID uint) error {
+	team, err := s.getTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, int(team.TournamentID)); err != nil {
+		return err
+	}
+
+	entry, err := s.playerRepo.GetRosterEntry(ctx, team.TournamentID, playerID)
+	if err != nil {
+		logger.Error("Failed to get roster entry", "tournamentID", team.TournamentID, "playerID", playerID, "error", err)
+		return NewDatabaseError("failed to get roster")
+	}
+	if entry == nil || entry.TeamID != team.ID {
+		return NewNotFoundError("player is not on the roster of this team")
+	}
+
+	records, err := s.playerRepo.CountRecords(ctx, team.TournamentID, playerID)
+	if err != nil {
+		logger.Error("Failed to count player records", "tournamentID", team.TournamentID, "playerID", playerID, "error", err)
+		return NewDatabaseError("failed to remove player from roster")
+	}
+	if records > 0 {
+		return NewConflictError("player has recorded events in this tournament and cannot be removed")
+	}
+
+	if err := s.playerRepo.RemoveFromRoster(ctx, team.ID, playerID); err != nil {
+		logger.Error("Failed to remove player from roster", "teamID", team.ID, "playerID", playerID, "error", err)
+		return NewDatabaseError("failed to remove player from roster")
+	}
+	return nil
+}
+
+// SetMatchMVP selects the MVP of a match from the rosters of the two teams
+func (s *playerService) SetMatchMVP(ctx context.Context, matchID int, playerID uint, selectedBy *int) (*models.Player, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, match.TournamentID); err != nil {
+		return nil, err
+	}
+	player, err := s.GetPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.playerRepo.GetRosterEntry(ctx, uint(match.TournamentID), player.ID)
+	if err != nil {
+		logger.Error("Failed to get roster entry", "tournamentID", match.TournamentID, "playerID", player.ID, "error", err)
+		return nil, NewDatabaseError("failed to check roster")
+	}
+	if entry == nil || !playsIn(match, entry.TeamID) {
+		return nil, NewValidationError("player is not on the roster of either team")
+	}
+
+	if err := s.playerRepo.SetMatchMVP(ctx, matchID, player.ID, selectedBy); err != nil {
+		logger.Error("Failed to set match MVP", "matchID", matchID, "playerID", player.ID, "error", err)
+		return nil, NewDatabaseError("failed to set match MVP")
+	}
+	return player, nil
+}
+
+// ClearMatchMVP removes the MVP selection of a match
+func (s *playerService) ClearMatchMVP(ctx context.Context, matchID int) error {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, match.TournamentID); err != nil {
+		return err
+	}
+
+	if err := s.playerRepo.ClearMatchMVP(ctx, matchID); err != nil {
+		logger.Error("Failed to clear match MVP", "matchID", matchID, "error", err)
+		return NewDatabaseError("failed to clear match MVP")
+	}
+	return nil
+}
+
+// GetTournamentLeaderboard returns the top scorers and MVPs of a tournament
+func (s *playerService) GetTournamentLeaderboard(ctx context.Context, tournamentID uint, size int) (*models.Leaderboard, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return nil, NewNotFoundError("tournament not found")
+	}
+
+	stats, err := s.playerRepo.GetStatsByTournament(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get player statistics", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get leaderboard")
+	}
+
+	leaderboard := &models.Leaderboard{EventID: tournament.EventID, TournamentID: &tournament.ID}
+	leaderboard.TopScorers, leaderboard.MVP = models.BuildLeaderboard(stats, size)
+	return leaderboard, nil
+}
+
+// GetEventLeaderboard returns the top scorers and MVPs of an event over all its
+// sports (0 means the current event)
+func (s *playerService) GetEventLeaderboard(ctx context.Context, eventID uint, size int) (*models.Leaderboard, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.playerRepo.GetStatsByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get player statistics", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get leaderboard")
+	}
+
+	leaderboard := &models.Leaderboard{EventID: event.ID}
+	leaderboard.TopScorers, leaderboard.MVP = models.BuildLeaderboard(stats, size)
+	return leaderboard, nil
+}
+
+// getTeam returns a team by ID
+func (s *playerService) getTeam(ctx context.Context, teamID uint) (*models.Team, error) {
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		logger.Error("Failed to get team", "teamID", teamID, "error", err)
+		return nil, NewDatabaseError("failed to get team")
+	}
+	if team == nil {
+		return nil, NewNotFoundError("team not found")
+	}
+	return team, nil
+}
+
+// getMatch returns a match by ID
+func (s *playerService) getMatch(ctx context.Context, matchID int) (*models.Match, error) {
+	match, err := s.matchRepo.GetByID(ctx, uint(matchID))
+	if err != nil {
+		logger.Error("Failed to get match", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to get match")
+	}
+	if match == nil {
+		return nil, NewNotFoundError("match not found")
+	}
+	return match, nil
+}
+
+// ensureStudentNumberAvailable rejects a student number already used by another player
+func (s *playerService) ensureStudentNumberAvailable(ctx context.Context, player *models.Player) error {
+	other, err := s.playerRepo.GetByStudentNumber(ctx, player.StudentNumber)
+	if err != nil {
+		logger.Error("Failed to check student number", "studentNumber", player.StudentNumber, "error", err)
+		return NewDatabaseError("failed to check student number")
+	}
+	if other != nil && other.ID != player.ID {
+		return NewConflictError("student number is already registered")
+	}
+	return nil
+}
+
+// normalizePlayer trims the player's text fields
+func normalizePlayer(player *models.Player) {
+	player.StudentNumber = strings.TrimSpace(player.StudentNumber)
+	player.Name = strings.TrimSpace(player.Name)
+	player.ClassName = strings.TrimSpace(player.ClassName)
+}
+
+// playsIn reports whether a team plays in a match
+func playsIn(match *models.Match, teamID uint) bool {
+	return (match.Team1ID != nil && uint(*match.Team1ID) == teamID) ||
+		(match.Team2ID != nil && uint(*match.Team2ID) == teamID)
+}
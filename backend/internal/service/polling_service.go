@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 type PollingService struct {
 	tournamentRepo repository.TournamentRepository
 	matchRepo      repository.MatchRepository
+	eventRepo      repository.EventRepository
 	
 	// キャッシュ管理
 	cache      map[string]*CacheEntry
@@ -33,6 +35,7 @@ type CacheEntry struct {
 
 // PollingResponse はポーリングレスポンスを表す
 type PollingResponse struct {
+	EventID    int         `json:"event_id"`
 	HasUpdates bool        `json:"has_updates"`
 	Data       interface{} `json:"data,omitempty"`
 	ETag       string      `json:"etag"`
@@ -41,8 +44,10 @@ type PollingResponse struct {
 }
 
 // UpdateCheckRequest はデータ更新チェックリクエストを表す
+// EventID を省略した場合は現在の大会を対象とする
 type UpdateCheckRequest struct {
-	Sport     models.SportType `json:"sport" validate:"required,oneof=volleyball table_tennis soccer"`
+	EventID   uint             `json:"event_id,omitempty"`
+	Sport     models.SportType `json:"sport" validate:"required,sport"`
 	DataType  string           `json:"data_type" validate:"required,oneof=tournament matches bracket"`
 	LastETag  string           `json:"last_etag,omitempty"`
 	LastCheck string           `json:"last_check,omitempty"`
@@ -52,10 +57,12 @@ type UpdateCheckRequest struct {
 func NewPollingService(
 	tournamentRepo repository.TournamentRepository,
 	matchRepo repository.MatchRepository,
+	eventRepo repository.EventRepository,
 ) *PollingService {
 	return &PollingService{
 		tournamentRepo: tournamentRepo,
 		matchRepo:      matchRepo,
+		eventRepo:      eventRepo,
 		cache:          make(map[string]*CacheEntry),
 		cacheExpiry:    30 * time.Second, // 30秒でキャッシュ期限切れ
 	}
@@ -68,8 +75,15 @@ func (s *PollingService) CheckForUpdates(ctx context.Context, request *UpdateChe
 		return nil, NewValidationError("無効なスポーツタイプです")
 	}
 
+	// 大会を特定（省略時は現在の大会）
+	event, err := resolveEvent(ctx, s.eventRepo, request.EventID)
+	if err != nil {
+		return nil, err
+	}
+	eventID := uint(event.ID)
+
 	// キャッシュキーを生成
-	cacheKey := s.generateCacheKey(request.Sport, request.DataType)
+	cacheKey := s.generateCacheKey(eventID, request.Sport, request.DataType)
 
 	// キャッシュから現在のデータを取得
 	currentEntry := s.getCacheEntry(cacheKey)
@@ -78,6 +92,7 @@ func (s *PollingService) CheckForUpdates(ctx context.Context, request *UpdateChe
 	if request.LastETag != "" && currentEntry != nil && currentEntry.ETag == request.LastETag {
 		// データに変更なし
 		return &PollingResponse{
+			EventID:    event.ID,
 			HasUpdates: false,
 			ETag:       currentEntry.ETag,
 			Timestamp:  currentEntry.Timestamp.UTC().Format(time.RFC3339),
@@ -86,7 +101,7 @@ func (s *PollingService) CheckForUpdates(ctx context.Context, request *UpdateChe
 	}
 
 	// データを取得（キャッシュまたはDB）
-	data, err := s.fetchData(ctx, request.Sport, request.DataType)
+	data, err := s.fetchData(ctx, eventID, request.Sport, request.DataType)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +114,7 @@ func (s *PollingService) CheckForUpdates(ctx context.Context, request *UpdateChe
 
 	// レスポンスを作成
 	response := &PollingResponse{
+		EventID:    event.ID,
 		HasUpdates: true,
 		Data:       data,
 		ETag:       etag,
@@ -110,14 +126,22 @@ func (s *PollingService) CheckForUpdates(ctx context.Context, request *UpdateChe
 }
 
 // GetLatestData は最新のデータを取得する（強制更新）
-func (s *PollingService) GetLatestData(ctx context.Context, sport models.SportType, dataType string) (*PollingResponse, error) {
+// eventID が0の場合は現在の大会を対象とする
+func (s *PollingService) GetLatestData(ctx context.Context, eventID uint, sport models.SportType, dataType string) (*PollingResponse, error) {
 	// バリデーション
 	if !sport.IsValid() {
 		return nil, NewValidationError("無効なスポーツタイプです")
 	}
 
+	// 大会を特定
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+	eventID = uint(event.ID)
+
 	// データを強制取得
-	data, err := s.fetchData(ctx, sport, dataType)
+	data, err := s.fetchData(ctx, eventID, sport, dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -126,10 +150,11 @@ func (s *PollingService) GetLatestData(ctx context.Context, sport models.SportTy
 	etag := s.generateETag(data)
 
 	// キャッシュを更新
-	cacheKey := s.generateCacheKey(sport, dataType)
+	cacheKey := s.generateCacheKey(eventID, sport, dataType)
 	s.updateCache(cacheKey, data, etag)
 
 	return &PollingResponse{
+		EventID:    event.ID,
 		HasUpdates: true,
 		Data:       data,
 		ETag:       etag,
@@ -139,13 +164,22 @@ func (s *PollingService) GetLatestData(ctx context.Context, sport models.SportTy
 }
 
 // InvalidateCache は指定されたキャッシュを無効化する
-func (s *PollingService) InvalidateCache(sport models.SportType, dataType string) {
-	cacheKey := s.generateCacheKey(sport, dataType)
-	
+// eventID が0の場合は全ての大会の該当キャッシュを無効化する
+func (s *PollingService) InvalidateCache(eventID uint, sport models.SportType, dataType string) {
 	s.cacheMutex.Lock()
 	defer s.cacheMutex.Unlock()
 	
-	delete(s.cache, cacheKey)
+	if eventID != 0 {
+		delete(s.cache, s.generateCacheKey(eventID, sport, dataType))
+		return
+	}
+	
+	suffix := fmt.Sprintf(":%s:%s", sport.String(), dataType)
+	for key := range s.cache {
+		if strings.HasSuffix(key, suffix) {
+			delete(s.cache, key)
+		}
+	}
 }
 
 // InvalidateAllCache は全てのキャッシュを無効化する
@@ -157,47 +191,47 @@ func (s *PollingService) InvalidateAllCache() {
 }
 
 // fetchData はデータベースからデータを取得する
-func (s *PollingService) fetchData(ctx context.Context, sport models.SportType, dataType string) (interface{}, error) {
+func (s *PollingService) fetchData(ctx context.Context, eventID uint, sport models.SportType, dataType string) (interface{}, error) {
 	switch dataType {
 	case "tournament":
-		return s.fetchTournamentData(ctx, sport)
+		return s.fetchTournamentData(ctx, eventID, sport)
 	case "matches":
-		return s.fetchMatchesData(ctx, sport)
+		return s.fetchMatchesData(ctx, eventID, sport)
 	case "bracket":
-		return s.fetchBracketData(ctx, sport)
+		return s.fetchBracketData(ctx, eventID, sport)
 	default:
 		return nil, NewValidationError("無効なデータタイプです")
 	}
 }
 
-// fetchTournamentData はトーナメントデータを取得する
-func (s *PollingService) fetchTournamentData(ctx context.Context, sport models.SportType) (interface{}, error) {
-	tournaments, err := s.tournamentRepo.GetBySport(ctx, sport.String(), 1, 0)
+// fetchTournamentData は大会のトーナメントデータを取得する
+func (s *PollingService) fetchTournamentData(ctx context.Context, eventID uint, sport models.SportType) (interface{}, error) {
+	tournament, err := s.tournamentRepo.GetByEventAndSport(ctx, eventID, sport.String())
 	if err != nil {
 		return nil, NewDatabaseError("トーナメントデータの取得に失敗しました")
 	}
 	
-	if len(tournaments) == 0 {
+	if tournament == nil {
 		return nil, NewNotFoundError("トーナメントが見つかりません")
 	}
 	
-	return tournaments[0], nil
+	return tournament, nil
 }
 
-// fetchMatchesData は試合データを取得する
-func (s *PollingService) fetchMatchesData(ctx context.Context, sport models.SportType) (interface{}, error) {
-	// スポーツに対応するトーナメントを取得
-	tournaments, err := s.tournamentRepo.GetBySport(ctx, sport.String(), 1, 0)
+// fetchMatchesData は大会の試合データを取得する
+func (s *PollingService) fetchMatchesData(ctx context.Context, eventID uint, sport models.SportType) (interface{}, error) {
+	// 大会のスポーツに対応するトーナメントを取得
+	tournament, err := s.tournamentRepo.GetByEventAndSport(ctx, eventID, sport.String())
 	if err != nil {
 		return nil, NewDatabaseError("トーナメントデータの取得に失敗しました")
 	}
 	
-	if len(tournaments) == 0 {
+	if tournament == nil {
 		return []interface{}{}, nil // 空の配列を返す
 	}
 	
 	// 試合データを取得
-	matches, err := s.matchRepo.GetByTournamentID(ctx, uint(tournaments[0].ID))
+	matches, err := s.matchRepo.GetByTournamentID(ctx, uint(tournament.ID))
 	if err != nil {
 		return nil, NewDatabaseError("試合データの取得に失敗しました")
 	}
@@ -206,9 +240,9 @@ func (s *PollingService) fetchMatchesData(ctx context.Context, sport models.Spor
 }
 
 // fetchBracketData はブラケットデータを取得する
-func (s *PollingService) fetchBracketData(ctx context.Context, sport models.SportType) (interface{}, error) {
+func (s *PollingService) fetchBracketData(ctx context.Context, eventID uint, sport models.SportType) (interface{}, error) {
 	// 試合データを取得してブラケット形式に整形
-	matchesData, err := s.fetchMatchesData(ctx, sport)
+	matchesData, err := s.fetchMatchesData(ctx, eventID, sport)
 	if err != nil {
 		return nil, err
 	}
@@ -218,34 +252,26 @@ func (s *PollingService) fetchBracketData(ctx context.Context, sport models.Spor
 		return nil, NewDatabaseError("試合データの形式が無効です")
 	}
 	
-	// ブラケット形式に変換（簡易実装）
-	bracket := s.convertToBracket(matches)
+	// ブラケット形式に変換（ラウンド内は保存された位置順）
+	bracket := s.convertToBracket(sport, matches)
 	return bracket, nil
 }
 
 // convertToBracket は試合データをブラケット形式に変換する
-func (s *PollingService) convertToBracket(matches []*models.Match) *models.Bracket {
-	// 簡易的なブラケット作成
+func (s *PollingService) convertToBracket(sport models.SportType, matches []*models.Match) *models.Bracket {
 	bracket := &models.Bracket{
-		Sport:   models.SportTypeVolleyball, // 実際にはパラメータから取得
-		Rounds:  make(map[string][]*models.Match),
-		Updated: models.Now(),
+		Sport:  sport.String(),
+		Rounds: models.GroupMatchesIntoRounds(matches),
 	}
-	
-	// ラウンド別に試合を分類
-	for _, match := range matches {
-		if bracket.Rounds[match.Round] == nil {
-			bracket.Rounds[match.Round] = make([]*models.Match, 0)
-		}
-		bracket.Rounds[match.Round] = append(bracket.Rounds[match.Round], match)
+	if len(matches) > 0 {
+		bracket.TournamentID = matches[0].TournamentID
 	}
-	
 	return bracket
 }
 
-// generateCacheKey はキャッシュキーを生成する
-func (s *PollingService) generateCacheKey(sport models.SportType, dataType string) string {
-	return fmt.Sprintf("%s:%s", sport.String(), dataType)
+// generateCacheKey は大会・スポーツ・データタイプごとのキャッシュキーを生成する
+func (s *PollingService) generateCacheKey(eventID uint, sport models.SportType, dataType string) string {
+	return fmt.Sprintf("event:%d:%s:%s", eventID, sport.String(), dataType)
 }
 
 // getCacheEntry はキャッシュエントリを取得する
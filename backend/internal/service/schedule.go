@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// ScheduleService defines the interface for generating the match schedule of
+// an event, pushing it back when matches overrun, pinning matches to a time
+// and court by hand and publishing the schedule as an iCalendar feed
+type ScheduleService interface {
+	GetScheduleOptions(ctx context.Context, eventID uint) (*models.ScheduleOptions, error)
+	GenerateSchedule(ctx context.Context, eventID uint, options *models.ScheduleOptions, dryRun bool) (*models.Schedule, error)
+	ShiftSchedule(ctx context.Context, eventID uint, request *models.ScheduleShiftRequest, dryRun bool) (*models.ScheduleShift, error)
+	PinMatch(ctx context.Context, matchID uint, scheduledAt time.Time, courtID *int) (*models.Match, error)
+	UnpinMatch(ctx context.Context, matchID uint) (*models.Match, error)
+	AssignCourt(ctx context.Context, matchID uint, courtID *int) (*models.Match, error)
+	GetCalendar(ctx context.Context, eventID uint, filter models.CalendarFilter) (*models.Calendar, error)
+	SetNotificationService(notificationService *NotificationService)
+	SetVenueService(venueService VenueService)
+}
+
+// scheduleService implements ScheduleService
+type scheduleService struct {
+	matchRepo           repository.MatchRepository
+	tournamentRepo      repository.TournamentRepository
+	eventRepo           repository.EventRepository
+	venueRepo           repository.VenueRepository
+	notificationService *NotificationService
+	venueService        VenueService
+}
+
+// NewScheduleService creates a new schedule service
+func NewScheduleService(matchRepo repository.MatchRepository, tournamentRepo repository.TournamentRepository, eventRepo repository.EventRepository, venueRepo repository.VenueRepository) ScheduleService {
+	return &scheduleService{
+		matchRepo:      matchRepo,
+		tournamentRepo: tournamentRepo,
+		eventRepo:      eventRepo,
+		venueRepo:      venueRepo,
+	}
+}
+
+// SetNotificationService sets the notification service for real-time updates
+func (s *scheduleService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// SetVenueService sets the venue service used to refresh the boards of the
+// courts whose queue changed
+func (s *scheduleService) SetVenueService(venueService VenueService) {
+	s.venueService = venueService
+}
+
+// GetScheduleOptions returns the conditions the event's schedule was last
+// generated with, or sensible defaults for the first day of the event
+func (s *scheduleService) GetScheduleOptions(ctx context.Context, eventID uint) (*models.ScheduleOptions, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := s.eventRepo.GetScheduleOptions(ctx, event.ID)
+	if err != nil {
+		logger.Error("Failed to get schedule options", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get schedule options")
+	}
+	if options != nil {
+		return options, nil
+	}
+
+	return &models.ScheduleOptions{
+		Date:        models.FormatEventDate(event.StartDate),
+		StartTime:   "09:00",
+		EndTime:     "16:00",
+		Breaks:      []models.ScheduleBreak{{Name: "昼休み", Start: "12:00", End: "13:00"}},
+		SlotMinutes: map[models.SportType]int{},
+		RestMinutes: 10,
+	}, nil
+}
+
+// GenerateSchedule assigns a start time and court to every match of the event
+// that is not pinned, in progress or completed. The schedule is only applied
+// when every match could be placed and dryRun is false; otherwise the result
+// lists the matches that could not be placed and why, leaving matches as they are.
+func (s *scheduleService) GenerateSchedule(ctx context.Context, eventID uint, options *models.ScheduleOptions, dryRun bool) (*models.Schedule, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := ensureEventWritable(ctx, s.eventRepo, event.ID); err != nil {
+			return nil, err
+		}
+	}
+	if options.Date == "" {
+		options.Date = models.FormatEventDate(event.StartDate)
+	}
+	if err := options.Validate(); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
+	courts, err := s.venueRepo.GetCourtsByEvent(ctx, event.ID)
+	if err != nil {
+		logger.Error("Failed to get courts", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get courts")
+	}
+
+	matches, byID, sports, err := s.getEventMatches(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := models.BuildSchedule(models.ScheduleInput{
+		Options:  *options,
+		Location: event.Location(),
+		Courts:   courts,
+		Matches:  matches,
+		Sports:   sports,
+	})
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	schedule.EventID = event.ID
+
+	if dryRun || !schedule.Feasible {
+		return schedule, nil
+	}
+
+	matchByID := make(map[int]*models.Match, len(matches))
+	for _, match := range matches {
+		matchByID[match.ID] = match
+	}
+	var updated []*models.Match
+	var touched []*int
+	changed := make(map[int]bool)
+	for _, scheduled := range schedule.Matches {
+		match := matchByID[scheduled.MatchID]
+		if scheduled.Fixed || match == nil {
+			continue
+		}
+		touched = append(touched, match.CourtID, scheduled.CourtID)
+		// A generated schedule is a new plan, so earlier delays no longer apply
+		match.ScheduledAt = scheduled.StartsAt
+		match.PlannedAt = nil
+		match.CourtID = scheduled.CourtID
+		updated = append(updated, match)
+		changed[match.TournamentID] = true
+	}
+
+	if err := s.matchRepo.UpdateSchedule(ctx, updated); err != nil {
+		logger.Error("Failed to apply schedule", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to apply schedule")
+	}
+	if err := s.eventRepo.SaveScheduleOptions(ctx, event.ID, options); err != nil {
+		// The schedule itself is applied; only the remembered conditions are lost
+		logger.Error("Failed to save schedule options", "eventID", event.ID, "error", err)
+	}
+	schedule.Applied = true
+
+	logger.Info("Schedule applied", "eventID", event.ID, "matches", len(updated))
+	if s.notificationService != nil {
+		for tournamentID := range changed {
+			s.notificationService.NotifyTournamentUpdate(byID[tournamentID], "schedule_updated")
+		}
+	}
+	s.refreshCourts(ctx, touched...)
+	return schedule, nil
+}
+
+// ShiftSchedule pushes back the matches of a court or tournament that have not
+// started yet, e.g. after a match overran. Later matches sharing a court, a
+// team or a bracket dependency with them follow as far as needed, matches are
+// moved past breaks and around pinned, in-progress and completed matches, and
+// each moved match remembers the start time it was originally planned for.
+// Unlike GenerateSchedule the shift is applied even when it reports issues,
+// since the delay has already happened; subscribers receive the new timetable
+// in a single message.
+func (s *scheduleService) ShiftSchedule(ctx context.Context, eventID uint, request *models.ScheduleShiftRequest, dryRun bool) (*models.ScheduleShift, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := ensureEventWritable(ctx, s.eventRepo, event.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := request.Validate(); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	if err := s.ensureShiftScope(ctx, event, request); err != nil {
+		return nil, err
+	}
+
+	options, err := s.GetScheduleOptions(ctx, uint(event.ID))
+	if err != nil {
+		return nil, err
+	}
+	matches, _, sports, err := s.getEventMatches(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	shift, err := models.ShiftSchedule(models.ShiftInput{
+		Request:  *request,
+		Options:  *options,
+		Location: event.Location(),
+		Matches:  matches,
+		Sports:   sports,
+	})
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	shift.EventID = event.ID
+
+	if dryRun || len(shift.Matches) == 0 {
+		return shift, nil
+	}
+
+	matchByID := make(map[int]*models.Match, len(matches))
+	for _, match := range matches {
+		matchByID[match.ID] = match
+	}
+	updated := make([]*models.Match, 0, len(shift.Matches))
+	for _, shifted := range shift.Matches {
+		match := matchByID[shifted.MatchID]
+		planned := shifted.PlannedAt
+		match.PlannedAt = &planned
+		match.ScheduledAt = shifted.ScheduledAt
+		updated = append(updated, match)
+	}
+
+	if err := s.matchRepo.UpdateSchedule(ctx, updated); err != nil {
+		logger.Error("Failed to shift schedule", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to shift schedule")
+	}
+	shift.Applied = true
+
+	logger.Info("Schedule shifted", "eventID", event.ID, "courtID", request.CourtID, "tournamentID", request.TournamentID,
+		"delayMinutes", request.DelayMinutes, "matches", len(updated))
+	if s.notificationService != nil {
+		s.notificationService.NotifyScheduleUpdate(shift)
+	}
+	return shift, nil
+}
+
+// PinMatch fixes a match to a start time and, optionally, a court so that
+// generating the schedule again leaves it where the admin put it
+func (s *scheduleService) PinMatch(ctx context.Context, matchID uint, scheduledAt time.Time, courtID *int) (*models.Match, error) {
+	match, tournament, err := s.getWritableMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.IsCompleted() {
+		return nil, NewConflictError("completed matches cannot be rescheduled")
+	}
+	if scheduledAt.IsZero() {
+		return nil, NewValidationError("scheduled_at is required")
+	}
+
+	if err := s.ensureCourtHostsMatch(ctx, courtID, tournament); err != nil {
+		return nil, err
+	}
+
+	previousCourt := match.CourtID
+	match.ScheduledAt = scheduledAt
+	match.CourtID = courtID
+	match.SchedulePinned = true
+	if err := s.matchRepo.UpdateSchedule(ctx, []*models.Match{match}); err != nil {
+		logger.Error("Failed to pin match", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to pin match")
+	}
+
+	logger.Info("Match pinned", "matchID", matchID, "scheduledAt", scheduledAt, "courtID", courtID)
+	if s.notificationService != nil {
+		s.notificationService.NotifyMatchUpdate(match, "rescheduled")
+	}
+	s.refreshCourts(ctx, previousCourt, courtID)
+	return match, nil
+}
+
+// UnpinMatch releases a pinned match so that the next generated schedule may
+// move it. Its current start time and court are kept until then.
+func (s *scheduleService) UnpinMatch(ctx context.Context, matchID uint) (*models.Match, error) {
+	match, _, err := s.getWritableMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if !match.SchedulePinned {
+		return match, nil
+	}
+
+	match.SchedulePinned = false
+	if err := s.matchRepo.UpdateSchedule(ctx, []*models.Match{match}); err != nil {
+		logger.Error("Failed to unpin match", "matchID", matchID, "error", err)
+		return nil, NewDatabaseError("failed to unpin match")
+	}
+	return match, nil
+}
+
+// AssignCourt moves a match to another court, or leaves it unassigned when
+// courtID is nil, without touching its start time or pin
+func (s *scheduleService) AssignCourt(ctx context.Context, matchID uint, courtID *int) (*models.Match, error) {
+	match, tournament, err := s.getWritableMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.IsCompleted() {
+		return nil, NewConflictError("completed matches cannot be moved to another court")
+	}
+	if err := s.ensureCourtHostsMatch(ctx, courtID, tournament); err != nil {
+		return nil, err
+	}
+
+	previousCourt := match.CourtID
+	match.CourtID = courtID
+	if err := s.matchRepo.UpdateSchedule(ctx, []*models.Match{match}); err != nil {
+		logger.Error("Failed to assign court", "matchID", matchID, "courtID", courtID, "error", err)
+		return nil, NewDatabaseError("failed to assign court")
+	}
+
+	logger.Info("Match court assigned", "matchID", matchID, "courtID", courtID)
+	if s.notificationService != nil {
+		s.notificationService.NotifyMatchUpdate(match, "court_assigned")
+	}
+	s.refreshCourts(ctx, previousCourt, courtID)
+	return match, nil
+}
+
+// GetCalendar returns the event's scheduled matches as a calendar, narrowed
+// to a team, sport or court when the filter names one. Every match keeps the
+// same UID so calendar apps update it when it is rescheduled.
+func (s *scheduleService) GetCalendar(ctx context.Context, eventID uint, filter models.CalendarFilter) (*models.Calendar, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
+	venues, err := s.venueRepo.GetByEvent(ctx, event.ID)
+	if err != nil {
+		logger.Error("Failed to get venues for calendar", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get venues")
+	}
+	courts := make(map[int]string)
+	for _, venue := range venues {
+		for _, court := range venue.Courts {
+			courts[int(court.ID)] = venue.Name + " " + court.Name
+		}
+	}
+	if filter.CourtID != nil {
+		if _, ok := courts[*filter.CourtID]; !ok {
+			return nil, NewNotFoundError("court not found in this event")
+		}
+	}
+
+	options, err := s.GetScheduleOptions(ctx, uint(event.ID))
+	if err != nil {
+		return nil, err
+	}
+	matches, _, sports, err := s.getEventMatches(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.BuildCalendar(models.CalendarInput{
+		Name:     event.Name,
+		Filter:   filter,
+		Options:  *options,
+		Location: event.Location(),
+		Matches:  matches,
+		Sports:   sports,
+		Courts:   courts,
+	}), nil
+}
+
+// ensureShiftScope checks that the court or tournament to shift belongs to the event
+func (s *scheduleService) ensureShiftScope(ctx context.Context, event *models.Event, request *models.ScheduleShiftRequest) error {
+	if request.TournamentID != nil {
+		tournament, err := s.tournamentRepo.GetByID(ctx, uint(*request.TournamentID))
+		if err != nil {
+			logger.Error("Failed to get tournament", "tournamentID", *request.TournamentID, "error", err)
+			return NewDatabaseError("failed to get tournament")
+		}
+		if tournament == nil || tournament.EventID != event.ID {
+			return NewNotFoundError("tournament not found in this event")
+		}
+		return nil
+	}
+
+	court, err := s.venueRepo.GetCourt(ctx, uint(*request.CourtID))
+	if err != nil {
+		logger.Error("Failed to get court", "courtID", *request.CourtID, "error", err)
+		return NewDatabaseError("failed to get court")
+	}
+	if court == nil {
+		return NewNotFoundError("court not found")
+	}
+	venue, err := s.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		logger.Error("Failed to get venue", "venueID", court.VenueID, "error", err)
+		return NewDatabaseError("failed to get venue")
+	}
+	if venue == nil || venue.EventID != event.ID {
+		return NewNotFoundError("court not found in this event")
+	}
+	return nil
+}
+
+// getEventMatches returns the matches of an event's tournaments that are
+// still played, with those tournaments and their sports by tournament ID
+func (s *scheduleService) getEventMatches(ctx context.Context, event *models.Event) ([]*models.Match, map[int]*models.Tournament, map[int]models.SportType, error) {
+	tournaments, err := s.tournamentRepo.GetByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get tournaments for schedule", "eventID", event.ID, "error", err)
+		return nil, nil, nil, NewDatabaseError("failed to get tournaments")
+	}
+	sports := make(map[int]models.SportType, len(tournaments))
+	byID := make(map[int]*models.Tournament, len(tournaments))
+	for _, tournament := range tournaments {
+		if tournament.IsCancelled() {
+			continue
+		}
+		sports[tournament.ID] = tournament.GetSportType()
+		byID[tournament.ID] = tournament
+	}
+
+	all, err := s.matchRepo.GetByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get matches for schedule", "eventID", event.ID, "error", err)
+		return nil, nil, nil, NewDatabaseError("failed to get matches")
+	}
+	// Matches of cancelled tournaments are never played
+	matches := make([]*models.Match, 0, len(all))
+	for _, match := range all {
+		if _, ok := sports[match.TournamentID]; ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches, byID, sports, nil
+}
+
+// ensureCourtHostsMatch checks that a court belongs to the tournament's event
+// and hosts its sport. A nil court (unassigned) is always accepted.
+func (s *scheduleService) ensureCourtHostsMatch(ctx context.Context, courtID *int, tournament *models.Tournament) error {
+	if courtID == nil {
+		return nil
+	}
+
+	court, err := s.venueRepo.GetCourt(ctx, uint(*courtID))
+	if err != nil {
+		logger.Error("Failed to get court", "courtID", *courtID, "error", err)
+		return NewDatabaseError("failed to get court")
+	}
+	if court == nil {
+		return NewNotFoundError("court not found")
+	}
+	venue, err := s.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		logger.Error("Failed to get venue", "venueID", court.VenueID, "error", err)
+		return NewDatabaseError("failed to get venue")
+	}
+	if venue == nil || venue.EventID != tournament.EventID {
+		return NewValidationError("court does not belong to the match's event")
+	}
+	if !court.Supports(tournament.GetSportType()) {
+		return NewValidationError("court does not host the match's sport")
+	}
+	return nil
+}
+
+// refreshCourts pushes the boards of the courts whose queue changed
+func (s *scheduleService) refreshCourts(ctx context.Context, courtIDs ...*int) {
+	if s.venueService != nil {
+		s.venueService.RefreshCourtBoards(ctx, courtIDs...)
+	}
+}
+
+// getWritableMatch returns a match and its tournament, rejecting changes to an archived event
+func (s *scheduleService) getWritableMatch(ctx context.Context, matchID uint) (*models.Match, *models.Tournament, error) {
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		logger.Error("Failed to get match", "matchID", matchID, "error", err)
+		return nil, nil, NewDatabaseError("failed to get match")
+	}
+	if match == nil {
+		return nil, nil, NewNotFoundError("match not found")
+	}
+
+	tournament, err := s.tournamentRepo.GetByID(ctx, uint(match.TournamentID))
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", match.TournamentID, "error", err)
+		return nil, nil, NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return nil, nil, NewNotFoundError("tournament not found")
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return nil, nil, err
+	}
+	return match, tournament, nil
+}
@@ -90,7 +90,7 @@ func (s *seedingServiceImpl) InitializeVolleyballTournament() error {
 	tournament := &models.Tournament{
 		Sport:  models.SportVolleyball,
 		Format: models.FormatStandard,
-		Status: models.TournamentStatusActive,
+		Status: models.TournamentStatusRegistration,
 	}
 	err := s.tournamentSvc.CreateTournament(context.Background(), tournament)
 	if err != nil {
@@ -109,7 +109,7 @@ func (s *seedingServiceImpl) InitializeTableTennisTournament(format string) erro
 	tournament := &models.Tournament{
 		Sport:  models.SportTableTennis,
 		Format: format,
-		Status: models.TournamentStatusActive,
+		Status: models.TournamentStatusRegistration,
 	}
 	err := s.tournamentSvc.CreateTournament(context.Background(), tournament)
 	if err != nil {
@@ -128,7 +128,7 @@ func (s *seedingServiceImpl) InitializeSoccerTournament() error {
 	tournament := &models.Tournament{
 		Sport:  models.SportSoccer,
 		Format: models.FormatStandard,
-		Status: models.TournamentStatusActive,
+		Status: models.TournamentStatusRegistration,
 	}
 	err := s.tournamentSvc.CreateTournament(context.Background(), tournament)
 	if err != nil {
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// SportService defines the interface for sport registry operations
+type SportService interface {
+	LoadRegistry(ctx context.Context) error
+	ListSports(ctx context.Context, activeOnly bool) ([]*models.Sport, error)
+	GetSport(ctx context.Context, code models.SportType) (*models.Sport, error)
+	CreateSport(ctx context.Context, sport *models.Sport) error
+	UpdateSport(ctx context.Context, code models.SportType, sport *models.Sport) error
+}
+
+// sportService implements SportService
+type sportService struct {
+	sportRepo repository.SportRepository
+}
+
+// NewSportService creates a new sport service
+func NewSportService(sportRepo repository.SportRepository) SportService {
+	return &sportService{
+		sportRepo: sportRepo,
+	}
+}
+
+// LoadRegistry replaces the in-memory sport registry with the sports table.
+// The built-in sports stay registered when the table is empty.
+func (s *sportService) LoadRegistry(ctx context.Context) error {
+	sports, err := s.sportRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to load sports", "error", err)
+		return NewDatabaseError("failed to load sports")
+	}
+	if len(sports) == 0 {
+		logger.Warn("Sports table is empty; using the built-in sports")
+		return nil
+	}
+
+	if err := models.RegisterSports(sports); err != nil {
+		logger.Error("Invalid sport definition", "error", err)
+		return NewValidationError(err.Error())
+	}
+
+	logger.Info("Sport registry loaded", "sports", len(sports))
+	return nil
+}
+
+// ListSports returns the registered sports in display order
+func (s *sportService) ListSports(ctx context.Context, activeOnly bool) ([]*models.Sport, error) {
+	return models.RegisteredSports(activeOnly), nil
+}
+
+// GetSport returns a registered sport
+func (s *sportService) GetSport(ctx context.Context, code models.SportType) (*models.Sport, error) {
+	sport, ok := models.LookupSport(code)
+	if !ok {
+		return nil, NewNotFoundError("sport not found")
+	}
+	return sport, nil
+}
+
+// CreateSport adds a sport to the sports table and the registry
+func (s *sportService) CreateSport(ctx context.Context, sport *models.Sport) error {
+	if err := sport.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	existing, err := s.sportRepo.GetByCode(ctx, sport.Code)
+	if err != nil {
+		logger.Error("Failed to check existing sport", "code", sport.Code, "error", err)
+		return NewDatabaseError("failed to check existing sport")
+	}
+	if existing != nil {
+		return NewConflictError("sport with this code already exists")
+	}
+
+	if err := s.sportRepo.Create(ctx, sport); err != nil {
+		logger.Error("Failed to create sport", "code", sport.Code, "error", err)
+		return NewDatabaseError("failed to create sport")
+	}
+
+	if err := models.RegisterSport(sport); err != nil {
+		return NewInternalError(err.Error())
+	}
+
+	logger.Info("Sport registered", "code", sport.Code)
+	return nil
+}
+
+// UpdateSport updates a sport definition in the sports table and the registry.
+// The code cannot be changed since tournaments, URLs and subscriptions refer to it.
+func (s *sportService) UpdateSport(ctx context.Context, code models.SportType, sport *models.Sport) error {
+	existing, err := s.sportRepo.GetByCode(ctx, code)
+	if err != nil {
+		logger.Error("Failed to get sport", "code", code, "error", err)
+		return NewDatabaseError("failed to get sport")
+	}
+	if existing == nil {
+		return NewNotFoundError("sport not found")
+	}
+
+	sport.Code = code
+	sport.CreatedAt = existing.CreatedAt
+	if err := sport.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	if err := s.sportRepo.Update(ctx, sport); err != nil {
+		logger.Error("Failed to update sport", "code", code, "error", err)
+		return NewDatabaseError("failed to update sport")
+	}
+
+	if err := models.RegisterSport(sport); err != nil {
+		return NewInternalError(err.Error())
+	}
+
+	logger.Info("Sport updated", "code", code)
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// TeamService defines the interface for team registration and management
+type TeamService interface {
+	ListTeams(ctx context.Context, tournamentID uint) ([]*models.Team, error)
+	GetTeam(ctx context.Context, id uint) (*models.Team, error)
+	RegisterTeam(ctx context.Context, tournamentID uint, team *models.Team) error
+	UpdateTeam(ctx context.Context, id uint, team *models.Team) error
+	DeleteTeam(ctx context.Context, id uint) error
+	SetNotificationService(notificationService *NotificationService)
+}
+
+// teamService implements TeamService
+type teamService struct {
+	teamRepo            repository.TeamRepository
+	tournamentRepo      repository.TournamentRepository
+	eventRepo           repository.EventRepository
+	notificationService *NotificationService
+}
+
+// NewTeamService creates a new team service
+func NewTeamService(teamRepo repository.TeamRepository, tournamentRepo repository.TournamentRepository, eventRepo repository.EventRepository) TeamService {
+	return &teamService{
+		teamRepo:       teamRepo,
+		tournamentRepo: tournamentRepo,
+		eventRepo:      eventRepo,
+	}
+}
+
+// SetNotificationService sets the notification service for real-time updates
+func (s *teamService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// ListTeams returns the teams registered in a tournament
+func (s *teamService) ListTeams(ctx context.Context, tournamentID uint) ([]*models.Team, error) {
+	if _, err := s.getTournament(ctx, tournamentID); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get teams", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get teams")
+	}
+	return teams, nil
+}
+
+// GetTeam returns a team by ID
+func (s *teamService) GetTeam(ctx context.Context, id uint) (*models.Team, error) {
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get team", "teamID", id, "error", err)
+		return nil, NewDatabaseError("failed to get team")
+	}
+	if team == nil {
+		return nil, NewNotFoundError("team not found")
+	}
+	return team, nil
+}
+
+// RegisterTeam registers a team in a tournament. Teams can only be registered
+// before the draw, since the draw fixes the participants.
+func (s *teamService) RegisterTeam(ctx context.Context, tournamentID uint, team *models.Team) error {
+	tournament, err := s.getWritableTournament(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if !tournament.IsRegistration() {
+		return NewConflictError("teams can only be registered while the tournament is in registration")
+	}
+
+	team.TournamentID = tournamentID
+	team.Name = strings.TrimSpace(team.Name)
+	if err := team.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureNameAvailable(ctx, team); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		logger.Error("Failed to register team", "tournamentID", tournamentID, "error", err)
+		return NewDatabaseError("failed to register team")
+	}
+
+	logger.Info("Team registered", "teamID", team.ID, "tournamentID", tournamentID, "name", team.Name)
+	return nil
+}
+
+// UpdateTeam updates a team's name and description. Renaming a team also
+// renames it in the tournament's matches, result corrections and recorded
+// draw, so history, winners and draw replays are kept.
+func (s *teamService) UpdateTeam(ctx context.Context, id uint, team *models.Team) error {
+	existing, err := s.GetTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+	tournament, err := s.getWritableTournament(ctx, existing.TournamentID)
+	if err != nil {
+		return err
+	}
+
+	team.ID = existing.ID
+	team.TournamentID = existing.TournamentID
+	team.CreatedAt = existing.CreatedAt
+	team.Name = strings.TrimSpace(team.Name)
+	if err := team.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureNameAvailable(ctx, team); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		logger.Error("Failed to update team", "teamID", id, "error", err)
+		return NewDatabaseError("failed to update team")
+	}
+
+	if team.Name != existing.Name {
+		logger.Info("Team renamed", "teamID", id, "from", existing.Name, "to", team.Name)
+		if s.notificationService != nil {
+			s.notificationService.NotifyTournamentUpdate(tournament, "team_renamed")
+		}
+	}
+	return nil
+}
+
+// DeleteTeam removes a team that has not been drawn into any match
+func (s *teamService) DeleteTeam(ctx context.Context, id uint) error {
+	team, err := s.GetTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getWritableTournament(ctx, team.TournamentID); err != nil {
+		return err
+	}
+
+	count, err := s.teamRepo.CountMatches(ctx, id)
+	if err != nil {
+		logger.Error("Failed to count matches of team", "teamID", id, "error", err)
+		return NewDatabaseError("failed to delete team")
+	}
+	if count > 0 {
+		return NewConflictError("team has matches and cannot be deleted")
+	}
+
+	if err := s.teamRepo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete team", "teamID", id, "error", err)
+		return NewDatabaseError("failed to delete team")
+	}
+	return nil
+}
+
+// getTournament returns the tournament the teams belong to
+func (s *teamService) getTournament(ctx context.Context, tournamentID uint) (*models.Tournament, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get tournament", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get tournament")
+	}
+	if tournament == nil {
+		return nil, NewNotFoundError("tournament not found")
+	}
+	return tournament, nil
+}
+
+// getWritableTournament returns the tournament, rejecting changes to an archived event
+func (s *teamService) getWritableTournament(ctx context.Context, tournamentID uint) (*models.Tournament, error) {
+	tournament, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// ensureNameAvailable rejects a name already used by another team of the tournament
+func (s *teamService) ensureNameAvailable(ctx context.Context, team *models.Team) error {
+	other, err := s.teamRepo.GetByTournamentAndName(ctx, team.TournamentID, team.Name)
+	if err != nil {
+		logger.Error("Failed to check team name", "tournamentID", team.TournamentID, "error", err)
+		return NewDatabaseError("failed to check team name")
+	}
+	if other != nil && other.ID != team.ID {
+		return NewConflictError("team name is already registered in this tournament")
+	}
+	return nil
+}
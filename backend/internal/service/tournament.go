@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"backend/internal/models"
 	"backend/internal/repository"
 )
@@ -11,19 +13,28 @@ type TournamentService interface {
 	CreateTournament(ctx context.Context, tournament *models.Tournament) error
 	GetTournament(ctx context.Context, id uint) (*models.Tournament, error)
 	GetTournaments(ctx context.Context, limit, offset int) ([]*models.Tournament, error)
-	GetTournamentBySport(ctx context.Context, sport string, limit, offset int) ([]*models.Tournament, error)
+	GetTournamentBySport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error)
+	GetTournamentsByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error)
 	UpdateTournament(ctx context.Context, id uint, tournament *models.Tournament) error
+	SwitchTournamentFormat(ctx context.Context, id uint, format models.TournamentFormat, dryRun bool) (*models.FormatSwitch, error)
+	ChangeTournamentStatus(ctx context.Context, id uint, status models.TournamentStatus) (*models.Tournament, error)
 	DeleteTournament(ctx context.Context, id uint) error
 	GenerateBracket(ctx context.Context, tournamentID uint) error
+	DrawBracket(ctx context.Context, tournamentID uint, opts models.DrawOptions) (*models.DrawResult, error)
+	GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error)
+	GenerateNextSwissRound(ctx context.Context, tournamentID uint) ([]*models.Match, error)
+	GetStandings(ctx context.Context, tournamentID uint) (*models.Standings, error)
+	UpdateLeagueRules(ctx context.Context, tournamentID uint, rules models.LeagueRules) error
 	GetBracket(ctx context.Context, tournamentID uint) ([]*models.Match, error)
 	UpdateMatchResult(ctx context.Context, matchID uint, team1Score, team2Score int, winnerID uint) error
 	AdvanceWinner(ctx context.Context, matchID uint) error
-	GetTournamentProgress(sport string) (*TournamentProgress, error)
+	GetTournamentProgress(eventID uint, sport string) (*TournamentProgress, error)
 }
 
 // TournamentProgress represents tournament progress information
 type TournamentProgress struct {
 	TournamentID     int     `json:"tournament_id"`
+	EventID          int     `json:"event_id"`
 	Sport            string  `json:"sport"`
 	Format           string  `json:"format"`
 	Status           string  `json:"status"`
@@ -41,7 +52,9 @@ type tournamentService struct {
 	tournamentRepo      repository.TournamentRepository
 	teamRepo            repository.TeamRepository
 	matchRepo           repository.MatchRepository
+	eventRepo           repository.EventRepository
 	notificationService *NotificationService
+	championshipService ChampionshipService
 }
 
 // NewTournamentService creates a new tournament service
@@ -49,11 +62,13 @@ func NewTournamentService(
 	tournamentRepo repository.TournamentRepository,
 	teamRepo repository.TeamRepository,
 	matchRepo repository.MatchRepository,
+	eventRepo repository.EventRepository,
 ) TournamentService {
 	return &tournamentService{
 		tournamentRepo: tournamentRepo,
 		teamRepo:       teamRepo,
 		matchRepo:      matchRepo,
+		eventRepo:      eventRepo,
 	}
 }
 
@@ -66,14 +81,46 @@ func (s *tournamentService) CreateTournament(ctx context.Context, tournament *mo
 		return NewValidationError("tournament format is required")
 	}
 
-	// Check if tournament with same sport exists
-	existing, err := s.tournamentRepo.GetByName(ctx, tournament.Sport)
+	// New tournaments start the lifecycle in draft, or open registration at once
+	switch tournament.GetStatus() {
+	case "":
+		tournament.SetStatus(models.TournamentStatusDraftEnum)
+	case models.TournamentStatusDraftEnum, models.TournamentStatusRegistrationEnum:
+	default:
+		return NewValidationError("new tournaments start in draft or registration status")
+	}
+
+	// The sport registry decides which sports and formats can be used
+	sport, ok := models.LookupSport(tournament.GetSportType())
+	if !ok {
+		return NewValidationError("unsupported sport")
+	}
+	if !sport.IsActive {
+		return NewValidationError("sport is not accepting new tournaments")
+	}
+	if !sport.AllowsFormat(tournament.GetFormat()) {
+		return NewValidationError("tournament format is not available for this sport")
+	}
+
+	// Tournaments belong to an event (the current one unless specified);
+	// archived events are read-only
+	event, err := resolveEvent(ctx, s.eventRepo, uint(tournament.EventID))
+	if err != nil {
+		return err
+	}
+	if event.IsArchived() {
+		return NewConflictError("event is archived and read-only")
+	}
+	tournament.EventID = event.ID
+
+	// Each event holds one tournament per sport
+	existing, err := s.tournamentRepo.GetByEventAndSport(ctx, uint(event.ID), tournament.Sport)
 	if err != nil {
 		logger.Error("Failed to check existing tournament", "error", err)
 		return NewDatabaseError("failed to check existing tournament")
 	}
 	if existing != nil {
-		return NewConflictError("tournament with this sport already exists")
+		return NewConflictError("tournament with this sport already exists in this event")
 	}
 
 	if err := s.tournamentRepo.Create(ctx, tournament); err != nil {
@@ -112,31 +159,85 @@ func (s *tournamentService) GetTournaments(ctx context.Context, limit, offset in
 	return tournaments, nil
 }
 
-// GetTournamentBySport retrieves tournaments by sport
-func (s *tournamentService) GetTournamentBySport(ctx context.Context, sport string, limit, offset int) ([]*models.Tournament, error) {
-	tournaments, err := s.tournamentRepo.GetBySport(ctx, sport, limit, offset)
+// GetTournamentBySport retrieves the tournament of a sport in an event
+// (the current event when eventID is 0)
+func (s *tournamentService) GetTournamentBySport(ctx context.Context, eventID uint, sport string) (*models.Tournament, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tournament, err := s.tournamentRepo.GetByEventAndSport(ctx, uint(event.ID), sport)
+	if err != nil {
+		logger.Error("Failed to get tournament by sport", "eventID", event.ID, "sport", sport, "error", err)
+		return nil, NewDatabaseError("failed to get tournament by sport")
+	}
+	if tournament == nil {
+		return nil, NewNotFoundError("tournament not found")
+	}
+	return tournament, nil
+}
+
+// GetTournamentsByEvent retrieves the tournaments of an event
+// (the current event when eventID is 0)
+func (s *tournamentService) GetTournamentsByEvent(ctx context.Context, eventID uint) ([]*models.Tournament, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
 	if err != nil {
-		logger.Error("Failed to get tournaments by sport", "sport", sport, "error", err)
-		return nil, NewDatabaseError("failed to get tournaments by sport")
+		return nil, err
+	}
+
+	tournaments, err := s.tournamentRepo.GetByEvent(ctx, uint(event.ID))
+	if err != nil {
+		logger.Error("Failed to get tournaments by event", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get tournaments")
 	}
 	return tournaments, nil
 }
 
-// UpdateTournament updates an existing tournament
+// UpdateTournament updates an existing tournament. A status change goes
+// through the lifecycle state machine like ChangeTournamentStatus.
 func (s *tournamentService) UpdateTournament(ctx context.Context, id uint, tournament *models.Tournament) error {
 	existing, err := s.GetTournament(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, existing.ID); err != nil {
+		return err
+	}
 
 	if tournament.Sport != "" {
+		if !tournament.GetSportType().IsValid() {
+			return NewValidationError("unsupported sport")
+		}
 		existing.Sport = tournament.Sport
 	}
-	if tournament.Format != "" {
+	if tournament.Format != "" && tournament.Format != existing.Format {
+		if !tournament.GetFormat().IsValid() {
+			return NewValidationError("unsupported tournament format")
+		}
+		if sport, ok := models.LookupSport(existing.GetSportType()); ok && !sport.AllowsFormat(tournament.GetFormat()) {
+			return NewValidationError("tournament format is not available for this sport")
+		}
+		// Formats with a different match structure (knockout, double elimination,
+		// round-robin) can only be selected before the bracket is generated, and
+		// the matches of a generated bracket are migrated by SwitchTournamentFormat
+		if !existing.IsDraft() && !existing.IsRegistration() {
+			if tournament.GetFormat().BracketStructure() != existing.GetFormat().BracketStructure() {
+				return NewConflictError("cannot change the bracket structure after the bracket has been generated")
+			}
+			return NewConflictError("use the format switch to change the format after the bracket has been generated")
+		}
 		existing.Format = tournament.Format
 	}
-	if tournament.Status != "" {
-		existing.Status = tournament.Status
+	from := existing.GetStatus()
+	if next := tournament.GetStatus(); next != "" && next != from {
+		input, err := loadTransitionInput(ctx, s.teamRepo, s.matchRepo, existing.ID)
+		if err != nil {
+			return err
+		}
+		if err := existing.TransitionTo(next, input); err != nil {
+			return NewTransitionError(err)
+		}
 	}
 
 	if err := s.tournamentRepo.Update(ctx, existing); err != nil {
@@ -146,18 +247,152 @@ func (s *tournamentService) UpdateTournament(ctx context.Context, id uint, tourn
 
 	// Send real-time notification
 	if s.notificationService != nil {
-		s.notificationService.NotifyTournamentUpdate(existing, "updated")
+		if existing.GetStatus() != from {
+			s.notificationService.NotifyTournamentTransition(existing, from)
+		} else {
+			s.notificationService.NotifyTournamentUpdate(existing, "updated")
+		}
 	}
+	// Completing a Swiss tournament finalises its table
+	s.refreshPlacements(ctx, existing.ID)
 
 	return nil
 }
 
+// SwitchTournamentFormat changes the format of a tournament, e.g. to the rainy
+// format on a rainy day, and migrates the matches already created: matches
+// the new format keeps survive with their results, unfinished matches it
+// drops are cancelled and the matches it adds (the rainy format's consolation
+// bracket for first-round losers) are created with the teams already known.
+// Once matches exist only the standard and rainy formats can be switched
+// between; other formats are chosen before the bracket is generated. A dry
+// run only returns the plan as a preview; otherwise the plan is applied in a
+// single transaction together with the format.
+func (s *tournamentService) SwitchTournamentFormat(ctx context.Context, id uint, format models.TournamentFormat, dryRun bool) (*models.FormatSwitch, error) {
+	tournament, err := s.GetTournament(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, tournament.ID); err != nil {
+			return nil, err
+		}
+	}
+	if !format.IsValid() {
+		return nil, NewValidationError("unsupported tournament format")
+	}
+	if sport, ok := models.LookupSport(tournament.GetSportType()); ok && !sport.AllowsFormat(format) {
+		return nil, NewValidationError("tournament format is not available for this sport")
+	}
+	if tournament.GetFormat() == format {
+		return nil, NewConflictError("tournament already uses this format")
+	}
+	if tournament.IsCompleted() || tournament.IsCancelled() || tournament.IsArchived() {
+		return nil, NewConflictError("cannot switch the format of a finished tournament")
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, uint(tournament.ID))
+	if err != nil {
+		logger.Error("Failed to get matches for tournament", "tournamentID", tournament.ID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+	if len(matches) > 0 && !tournament.GetFormat().CanMigrateMatchesTo(format) {
+		return nil, NewValidationError("only the standard and rainy formats can be switched between after the bracket has been generated")
+	}
+
+	plan, err := models.PlanFormatSwitch(tournament, format, matches)
+	if err != nil {
+		return nil, NewConflictError(err.Error())
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	// Placeholder schedule until the new matches are scheduled explicitly
+	now := time.Now()
+	for _, match := range plan.NewMatches() {
+		match.ScheduledAt = now
+	}
+
+	if err := s.matchRepo.SwitchFormat(ctx, tournament.ID, format, plan.Layout(), plan.Retired()); err != nil {
+		logger.Error("Failed to switch tournament format", "tournamentID", tournament.ID, "format", format, "error", err)
+		return nil, NewDatabaseError("failed to switch tournament format")
+	}
+	plan.MarkApplied()
+	tournament.Format = format.String()
+
+	logger.Info("Tournament format switched", "tournamentID", tournament.ID, "from", plan.From, "to", plan.To,
+		"kept", len(plan.Kept), "cancelled", len(plan.Cancelled), "created", len(plan.Created))
+	if s.notificationService != nil {
+		s.notificationService.NotifyTournamentUpdate(tournament, "format_switched")
+		if len(plan.Cancelled) > 0 || len(plan.Created) > 0 {
+			if matches, err := s.matchRepo.GetByTournamentID(ctx, uint(tournament.ID)); err == nil {
+				bracket := &models.Bracket{
+					TournamentID: tournament.ID,
+					Sport:        tournament.Sport,
+					Format:       tournament.Format,
+					Rounds:       models.GroupMatchesIntoRounds(matches),
+				}
+				s.notificationService.NotifyBracketUpdate(tournament.GetSportType(), bracket, "format_switched")
+			}
+		}
+	}
+	// Cancelling the rest of the consolation bracket may leave nothing to play
+	transitions, err := syncTournamentStatus(ctx, s.matchRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshPlacements(ctx, tournament.ID)
+
+	return plan, nil
+}
+
+// ChangeTournamentStatus moves a tournament along its lifecycle (draft,
+// registration, seeded, active, completed, cancelled, archived). Changes the
+// state machine does not allow, or whose guards fail, are refused with an
+// invalid transition error.
+func (s *tournamentService) ChangeTournamentStatus(ctx context.Context, id uint, status models.TournamentStatus) (*models.Tournament, error) {
+	tournament, err := s.GetTournament(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return nil, err
+	}
+
+	input, err := loadTransitionInput(ctx, s.teamRepo, s.matchRepo, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	from := tournament.GetStatus()
+	if err := tournament.TransitionTo(status, input); err != nil {
+		return nil, NewTransitionError(err)
+	}
+
+	if err := s.tournamentRepo.Update(ctx, tournament); err != nil {
+		logger.Error("Failed to change tournament status", "id", id, "status", status, "error", err)
+		return nil, NewDatabaseError("failed to change tournament status")
+	}
+
+	logger.Info("Tournament status changed", "tournamentID", tournament.ID, "from", from, "to", status)
+	if s.notificationService != nil {
+		s.notificationService.NotifyTournamentTransition(tournament, from)
+	}
+	s.refreshPlacements(ctx, tournament.ID)
+
+	return tournament, nil
+}
+
 // DeleteTournament deletes a tournament
 func (s *tournamentService) DeleteTournament(ctx context.Context, id uint) error {
 	tournament, err := s.GetTournament(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return err
+	}
 
 	if err := s.tournamentRepo.Delete(ctx, uint(tournament.ID)); err != nil {
 		logger.Error("Failed to delete tournament", "id", id, "error", err)
@@ -166,70 +401,322 @@ func (s *tournamentService) DeleteTournament(ctx context.Context, id uint) error
 	return nil
 }
 
-// GenerateBracket generates tournament bracket
+// GenerateBracket generates tournament bracket with a fresh random draw that
+// keeps same-grade and same-department classes apart in the first round where
+// the teams allow it
 func (s *tournamentService) GenerateBracket(ctx context.Context, tournamentID uint) error {
+	_, err := s.DrawBracket(ctx, tournamentID, models.DrawOptions{
+		Separation: []models.SeparationRule{models.SeparationGrade, models.SeparationDepartment},
+		RandomSeed: time.Now().UnixNano(),
+	})
+	return err
+}
+
+// DrawBracket draws the first round from seed rankings and separation rules,
+// generates the bracket and records the draw with the tournament. Drawing
+// again with the recorded options replays the same pairings.
+func (s *tournamentService) DrawBracket(ctx context.Context, tournamentID uint, opts models.DrawOptions) (*models.DrawResult, error) {
 	tournament, err := s.GetTournament(ctx, tournamentID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return nil, err
 	}
 
-	if tournament.Status != "registration" {
-		return NewValidationError("can only generate bracket for tournaments in registration status")
+	// Drawing fixes the participants, so it closes registration
+	if err := tournament.CheckTransition(models.TournamentStatusSeededEnum); err != nil {
+		return nil, NewTransitionError(err)
 	}
 
 	// Get registered teams
 	teams, err := s.teamRepo.GetByTournamentID(ctx, tournamentID)
 	if err != nil {
 		logger.Error("Failed to get teams for tournament", "tournamentID", tournamentID, "error", err)
-		return NewDatabaseError("failed to get teams")
+		return nil, NewDatabaseError("failed to get teams")
 	}
 
 	if len(teams) < 2 {
-		return NewValidationError("need at least 2 teams to generate bracket")
+		return nil, NewValidationError("need at least 2 teams to generate bracket")
 	}
 
-	// Check if teams count is power of 2
-	teamCount := len(teams)
-	if !isPowerOfTwo(teamCount) {
-		return NewValidationError("number of teams must be a power of 2")
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
 	}
 
-	// Generate matches for first round
-	matches := make([]*models.Match, 0)
-	for i := 0; i < teamCount; i += 2 {
-		match := &models.Match{
-			TournamentID: int(tournamentID),
-			Team1:        teams[i].Name,
-			Team2:        teams[i+1].Name,
-			Round:        "1st_round",
-			Status:       "pending",
-		}
-		matches = append(matches, match)
+	// Every team meets every other team in a league, group-stage teams are
+	// spread across groups by seed and Swiss rounds are paired by score, so
+	// separation does not apply
+	format := tournament.GetFormat()
+	switch format {
+	case models.TournamentFormatRoundRobin, models.TournamentFormatGroupKnockout, models.TournamentFormatSwiss:
+		opts.Separation = nil
 	}
 
-	// Create matches in database
+	// When the team count is not a power of two, the top seeds receive byes
+	// and advance without a match row
+	draw, err := models.Draw(names, opts)
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	if len(draw.Warnings) > 0 {
+		logger.Warn("Draw relaxed separation rules", "tournamentID", tournamentID, "warnings", draw.Warnings)
+	}
+
+	var matches []*models.Match
+	switch format {
+	case models.TournamentFormatRoundRobin:
+		matches, err = models.NewRoundRobinMatches(int(tournamentID), draw.Order)
+	case models.TournamentFormatGroupKnockout:
+		// Knockout slots are named after group places (e.g. A組1位) until the group stage ends
+		matches, err = models.NewGroupStageMatches(int(tournamentID), draw.Order, opts.GroupCount, opts.QualifiersPerGroup)
+	case models.TournamentFormatSwiss:
+		// Only the first round is created; later rounds are paired from the standings
+		var pairs [][2]string
+		pairs, _, err = models.PairSwissRound(draw.Order, nil, models.DefaultSwissRules())
+		matches = models.NewSwissRoundMatches(int(tournamentID), 1, 0, pairs)
+	case models.TournamentFormatDoubleElimination:
+		matches, err = models.NewDoubleEliminationMatches(int(tournamentID), draw.Pairs)
+	case models.TournamentFormatRainy:
+		matches, err = models.NewRainyMatches(int(tournamentID), draw.Pairs)
+	default:
+		matches, err = models.NewKnockoutMatches(int(tournamentID), draw.Pairs)
+	}
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
+	// The draw pairs teams by name; matches and the recorded draw reference them by ID
+	models.AssignTeamIDs(matches, teams)
+	draw.RecordTeamIDs(teams)
+
+	// Placeholder schedule until the matches are scheduled explicitly
+	now := time.Now()
 	for _, match := range matches {
-		if err := s.matchRepo.Create(ctx, match); err != nil {
-			logger.Error("Failed to create match", "error", err)
-			return NewDatabaseError("failed to create match")
-		}
+		match.ScheduledAt = now
 	}
 
-	// Update tournament status
-	tournament.Status = "active"
-	if err := s.tournamentRepo.Update(ctx, tournament); err != nil {
-		logger.Error("Failed to update tournament status", "error", err)
-		return NewDatabaseError("failed to update tournament status")
+	// Every team in the bracket must be registered
+	from := tournament.GetStatus()
+	if err := tournament.TransitionTo(models.TournamentStatusSeededEnum, models.TournamentTransitionInput{Teams: names, Matches: matches}); err != nil {
+		return nil, NewTransitionError(err)
 	}
 
+	// Create the matches with their winner/loser destinations, record the draw
+	// so it can be audited and replayed, and update the tournament status in
+	// one transaction. A tournament that already has matches was drawn by an
+	// earlier or concurrent request.
+	if err := s.matchRepo.DrawBracket(ctx, tournament, draw, matches); err != nil {
+		if repository.GetRepositoryErrorType(err) == repository.ErrTypeDuplicate {
+			return nil, NewConflictError("tournament already has matches; the bracket can only be drawn once")
+		}
+		logger.Error("Failed to draw bracket", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to create bracket")
+	}
+	logger.Info("Bracket drawn", "tournamentID", tournamentID, "randomSeed", opts.RandomSeed, "seeds", len(opts.Seeds))
+
 	// Send real-time notification for tournament status change
 	if s.notificationService != nil {
-		s.notificationService.NotifyTournamentUpdate(tournament, "status_changed")
+		s.notificationService.NotifyTournamentTransition(tournament, from)
+	}
+
+	return draw, nil
+}
+
+// GetStandings computes the league table of a round-robin or Swiss tournament,
+// or the table of each group of a group-stage tournament, using the
+// tournament's configured points and tiebreakers (or the defaults)
+func (s *tournamentService) GetStandings(ctx context.Context, tournamentID uint) (*models.Standings, error) {
+	tournament, err := s.GetTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tournament.GetFormat() == models.TournamentFormatSwiss {
+		return s.getSwissStandings(ctx, tournamentID)
+	}
+
+	rules, err := loadLeagueRules(ctx, s.tournamentRepo, tournamentID, models.DefaultLeagueRules())
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get matches for standings", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+
+	return &models.Standings{
+		TournamentID: int(tournamentID),
+		Rules:        rules,
+		Table:        models.ComputeStandings(matches, rules),
+		Groups:       models.ComputeGroupStandings(matches, rules),
+	}, nil
+}
+
+// getSwissStandings computes the Swiss table, counting a round without a match
+// as a bye for every registered team
+func (s *tournamentService) getSwissStandings(ctx context.Context, tournamentID uint) (*models.Standings, error) {
+	rules, err := loadLeagueRules(ctx, s.tournamentRepo, tournamentID, models.DefaultSwissRules())
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get teams for standings", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get teams")
+	}
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get matches for standings", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+
+	return &models.Standings{
+		TournamentID: int(tournamentID),
+		Rules:        rules,
+		Table:        models.ComputeSwissStandings(names, matches, rules),
+	}, nil
+}
+
+// GenerateNextSwissRound pairs the next round of a Swiss tournament from the
+// current standings, avoiding rematches. The current round must be complete
+// and the number of rounds recorded with the draw must not be exhausted.
+func (s *tournamentService) GenerateNextSwissRound(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
+	tournament, err := s.GetTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return nil, err
+	}
+
+	if tournament.GetFormat() != models.TournamentFormatSwiss {
+		return nil, NewValidationError("tournament is not a Swiss-system tournament")
+	}
+	if !tournament.IsActive() {
+		return nil, NewValidationError("can only generate rounds for active tournaments")
+	}
+
+	// The draw holds the seed order and the configured number of rounds
+	draw, err := s.GetDraw(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The draw keeps the names at draw time; pair the teams under their current names
+	teams, err := s.teamRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get teams for tournament", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get teams")
+	}
+	order := draw.CurrentOrder(teams)
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get matches for tournament", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+
+	current, complete := models.CurrentSwissRound(matches)
+	if !complete {
+		return nil, NewConflictError("current round is not complete")
+	}
+	totalRounds := draw.Options.SwissRounds
+	if totalRounds == 0 {
+		totalRounds = models.DefaultSwissRounds(len(order))
+	}
+	if current >= totalRounds {
+		return nil, NewConflictError("all Swiss rounds have been played")
+	}
+
+	rules, err := loadLeagueRules(ctx, s.tournamentRepo, tournamentID, models.DefaultSwissRules())
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, bye, err := models.PairSwissRound(order, matches, rules)
+	if err != nil {
+		return nil, NewConflictError(err.Error())
+	}
+
+	round := models.NewSwissRoundMatches(int(tournamentID), current+1, len(matches), pairs)
+	models.AssignTeamIDs(round, teams)
+	now := time.Now()
+	for _, match := range round {
+		match.ScheduledAt = now
+	}
+
+	// A round paired by a concurrent request in the meantime is refused
+	if err := s.matchRepo.CreateSwissRound(ctx, tournamentID, current+1, round); err != nil {
+		if repository.GetRepositoryErrorType(err) == repository.ErrTypeDuplicate {
+			return nil, NewConflictError("this round has already been generated")
+		}
+		logger.Error("Failed to create Swiss round", "tournamentID", tournamentID, "round", current+1, "error", err)
+		return nil, NewDatabaseError("failed to create round")
+	}
+	logger.Info("Swiss round generated", "tournamentID", tournamentID, "round", current+1, "matches", len(round), "bye", bye)
+
+	if s.notificationService != nil {
+		bracket := &models.Bracket{
+			TournamentID: int(tournamentID),
+			Sport:        tournament.Sport,
+			Rounds:       models.GroupMatchesIntoRounds(append(matches, round...)),
+		}
+		s.notificationService.NotifyBracketUpdate(tournament.GetSportType(), bracket, "swiss_round_generated")
+	}
+
+	return round, nil
+}
+
+// UpdateLeagueRules sets the points and tiebreakers used for the standings
+func (s *tournamentService) UpdateLeagueRules(ctx context.Context, tournamentID uint, rules models.LeagueRules) error {
+	tournament, err := s.GetTournament(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, tournament.EventID); err != nil {
+		return err
+	}
+
+	if err := rules.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	if err := s.tournamentRepo.SaveLeagueRules(ctx, tournamentID, &rules); err != nil {
+		logger.Error("Failed to save league rules", "tournamentID", tournamentID, "error", err)
+		return NewDatabaseError("failed to save league rules")
 	}
 
 	return nil
 }
 
+// GetDraw retrieves the recorded draw of a tournament
+func (s *tournamentService) GetDraw(ctx context.Context, tournamentID uint) (*models.DrawResult, error) {
+	if _, err := s.GetTournament(ctx, tournamentID); err != nil {
+		return nil, err
+	}
+
+	draw, err := s.tournamentRepo.GetDraw(ctx, tournamentID)
+	if err != nil {
+		logger.Error("Failed to get draw", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to get draw")
+	}
+	if draw == nil {
+		return nil, NewNotFoundError("draw not found")
+	}
+
+	return draw, nil
+}
+
 // GetBracket retrieves tournament bracket
 func (s *tournamentService) GetBracket(ctx context.Context, tournamentID uint) ([]*models.Match, error) {
 	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
@@ -250,12 +737,20 @@ func (s *tournamentService) UpdateMatchResult(ctx context.Context, matchID uint,
 	if match == nil {
 		return NewNotFoundError("match not found")
 	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, match.TournamentID); err != nil {
+		return err
+	}
 
-	if match.Status != "pending" && match.Status != "in_progress" {
-		return NewValidationError("can only update result for pending or in-progress matches")
+	if err := match.CheckTransition(models.MatchStatusCompletedEnum); err != nil {
+		return NewTransitionError(err)
+	}
+
+	if match.HasUndecidedTeams() {
+		return NewValidationError("match teams are not decided yet")
 	}
 
 	// Update match result
+	from := match.GetStatus()
 	match.Score1 = &team1Score
 	match.Score2 = &team2Score
 	
@@ -266,49 +761,65 @@ func (s *tournamentService) UpdateMatchResult(ctx context.Context, matchID uint,
 		match.Winner = &match.Team2
 	}
 	
-	match.Status = "completed"
+	if err := match.TransitionTo(models.MatchStatusCompletedEnum); err != nil {
+		return NewTransitionError(err)
+	}
 
-	if err := s.matchRepo.Update(ctx, match); err != nil {
-		logger.Error("Failed to update match", "matchID", matchID, "error", err)
-		return NewDatabaseError("failed to update match")
+	// Save the result and advance the winner in one transaction
+	matches, advanced, transitions, err := saveWithAdvancement(ctx, s.matchRepo, s.tournamentRepo, match, from)
+	if err != nil {
+		return err
+	}
+
+	if advanced && s.notificationService != nil {
+		s.notificationService.NotifyMatchAdvancement(match, matches)
 	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshPlacements(ctx, match.TournamentID)
 
-	// Advance winner to next round
-	return s.AdvanceWinner(ctx, matchID)
+	return nil
 }
 
-// AdvanceWinner advances the winner to the next round
+// AdvanceWinner advances the winner to the next round and, for semifinals,
+// the loser to the third place match
 func (s *tournamentService) AdvanceWinner(ctx context.Context, matchID uint) error {
 	match, err := s.matchRepo.GetByID(ctx, matchID)
 	if err != nil {
 		return NewDatabaseError("failed to get match")
 	}
+	if match == nil {
+		return NewNotFoundError("match not found")
+	}
 	if match.Winner == nil {
 		return NewValidationError("match has no winner")
 	}
+	if err := ensureTournamentWritable(ctx, s.tournamentRepo, s.eventRepo, match.TournamentID); err != nil {
+		return err
+	}
+
+	matches, advanced, transitions, err := saveWithAdvancement(ctx, s.matchRepo, s.tournamentRepo, match, match.GetStatus())
+	if err != nil {
+		return err
+	}
+
+	if advanced && s.notificationService != nil {
+		s.notificationService.NotifyMatchAdvancement(match, matches)
+	}
+	notifyTournamentTransitions(s.notificationService, transitions)
+	s.refreshPlacements(ctx, match.TournamentID)
 
-	// This is a simplified implementation
-	// In a real system, you would need more complex logic to determine the next match
-	logger.Info("Winner advanced", "winner", *match.Winner, "matchID", matchID)
-	
 	return nil
 }
 
-// GetTournamentProgress retrieves tournament progress information
-func (s *tournamentService) GetTournamentProgress(sport string) (*TournamentProgress, error) {
-	// Get tournament by sport
-	tournaments, err := s.tournamentRepo.GetBySport(context.Background(), sport, 1, 0)
+// GetTournamentProgress retrieves tournament progress information for a sport
+// in an event (the current event when eventID is 0)
+func (s *tournamentService) GetTournamentProgress(eventID uint, sport string) (*TournamentProgress, error) {
+	// Get tournament by event and sport
+	tournament, err := s.GetTournamentBySport(context.Background(), eventID, sport)
 	if err != nil {
-		logger.Error("Failed to get tournament by sport", "sport", sport, "error", err)
-		return nil, NewDatabaseError("failed to get tournament")
+		return nil, err
 	}
 	
-	if len(tournaments) == 0 {
-		return nil, NewNotFoundError("tournament not found")
-	}
-	
-	tournament := tournaments[0]
-	
 	// Get matches for this tournament
 	matches, err := s.matchRepo.GetByTournamentID(context.Background(), uint(tournament.ID))
 	if err != nil {
@@ -322,10 +833,10 @@ func (s *tournamentService) GetTournamentProgress(sport string) (*TournamentProg
 	currentRound := ""
 	
 	for _, match := range matches {
-		if match.Status == "completed" {
+		if match.IsCompleted() {
 			completedMatches++
 		}
-		if match.Status == "pending" && currentRound == "" {
+		if match.GetStatus().IsPlayable() && currentRound == "" {
 			currentRound = match.Round
 		}
 	}
@@ -338,6 +849,7 @@ func (s *tournamentService) GetTournamentProgress(sport string) (*TournamentProg
 	
 	progress := &TournamentProgress{
 		TournamentID:     tournament.ID,
+		EventID:          tournament.EventID,
 		Sport:            tournament.Sport,
 		Format:           tournament.Format,
 		Status:           tournament.Status,
@@ -353,12 +865,21 @@ func (s *tournamentService) GetTournamentProgress(sport string) (*TournamentProg
 	return progress, nil
 }
 
-// Helper function to check if number is power of 2
-func isPowerOfTwo(n int) bool {
-	return n > 0 && (n&(n-1)) == 0
-}
-
 // SetNotificationService sets the notification service for real-time updates
 func (s *tournamentService) SetNotificationService(notificationService *NotificationService) {
 	s.notificationService = notificationService
+}
+
+// SetChampionshipService sets the championship service that recomputes final
+// placements after a result is saved
+func (s *tournamentService) SetChampionshipService(championshipService ChampionshipService) {
+	s.championshipService = championshipService
+}
+
+// refreshPlacements updates the championship table when a change may have
+// decided or changed a final placement of the tournament
+func (s *tournamentService) refreshPlacements(ctx context.Context, tournamentID int) {
+	if s.championshipService != nil {
+		s.championshipService.RefreshPlacements(ctx, tournamentID)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// loadTransitionInput returns the registered teams and the matches of a
+// tournament, which the lifecycle guards are checked against
+func loadTransitionInput(ctx context.Context, teamRepo repository.TeamRepository, matchRepo repository.MatchRepository, tournamentID int) (models.TournamentTransitionInput, error) {
+	var input models.TournamentTransitionInput
+
+	if teamRepo != nil {
+		teams, err := teamRepo.GetByTournamentID(ctx, uint(tournamentID))
+		if err != nil {
+			logger.Error("Failed to get teams for tournament", "tournamentID", tournamentID, "error", err)
+			return input, NewDatabaseError("failed to get teams")
+		}
+		for _, team := range teams {
+			input.Teams = append(input.Teams, team.Name)
+		}
+	}
+
+	matches, err := matchRepo.GetByTournamentID(ctx, uint(tournamentID))
+	if err != nil {
+		logger.Error("Failed to get matches for tournament", "tournamentID", tournamentID, "error", err)
+		return input, NewDatabaseError("failed to get matches")
+	}
+	input.Matches = matches
+
+	return input, nil
+}
+
+// tournamentTransition is a lifecycle transition applied by advanceTournamentStatus
+type tournamentTransition struct {
+	tournament models.Tournament // the tournament right after the transition
+	from       models.TournamentStatus
+}
+
+// advanceTournamentStatus applies the automatic lifecycle transitions to a
+// tournament locked together with its matches, after one of them was started,
+// finished or corrected: a seeded tournament becomes active, an active one is
+// completed once its final (and third-place match) is done, and a completed one
+// is reopened when a correction puts a deciding match back to be played.
+// Transitions are applied until none is left, so a walkover that starts and
+// decides a seeded tournament completes it. The caller saves the tournament in
+// the same transaction as the matches and announces the returned transitions
+// with notifyTournamentTransitions once it is committed.
+func advanceTournamentStatus(tournament *models.Tournament, matches []*models.Match) ([]tournamentTransition, error) {
+	var transitions []tournamentTransition
+	for {
+		to, ok := tournament.AutoTransition(matches)
+		if !ok {
+			return transitions, nil
+		}
+		from := tournament.GetStatus()
+		if err := tournament.TransitionTo(to, models.TournamentTransitionInput{Matches: matches}); err != nil {
+			return nil, NewTransitionError(err)
+		}
+		transitions = append(transitions, tournamentTransition{tournament: *tournament, from: from})
+	}
+}
+
+// syncTournamentStatus applies the automatic lifecycle transitions to a
+// tournament whose matches were changed without a result, such as by a format
+// switch, locking the tournament and its matches like a result does
+func syncTournamentStatus(ctx context.Context, matchRepo repository.MatchRepository, tournamentID int) ([]tournamentTransition, error) {
+	var transitions []tournamentTransition
+	err := matchRepo.AdvanceBracket(ctx, uint(tournamentID), func(tournament *models.Tournament, matches []*models.Match) ([]*models.Match, error) {
+		var err error
+		transitions, err = advanceTournamentStatus(tournament, matches)
+		return nil, err
+	})
+	if err != nil {
+		var serviceErr *ServiceError
+		if errors.As(err, &serviceErr) {
+			return nil, serviceErr
+		}
+		logger.Error("Failed to sync tournament status", "tournamentID", tournamentID, "error", err)
+		return nil, NewDatabaseError("failed to update tournament status")
+	}
+	return transitions, nil
+}
+
+// notifyTournamentTransitions logs and announces the lifecycle transitions
+// applied by advanceTournamentStatus
+func notifyTournamentTransitions(notificationService *NotificationService, transitions []tournamentTransition) {
+	for i := range transitions {
+		transition := &transitions[i]
+		logger.Info("Tournament status changed", "tournamentID", transition.tournament.ID,
+			"from", transition.from, "to", transition.tournament.GetStatus())
+		if notificationService != nil {
+			notificationService.NotifyTournamentTransition(&transition.tournament, transition.from)
+		}
+	}
+}
@@ -1,498 +1,55 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
-	"time"
+
+	"github.com/stretchr/testify/mock"
 
 	"backend/internal/models"
+	"backend/internal/repository"
 )
 
-// MockTournamentRepository はテスト用のTournamentRepositoryモック
-type MockTournamentRepository struct {
-	tournaments map[int]*models.Tournament
-	sportIndex  map[string]*models.Tournament
-	err         error
-	nextID      int
-}
-
-func NewMockTournamentRepository() *MockTournamentRepository {
-	return &MockTournamentRepository{
-		tournaments: make(map[int]*models.Tournament),
-		sportIndex:  make(map[string]*models.Tournament),
-		nextID:      1,
-	}
-}
-
-func (m *MockTournamentRepository) Create(tournament *models.Tournament) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	tournament.ID = m.nextID
-	m.nextID++
-	tournament.CreatedAt = time.Now()
-	tournament.UpdatedAt = time.Now()
-	
-	m.tournaments[tournament.ID] = tournament
-	m.sportIndex[tournament.Sport] = tournament
-	return nil
-}
-
-func (m *MockTournamentRepository) GetByID(id int) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return nil, errors.New("トーナメントが見つかりません")
-	}
-	
-	return tournament, nil
-}
-
-func (m *MockTournamentRepository) GetBySport(sport string) (*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, exists := m.sportIndex[sport]
-	if !exists {
-		return nil, errors.New("指定されたスポーツのトーナメントが見つかりません")
-	}
-	
-	return tournament, nil
-}
-
-func (m *MockTournamentRepository) GetAll() ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var tournaments []*models.Tournament
-	for _, tournament := range m.tournaments {
-		tournaments = append(tournaments, tournament)
-	}
-	
-	return tournaments, nil
-}
-
-func (m *MockTournamentRepository) Update(tournament *models.Tournament) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	_, exists := m.tournaments[tournament.ID]
-	if !exists {
-		return errors.New("更新対象のトーナメントが見つかりません")
-	}
-	
-	tournament.UpdatedAt = time.Now()
-	m.tournaments[tournament.ID] = tournament
-	m.sportIndex[tournament.Sport] = tournament
-	return nil
-}
-
-func (m *MockTournamentRepository) Delete(id int) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("削除対象のトーナメントが見つかりません")
-	}
-	
-	delete(m.tournaments, id)
-	delete(m.sportIndex, tournament.Sport)
-	return nil
+// newTestTournamentService はモックのリポジトリを使うTournamentServiceを作成する
+func newTestTournamentService() (TournamentService, *MockTournamentRepository, *MockMatchRepository, *MockEventRepository) {
+	tournamentRepo := new(MockTournamentRepository)
+	matchRepo := new(MockMatchRepository)
+	eventRepo := new(MockEventRepository)
+	return NewTournamentService(tournamentRepo, new(MockTeamRepository), matchRepo, eventRepo), tournamentRepo, matchRepo, eventRepo
 }
 
-func (m *MockTournamentRepository) GetTournamentBracket(sport string) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, err := m.GetBySport(sport)
-	if err != nil {
-		return nil, err
-	}
-	
-	// モック用の簡単なブラケットを作成
-	bracket := &models.Bracket{
-		TournamentID: tournament.ID,
-		Sport:        tournament.Sport,
-		Format:       tournament.Format,
-		Rounds:       []models.Round{},
-	}
-	
-	// 1回戦のラウンドを追加（テスト用）
-	round := models.Round{
-		Name:    models.Round1stRound,
-		Matches: []models.Match{},
-	}
-	
-	// テスト用の試合を追加
-	for i := 0; i < 4; i++ {
-		match := models.Match{
-			ID:           i + 1,
-			TournamentID: tournament.ID,
-			Round:        models.Round1stRound,
-			Team1:        "チーム1",
-			Team2:        "チーム2",
-			Status:       models.MatchStatusPending,
-			ScheduledAt:  time.Now(),
-		}
-		round.Matches = append(round.Matches, match)
-	}
-	
-	bracket.Rounds = append(bracket.Rounds, round)
-	return bracket, nil
-}
+// assertServiceError はエラーが指定した種類のServiceErrorであることを確認する
+// newTestKnockoutMatches は4チームの標準形式のブラケットを作成し、IDと進出先を設定する
+func newTestKnockoutMatches(t *testing.T) []*models.Match {
+	t.Helper()
 
-func (m *MockTournamentRepository) GetTournamentBracketByID(tournamentID int) (*models.Bracket, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	tournament, err := m.GetByID(tournamentID)
+	matches, err := models.NewKnockoutMatches(1, [][2]string{{"A", "D"}, {"B", "C"}})
 	if err != nil {
-		return nil, err
-	}
-	
-	return &models.Bracket{
-		TournamentID: tournament.ID,
-		Sport:        tournament.Sport,
-		Format:       tournament.Format,
-		Rounds:       []models.Round{},
-	}, nil
-}
-
-func (m *MockTournamentRepository) UpdateStatus(id int, status string) error {
-	if m.err != nil {
-		return m.err
+		t.Fatalf("failed to create bracket: %v", err)
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("更新対象のトーナメントが見つかりません")
+	for i, match := range matches {
+		match.ID = i + 1
 	}
-	
-	tournament.Status = status
-	tournament.UpdatedAt = time.Now()
-	return nil
+	models.LinkBracket(matches)
+	return matches
 }
 
-func (m *MockTournamentRepository) UpdateFormat(id int, format string) error {
-	if m.err != nil {
-		return m.err
+func assertServiceError(t *testing.T, err error, errorType string) {
+	t.Helper()
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("ServiceErrorが期待されましたが、実際: %v", err)
 	}
-	
-	tournament, exists := m.tournaments[id]
-	if !exists {
-		return errors.New("更新対象のトーナメントが見つかりません")
+	if serviceErr.Type != errorType {
+		t.Errorf("期待されたエラー種別: %s, 実際: %s (%s)", errorType, serviceErr.Type, serviceErr.Message)
 	}
-	
-	tournament.Format = format
-	tournament.UpdatedAt = time.Now()
-	return nil
-}
-
-func (m *MockTournamentRepository) GetByStatus(status string) ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var tournaments []*models.Tournament
-	for _, tournament := range m.tournaments {
-		if tournament.Status == status {
-			tournaments = append(tournaments, tournament)
-		}
-	}
-	
-	return tournaments, nil
-}
-
-func (m *MockTournamentRepository) GetActiveByFormat(format string) ([]*models.Tournament, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var tournaments []*models.Tournament
-	for _, tournament := range m.tournaments {
-		if tournament.Format == format && tournament.Status == models.TournamentStatusActive {
-			tournaments = append(tournaments, tournament)
-		}
-	}
-	
-	return tournaments, nil
-}
-
-func (m *MockTournamentRepository) SetError(err error) {
-	m.err = err
-}
-
-func (m *MockTournamentRepository) AddTournament(sport, format, status string) *models.Tournament {
-	tournament := &models.Tournament{
-		ID:        m.nextID,
-		Sport:     sport,
-		Format:    format,
-		Status:    status,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	
-	m.nextID++
-	m.tournaments[tournament.ID] = tournament
-	m.sportIndex[tournament.Sport] = tournament
-	
-	return tournament
-}
-
-// MockMatchRepository はテスト用のMatchRepositoryモック
-type MockMatchRepository struct {
-	matches    map[int]*models.Match
-	err        error
-	nextID     int
-	matchCount map[int]int // tournamentID -> match count
-}
-
-func NewMockMatchRepository() *MockMatchRepository {
-	return &MockMatchRepository{
-		matches:    make(map[int]*models.Match),
-		matchCount: make(map[int]int),
-		nextID:     1,
-	}
-}
-
-func (m *MockMatchRepository) Create(match *models.Match) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	match.ID = m.nextID
-	m.nextID++
-	
-	m.matches[match.ID] = match
-	m.matchCount[match.TournamentID]++
-	return nil
-}
-
-func (m *MockMatchRepository) GetByID(id int) (*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	match, exists := m.matches[id]
-	if !exists {
-		return nil, errors.New("試合が見つかりません")
-	}
-	
-	return match, nil
-}
-
-func (m *MockMatchRepository) Update(match *models.Match) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	_, exists := m.matches[match.ID]
-	if !exists {
-		return errors.New("更新対象の試合が見つかりません")
-	}
-	
-	m.matches[match.ID] = match
-	return nil
-}
-
-func (m *MockMatchRepository) Delete(id int) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	match, exists := m.matches[id]
-	if !exists {
-		return errors.New("削除対象の試合が見つかりません")
-	}
-	
-	delete(m.matches, id)
-	m.matchCount[match.TournamentID]--
-	return nil
-}
-
-func (m *MockMatchRepository) UpdateResult(matchID int, result models.MatchResult) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	match, exists := m.matches[matchID]
-	if !exists {
-		return errors.New("試合が見つかりません")
-	}
-	
-	match.Score1 = &result.Score1
-	match.Score2 = &result.Score2
-	match.Winner = &result.Winner
-	match.Status = models.MatchStatusCompleted
-	completedAt := time.Now()
-	match.CompletedAt = &completedAt
-	
-	return nil
-}
-
-func (m *MockMatchRepository) UpdateStatus(matchID int, status string) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	match, exists := m.matches[matchID]
-	if !exists {
-		return errors.New("試合が見つかりません")
-	}
-	
-	match.Status = status
-	return nil
-}
-
-func (m *MockMatchRepository) GetBySport(sport string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var matches []*models.Match
-	for _, match := range m.matches {
-		matches = append(matches, match)
-	}
-	
-	return matches, nil
-}
-
-func (m *MockMatchRepository) GetByTournament(tournamentID int) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var matches []*models.Match
-	for _, match := range m.matches {
-		if match.TournamentID == tournamentID {
-			matches = append(matches, match)
-		}
-	}
-	
-	return matches, nil
-}
-
-func (m *MockMatchRepository) GetByTournamentAndRound(tournamentID int, round string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var matches []*models.Match
-	for _, match := range m.matches {
-		if match.TournamentID == tournamentID && match.Round == round {
-			matches = append(matches, match)
-		}
-	}
-	
-	return matches, nil
-}
-
-func (m *MockMatchRepository) GetByStatus(status string) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var matches []*models.Match
-	for _, match := range m.matches {
-		if match.Status == status {
-			matches = append(matches, match)
-		}
-	}
-	
-	return matches, nil
-}
-
-func (m *MockMatchRepository) GetPendingMatches() ([]*models.Match, error) {
-	return m.GetByStatus(models.MatchStatusPending)
-}
-
-func (m *MockMatchRepository) GetCompletedMatches() ([]*models.Match, error) {
-	return m.GetByStatus(models.MatchStatusCompleted)
-}
-
-func (m *MockMatchRepository) GetMatchesByDateRange(startDate, endDate time.Time) ([]*models.Match, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	
-	var matches []*models.Match
-	for _, match := range m.matches {
-		if match.ScheduledAt.After(startDate) && match.ScheduledAt.Before(endDate) {
-			matches = append(matches, match)
-		}
-	}
-	
-	return matches, nil
-}
-
-func (m *MockMatchRepository) CountByTournament(tournamentID int) (int, error) {
-	if m.err != nil {
-		return 0, m.err
-	}
-	
-	return m.matchCount[tournamentID], nil
-}
-
-func (m *MockMatchRepository) CountByStatus(status string) (int, error) {
-	if m.err != nil {
-		return 0, m.err
-	}
-	
-	count := 0
-	for _, match := range m.matches {
-		if match.Status == status {
-			count++
-		}
-	}
-	
-	return count, nil
-}
-
-func (m *MockMatchRepository) DeleteByTournament(tournamentID int) error {
-	if m.err != nil {
-		return m.err
-	}
-	
-	// 削除対象の試合を特定
-	toDelete := make([]int, 0)
-	for id, match := range m.matches {
-		if match.TournamentID == tournamentID {
-			toDelete = append(toDelete, id)
-		}
-	}
-	
-	// 試合を削除
-	for _, id := range toDelete {
-		delete(m.matches, id)
-	}
-	
-	// カウントをリセット
-	m.matchCount[tournamentID] = 0
-	
-	return nil
-}
-
-func (m *MockMatchRepository) SetError(err error) {
-	m.err = err
 }
 
 func TestNewTournamentService(t *testing.T) {
-	mockTournamentRepo := NewMockTournamentRepository()
-	mockMatchRepo := NewMockMatchRepository()
-	
-	service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-	
+	service, _, _, _ := newTestTournamentService()
+
 	if service == nil {
 		t.Error("TournamentServiceの作成に失敗しました")
 	}
@@ -500,82 +57,103 @@ func TestNewTournamentService(t *testing.T) {
 
 func TestTournamentService_CreateTournament(t *testing.T) {
 	tests := []struct {
-		name          string
-		sport         string
-		format        string
+		name               string
+		sport              string
+		format             string
+		status             string
+		eventStatus        models.EventStatus
 		existingTournament bool
-		expectedError bool
-		errorMessage  string
+		expectedErrorType  string
 	}{
 		{
-			name:          "正常なトーナメント作成",
-			sport:         models.SportVolleyball,
-			format:        models.FormatStandard,
-			expectedError: false,
+			name:   "正常なトーナメント作成",
+			sport:  models.SportVolleyball,
+			format: models.FormatStandard,
 		},
 		{
-			name:          "無効なスポーツ",
-			sport:         "invalid_sport",
-			format:        models.FormatStandard,
-			expectedError: true,
-			errorMessage:  "無効なスポーツです",
+			name:   "受付中で作成",
+			sport:  models.SportTableTennis,
+			format: models.FormatRainy,
+			status: models.TournamentStatusRegistration,
 		},
 		{
-			name:          "無効なフォーマット",
-			sport:         models.SportVolleyball,
-			format:        "invalid_format",
-			expectedError: true,
-			errorMessage:  "無効なトーナメントフォーマットです",
+			name:              "スポーツ未指定",
+			format:            models.FormatStandard,
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "無効なスポーツ",
+			sport:             "invalid_sport",
+			format:            models.FormatStandard,
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "種目で使えないフォーマット",
+			sport:             models.SportVolleyball,
+			format:            models.FormatRainy,
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "作成時に指定できないステータス",
+			sport:             models.SportVolleyball,
+			format:            models.FormatStandard,
+			status:            models.TournamentStatusActive,
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "アーカイブ済みの大会",
+			sport:             models.SportVolleyball,
+			format:            models.FormatStandard,
+			eventStatus:       models.EventStatusArchivedEnum,
+			expectedErrorType: ErrorTypeConflict,
 		},
 		{
 			name:               "既存のアクティブトーナメント",
 			sport:              models.SportVolleyball,
 			format:             models.FormatStandard,
 			existingTournament: true,
-			expectedError:      true,
-			errorMessage:       "スポーツ volleyball には既にアクティブなトーナメントが存在します",
+			expectedErrorType:  ErrorTypeConflict,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTournamentRepo := NewMockTournamentRepository()
-			mockMatchRepo := NewMockMatchRepository()
-			service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-			
-			// 既存のトーナメントを設定
+			service, tournamentRepo, _, eventRepo := newTestTournamentService()
+
+			event := newTestEvent(1)
+			if tt.eventStatus != "" {
+				event.SetStatus(tt.eventStatus)
+			}
+			eventRepo.On("GetCurrent", mock.Anything).Return(event, nil).Maybe()
+
+			var existing *models.Tournament
 			if tt.existingTournament {
-				mockTournamentRepo.AddTournament(tt.sport, tt.format, models.TournamentStatusActive)
-			}
-			
-			tournament, err := service.CreateTournament(tt.sport, tt.format)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-				if tournament != nil {
-					t.Error("エラー時にトーナメントが返されました")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
-				if tournament == nil {
-					t.Error("トーナメントが返されませんでした")
-				} else {
-					if tournament.Sport != tt.sport {
-						t.Errorf("期待されたスポーツ: %s, 実際: %s", tt.sport, tournament.Sport)
-					}
-					if tournament.Format != tt.format {
-						t.Errorf("期待されたフォーマット: %s, 実際: %s", tt.format, tournament.Format)
-					}
-					if tournament.Status != models.TournamentStatusActive {
-						t.Errorf("期待されたステータス: %s, 実際: %s", models.TournamentStatusActive, tournament.Status)
-					}
-				}
+				existing = &models.Tournament{ID: 9, EventID: 1, Sport: tt.sport}
+			}
+			tournamentRepo.On("GetByEventAndSport", mock.Anything, uint(1), tt.sport).Return(existing, nil).Maybe()
+			tournamentRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			tournament := &models.Tournament{Sport: tt.sport, Format: tt.format, Status: tt.status}
+			err := service.CreateTournament(context.Background(), tournament)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				tournamentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			tournamentRepo.AssertCalled(t, "Create", mock.Anything, tournament)
+			if tournament.EventID != 1 {
+				t.Errorf("期待された大会ID: 1, 実際: %d", tournament.EventID)
+			}
+			wantStatus := tt.status
+			if wantStatus == "" {
+				wantStatus = string(models.TournamentStatusDraftEnum)
+			}
+			if tournament.Status != wantStatus {
+				t.Errorf("期待されたステータス: %s, 実際: %s", wantStatus, tournament.Status)
 			}
 		})
 	}
@@ -583,300 +161,574 @@ func TestTournamentService_CreateTournament(t *testing.T) {
 
 func TestTournamentService_GetTournament(t *testing.T) {
 	tests := []struct {
-		name          string
-		sport         string
-		setupTournament bool
-		expectedError bool
-		errorMessage  string
+		name              string
+		tournament        *models.Tournament
+		repoErr           error
+		expectedErrorType string
 	}{
 		{
-			name:            "正常なトーナメント取得",
-			sport:           models.SportVolleyball,
-			setupTournament: true,
-			expectedError:   false,
+			name:       "正常なトーナメント取得",
+			tournament: &models.Tournament{ID: 1, Sport: models.SportVolleyball, Format: models.FormatStandard},
 		},
 		{
-			name:          "無効なスポーツ",
-			sport:         "invalid_sport",
-			expectedError: true,
-			errorMessage:  "無効なスポーツです",
+			name:              "存在しないトーナメント",
+			expectedErrorType: ErrorTypeNotFound,
 		},
 		{
-			name:          "存在しないトーナメント",
-			sport:         models.SportVolleyball,
-			expectedError: true,
-			errorMessage:  "スポーツ volleyball のトーナメントが見つかりません",
+			name:              "データベースエラー",
+			repoErr:           errors.New("connection refused"),
+			expectedErrorType: ErrorTypeDatabase,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTournamentRepo := NewMockTournamentRepository()
-			mockMatchRepo := NewMockMatchRepository()
-			service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-			
-			// テストトーナメントを設定
-			if tt.setupTournament {
-				mockTournamentRepo.AddTournament(tt.sport, models.FormatStandard, models.TournamentStatusActive)
-			}
-			
-			tournament, err := service.GetTournament(tt.sport)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
+			service, tournamentRepo, _, _ := newTestTournamentService()
+			tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tt.tournament, tt.repoErr)
+
+			tournament, err := service.GetTournament(context.Background(), 1)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
 				if tournament != nil {
 					t.Error("エラー時にトーナメントが返されました")
 				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
-				if tournament == nil {
-					t.Error("トーナメントが返されませんでした")
-				} else {
-					if tournament.Sport != tt.sport {
-						t.Errorf("期待されたスポーツ: %s, 実際: %s", tt.sport, tournament.Sport)
-					}
-				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if tournament != tt.tournament {
+				t.Errorf("期待されたトーナメント: %+v, 実際: %+v", tt.tournament, tournament)
 			}
 		})
 	}
 }
 
-func TestTournamentService_GenerateBracket(t *testing.T) {
+func TestTournamentService_GetTournamentBySport(t *testing.T) {
+	service, tournamentRepo, _, eventRepo := newTestTournamentService()
+	tournament := &models.Tournament{ID: 3, EventID: 2, Sport: models.SportSoccer}
+	eventRepo.On("GetByID", mock.Anything, uint(2)).Return(newTestEvent(2), nil)
+	tournamentRepo.On("GetByEventAndSport", mock.Anything, uint(2), models.SportSoccer).Return(tournament, nil)
+	tournamentRepo.On("GetByEventAndSport", mock.Anything, uint(2), models.SportVolleyball).Return(nil, nil)
+
+	got, err := service.GetTournamentBySport(context.Background(), 2, models.SportSoccer)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if got != tournament {
+		t.Errorf("期待されたトーナメント: %+v, 実際: %+v", tournament, got)
+	}
+
+	_, err = service.GetTournamentBySport(context.Background(), 2, models.SportVolleyball)
+	assertServiceError(t, err, ErrorTypeNotFound)
+}
+
+func TestTournamentService_SwitchTournamentFormat(t *testing.T) {
 	tests := []struct {
-		name          string
-		sport         string
-		format        string
-		teams         []string
-		expectedError bool
-		errorMessage  string
+		name              string
+		sport             string
+		currentFormat     string
+		newFormat         models.TournamentFormat
+		status            models.TournamentStatus
+		matches           []*models.Match
+		dryRun            bool
+		expectedErrorType string
 	}{
 		{
-			name:          "バレーボール正常ブラケット生成",
-			sport:         models.SportVolleyball,
-			format:        models.FormatStandard,
-			teams:         []string{"チーム1", "チーム2", "チーム3", "チーム4", "チーム5", "チーム6", "チーム7", "チーム8"},
-			expectedError: false,
+			name:          "卓球フォーマット切り替え成功",
+			sport:         models.SportTableTennis,
+			currentFormat: models.FormatStandard,
+			newFormat:     models.TournamentFormatRainy,
 		},
 		{
-			name:          "卓球標準フォーマット",
+			name:          "プレビューのみ",
 			sport:         models.SportTableTennis,
-			format:        models.FormatStandard,
-			teams:         []string{"チーム1", "チーム2", "チーム3", "チーム4", "チーム5", "チーム6", "チーム7", "チーム8"},
-			expectedError: false,
+			currentFormat: models.FormatStandard,
+			newFormat:     models.TournamentFormatRainy,
+			dryRun:        true,
 		},
 		{
-			name:          "卓球雨天フォーマット",
+			name:          "試合作成後の雨天時形式への切り替え",
 			sport:         models.SportTableTennis,
-			format:        models.FormatRainy,
-			teams:         []string{"チーム1", "チーム2", "チーム3", "チーム4", "チーム5", "チーム6", "チーム7", "チーム8"},
-			expectedError: false,
+			currentFormat: models.FormatStandard,
+			newFormat:     models.TournamentFormatRainy,
+			status:        models.TournamentStatusActiveEnum,
+			matches:       newTestKnockoutMatches(t),
+		},
+		{
+			name:              "試合作成後は標準・雨天時以外の形式に切り替えられない",
+			sport:             models.SportTableTennis,
+			currentFormat:     models.FormatStandard,
+			newFormat:         models.TournamentFormatDoubleElimination,
+			status:            models.TournamentStatusActiveEnum,
+			matches:           newTestKnockoutMatches(t),
+			expectedErrorType: ErrorTypeValidation,
 		},
 		{
-			name:          "サッカー正常ブラケット生成",
-			sport:         models.SportSoccer,
-			format:        models.FormatStandard,
-			teams:         []string{"チーム1", "チーム2", "チーム3", "チーム4", "チーム5", "チーム6", "チーム7", "チーム8"},
-			expectedError: false,
+			name:              "無効なフォーマット",
+			sport:             models.SportTableTennis,
+			currentFormat:     models.FormatStandard,
+			newFormat:         models.TournamentFormat("invalid_format"),
+			expectedErrorType: ErrorTypeValidation,
 		},
 		{
-			name:          "無効なスポーツ",
-			sport:         "invalid_sport",
-			format:        models.FormatStandard,
-			teams:         []string{"チーム1", "チーム2"},
-			expectedError: true,
-			errorMessage:  "無効なスポーツです",
+			name:              "サポートされていないスポーツ",
+			sport:             models.SportVolleyball,
+			currentFormat:     models.FormatStandard,
+			newFormat:         models.TournamentFormatRainy,
+			expectedErrorType: ErrorTypeValidation,
 		},
 		{
-			name:          "チーム不足",
-			sport:         models.SportVolleyball,
-			format:        models.FormatStandard,
-			teams:         []string{"チーム1", "チーム2"},
-			expectedError: true,
-			errorMessage:  "スポーツ volleyball には最低 8 チームが必要です",
+			name:              "同じフォーマット",
+			sport:             models.SportTableTennis,
+			currentFormat:     models.FormatStandard,
+			newFormat:         models.TournamentFormatStandard,
+			expectedErrorType: ErrorTypeConflict,
 		},
 		{
-			name:          "空のチーム",
-			sport:         models.SportVolleyball,
-			format:        models.FormatStandard,
-			teams:         []string{},
-			expectedError: true,
-			errorMessage:  "チームが指定されていません",
+			name:              "終了したトーナメント",
+			sport:             models.SportTableTennis,
+			currentFormat:     models.FormatStandard,
+			newFormat:         models.TournamentFormatRainy,
+			status:            models.TournamentStatusCompletedEnum,
+			expectedErrorType: ErrorTypeConflict,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTournamentRepo := NewMockTournamentRepository()
-			mockMatchRepo := NewMockMatchRepository()
-			service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-			
-			bracket, err := service.GenerateBracket(tt.sport, tt.format, tt.teams)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-				if bracket != nil {
-					t.Error("エラー時にブラケットが返されました")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
-				if bracket == nil {
-					t.Error("ブラケットが返されませんでした")
-				} else {
-					if bracket.Sport != tt.sport {
-						t.Errorf("期待されたスポーツ: %s, 実際: %s", tt.sport, bracket.Sport)
-					}
-					if bracket.Format != tt.format {
-						t.Errorf("期待されたフォーマット: %s, 実際: %s", tt.format, bracket.Format)
-					}
-					if len(bracket.Rounds) == 0 {
-						t.Error("ラウンドが生成されませんでした")
-					}
-					
-					// 1回戦の試合数をチェック
-					if len(bracket.Rounds) > 0 {
-						firstRound := bracket.Rounds[0]
-						expectedMatches := len(tt.teams) / 2
-						if len(firstRound.Matches) != expectedMatches {
-							t.Errorf("期待された1回戦試合数: %d, 実際: %d", expectedMatches, len(firstRound.Matches))
-						}
-					}
+			service, tournamentRepo, matchRepo, eventRepo := newTestTournamentService()
+
+			status := tt.status
+			if status == "" {
+				status = models.TournamentStatusRegistrationEnum
+			}
+			tournament := &models.Tournament{ID: 1, EventID: 1, Sport: tt.sport, Format: tt.currentFormat, Status: string(status)}
+			tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tournament, nil)
+			tournamentRepo.On("GetLeagueRules", mock.Anything, uint(1)).Return(nil, nil).Maybe()
+			eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil).Maybe()
+			matches := tt.matches
+			if matches == nil {
+				matches = []*models.Match{}
+			}
+			matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(matches, nil).Maybe()
+			matchRepo.On("SwitchFormat", mock.Anything, 1, tt.newFormat, mock.Anything, mock.Anything).Return(nil).Maybe()
+			matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return(matches, nil).Maybe()
+			matchRepo.Tournament = tournament
+
+			plan, err := service.SwitchTournamentFormat(context.Background(), 1, tt.newFormat, tt.dryRun)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				matchRepo.AssertNotCalled(t, "SwitchFormat", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if plan.From != models.TournamentFormat(tt.currentFormat) || plan.To != tt.newFormat {
+				t.Errorf("期待された切り替え: %s -> %s, 実際: %s -> %s", tt.currentFormat, tt.newFormat, plan.From, plan.To)
+			}
+
+			if tt.dryRun {
+				matchRepo.AssertNotCalled(t, "SwitchFormat", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				if tournament.Format != tt.currentFormat {
+					t.Errorf("プレビューでフォーマットが変更されました: %s", tournament.Format)
 				}
+				return
+			}
+			matchRepo.AssertCalled(t, "SwitchFormat", mock.Anything, 1, tt.newFormat, mock.Anything, mock.Anything)
+			if tournament.Format != string(tt.newFormat) {
+				t.Errorf("期待されたフォーマット: %s, 実際: %s", tt.newFormat, tournament.Format)
 			}
 		})
 	}
 }
 
-func TestTournamentService_SwitchTournamentFormat(t *testing.T) {
+func TestTournamentService_DrawBracket(t *testing.T) {
 	tests := []struct {
-		name          string
-		sport         string
-		currentFormat string
-		newFormat     string
-		expectedError bool
-		errorMessage  string
+		name              string
+		drawErr           error
+		expectedErrorType string
 	}{
 		{
-			name:          "卓球フォーマット切り替え成功",
-			sport:         models.SportTableTennis,
-			currentFormat: models.FormatStandard,
-			newFormat:     models.FormatRainy,
-			expectedError: false,
+			name: "抽選成功",
 		},
 		{
-			name:          "無効なスポーツ",
-			sport:         "invalid_sport",
-			currentFormat: models.FormatStandard,
-			newFormat:     models.FormatRainy,
-			expectedError: true,
-			errorMessage:  "無効なスポーツです",
+			name:              "既に試合が作成済み",
+			drawErr:           fmt.Errorf("トランザクション内操作エラー: %w", repository.NewRepositoryError(repository.ErrTypeDuplicate, "トーナメントには既に試合が作成されています", nil)),
+			expectedErrorType: ErrorTypeConflict,
 		},
 		{
-			name:          "サポートされていないスポーツ",
-			sport:         models.SportVolleyball,
-			currentFormat: models.FormatStandard,
-			newFormat:     models.FormatRainy,
-			expectedError: true,
-			errorMessage:  "スポーツ volleyball はフォーマット切り替えをサポートしていません",
+			name:              "データベースエラー",
+			drawErr:           errors.New("connection refused"),
+			expectedErrorType: ErrorTypeDatabase,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournamentRepo := new(MockTournamentRepository)
+			teamRepo := new(MockTeamRepository)
+			matchRepo := new(MockMatchRepository)
+			eventRepo := new(MockEventRepository)
+			service := NewTournamentService(tournamentRepo, teamRepo, matchRepo, eventRepo)
+
+			tournament := &models.Tournament{ID: 1, EventID: 1, Sport: models.SportVolleyball, Format: models.FormatStandard, Status: models.TournamentStatusRegistration}
+			teams := []*models.Team{{ID: 1, Name: "IE1"}, {ID: 2, Name: "IE2"}, {ID: 3, Name: "IE3"}, {ID: 4, Name: "IE4"}}
+			tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tournament, nil)
+			eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil)
+			teamRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(teams, nil)
+			matchRepo.On("DrawBracket", mock.Anything, tournament, mock.Anything, mock.Anything).Return(tt.drawErr)
+
+			draw, err := service.DrawBracket(context.Background(), 1, models.DrawOptions{RandomSeed: 1})
+
+			// 試合・抽選結果・状態は1回の呼び出しでまとめて保存する
+			matchRepo.AssertNumberOfCalls(t, "DrawBracket", 1)
+			matchRepo.AssertNotCalled(t, "CreateBracket", mock.Anything, mock.Anything)
+			tournamentRepo.AssertNotCalled(t, "SaveDraw", mock.Anything, mock.Anything, mock.Anything)
+			tournamentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(draw.Order) != len(teams) || len(draw.TeamIDs) != len(teams) {
+				t.Errorf("期待された参加チーム数: %d, 実際: %d（チームID %d）", len(teams), len(draw.Order), len(draw.TeamIDs))
+			}
+			if tournament.GetStatus() != models.TournamentStatusSeededEnum {
+				t.Errorf("期待された状態: %s, 実際: %s", models.TournamentStatusSeededEnum, tournament.GetStatus())
+			}
+		})
+	}
+}
+
+func TestTournamentService_GenerateBracket(t *testing.T) {
+	newTeams := func(count int) []*models.Team {
+		teams := make([]*models.Team, 0, count)
+		for i := 1; i <= count; i++ {
+			teams = append(teams, &models.Team{ID: uint(i), TournamentID: 1, Name: fmt.Sprintf("チーム%d", i)})
+		}
+		return teams
+	}
+
+	tests := []struct {
+		name              string
+		sport             string
+		format            string
+		teams             []*models.Team
+		expectedErrorType string
+	}{
 		{
-			name:          "同じフォーマット",
-			sport:         models.SportTableTennis,
-			currentFormat: models.FormatStandard,
-			newFormat:     models.FormatStandard,
-			expectedError: true,
-			errorMessage:  "トーナメントは既に standard フォーマットです",
+			name:   "バレーボール正常ブラケット生成",
+			sport:  models.SportVolleyball,
+			format: models.FormatStandard,
+			teams:  newTeams(8),
+		},
+		{
+			name:   "卓球標準フォーマット",
+			sport:  models.SportTableTennis,
+			format: models.FormatStandard,
+			teams:  newTeams(8),
+		},
+		{
+			name:   "卓球雨天フォーマット",
+			sport:  models.SportTableTennis,
+			format: models.FormatRainy,
+			teams:  newTeams(8),
+		},
+		{
+			name:   "サッカー正常ブラケット生成",
+			sport:  models.SportSoccer,
+			format: models.FormatStandard,
+			teams:  newTeams(8),
+		},
+		{
+			name:              "チーム不足",
+			sport:             models.SportVolleyball,
+			format:            models.FormatStandard,
+			teams:             newTeams(1),
+			expectedErrorType: ErrorTypeValidation,
+		},
+		{
+			name:              "空のチーム",
+			sport:             models.SportVolleyball,
+			format:            models.FormatStandard,
+			teams:             newTeams(0),
+			expectedErrorType: ErrorTypeValidation,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTournamentRepo := NewMockTournamentRepository()
-			mockMatchRepo := NewMockMatchRepository()
-			service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-			
-			// テストトーナメントを設定
-			if models.IsValidSport(tt.sport) {
-				mockTournamentRepo.AddTournament(tt.sport, tt.currentFormat, models.TournamentStatusActive)
-			}
-			
-			err := service.SwitchTournamentFormat(tt.sport, tt.newFormat)
-			
-			if tt.expectedError {
-				if err == nil {
-					t.Error("エラーが期待されましたが、エラーが発生しませんでした")
-				} else if tt.errorMessage != "" && err.Error() != tt.errorMessage {
-					t.Errorf("期待されたエラーメッセージ: %s, 実際: %s", tt.errorMessage, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Errorf("予期しないエラー: %v", err)
-				}
-				
-				// フォーマットが更新されたかチェック
-				tournament, err := service.GetTournament(tt.sport)
-				if err != nil {
-					t.Errorf("更新後のトーナメント取得エラー: %v", err)
-				} else if tournament.Format != tt.newFormat {
-					t.Errorf("期待されたフォーマット: %s, 実際: %s", tt.newFormat, tournament.Format)
+			tournamentRepo := new(MockTournamentRepository)
+			teamRepo := new(MockTeamRepository)
+			matchRepo := new(MockMatchRepository)
+			eventRepo := new(MockEventRepository)
+			service := NewTournamentService(tournamentRepo, teamRepo, matchRepo, eventRepo)
+
+			tournament := &models.Tournament{ID: 1, EventID: 1, Sport: tt.sport, Format: tt.format, Status: models.TournamentStatusRegistration}
+			tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tournament, nil)
+			eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil)
+			teamRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(tt.teams, nil)
+			var bracket []*models.Match
+			matchRepo.On("DrawBracket", mock.Anything, tournament, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { bracket = args.Get(3).([]*models.Match) }).
+				Return(nil).Maybe()
+
+			err := service.GenerateBracket(context.Background(), 1)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				matchRepo.AssertNotCalled(t, "DrawBracket", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(bracket) == 0 {
+				t.Fatal("試合が生成されませんでした")
+			}
+
+			// 1回戦（対戦チームが決まっている試合）の試合数をチェック
+			firstRound := 0
+			for _, match := range bracket {
+				if !match.HasUndecidedTeams() {
+					firstRound++
 				}
 			}
+			if expected := len(tt.teams) / 2; firstRound != expected {
+				t.Errorf("期待された1回戦試合数: %d, 実際: %d", expected, firstRound)
+			}
 		})
 	}
 }
 
+func TestTournamentService_AdvanceWinner(t *testing.T) {
+	service, tournamentRepo, matchRepo, eventRepo := newTestTournamentService()
+	tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(&models.Tournament{ID: 1, EventID: 1, Sport: models.SportVolleyball, Format: models.FormatStandard, Status: models.TournamentStatusActive}, nil)
+	eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil)
+
+	// 1回戦の試合（完了済み）の勝者は準々決勝の1枠目に進む
+	match1 := newTestMatch(1, models.Round1stRoundEnum, "チーム1", "チーム2")
+	match1.SetProgression(models.BracketProgression{Winner: &models.BracketSlot{MatchID: 2, Slot: models.SlotTeam1}})
+	match1.ApplyResult(models.MatchResult{Score1: 3, Score2: 1, Winner: "チーム1"})
+	match1.SetStatus(models.MatchStatusCompletedEnum)
+
+	// 準々決勝の試合（TBD）
+	match2 := newTestMatch(2, models.RoundQuarterfinalEnum, models.TeamTBD, "チーム3")
+
+	read := *match1
+	matchRepo.On("GetByID", mock.Anything, uint(1)).Return(&read, nil)
+	matchRepo.On("AdvanceBracket", mock.Anything, uint(1)).Return([]*models.Match{match1, match2}, nil)
+
+	// 勝者進出処理を実行
+	err := service.AdvanceWinner(context.Background(), 1)
+
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if match2.Team1 != "チーム1" {
+		t.Errorf("期待されたチーム1: チーム1, 実際: %s", match2.Team1)
+	}
+}
+
 func TestTournamentService_GetTournamentProgress(t *testing.T) {
-	mockTournamentRepo := NewMockTournamentRepository()
-	mockMatchRepo := NewMockMatchRepository()
-	service := NewTournamentService(mockTournamentRepo, mockMatchRepo)
-	
-	// テストトーナメントを設定
-	tournament := mockTournamentRepo.AddTournament(models.SportVolleyball, models.FormatStandard, models.TournamentStatusActive)
-	
-	// テスト試合を追加
-	for i := 0; i < 4; i++ {
-		match := &models.Match{
+	service, tournamentRepo, matchRepo, eventRepo := newTestTournamentService()
+
+	tournament := &models.Tournament{ID: 1, EventID: 1, Sport: models.SportVolleyball, Format: models.FormatStandard, Status: models.TournamentStatusActive}
+	eventRepo.On("GetCurrent", mock.Anything).Return(newTestEvent(1), nil)
+	tournamentRepo.On("GetByEventAndSport", mock.Anything, uint(1), models.SportVolleyball).Return(tournament, nil)
+
+	// テスト試合を追加（1試合終了、3試合未実施）
+	var matches []*models.Match
+	for i := 1; i <= 4; i++ {
+		matches = append(matches, &models.Match{
+			ID:           i,
 			TournamentID: tournament.ID,
 			Round:        models.Round1stRound,
 			Team1:        "チーム1",
 			Team2:        "チーム2",
 			Status:       models.MatchStatusPending,
-			ScheduledAt:  time.Now(),
-		}
-		mockMatchRepo.Create(match)
+		})
 	}
-	
-	progress, err := service.GetTournamentProgress(models.SportVolleyball)
-	
+	matches[0].ApplyResult(models.MatchResult{Score1: 2, Score2: 0, Winner: "チーム1"})
+	matches[0].SetStatus(models.MatchStatusCompletedEnum)
+	matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(matches, nil)
+
+	progress, err := service.GetTournamentProgress(0, models.SportVolleyball)
 	if err != nil {
-		t.Errorf("予期しないエラー: %v", err)
-	}
-	
-	if progress == nil {
-		t.Error("進行状況が返されませんでした")
-	} else {
-		if progress.Sport != models.SportVolleyball {
-			t.Errorf("期待されたスポーツ: %s, 実際: %s", models.SportVolleyball, progress.Sport)
-		}
-		if progress.TotalMatches != 4 {
-			t.Errorf("期待された総試合数: 4, 実際: %d", progress.TotalMatches)
-		}
-		if progress.CompletedMatches != 0 {
-			t.Errorf("期待された完了試合数: 0, 実際: %d", progress.CompletedMatches)
-		}
-		if progress.PendingMatches != 4 {
-			t.Errorf("期待された未実施試合数: 4, 実際: %d", progress.PendingMatches)
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	if progress.Sport != models.SportVolleyball {
+		t.Errorf("期待されたスポーツ: %s, 実際: %s", models.SportVolleyball, progress.Sport)
+	}
+	if progress.TotalMatches != 4 {
+		t.Errorf("期待された総試合数: 4, 実際: %d", progress.TotalMatches)
+	}
+	if progress.CompletedMatches != 1 {
+		t.Errorf("期待された完了試合数: 1, 実際: %d", progress.CompletedMatches)
+	}
+	if progress.PendingMatches != 3 {
+		t.Errorf("期待された未実施試合数: 3, 実際: %d", progress.PendingMatches)
+	}
+	if progress.CompletionRate != 25 {
+		t.Errorf("期待された完了率: 25, 実際: %v", progress.CompletionRate)
+	}
+	if progress.CurrentRound != models.Round1stRound {
+		t.Errorf("期待された現在のラウンド: %s, 実際: %s", models.Round1stRound, progress.CurrentRound)
+	}
+}
+
+func TestAdvanceTournamentStatus(t *testing.T) {
+	final := func(status models.MatchStatus) []*models.Match {
+		match := newTestMatch(1, models.RoundFinalEnum, "IE4", "IS4")
+		if status == models.MatchStatusCompletedEnum {
+			match.ApplyResult(models.MatchResult{Score1: 2, Score2: 0, Winner: "IE4"})
 		}
+		match.SetStatus(status)
+		return []*models.Match{match}
+	}
+
+	tests := []struct {
+		name      string
+		status    models.TournamentStatus
+		matches   []*models.Match
+		wantFrom  []models.TournamentStatus
+		wantFinal models.TournamentStatus
+	}{
+		{
+			name:      "試合の開始で開催中にする",
+			status:    models.TournamentStatusSeededEnum,
+			matches:   final(models.MatchStatusInProgressEnum),
+			wantFrom:  []models.TournamentStatus{models.TournamentStatusSeededEnum},
+			wantFinal: models.TournamentStatusActiveEnum,
+		},
+		{
+			// 不戦勝などで最初の結果が決勝を決めた場合は、開催中を経て終了にする
+			name:      "開催中と終了を続けて適用する",
+			status:    models.TournamentStatusSeededEnum,
+			matches:   final(models.MatchStatusCompletedEnum),
+			wantFrom:  []models.TournamentStatus{models.TournamentStatusSeededEnum, models.TournamentStatusActiveEnum},
+			wantFinal: models.TournamentStatusCompletedEnum,
+		},
+		{
+			name:      "決勝のやり直しで再開する",
+			status:    models.TournamentStatusCompletedEnum,
+			matches:   final(models.MatchStatusPendingEnum),
+			wantFrom:  []models.TournamentStatus{models.TournamentStatusCompletedEnum},
+			wantFinal: models.TournamentStatusActiveEnum,
+		},
+		{
+			name:      "変更なし",
+			status:    models.TournamentStatusActiveEnum,
+			matches:   final(models.MatchStatusInProgressEnum),
+			wantFinal: models.TournamentStatusActiveEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &models.Tournament{ID: 1, EventID: 1, Sport: models.SportSoccer, Format: models.FormatStandard, Status: string(tt.status)}
+
+			transitions, err := advanceTournamentStatus(tournament, tt.matches)
+
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if tournament.GetStatus() != tt.wantFinal {
+				t.Errorf("期待されたステータス: %s, 実際: %s", tt.wantFinal, tournament.GetStatus())
+			}
+			if len(transitions) != len(tt.wantFrom) {
+				t.Fatalf("期待された遷移の数: %d, 実際: %d", len(tt.wantFrom), len(transitions))
+			}
+			for i, transition := range transitions {
+				if transition.from != tt.wantFrom[i] {
+					t.Errorf("遷移%d の変更前: %s, want %s", i, transition.from, tt.wantFrom[i])
+				}
+			}
+		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTournamentService_GenerateNextSwissRound(t *testing.T) {
+	tests := []struct {
+		name              string
+		createErr         error
+		expectedErrorType string
+	}{
+		{
+			name: "2回戦を作成",
+		},
+		{
+			// 同時に受け付けた別のリクエストが先に同じ回戦を作成した
+			name:              "作成済みの回戦",
+			createErr:         repository.NewRepositoryError(repository.ErrTypeDuplicate, "この回戦の試合は既に作成されています", nil),
+			expectedErrorType: ErrorTypeConflict,
+		},
+		{
+			name:              "データベースエラー",
+			createErr:         errors.New("connection refused"),
+			expectedErrorType: ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournamentRepo := new(MockTournamentRepository)
+			teamRepo := new(MockTeamRepository)
+			matchRepo := new(MockMatchRepository)
+			eventRepo := new(MockEventRepository)
+			service := NewTournamentService(tournamentRepo, teamRepo, matchRepo, eventRepo)
+
+			// 抽選の後にAをA1に変更した。抽選の記録は抽選時の名前のまま残り、試合には現在の名前が表示される
+			tournament := &models.Tournament{ID: 1, EventID: 1, Sport: models.SportSoccer, Format: string(models.TournamentFormatSwiss), Status: string(models.TournamentStatusActiveEnum)}
+			draw := &models.DrawResult{Order: []string{"A", "B", "C", "D"}, TeamIDs: []int{1, 2, 3, 4}, Options: models.DrawOptions{SwissRounds: 3}}
+			teams := []*models.Team{{ID: 1, TournamentID: 1, Name: "A1"}, {ID: 2, TournamentID: 1, Name: "B"}, {ID: 3, TournamentID: 1, Name: "C"}, {ID: 4, TournamentID: 1, Name: "D"}}
+			matches := models.NewSwissRoundMatches(1, 1, 0, [][2]string{{"A1", "B"}, {"C", "D"}})
+			models.AssignTeamIDs(matches, teams)
+			for i, match := range matches {
+				match.ID = i + 1
+				match.ApplyResult(models.MatchResult{Score1: 2, Score2: 0, Winner: match.Team1})
+				match.SetStatus(models.MatchStatusCompletedEnum)
+			}
+			tournamentRepo.On("GetByID", mock.Anything, uint(1)).Return(tournament, nil)
+			tournamentRepo.On("GetDraw", mock.Anything, uint(1)).Return(draw, nil)
+			tournamentRepo.On("GetLeagueRules", mock.Anything, uint(1)).Return(nil, nil).Maybe()
+			eventRepo.On("GetByID", mock.Anything, uint(1)).Return(newTestEvent(1), nil)
+			teamRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(teams, nil)
+			matchRepo.On("GetByTournamentID", mock.Anything, uint(1)).Return(matches, nil)
+			matchRepo.On("CreateSwissRound", mock.Anything, uint(1), 2, mock.Anything).Return(tt.createErr)
+
+			round, err := service.GenerateNextSwissRound(context.Background(), 1)
+
+			if tt.expectedErrorType != "" {
+				assertServiceError(t, err, tt.expectedErrorType)
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(round) != 2 {
+				t.Fatalf("期待された試合数: 2, 実際: %d", len(round))
+			}
+			for _, match := range round {
+				if match.SwissRound == nil || *match.SwissRound != 2 {
+					t.Errorf("期待された回戦: 2, 実際: %v", match.SwissRound)
+				}
+				if match.Team1ID == nil || match.Team2ID == nil {
+					t.Errorf("チームIDが設定されていません: %s vs %s", match.Team1, match.Team2)
+				}
+			}
+			// 1回戦の勝者同士（A1とC）が対戦する
+			if pair := round[0].Team1 + " vs " + round[0].Team2; pair != "A1 vs C" && pair != "C vs A1" {
+				t.Errorf("期待された対戦: A1 vs C, 実際: %s", pair)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend/internal/models"
+	"backend/internal/repository"
+)
+
+// VenueService defines the interface for managing the venues and courts of an event
+type VenueService interface {
+	ListVenues(ctx context.Context, eventID uint) ([]*models.Venue, error)
+	GetVenue(ctx context.Context, id uint) (*models.Venue, error)
+	CreateVenue(ctx context.Context, eventID uint, venue *models.Venue) error
+	UpdateVenue(ctx context.Context, id uint, venue *models.Venue) error
+	DeleteVenue(ctx context.Context, id uint) error
+	CreateCourt(ctx context.Context, venueID uint, court *models.Court) error
+	UpdateCourt(ctx context.Context, id uint, court *models.Court) error
+	DeleteCourt(ctx context.Context, id uint) error
+	GetCourtBoard(ctx context.Context, courtID uint) (*models.CourtBoard, error)
+	GetCourtBoards(ctx context.Context, eventID uint) ([]*models.CourtBoard, error)
+	RefreshCourtBoards(ctx context.Context, courtIDs ...*int)
+	SetNotificationService(notificationService *NotificationService)
+}
+
+// courtOverviewQueue is the number of upcoming matches listed per court when
+// every court of an event is shown at once
+const courtOverviewQueue = 3
+
+// venueService implements VenueService
+type venueService struct {
+	venueRepo           repository.VenueRepository
+	eventRepo           repository.EventRepository
+	matchRepo           repository.MatchRepository
+	notificationService *NotificationService
+}
+
+// NewVenueService creates a new venue service
+func NewVenueService(venueRepo repository.VenueRepository, eventRepo repository.EventRepository, matchRepo repository.MatchRepository) VenueService {
+	return &venueService{
+		venueRepo: venueRepo,
+		eventRepo: eventRepo,
+		matchRepo: matchRepo,
+	}
+}
+
+// SetNotificationService sets the notification service for real-time updates
+func (s *venueService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// ListVenues returns the venues of an event (the current event when eventID
+// is 0) with their courts
+func (s *venueService) ListVenues(ctx context.Context, eventID uint) ([]*models.Venue, error) {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	venues, err := s.venueRepo.GetByEvent(ctx, event.ID)
+	if err != nil {
+		logger.Error("Failed to get venues", "eventID", event.ID, "error", err)
+		return nil, NewDatabaseError("failed to get venues")
+	}
+	if venues == nil {
+		venues = []*models.Venue{}
+	}
+	return venues, nil
+}
+
+// GetVenue returns a venue with its courts by ID
+func (s *venueService) GetVenue(ctx context.Context, id uint) (*models.Venue, error) {
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get venue", "venueID", id, "error", err)
+		return nil, NewDatabaseError("failed to get venue")
+	}
+	if venue == nil {
+		return nil, NewNotFoundError("venue not found")
+	}
+	return venue, nil
+}
+
+// CreateVenue registers a venue in an event
+func (s *venueService) CreateVenue(ctx context.Context, eventID uint, venue *models.Venue) error {
+	event, err := resolveEvent(ctx, s.eventRepo, eventID)
+	if err != nil {
+		return err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, event.ID); err != nil {
+		return err
+	}
+
+	venue.ID = 0
+	venue.EventID = event.ID
+	venue.Name = strings.TrimSpace(venue.Name)
+	if err := venue.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureVenueNameAvailable(ctx, venue); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.Create(ctx, venue); err != nil {
+		logger.Error("Failed to create venue", "eventID", event.ID, "error", err)
+		return NewDatabaseError("failed to create venue")
+	}
+	venue.Courts = []*models.Court{}
+
+	logger.Info("Venue created", "venueID", venue.ID, "eventID", event.ID, "name", venue.Name)
+	return nil
+}
+
+// UpdateVenue updates a venue's name and display order
+func (s *venueService) UpdateVenue(ctx context.Context, id uint, venue *models.Venue) error {
+	existing, err := s.getWritableVenue(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	venue.ID = existing.ID
+	venue.EventID = existing.EventID
+	venue.CreatedAt = existing.CreatedAt
+	venue.Courts = existing.Courts
+	venue.Name = strings.TrimSpace(venue.Name)
+	if err := venue.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := s.ensureVenueNameAvailable(ctx, venue); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.Update(ctx, venue); err != nil {
+		logger.Error("Failed to update venue", "venueID", id, "error", err)
+		return NewDatabaseError("failed to update venue")
+	}
+	return nil
+}
+
+// DeleteVenue removes a venue and its courts. Matches assigned to its courts
+// keep their start time but become unassigned.
+func (s *venueService) DeleteVenue(ctx context.Context, id uint) error {
+	if _, err := s.getWritableVenue(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete venue", "venueID", id, "error", err)
+		return NewDatabaseError("failed to delete venue")
+	}
+	return nil
+}
+
+// CreateCourt adds a court to a venue
+func (s *venueService) CreateCourt(ctx context.Context, venueID uint, court *models.Court) error {
+	venue, err := s.getWritableVenue(ctx, venueID)
+	if err != nil {
+		return err
+	}
+
+	court.ID = 0
+	court.VenueID = venue.ID
+	court.Name = strings.TrimSpace(court.Name)
+	if err := court.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := ensureCourtNameAvailable(venue, court); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.CreateCourt(ctx, court); err != nil {
+		logger.Error("Failed to create court", "venueID", venueID, "error", err)
+		return NewDatabaseError("failed to create court")
+	}
+
+	logger.Info("Court created", "courtID", court.ID, "venueID", venueID, "name", court.Name)
+	return nil
+}
+
+// UpdateCourt updates a court's name, sports and display order
+func (s *venueService) UpdateCourt(ctx context.Context, id uint, court *models.Court) error {
+	existing, err := s.getCourt(ctx, id)
+	if err != nil {
+		return err
+	}
+	venue, err := s.getWritableVenue(ctx, existing.VenueID)
+	if err != nil {
+		return err
+	}
+
+	court.ID = existing.ID
+	court.VenueID = existing.VenueID
+	court.CreatedAt = existing.CreatedAt
+	court.Name = strings.TrimSpace(court.Name)
+	if err := court.Validate(); err != nil {
+		return NewValidationError(err.Error())
+	}
+	if err := ensureCourtNameAvailable(venue, court); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.UpdateCourt(ctx, court); err != nil {
+		logger.Error("Failed to update court", "courtID", id, "error", err)
+		return NewDatabaseError("failed to update court")
+	}
+	return nil
+}
+
+// DeleteCourt removes a court. Matches assigned to it keep their start time
+// but become unassigned until the schedule is generated again.
+func (s *venueService) DeleteCourt(ctx context.Context, id uint) error {
+	court, err := s.getCourt(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getWritableVenue(ctx, court.VenueID); err != nil {
+		return err
+	}
+
+	if err := s.venueRepo.DeleteCourt(ctx, id); err != nil {
+		logger.Error("Failed to delete court", "courtID", id, "error", err)
+		return NewDatabaseError("failed to delete court")
+	}
+	return nil
+}
+
+// GetCourtBoard returns the queue of a court: the match being played, the
+// next match and every match still to be played there
+func (s *venueService) GetCourtBoard(ctx context.Context, courtID uint) (*models.CourtBoard, error) {
+	court, err := s.getCourt(ctx, courtID)
+	if err != nil {
+		return nil, err
+	}
+	venue, err := s.GetVenue(ctx, court.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildBoard(ctx, court, venue.Name, 0)
+}
+
+// GetCourtBoards returns what is playing now and up next on every court of an
+// event (the current event when eventID is 0), in venue and court order
+func (s *venueService) GetCourtBoards(ctx context.Context, eventID uint) ([]*models.CourtBoard, error) {
+	venues, err := s.ListVenues(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := []*models.CourtBoard{}
+	for _, venue := range venues {
+		for _, court := range venue.Courts {
+			board, err := s.buildBoard(ctx, court, venue.Name, courtOverviewQueue)
+			if err != nil {
+				return nil, err
+			}
+			boards = append(boards, board)
+		}
+	}
+	return boards, nil
+}
+
+// RefreshCourtBoards pushes the current queue of the given courts to their
+// subscribers after a match on them was rescheduled, started or finished.
+// Unassigned (nil) and repeated courts are skipped, and failures are only
+// logged so that they never undo the change that triggered the refresh.
+func (s *venueService) RefreshCourtBoards(ctx context.Context, courtIDs ...*int) {
+	if s.notificationService == nil {
+		return
+	}
+
+	seen := make(map[int]bool)
+	for _, courtID := range courtIDs {
+		if courtID == nil || seen[*courtID] {
+			continue
+		}
+		seen[*courtID] = true
+
+		board, err := s.GetCourtBoard(ctx, uint(*courtID))
+		if err != nil {
+			logger.Error("Failed to refresh court board", "courtID", *courtID, "error", err)
+			continue
+		}
+		s.notificationService.NotifyCourtUpdate(board)
+	}
+}
+
+// buildBoard builds the queue of a court from the matches assigned to it
+func (s *venueService) buildBoard(ctx context.Context, court *models.Court, venueName string, queueLimit int) (*models.CourtBoard, error) {
+	matches, err := s.matchRepo.GetByCourt(ctx, court.ID)
+	if err != nil {
+		logger.Error("Failed to get matches of court", "courtID", court.ID, "error", err)
+		return nil, NewDatabaseError("failed to get matches")
+	}
+	return models.BuildCourtBoard(court, venueName, matches, time.Now(), queueLimit), nil
+}
+
+// getWritableVenue returns a venue, rejecting changes to an archived event
+func (s *venueService) getWritableVenue(ctx context.Context, id uint) (*models.Venue, error) {
+	venue, err := s.GetVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureEventWritable(ctx, s.eventRepo, venue.EventID); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// getCourt returns a court by ID
+func (s *venueService) getCourt(ctx context.Context, id uint) (*models.Court, error) {
+	court, err := s.venueRepo.GetCourt(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get court", "courtID", id, "error", err)
+		return nil, NewDatabaseError("failed to get court")
+	}
+	if court == nil {
+		return nil, NewNotFoundError("court not found")
+	}
+	return court, nil
+}
+
+// ensureVenueNameAvailable rejects a name already used by another venue of the event
+func (s *venueService) ensureVenueNameAvailable(ctx context.Context, venue *models.Venue) error {
+	venues, err := s.venueRepo.GetByEvent(ctx, venue.EventID)
+	if err != nil {
+		logger.Error("Failed to check venue name", "eventID", venue.EventID, "error", err)
+		return NewDatabaseError("failed to check venue name")
+	}
+	for _, other := range venues {
+		if other.Name == venue.Name && other.ID != venue.ID {
+			return NewConflictError("venue name is already registered in this event")
+		}
+	}
+	return nil
+}
+
+// ensureCourtNameAvailable rejects a name already used by another court of the venue
+func ensureCourtNameAvailable(venue *models.Venue, court *models.Court) error {
+	for _, other := range venue.Courts {
+		if other.Name == court.Name && other.ID != court.ID {
+			return NewConflictError("court name is already registered in this venue")
+		}
+	}
+	return nil
+}
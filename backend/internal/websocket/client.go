@@ -208,6 +208,12 @@ func (c *Client) handleSubscribe(data json.RawMessage) {
 			c.Manager.stats.ConnectionsBySport[sport]++
 		}
 	}
+	// コートを購読リストに追加
+	for _, courtID := range subscribeRequest.Courts {
+		if courtID > 0 {
+			c.Info.AddCourt(courtID)
+		}
+	}
 	c.Manager.mutex.Unlock()
 
 	// 購読成功メッセージを送信
@@ -216,12 +222,13 @@ func (c *Client) handleSubscribe(data json.RawMessage) {
 		map[string]interface{}{
 			"success": true,
 			"sports":  subscribeRequest.Sports,
+			"courts":  subscribeRequest.Courts,
 			"message": "購読が完了しました",
 		},
 	)
 	c.sendMessage(subscribeSuccessMsg)
 
-	log.Printf("Client %s subscribed to sports: %v", c.ID, subscribeRequest.Sports)
+	log.Printf("Client %s subscribed to sports: %v, courts: %v", c.ID, subscribeRequest.Sports, subscribeRequest.Courts)
 }
 
 // handleUnsubscribe は購読解除メッセージを処理する
@@ -256,6 +263,10 @@ func (c *Client) handleUnsubscribe(data json.RawMessage) {
 			}
 		}
 	}
+	// コートを購読リストから削除
+	for _, courtID := range unsubscribeRequest.Courts {
+		c.Info.RemoveCourt(courtID)
+	}
 	c.Manager.mutex.Unlock()
 
 	// 購読解除成功メッセージを送信
@@ -264,12 +275,13 @@ func (c *Client) handleUnsubscribe(data json.RawMessage) {
 		map[string]interface{}{
 			"success": true,
 			"sports":  unsubscribeRequest.Sports,
+			"courts":  unsubscribeRequest.Courts,
 			"message": "購読解除が完了しました",
 		},
 	)
 	c.sendMessage(unsubscribeSuccessMsg)
 
-	log.Printf("Client %s unsubscribed from sports: %v", c.ID, unsubscribeRequest.Sports)
+	log.Printf("Client %s unsubscribed from sports: %v, courts: %v", c.ID, unsubscribeRequest.Sports, unsubscribeRequest.Courts)
 }
 
 // validateToken はJWTトークンを検証する
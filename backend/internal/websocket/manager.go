@@ -55,6 +55,7 @@ type Client struct {
 type BroadcastMessage struct {
 	Message *models.WebSocketMessage
 	Sports  []models.SportType // 対象スポーツ（空の場合は全体）
+	Courts  []int              // 対象コート（スポーツまたはコートのいずれかを購読していれば送信）
 	UserIDs []int              // 対象ユーザーID（空の場合は全ユーザー）
 }
 
@@ -260,8 +261,8 @@ func (m *Manager) broadcastMessage(broadcastMsg *BroadcastMessage) {
 			}
 		}
 		
-		// 対象スポーツが指定されている場合はチェック
-		if len(broadcastMsg.Sports) > 0 {
+		// 対象スポーツ・コートが指定されている場合はチェック
+		if len(broadcastMsg.Sports) > 0 || len(broadcastMsg.Courts) > 0 {
 			found := false
 			for _, sport := range broadcastMsg.Sports {
 				if client.Info.IsSubscribedTo(sport) {
@@ -269,6 +270,12 @@ func (m *Manager) broadcastMessage(broadcastMsg *BroadcastMessage) {
 					break
 				}
 			}
+			for _, courtID := range broadcastMsg.Courts {
+				if found {
+					break
+				}
+				found = client.Info.IsSubscribedToCourt(courtID)
+			}
 			if !found {
 				continue
 			}
@@ -303,6 +310,30 @@ func (m *Manager) BroadcastToSports(message *models.WebSocketMessage, sports []m
 	}
 }
 
+// BroadcastToCourts は指定されたコートの購読者にメッセージをブロードキャストする
+func (m *Manager) BroadcastToCourts(message *models.WebSocketMessage, courtIDs []int) {
+	if len(courtIDs) == 0 {
+		return
+	}
+	m.BroadcastToSubscribers(message, nil, courtIDs)
+}
+
+// BroadcastToSubscribers は指定されたスポーツまたはコートの購読者にメッセージをブロードキャストする
+// 両方を購読しているクライアントにも1回だけ送信する
+func (m *Manager) BroadcastToSubscribers(message *models.WebSocketMessage, sports []models.SportType, courtIDs []int) {
+	broadcastMsg := &BroadcastMessage{
+		Message: message,
+		Sports:  sports,
+		Courts:  courtIDs,
+	}
+
+	select {
+	case m.broadcast <- broadcastMsg:
+	default:
+		log.Println("Broadcast channel is full, message dropped")
+	}
+}
+
 // BroadcastToUsers は指定されたユーザーにメッセージをブロードキャストする
 func (m *Manager) BroadcastToUsers(message *models.WebSocketMessage, userIDs []int) {
 	broadcastMsg := &BroadcastMessage{
@@ -0,0 +1,47 @@
+-- 試合テーブルにブラケット構造（ラウンド内位置と進出先）を追加
+
+-- 3位決定戦のラウンド名を統一（005で3rd_placeとして挿入されたデータ）
+UPDATE matches SET round = 'third_place' WHERE round = '3rd_place';
+
+ALTER TABLE matches
+    ADD COLUMN position INT NOT NULL DEFAULT 0 COMMENT 'ラウンド内の位置（0始まり）' AFTER round,
+    ADD COLUMN next_match_id INT NULL COMMENT '勝者の進出先試合ID' AFTER winner,
+    ADD COLUMN next_slot TINYINT NULL COMMENT '勝者の進出先の枠（1: team1, 2: team2）' AFTER next_match_id,
+    ADD COLUMN loser_next_match_id INT NULL COMMENT '敗者の進出先試合ID（3位決定戦など）' AFTER next_slot,
+    ADD COLUMN loser_next_slot TINYINT NULL COMMENT '敗者の進出先の枠（1: team1, 2: team2）' AFTER loser_next_match_id,
+    ADD CONSTRAINT fk_matches_next_match FOREIGN KEY (next_match_id) REFERENCES matches(id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_matches_loser_next_match FOREIGN KEY (loser_next_match_id) REFERENCES matches(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_next_slot CHECK (next_slot IS NULL OR next_slot IN (1, 2)),
+    ADD CONSTRAINT chk_loser_next_slot CHECK (loser_next_slot IS NULL OR loser_next_slot IN (1, 2)),
+    ADD INDEX idx_tournament_round_position (tournament_id, round, position),
+    ADD INDEX idx_next_match_id (next_match_id),
+    ADD INDEX idx_loser_next_match_id (loser_next_match_id);
+
+-- 既存データのラウンド内位置を登録順で設定
+UPDATE matches m
+JOIN (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY tournament_id, round ORDER BY id) - 1 AS pos
+    FROM matches
+) p ON m.id = p.id
+SET m.position = p.pos;
+
+-- 既存データの勝者の進出先を設定（1回戦→準々決勝→準決勝→決勝）
+UPDATE matches m
+JOIN matches n ON n.tournament_id = m.tournament_id
+    AND n.position = FLOOR(m.position / 2)
+    AND n.round = CASE m.round
+        WHEN '1st_round' THEN 'quarterfinal'
+        WHEN 'quarterfinal' THEN 'semifinal'
+        WHEN 'semifinal' THEN 'final'
+    END
+SET m.next_match_id = n.id,
+    m.next_slot = MOD(m.position, 2) + 1;
+
+-- 既存データの準決勝の敗者の進出先を設定（3位決定戦）
+UPDATE matches m
+JOIN matches n ON n.tournament_id = m.tournament_id
+    AND n.round = 'third_place'
+    AND n.position = 0
+SET m.loser_next_match_id = n.id,
+    m.loser_next_slot = m.position + 1
+WHERE m.round = 'semifinal' AND m.position < 2;
@@ -0,0 +1,6 @@
+-- トーナメントに抽選（シード順位・分離規則・乱数シード）の記録を追加
+-- 同じ条件で再抽選すると同じ組み合わせになるため、抽選の監査と再現に使用する
+
+ALTER TABLE tournaments
+    ADD COLUMN draw_seed BIGINT NULL COMMENT '抽選に使用した乱数シード' AFTER status,
+    ADD COLUMN draw_result JSON NULL COMMENT '抽選条件と結果（シード順位・分離規則・組み合わせ）' AFTER draw_seed;
@@ -0,0 +1,12 @@
+-- 総当たり戦（リーグ戦）形式のサポート
+-- 引き分けの試合は勝者なしで完了とし、トーナメントに勝点と順位決定方法の規則を記録する
+
+ALTER TABLE matches
+    DROP CHECK chk_completed_match_has_scores,
+    ADD CONSTRAINT chk_completed_match_has_scores CHECK (
+        (status = 'completed' AND score1 IS NOT NULL AND score2 IS NOT NULL AND (winner IS NOT NULL OR score1 = score2)) OR
+        (status = 'pending')
+    );
+
+ALTER TABLE tournaments
+    ADD COLUMN league_rules JSON NULL COMMENT '総当たり戦の勝点と順位決定方法（NULLの場合は標準の規則）' AFTER draw_result;
@@ -0,0 +1,6 @@
+-- グループリーグ＋決勝トーナメント形式のサポート
+-- グループリーグの試合（round = 'group_stage'）にグループ名を記録する
+
+ALTER TABLE matches
+    ADD COLUMN group_name VARCHAR(10) NULL COMMENT 'グループリーグのグループ名（A, B, ...）' AFTER position,
+    ADD INDEX idx_tournament_group (tournament_id, group_name);
@@ -0,0 +1,6 @@
+-- スイス式のサポート
+-- スイス式の試合（round = 'swiss'）に回戦番号を記録する
+
+ALTER TABLE matches
+    ADD COLUMN swiss_round INT NULL COMMENT 'スイス式の回戦番号（1始まり）' AFTER group_name,
+    ADD INDEX idx_tournament_swiss_round (tournament_id, swiss_round);
@@ -0,0 +1,28 @@
+-- 試合結果の訂正記録テーブルの作成
+-- 完了した試合結果の訂正内容と、訂正により変更された後続の試合を記録する
+CREATE TABLE IF NOT EXISTS match_corrections (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    match_id INT NOT NULL COMMENT '訂正した試合ID',
+    tournament_id INT NOT NULL COMMENT 'トーナメントID',
+    previous_score1 INT NULL COMMENT '訂正前のチーム1のスコア',
+    previous_score2 INT NULL COMMENT '訂正前のチーム2のスコア',
+    previous_winner VARCHAR(100) NULL COMMENT '訂正前の勝者チーム名',
+    score1 INT NOT NULL COMMENT '訂正後のチーム1のスコア',
+    score2 INT NOT NULL COMMENT '訂正後のチーム2のスコア',
+    winner VARCHAR(100) NULL COMMENT '訂正後の勝者チーム名（引き分けの場合はNULL）',
+    reason VARCHAR(500) NOT NULL COMMENT '訂正理由',
+    corrected_by INT NULL COMMENT '訂正した管理者のユーザーID',
+    impacts JSON NULL COMMENT '訂正により変更された後続の試合',
+    warnings JSON NULL COMMENT '訂正時の警告',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '訂正日時',
+    
+    -- 外部キー制約
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (corrected_by) REFERENCES users(id) ON DELETE SET NULL,
+    
+    -- インデックス
+    INDEX idx_match_id (match_id),
+    INDEX idx_tournament_id (tournament_id),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='試合結果の訂正記録テーブル';
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- 延長戦・PK戦のサポート
+-- score1・score2 は正規時間のスコアとし、延長戦終了時のスコアとPK戦のスコア、決着方法を記録する
+-- PK戦で決着した試合は延長戦終了時のスコアが同点でも勝者が記録される
+
+ALTER TABLE matches
+    ADD COLUMN extra_time_score1 INT NULL COMMENT 'チーム1の延長戦終了時のスコア（正規時間を含む）' AFTER score2,
+    ADD COLUMN extra_time_score2 INT NULL COMMENT 'チーム2の延長戦終了時のスコア（正規時間を含む）' AFTER extra_time_score1,
+    ADD COLUMN penalty_score1 INT NULL COMMENT 'チーム1のPK戦のスコア' AFTER extra_time_score2,
+    ADD COLUMN penalty_score2 INT NULL COMMENT 'チーム2のPK戦のスコア' AFTER penalty_score1,
+    ADD COLUMN decision_method VARCHAR(20) NULL COMMENT '決着方法（regulation, extra_time, penalties）' AFTER penalty_score2,
+    ADD CONSTRAINT chk_extra_time_scores_non_negative CHECK (
+        (extra_time_score1 IS NULL OR extra_time_score1 >= 0) AND (extra_time_score2 IS NULL OR extra_time_score2 >= 0)
+    ),
+    ADD CONSTRAINT chk_penalty_scores_non_negative CHECK (
+        (penalty_score1 IS NULL OR penalty_score1 >= 0) AND (penalty_score2 IS NULL OR penalty_score2 >= 0)
+    );
+
+-- 既存の完了した試合は正規時間で決着したものとする
+UPDATE matches SET decision_method = 'regulation' WHERE status = 'completed' AND decision_method IS NULL;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- セットごとのスコアテーブルの作成
+-- バレーボール・卓球などセット制の試合のセットごとのスコアを記録する
+-- 試合のscore1・score2は取ったセット数とする
+CREATE TABLE IF NOT EXISTS match_sets (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    match_id INT NOT NULL COMMENT '試合ID',
+    set_number INT NOT NULL COMMENT 'セット番号（1始まり）',
+    score1 INT NOT NULL COMMENT 'チーム1のセットのスコア',
+    score2 INT NOT NULL COMMENT 'チーム2のセットのスコア',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    
+    -- 外部キー制約
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    
+    -- インデックス
+    UNIQUE KEY uk_match_set_number (match_id, set_number),
+    
+    -- 制約
+    CONSTRAINT chk_set_number_positive CHECK (set_number >= 1),
+    CONSTRAINT chk_set_scores_non_negative CHECK (score1 >= 0 AND score2 >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='試合のセットごとのスコアテーブル';
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- 試合経過（タイムライン）テーブルの作成
+-- 試合開始・得点・セット終了・タイムアウト・警告・試合終了を記録し、現在のスコアは記録から算出する
+CREATE TABLE IF NOT EXISTS match_events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    match_id INT NOT NULL COMMENT '試合ID',
+    sequence INT NOT NULL COMMENT '試合内の通し番号（1始まり）',
+    event_type VARCHAR(20) NOT NULL COMMENT '出来事の種類（kickoff, goal, set_won, timeout, card, end）',
+    slot INT NULL COMMENT '対象チームの枠（1: team1, 2: team2）',
+    minute INT NULL COMMENT '試合開始からの経過分',
+    set_score1 INT NULL COMMENT 'set_won の場合のチーム1のセットのスコア',
+    set_score2 INT NULL COMMENT 'set_won の場合のチーム2のセットのスコア',
+    card VARCHAR(10) NULL COMMENT 'card の場合のカードの色（yellow, red）',
+    note VARCHAR(200) NULL COMMENT '補足（選手名など）',
+    recorded_by INT NULL COMMENT '記録したユーザーID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '記録日時',
+    
+    -- 外部キー制約
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_by) REFERENCES users(id) ON DELETE SET NULL,
+    
+    -- インデックス
+    UNIQUE KEY uk_match_event_sequence (match_id, sequence),
+    INDEX idx_created_at (created_at),
+    
+    -- 制約
+    CONSTRAINT chk_event_sequence_positive CHECK (sequence >= 1),
+    CONSTRAINT chk_event_slot CHECK (slot IS NULL OR slot IN (1, 2)),
+    CONSTRAINT chk_event_minute_non_negative CHECK (minute IS NULL OR minute >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='試合経過テーブル';
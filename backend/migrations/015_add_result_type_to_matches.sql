@@ -0,0 +1,11 @@
+-- 不戦勝・棄権・両チーム棄権・失格のサポート
+-- 試合を行わずに決着した結果はスポーツごとの既定のスコアで記録し、結果の種類と棄権したチームを記録する
+-- 両チーム棄権は0対0で勝者なしとし、引き分けではなく両チームの負けとして扱う
+
+ALTER TABLE matches
+    ADD COLUMN result_type VARCHAR(20) NULL COMMENT '結果の種類（played, walkover, forfeit, double_forfeit, disqualification）' AFTER decision_method,
+    ADD COLUMN forfeiting_team VARCHAR(100) NULL COMMENT '棄権・不出場・失格となったチーム' AFTER result_type,
+    ADD INDEX idx_result_type (result_type);
+
+-- 既存の完了した試合は試合を行った結果とする
+UPDATE matches SET result_type = 'played' WHERE status = 'completed' AND result_type IS NULL;
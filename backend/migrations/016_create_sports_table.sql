@@ -0,0 +1,42 @@
+-- 種目テーブルの作成
+-- 種目ごとの表示名・利用可能な形式・ラウンド・得点方式を管理し、アプリケーションは起動時に読み込む
+-- 新しい種目（バスケットボール・ドッジボールなど）は行を追加するだけで利用できる
+CREATE TABLE IF NOT EXISTS sports (
+    code VARCHAR(50) PRIMARY KEY COMMENT '種目コード（URL・購読で使用）',
+    display_name VARCHAR(100) NOT NULL COMMENT '表示名',
+    formats JSON NOT NULL COMMENT '利用可能なトーナメント形式',
+    rounds JSON NOT NULL COMMENT '利用可能なラウンド',
+    set_rules JSON NULL COMMENT 'セット制の規則（best_of, points_to_win, deciding_set_points, win_by）。セット制でない場合はNULL',
+    forfeit_score INT NOT NULL DEFAULT 3 COMMENT '得点制の試合で不戦勝のチームに与える得点',
+    sort_order INT NOT NULL DEFAULT 0 COMMENT '一覧の表示順',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE COMMENT '新しいトーナメントを作成できるかどうか',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    
+    -- インデックス
+    INDEX idx_sort_order (sort_order),
+    
+    -- 制約
+    CONSTRAINT chk_forfeit_score_positive CHECK (forfeit_score >= 1)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='種目テーブル';
+
+-- 組み込みの種目
+INSERT INTO sports (code, display_name, formats, rounds, set_rules, forfeit_score, sort_order, is_active) VALUES
+('volleyball', 'バレーボール',
+    '["standard", "double_elimination", "round_robin", "group_knockout", "swiss"]',
+    '["1st_round", "quarterfinal", "semifinal", "third_place", "final", "loser_bracket", "grand_final", "grand_final_reset", "league", "group_stage", "swiss"]',
+    '{"best_of": 3, "points_to_win": 25, "deciding_set_points": 15, "win_by": 2}', 3, 1, TRUE),
+('table_tennis', '卓球',
+    '["rainy", "standard", "double_elimination", "round_robin", "group_knockout", "swiss"]',
+    '["1st_round", "quarterfinal", "semifinal", "third_place", "final", "loser_bracket", "grand_final", "grand_final_reset", "league", "group_stage", "swiss"]',
+    '{"best_of": 5, "points_to_win": 11, "deciding_set_points": 11, "win_by": 2}', 3, 2, TRUE),
+('soccer', 'サッカー',
+    '["standard", "double_elimination", "round_robin", "group_knockout", "swiss"]',
+    '["1st_round", "quarterfinal", "semifinal", "third_place", "final", "loser_bracket", "grand_final", "grand_final_reset", "league", "group_stage", "swiss"]',
+    NULL, 3, 3, TRUE)
+ON DUPLICATE KEY UPDATE code = code;
+
+-- トーナメントの種目をENUMから種目テーブルへの参照に変更する
+ALTER TABLE tournaments
+    MODIFY COLUMN sport VARCHAR(50) NOT NULL COMMENT 'スポーツタイプ（sports.code）',
+    ADD CONSTRAINT fk_tournaments_sport FOREIGN KEY (sport) REFERENCES sports(code) ON UPDATE CASCADE;
@@ -0,0 +1,36 @@
+-- 大会テーブルの作成
+-- 春季・秋季などスポーツ大会の開催回ごとにトーナメントをまとめ、過去の大会を履歴として残す
+-- アーカイブした大会（archived）は読み取り専用になる（終了日を過ぎただけでは結果の訂正などを行えるよう読み取り専用にしない）
+CREATE TABLE IF NOT EXISTS events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL COMMENT '大会名',
+    start_date DATE NOT NULL COMMENT '開始日（大会のタイムゾーンの日付）',
+    end_date DATE NOT NULL COMMENT '終了日（大会のタイムゾーンの日付）',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo' COMMENT 'タイムゾーン（IANA形式）',
+    status ENUM('planned', 'ongoing', 'archived') NOT NULL DEFAULT 'planned' COMMENT '大会ステータス',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    
+    -- インデックス
+    INDEX idx_status (status),
+    INDEX idx_start_date (start_date),
+    
+    -- 制約
+    CONSTRAINT chk_event_dates CHECK (end_date >= start_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='大会テーブル';
+
+-- 既存のトーナメントを所属させる大会
+INSERT INTO events (id, name, start_date, end_date, timezone, status) VALUES
+(1, 'スポーツ大会', CURDATE(), CURDATE(), 'Asia/Tokyo', 'ongoing')
+ON DUPLICATE KEY UPDATE id = id;
+
+-- トーナメントを大会に所属させる（同じ大会の同じ種目のトーナメントは1つ）
+ALTER TABLE tournaments
+    ADD COLUMN event_id INT NULL COMMENT '所属する大会' AFTER id;
+
+UPDATE tournaments SET event_id = 1 WHERE event_id IS NULL;
+
+ALTER TABLE tournaments
+    MODIFY COLUMN event_id INT NOT NULL COMMENT '所属する大会',
+    ADD CONSTRAINT fk_tournaments_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE RESTRICT,
+    ADD UNIQUE INDEX idx_event_sport (event_id, sport);
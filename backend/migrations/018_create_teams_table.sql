@@ -0,0 +1,45 @@
+-- チームテーブルの作成
+-- トーナメントごとに参加チーム（クラス）を登録し、試合はチームIDで参照する
+-- 試合のチーム・勝者・棄権したチームはIDを正とし、表示する名前はteamsテーブルから引く
+-- 試合に保存するチーム名は未確定の枠・棄権の記録・未登録のチームのためのもので、チーム名の変更時にも書き換えない
+CREATE TABLE IF NOT EXISTS teams (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    tournament_id INT NOT NULL COMMENT '登録先のトーナメントID',
+    name VARCHAR(100) NOT NULL COMMENT 'チーム名（トーナメント内で一意）',
+    description VARCHAR(255) NOT NULL DEFAULT '' COMMENT '補足',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+
+    -- 外部キー制約
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+
+    -- インデックス
+    UNIQUE KEY uk_tournament_team_name (tournament_id, name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='チームテーブル';
+
+-- 既存の試合に登場するチームを登録する（未確定の枠・グループ順位の枠・棄権の記録を除く）
+INSERT IGNORE INTO teams (tournament_id, name)
+SELECT DISTINCT tournament_id, team
+FROM (
+    SELECT tournament_id, team1 AS team FROM matches
+    UNION
+    SELECT tournament_id, team2 AS team FROM matches
+) AS match_teams
+WHERE team NOT IN ('TBD', 'WITHDRAWN')
+  AND team NOT REGEXP '^[A-Z]組[0-9]+位$';
+
+-- 試合からチームIDで参照する（未確定の枠はNULL）
+ALTER TABLE matches
+    ADD COLUMN team1_id INT NULL COMMENT 'チーム1のID' AFTER team2,
+    ADD COLUMN team2_id INT NULL COMMENT 'チーム2のID' AFTER team1_id,
+    ADD COLUMN winner_id INT NULL COMMENT '勝者チームのID' AFTER winner,
+    ADD COLUMN forfeiting_team_id INT NULL COMMENT '棄権・不出場・失格となったチームのID' AFTER forfeiting_team,
+    ADD CONSTRAINT fk_matches_team1 FOREIGN KEY (team1_id) REFERENCES teams(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_matches_team2 FOREIGN KEY (team2_id) REFERENCES teams(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_matches_winner FOREIGN KEY (winner_id) REFERENCES teams(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_matches_forfeiting_team FOREIGN KEY (forfeiting_team_id) REFERENCES teams(id) ON DELETE RESTRICT;
+
+UPDATE matches m JOIN teams t ON t.tournament_id = m.tournament_id AND t.name = m.team1 SET m.team1_id = t.id;
+UPDATE matches m JOIN teams t ON t.tournament_id = m.tournament_id AND t.name = m.team2 SET m.team2_id = t.id;
+UPDATE matches m JOIN teams t ON t.tournament_id = m.tournament_id AND t.name = m.winner SET m.winner_id = t.id;
+UPDATE matches m JOIN teams t ON t.tournament_id = m.tournament_id AND t.name = m.forfeiting_team SET m.forfeiting_team_id = t.id;
@@ -0,0 +1,58 @@
+-- 選手・名簿テーブルの作成
+-- 選手（学生）は大会をまたいで記録し、トーナメント（種目）ごとにチームの名簿へ登録する
+CREATE TABLE IF NOT EXISTS players (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    student_number VARCHAR(20) NOT NULL COMMENT '学籍番号',
+    name VARCHAR(100) NOT NULL COMMENT '氏名',
+    class_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '所属クラス',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+
+    -- インデックス
+    UNIQUE KEY uk_student_number (student_number),
+    INDEX idx_class_name (class_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='選手テーブル';
+
+-- 選手は1つのトーナメント（大会の種目）につき1つのチームにしか登録できない
+CREATE TABLE IF NOT EXISTS team_players (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    team_id INT NOT NULL COMMENT 'チームID',
+    player_id INT NOT NULL COMMENT '選手ID',
+    tournament_id INT NOT NULL COMMENT 'チームのトーナメントID',
+    shirt_number INT NULL COMMENT '背番号',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '登録日時',
+
+    -- 外部キー制約
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+
+    -- インデックス
+    UNIQUE KEY uk_tournament_player (tournament_id, player_id),
+    UNIQUE KEY uk_team_shirt_number (team_id, shirt_number),
+
+    -- 制約
+    CONSTRAINT chk_shirt_number CHECK (shirt_number IS NULL OR shirt_number BETWEEN 0 AND 99)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='チームの名簿テーブル';
+
+-- 試合のMVP（1試合につき1人）
+CREATE TABLE IF NOT EXISTS match_mvps (
+    match_id INT PRIMARY KEY COMMENT '試合ID',
+    player_id INT NOT NULL COMMENT 'MVPの選手ID',
+    selected_by INT NULL COMMENT '選んだユーザーID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '選出日時',
+
+    -- 外部キー制約
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    FOREIGN KEY (selected_by) REFERENCES users(id) ON DELETE SET NULL,
+
+    -- インデックス
+    INDEX idx_player_id (player_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='試合のMVPテーブル';
+
+-- 得点・警告を選手の記録として残す
+ALTER TABLE match_events
+    ADD COLUMN player_id INT NULL COMMENT '得点・警告の選手ID' AFTER card,
+    ADD CONSTRAINT fk_match_events_player FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE SET NULL,
+    ADD INDEX idx_player_event (player_id, event_type);
@@ -0,0 +1,9 @@
+-- 総合順位（総合優勝）の順位点
+-- 種目ごとに順位に与える点数を1位から順に設定し、クラスの総合順位は全種目の順位点の合計で決める
+-- 未設定（NULL）の種目は既定の順位点（1位10点・2位7点・3位5点・4位3点）を使用する
+
+ALTER TABLE sports
+    ADD COLUMN placement_points JSON NULL COMMENT '総合順位の順位点（1位から順に、NULLの場合は既定の順位点）' AFTER forfeit_score;
+
+-- 組み込みの種目は既定の順位点とする
+UPDATE sports SET placement_points = '[10, 7, 5, 3]' WHERE code IN ('volleyball', 'table_tennis', 'soccer');
@@ -0,0 +1,60 @@
+-- 会場・コートテーブルの作成と試合日程の自動作成のサポート
+-- 大会ごとに会場（体育館・卓球場・グラウンド）とコート（卓球台・グラウンドの面を含む）を登録し、
+-- 試合には開始時刻とコートを割り当てる。管理者が固定した試合は日程の自動作成で動かさない
+CREATE TABLE IF NOT EXISTS venues (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    event_id INT NOT NULL COMMENT '所属する大会',
+    name VARCHAR(100) NOT NULL COMMENT '会場名（大会内で一意）',
+    sort_order INT NOT NULL DEFAULT 0 COMMENT '一覧の表示順',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+
+    -- 外部キー制約
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+
+    -- インデックス
+    UNIQUE KEY uk_event_venue_name (event_id, name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='会場テーブル';
+
+CREATE TABLE IF NOT EXISTS courts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    venue_id INT NOT NULL COMMENT '所属する会場',
+    name VARCHAR(100) NOT NULL COMMENT 'コート名（会場内で一意）',
+    sports JSON NOT NULL COMMENT 'コートで行える種目（sports.code）',
+    sort_order INT NOT NULL DEFAULT 0 COMMENT '会場内の表示順・日程の割り当て順',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+
+    -- 外部キー制約
+    FOREIGN KEY (venue_id) REFERENCES venues(id) ON DELETE CASCADE,
+
+    -- インデックス
+    UNIQUE KEY uk_venue_court_name (venue_id, name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='コートテーブル';
+
+-- 試合の会場（コート）と日程の固定
+ALTER TABLE matches
+    ADD COLUMN court_id INT NULL COMMENT '試合を行うコート' AFTER scheduled_at,
+    ADD COLUMN schedule_pinned BOOLEAN NOT NULL DEFAULT FALSE COMMENT '管理者が日程とコートを固定したかどうか' AFTER court_id,
+    ADD CONSTRAINT fk_matches_court FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE SET NULL,
+    ADD INDEX idx_court_scheduled_at (court_id, scheduled_at);
+
+-- 最後に日程を自動作成した条件（試合日・時間帯・休憩・種目ごとの枠の長さ）
+ALTER TABLE events
+    ADD COLUMN schedule_options JSON NULL COMMENT '試合日程の自動作成の条件' AFTER status;
+
+-- 既存の大会の会場とコート
+INSERT INTO venues (id, event_id, name, sort_order) VALUES
+(1, 1, '体育館', 1),
+(2, 1, '卓球場', 2),
+(3, 1, 'グラウンド', 3)
+ON DUPLICATE KEY UPDATE id = id;
+
+INSERT INTO courts (venue_id, name, sports, sort_order) VALUES
+(1, 'Aコート', '["volleyball"]', 1),
+(1, 'Bコート', '["volleyball"]', 2),
+(2, '卓球台1', '["table_tennis"]', 1),
+(2, '卓球台2', '["table_tennis"]', 2),
+(2, '卓球台3', '["table_tennis"]', 3),
+(3, 'グラウンド', '["soccer"]', 1)
+ON DUPLICATE KEY UPDATE venue_id = venue_id;
@@ -0,0 +1,5 @@
+-- 試合の遅れによる日程の後ろ倒しのサポート
+-- 後ろ倒しした試合は最初に予定していた開始時刻を残し、予定と現在の開始時刻を比べられるようにする
+-- 日程を自動作成し直した場合は新しい予定となるためNULLに戻す
+ALTER TABLE matches
+    ADD COLUMN planned_at TIMESTAMP NULL COMMENT '後ろ倒しする前に予定していた開始時刻（遅れていない場合はNULL）' AFTER scheduled_at;
@@ -0,0 +1,17 @@
+-- 試合ステータスの拡張
+-- 003で pending・completed のみだったENUMを、アプリケーションの試合ステータスの遷移表（models.MatchStatuses）と一致させる
+-- 延期（postponed）・中断（suspended）・日程変更（rescheduled）を追加し、試合中（in_progress）・中止（cancelled）を保存できるようにする
+-- 008の完了した試合のCHECK制約は pending 以外のステータスを拒否するため、完了した試合のみスコアと勝者（または引き分け）を必須とするよう作り直す
+UPDATE matches SET status = 'pending' WHERE status IS NULL;
+
+ALTER TABLE matches
+    DROP CHECK chk_completed_match_has_scores;
+
+ALTER TABLE matches
+    MODIFY COLUMN status ENUM('pending', 'in_progress', 'completed', 'cancelled', 'postponed', 'suspended', 'rescheduled') NOT NULL DEFAULT 'pending' COMMENT '試合ステータス';
+
+ALTER TABLE matches
+    ADD CONSTRAINT chk_completed_match_has_scores CHECK (
+        (status = 'completed' AND score1 IS NOT NULL AND score2 IS NOT NULL AND (winner IS NOT NULL OR score1 = score2)) OR
+        (status <> 'completed')
+    );
@@ -0,0 +1,20 @@
+-- トーナメントステータスの拡張
+-- 002・004で registration・active・completed のみだったENUMを、アプリケーションのトーナメントのライフサイクル（models.TournamentStatuses）と一致させる
+-- 準備中（draft）・組み合わせ確定（seeded）・中止（cancelled）・保管（archived）を追加する
+UPDATE tournaments SET status = 'registration' WHERE status IS NULL;
+
+ALTER TABLE tournaments
+    MODIFY COLUMN status ENUM('draft', 'registration', 'seeded', 'active', 'completed', 'cancelled', 'archived') NOT NULL DEFAULT 'draft' COMMENT 'トーナメントステータス';
+
+-- 既存のトーナメントのステータスを、試合の状況からアプリケーションの自動遷移と同じ基準で設定する
+-- 試合がない: 受付中（registration）
+-- 試合があり、まだ始まっていない: 組み合わせ確定（seeded）
+-- 試合が始まり、未実施・試合中・中断・延期・日程変更の試合が残っている: 開催中（active）
+-- 中止を除く全ての試合が終了: 終了（completed）
+UPDATE tournaments t
+SET t.status = CASE
+    WHEN NOT EXISTS (SELECT 1 FROM matches m WHERE m.tournament_id = t.id) THEN 'registration'
+    WHEN NOT EXISTS (SELECT 1 FROM matches m WHERE m.tournament_id = t.id AND m.status IN ('in_progress', 'suspended', 'completed')) THEN 'seeded'
+    WHEN EXISTS (SELECT 1 FROM matches m WHERE m.tournament_id = t.id AND m.status NOT IN ('completed', 'cancelled')) THEN 'active'
+    ELSE 'completed'
+END;
@@ -24,7 +24,75 @@ SOURCE /docker-entrypoint-initdb.d/004_update_tournaments_and_add_initial_data.s
 -- 5. トーナメント情報初期化
 SOURCE /docker-entrypoint-initdb.d/005_insert_tournament_matches.sql
 
+-- 6. 試合のブラケット構造
+SOURCE /docker-entrypoint-initdb.d/006_add_bracket_structure_to_matches.sql;
+
+-- 7. トーナメントの抽選記録
+SOURCE /docker-entrypoint-initdb.d/007_add_draw_to_tournaments.sql;
+
+-- 8. 総当たり戦（引き分け・順位表の規則）
+SOURCE /docker-entrypoint-initdb.d/008_add_league_support.sql;
+
+-- 9. グループリーグのグループ名
+SOURCE /docker-entrypoint-initdb.d/009_add_group_stage_to_matches.sql;
+
+-- 10. スイス式の回戦番号
+SOURCE /docker-entrypoint-initdb.d/010_add_swiss_round_to_matches.sql;
+
+-- 11. 試合結果の訂正記録
+SOURCE /docker-entrypoint-initdb.d/011_create_match_corrections_table.sql;
+
+-- 12. 延長戦・PK戦のスコアと決着方法
+SOURCE /docker-entrypoint-initdb.d/012_add_extra_time_and_penalties_to_matches.sql;
+
+-- 13. セットごとのスコア
+SOURCE /docker-entrypoint-initdb.d/013_create_match_sets_table.sql;
+
+-- 14. 試合経過（タイムライン）
+SOURCE /docker-entrypoint-initdb.d/014_create_match_events_table.sql;
+
+-- 15. 不戦勝・棄権・失格の結果
+SOURCE /docker-entrypoint-initdb.d/015_add_result_type_to_matches.sql;
+
+-- 16. 種目（スポーツのレジストリ）
+SOURCE /docker-entrypoint-initdb.d/016_create_sports_table.sql;
+
+-- 17. 大会（開催回ごとのトーナメント）
+SOURCE /docker-entrypoint-initdb.d/017_create_events_table.sql;
+
+-- 18. チーム（試合からチームIDで参照）
+SOURCE /docker-entrypoint-initdb.d/018_create_teams_table.sql;
+
+-- 19. 選手・名簿・試合のMVP
+SOURCE /docker-entrypoint-initdb.d/019_create_players_and_rosters.sql;
+
+-- 20. 総合順位の順位点
+SOURCE /docker-entrypoint-initdb.d/020_add_placement_points_to_sports.sql;
+
+-- 21. 会場・コートと試合日程
+SOURCE /docker-entrypoint-initdb.d/021_create_venues_and_courts.sql;
+
+-- 22. 試合の遅れによる日程の後ろ倒し
+SOURCE /docker-entrypoint-initdb.d/022_add_match_planned_at.sql;
+
+-- 23. 試合ステータスの拡張（延期・中断・日程変更）
+SOURCE /docker-entrypoint-initdb.d/023_extend_match_status.sql;
+
+-- 24. トーナメントステータスの拡張（ライフサイクル）
+SOURCE /docker-entrypoint-initdb.d/024_extend_tournament_status.sql;
+
 -- インデックスの最適化
 ANALYZE TABLE users;
 ANALYZE TABLE tournaments;
-ANALYZE TABLE matches;
\ No newline at end of file
+ANALYZE TABLE matches;
+ANALYZE TABLE match_corrections;
+ANALYZE TABLE match_sets;
+ANALYZE TABLE match_events;
+ANALYZE TABLE sports;
+ANALYZE TABLE events;
+ANALYZE TABLE teams;
+ANALYZE TABLE players;
+ANALYZE TABLE team_players;
+ANALYZE TABLE match_mvps;
+ANALYZE TABLE venues;
+ANALYZE TABLE courts;
\ No newline at end of file